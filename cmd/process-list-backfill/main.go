@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+
+	"github.com/artii15/termination-detector/internal/dynamo"
+	"github.com/artii15/termination-detector/pkg/env"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/sirupsen/logrus"
+)
+
+const tasksTableNameEnvVar = "TASKS_TABLE_NAME"
+
+func main() {
+	tasksTableName, err := env.Read(tasksTableNameEnvVar)
+	if err != nil {
+		logrus.WithError(err).Fatal("failed to read tasks table name")
+	}
+	awsSess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		logrus.WithError(err).Fatal("failed to create AWS session")
+	}
+
+	backfiller := dynamo.NewProcessListBackfiller(dynamodb.New(awsSess), tasksTableName)
+	scannedItemsCount, err := backfiller.Backfill(context.Background())
+	if err != nil {
+		logrus.WithError(err).WithField("scanned_items", scannedItemsCount).Fatal("failed to backfill processes list")
+	}
+	logrus.WithField("scanned_items", scannedItemsCount).Info("processes list backfilled")
+}
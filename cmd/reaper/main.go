@@ -0,0 +1,24 @@
+package main
+
+import (
+	"github.com/artii15/termination-detector/internal/events"
+	"github.com/artii15/termination-detector/internal/reaper"
+	"github.com/artii15/termination-detector/internal/storage"
+	"github.com/artii15/termination-detector/pkg/dates"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	currentDateGetter := dates.NewCurrentDateGetter()
+	store, err := storage.NewDefaultRegistry(currentDateGetter).Build(storage.ReadBackend())
+	if err != nil {
+		logrus.WithError(err).Fatal("failed to build storage backend")
+	}
+	sink, err := events.BuildSink(events.ReadSinkType())
+	if err != nil {
+		logrus.WithError(err).Fatal("failed to build events sink")
+	}
+
+	lambda.Start(reaper.New(store, store, store, sink, currentDateGetter, reaper.DefaultConfig).Reap)
+}
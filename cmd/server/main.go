@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/api/handlers"
+	"github.com/artii15/termination-detector/internal/events"
+	"github.com/artii15/termination-detector/internal/reaper"
+	"github.com/artii15/termination-detector/internal/storage"
+	"github.com/artii15/termination-detector/internal/webhook"
+	"github.com/artii15/termination-detector/pkg/dates"
+	"github.com/artii15/termination-detector/pkg/env"
+	"github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/http/server"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	listenAddressEnvVar       = "LISTEN_ADDRESS"
+	tlsCertFileEnvVar         = "TLS_CERT_FILE"
+	tlsKeyFileEnvVar          = "TLS_KEY_FILE"
+	shutdownTimeoutEnvVar     = "SHUTDOWN_TIMEOUT"
+	readHeaderTimeoutEnvVar   = "READ_HEADER_TIMEOUT"
+	readTimeoutEnvVar         = "READ_TIMEOUT"
+	writeTimeoutEnvVar        = "WRITE_TIMEOUT"
+	idleTimeoutEnvVar         = "IDLE_TIMEOUT"
+	maxRequestBodyBytesEnvVar = "MAX_REQUEST_BODY_BYTES"
+	reaperIntervalEnvVar      = "REAPER_INTERVAL"
+
+	defaultListenAddress     = ":8080"
+	defaultShutdownTimeout   = "30s"
+	defaultReadHeaderTimeout = "10s"
+	defaultReadTimeout       = "30s"
+	defaultIdleTimeout       = "120s"
+	defaultProcessMaxWait    = "60s"
+	defaultWebhookInterval   = "5s"
+
+	writeTimeoutOverMaxWait = 30 * time.Second
+)
+
+func main() {
+	processWaitingConfig := handlers.ReadProcessWaitingConfig(defaultProcessMaxWait)
+	defaultWriteTimeout := (processWaitingConfig.MaxWait + writeTimeoutOverMaxWait).String()
+	serverConfig := server.Config{
+		ListenAddress:     env.ReadOrDefault(listenAddressEnvVar, defaultListenAddress),
+		TLSCertFile:       env.ReadOrDefault(tlsCertFileEnvVar, ""),
+		TLSKeyFile:        env.ReadOrDefault(tlsKeyFileEnvVar, ""),
+		ShutdownTimeout:   dates.MustParseDuration(env.ReadOrDefault(shutdownTimeoutEnvVar, defaultShutdownTimeout)),
+		ReadHeaderTimeout: dates.MustParseDuration(env.ReadOrDefault(readHeaderTimeoutEnvVar, defaultReadHeaderTimeout)),
+		ReadTimeout:       dates.MustParseDuration(env.ReadOrDefault(readTimeoutEnvVar, defaultReadTimeout)),
+		WriteTimeout:      dates.MustParseDuration(env.ReadOrDefault(writeTimeoutEnvVar, defaultWriteTimeout)),
+		IdleTimeout:       dates.MustParseDuration(env.ReadOrDefault(idleTimeoutEnvVar, defaultIdleTimeout)),
+	}
+	if serverConfig.WriteTimeout <= processWaitingConfig.MaxWait {
+		logrus.WithField("write_timeout", serverConfig.WriteTimeout).WithField("max_wait", processWaitingConfig.MaxWait).
+			Fatal("write timeout must be longer than the process max wait")
+	}
+
+	currentDateGetter := dates.NewCurrentDateGetter()
+	store, err := storage.NewDefaultRegistry(currentDateGetter).Build(storage.ReadBackend())
+	if err != nil {
+		logrus.WithError(err).Fatal("failed to build storage backend")
+	}
+
+	requestsHandlers := handlers.NewRequestsHandlersMap(handlers.NewStoreDependencies(store, currentDateGetter,
+		processWaitingConfig, handlers.ReadCallbackURLPolicy()))
+	router := http.NewRouter(requestsHandlers)
+	handler := server.NewHandler(router, server.NewResourcePathMatcher(requestsHandlers.ResourcePaths()),
+		readMaxRequestBodyBytes())
+
+	if webhookSecret, isSet := os.LookupEnv(webhook.SecretEnvVar); isSet {
+		dispatcherCtx, stopDispatcher := context.WithCancel(context.Background())
+		defer stopDispatcher()
+		dispatcher := webhook.NewDispatcher(store, store, webhook.NewDefaultDeliverer(webhookSecret, currentDateGetter),
+			currentDateGetter, webhook.ReadDispatcherConfig())
+		go dispatcher.Run(dispatcherCtx,
+			dates.MustParseDuration(env.ReadOrDefault(webhook.DispatchIntervalEnvVar, defaultWebhookInterval)))
+	}
+
+	if reaperInterval, isSet := os.LookupEnv(reaperIntervalEnvVar); isSet {
+		sink, err := events.BuildSink(events.ReadSinkType())
+		if err != nil {
+			logrus.WithError(err).Fatal("failed to build events sink")
+		}
+		reaperCtx, stopReaper := context.WithCancel(context.Background())
+		defer stopReaper()
+		go reaper.New(store, store, store, sink, currentDateGetter, reaper.DefaultConfig).
+			Run(reaperCtx, dates.MustParseDuration(reaperInterval))
+	}
+
+	stopSignals := make(chan os.Signal, 1)
+	signal.Notify(stopSignals, syscall.SIGINT, syscall.SIGTERM)
+
+	logrus.WithField("listen_address", serverConfig.ListenAddress).Info("starting http server")
+	if err := server.New(handler, serverConfig).Run(stopSignals); err != nil {
+		logrus.WithError(err).Fatal("http server failed")
+	}
+}
+
+func readMaxRequestBodyBytes() int64 {
+	maxRequestBodyBytesValue, isSet := os.LookupEnv(maxRequestBodyBytesEnvVar)
+	if !isSet {
+		return server.DefaultMaxRequestBodyBytes
+	}
+	maxRequestBodyBytes, err := strconv.ParseInt(maxRequestBodyBytesValue, 10, 64)
+	if err != nil || maxRequestBodyBytes <= 0 {
+		logrus.WithError(err).WithField("max_request_body_bytes", maxRequestBodyBytesValue).
+			Fatal("invalid max request body bytes")
+	}
+	return maxRequestBodyBytes
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"github.com/artii15/termination-detector/internal/events"
+	"github.com/artii15/termination-detector/internal/storage"
+	"github.com/artii15/termination-detector/internal/streams"
+	"github.com/artii15/termination-detector/pkg/dates"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	store, err := storage.NewDefaultRegistry(dates.NewCurrentDateGetter()).Build(storage.BackendDynamoDB)
+	if err != nil {
+		logrus.WithError(err).Fatal("failed to build storage backend")
+	}
+	sink, err := events.BuildSink(events.ReadSinkType())
+	if err != nil {
+		logrus.WithError(err).Fatal("failed to build events sink")
+	}
+
+	lambda.Start(streams.NewProcessor(store, store, sink).Handle)
+}
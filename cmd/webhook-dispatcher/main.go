@@ -0,0 +1,22 @@
+package main
+
+import (
+	"github.com/artii15/termination-detector/internal/storage"
+	"github.com/artii15/termination-detector/internal/webhook"
+	"github.com/artii15/termination-detector/pkg/dates"
+	"github.com/artii15/termination-detector/pkg/env"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	currentDateGetter := dates.NewCurrentDateGetter()
+	store, err := storage.NewDefaultRegistry(currentDateGetter).Build(storage.ReadBackend())
+	if err != nil {
+		logrus.WithError(err).Fatal("failed to build storage backend")
+	}
+
+	deliverer := webhook.NewDefaultDeliverer(env.MustRead(webhook.SecretEnvVar), currentDateGetter)
+	dispatcher := webhook.NewDispatcher(store, store, deliverer, currentDateGetter, webhook.ReadDispatcherConfig())
+	lambda.Start(dispatcher.Dispatch)
+}
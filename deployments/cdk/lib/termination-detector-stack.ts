@@ -1,8 +1,11 @@
 import * as cdk from '@aws-cdk/core';
 import * as apiGW from '@aws-cdk/aws-apigateway';
 import * as lambda from '@aws-cdk/aws-lambda';
+import * as sns from '@aws-cdk/aws-sns';
 import * as path from "path";
 import * as dynamo from '@aws-cdk/aws-dynamodb';
+import * as events from '@aws-cdk/aws-events';
+import * as iam from '@aws-cdk/aws-iam';
 
 export class TerminationDetectorStack extends cdk.Stack {
   constructor(scope: cdk.Construct, id: string, props?: cdk.StackProps) {
@@ -13,41 +16,171 @@ export class TerminationDetectorStack extends cdk.Stack {
       sortKey: {name: 'task_id', type: dynamo.AttributeType.STRING},
       billingMode: dynamo.BillingMode.PAY_PER_REQUEST,
       timeToLiveAttribute: 'ttl',
+      stream: dynamo.StreamViewType.KEYS_ONLY,
     });
     tasksTable.addLocalSecondaryIndex({
       indexName: 'badStateEnterTimeIndex',
       sortKey: {name: 'bad_state_enter_time', type: dynamo.AttributeType.STRING},
       projectionType: dynamo.ProjectionType.ALL,
     })
+    tasksTable.addGlobalSecondaryIndex({
+      indexName: 'callbackStateIndex',
+      partitionKey: {name: 'callback_state', type: dynamo.AttributeType.STRING},
+      sortKey: {name: 'process_id', type: dynamo.AttributeType.STRING},
+      projectionType: dynamo.ProjectionType.KEYS_ONLY,
+    })
+    tasksTable.addGlobalSecondaryIndex({
+      indexName: 'reapShardExpirationTimeIndex',
+      partitionKey: {name: 'reap_shard', type: dynamo.AttributeType.STRING},
+      sortKey: {name: 'expiration_time', type: dynamo.AttributeType.STRING},
+      projectionType: dynamo.ProjectionType.KEYS_ONLY,
+    })
+    tasksTable.addGlobalSecondaryIndex({
+      indexName: 'processCreationTimeIndex',
+      partitionKey: {name: 'item_type', type: dynamo.AttributeType.STRING},
+      sortKey: {name: 'creation_time', type: dynamo.AttributeType.STRING},
+      projectionType: dynamo.ProjectionType.KEYS_ONLY,
+    })
 
     const apiLambda = new lambda.Function(this, 'api-lambda', {
       runtime: lambda.Runtime.GO_1_X,
       handler: 'api',
       code: lambda.Code.fromAsset(path.join(__dirname, '..', '..', '..', 'build', 'api.zip')),
+      timeout: cdk.Duration.seconds(28),
       environment: {
         TASKS_TABLE_NAME: tasksTable.tableName,
-        TASKS_STORING_DURATION: '168h'
+        TASKS_STORING_DURATION: '168h',
+        PROCESS_MAX_WAIT: '20s'
       }
     });
     tasksTable.grantReadWriteData(apiLambda);
 
+    const webhookDispatcherLambda = new lambda.Function(this, 'webhook-dispatcher-lambda', {
+      runtime: lambda.Runtime.GO_1_X,
+      handler: 'webhook-dispatcher',
+      code: lambda.Code.fromAsset(path.join(__dirname, '..', '..', '..', 'build', 'webhook-dispatcher.zip')),
+      timeout: cdk.Duration.seconds(55),
+      environment: {
+        TASKS_TABLE_NAME: tasksTable.tableName,
+        TASKS_STORING_DURATION: '168h',
+        WEBHOOK_SECRET: new cdk.CfnParameter(this, 'webhook-secret', {noEcho: true}).valueAsString,
+      }
+    });
+    tasksTable.grantReadWriteData(webhookDispatcherLambda);
+    new events.Rule(this, 'webhook-dispatcher-schedule', {
+      schedule: events.Schedule.rate(cdk.Duration.minutes(1)),
+      targets: [new ScheduledLambdaTarget(webhookDispatcherLambda)],
+    });
+
+    const processEventsTopic = new sns.Topic(this, 'process-events-topic');
+    const streamProcessorLambda = new lambda.Function(this, 'stream-processor-lambda', {
+      runtime: lambda.Runtime.GO_1_X,
+      handler: 'stream-processor',
+      code: lambda.Code.fromAsset(path.join(__dirname, '..', '..', '..', 'build', 'stream-processor.zip')),
+      timeout: cdk.Duration.seconds(30),
+      environment: {
+        TASKS_TABLE_NAME: tasksTable.tableName,
+        TASKS_STORING_DURATION: '168h',
+        EVENTS_SINK: 'sns',
+        EVENTS_SNS_TOPIC_ARN: processEventsTopic.topicArn,
+      }
+    });
+    tasksTable.grantReadWriteData(streamProcessorLambda);
+    processEventsTopic.grantPublish(streamProcessorLambda);
+    tasksTable.grantStreamRead(streamProcessorLambda);
+    streamProcessorLambda.addEventSourceMapping('tasks-table-stream', {
+      eventSourceArn: tasksTable.tableStreamArn!,
+      startingPosition: lambda.StartingPosition.TRIM_HORIZON,
+      batchSize: 100,
+      retryAttempts: 10,
+    });
+    new cdk.CfnOutput(this, 'process-events-topic-arn', {value: processEventsTopic.topicArn});
+
+    const reaperLambda = new lambda.Function(this, 'reaper-lambda', {
+      runtime: lambda.Runtime.GO_1_X,
+      handler: 'reaper',
+      code: lambda.Code.fromAsset(path.join(__dirname, '..', '..', '..', 'build', 'reaper.zip')),
+      timeout: cdk.Duration.seconds(55),
+      environment: {
+        TASKS_TABLE_NAME: tasksTable.tableName,
+        TASKS_STORING_DURATION: '168h',
+        EVENTS_SINK: 'sns',
+        EVENTS_SNS_TOPIC_ARN: processEventsTopic.topicArn,
+      }
+    });
+    tasksTable.grantReadWriteData(reaperLambda);
+    processEventsTopic.grantPublish(reaperLambda);
+    new events.Rule(this, 'reaper-schedule', {
+      schedule: events.Schedule.rate(cdk.Duration.minutes(1)),
+      targets: [new ScheduledLambdaTarget(reaperLambda)],
+    });
+
     const apiLambdaIntegration = new apiGW.LambdaIntegration(apiLambda)
 
     const api = new apiGW.RestApi(this, 'processes-api');
 
+    const processesAction = api.root.addResource('{processes_action}');
+    processesAction.addMethod('POST', apiLambdaIntegration, {
+      authorizationType: apiGW.AuthorizationType.IAM,
+    })
     const processes = api.root.addResource('processes');
+    processes.addMethod('GET', apiLambdaIntegration, {
+      authorizationType: apiGW.AuthorizationType.IAM,
+    })
     const process = processes.addResource('{process_id}');
     process.addMethod('GET', apiLambdaIntegration, {
       authorizationType: apiGW.AuthorizationType.IAM,
     })
+    process.addMethod('PUT', apiLambdaIntegration, {
+      authorizationType: apiGW.AuthorizationType.IAM,
+    })
+    const processSeal = process.addResource('seal');
+    processSeal.addMethod('PUT', apiLambdaIntegration, {
+      authorizationType: apiGW.AuthorizationType.IAM,
+    })
+    const completions = process.addResource('completions');
+    completions.addMethod('PUT', apiLambdaIntegration, {
+      authorizationType: apiGW.AuthorizationType.IAM,
+    })
     const tasks = process.addResource('tasks');
+    tasks.addMethod('GET', apiLambdaIntegration, {
+      authorizationType: apiGW.AuthorizationType.IAM
+    });
+    tasks.addMethod('PUT', apiLambdaIntegration, {
+      authorizationType: apiGW.AuthorizationType.IAM
+    });
     const task = tasks.addResource('{task_id}');
     task.addMethod('PUT', apiLambdaIntegration, {
       authorizationType: apiGW.AuthorizationType.IAM
     });
+    task.addMethod('GET', apiLambdaIntegration, {
+      authorizationType: apiGW.AuthorizationType.IAM
+    });
     const taskCompletion = task.addResource('completion');
     taskCompletion.addMethod('PUT', apiLambdaIntegration, {
       authorizationType: apiGW.AuthorizationType.IAM
     });
+    const taskCompletionWithChildren = task.addResource('completion-with-children');
+    taskCompletionWithChildren.addMethod('PUT', apiLambdaIntegration, {
+      authorizationType: apiGW.AuthorizationType.IAM
+    });
+    const taskHeartbeat = task.addResource('heartbeat');
+    taskHeartbeat.addMethod('PUT', apiLambdaIntegration, {
+      authorizationType: apiGW.AuthorizationType.IAM
+    });
+  }
+}
+
+class ScheduledLambdaTarget implements events.IRuleTarget {
+  constructor(private readonly handler: lambda.IFunction) {
+  }
+
+  public bind(rule: events.IRule): events.RuleTargetConfig {
+    this.handler.addPermission(`AllowEventRule${rule.node.uniqueId}`, {
+      action: 'lambda:InvokeFunction',
+      principal: new iam.ServicePrincipal('events.amazonaws.com'),
+      sourceArn: rule.ruleArn,
+    });
+    return {id: '', arn: this.handler.functionArn};
   }
 }
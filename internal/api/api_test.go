@@ -1,12 +1,24 @@
 package api_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/artii15/termination-detector/internal/api/handlers"
 	"github.com/artii15/termination-detector/internal/dynamo"
+	"github.com/artii15/termination-detector/internal/memory"
+	"github.com/artii15/termination-detector/internal/sqldb"
+	"github.com/artii15/termination-detector/internal/webhook"
+	"github.com/artii15/termination-detector/pkg/dates"
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/http/server"
 	"github.com/artii15/termination-detector/pkg/process"
 	"github.com/artii15/termination-detector/pkg/sdk"
 	"github.com/artii15/termination-detector/pkg/task"
@@ -14,15 +26,22 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
 	iamAuthorizedAPIURLEnvVarName = "IAM_AUTHORIZED_API_URL"
 	tasksTableNameEnvVarName      = "TASKS_TABLE_NAME"
 
-	testProcessID   = "1"
-	requestsTimeout = time.Second * 30
+	testProcessID          = "1"
+	testFailingProcessID   = "2"
+	testSealingProcessID   = "3"
+	testNotExistProcessID  = "4"
+	testCompletedProcessID = "5"
+	testBatchGetProcessID  = "batch-get"
+	requestsTimeout        = time.Second * 30
 )
 
 type apiIntegrationTestConfig struct {
@@ -52,10 +71,115 @@ func TestUsingAWSIAMAuthorizedSDK(t *testing.T) {
 		AssumeRoleTokenProvider: stscreds.StdinTokenProvider,
 	}))
 	terminationDetectorSDK := sdk.NewAWSIAMAuthorized(requestsTimeout, apiTestConfig.apiURL, *awsSess.Config.Region, awsSess.Config.Credentials)
-	defer removeTestDataFromDB(t, awsSess, apiTestConfig.tasksTableName)
+	for _, processID := range []string{testProcessID, testFailingProcessID, testSealingProcessID,
+		testCompletedProcessID, testBatchGetProcessID} {
+		defer removeTestDataFromDB(t, awsSess, apiTestConfig.tasksTableName, processID)
+	}
+
+	testProcessLifecycle(t, terminationDetectorSDK)
+}
+
+func TestUsingInMemoryStore(t *testing.T) {
+	store := memory.NewStore(dates.NewCurrentDateGetter())
+	requestsHandlers := handlers.NewRequestsHandlersMap(handlers.NewStoreDependencies(store,
+		dates.NewCurrentDateGetter(), handlers.ProcessWaitingConfig{MaxWait: time.Second * 5, PollInterval: time.Millisecond * 10},
+		handlers.CallbackURLPolicy{}))
+	apiServer := httptest.NewServer(server.NewHandler(internalHTTP.NewRouter(requestsHandlers),
+		server.NewResourcePathMatcher(requestsHandlers.ResourcePaths()), server.DefaultMaxRequestBodyBytes))
+	defer apiServer.Close()
+
+	testProcessLifecycle(t, sdk.New(requestsTimeout, apiServer.URL))
+}
+
+func TestUsingSQLiteStore(t *testing.T) {
+	db, err := sqldb.Open(sqldb.DialectSQLite, ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, sqldb.Migrate(db, sqldb.DialectSQLite))
+	store := sqldb.NewStore(db, sqldb.DialectSQLite, dates.NewCurrentDateGetter())
+	requestsHandlers := handlers.NewRequestsHandlersMap(handlers.NewStoreDependencies(store,
+		dates.NewCurrentDateGetter(), handlers.ProcessWaitingConfig{MaxWait: time.Second * 5, PollInterval: time.Millisecond * 10},
+		handlers.CallbackURLPolicy{}))
+	apiServer := httptest.NewServer(server.NewHandler(internalHTTP.NewRouter(requestsHandlers),
+		server.NewResourcePathMatcher(requestsHandlers.ResourcePaths()), server.DefaultMaxRequestBodyBytes))
+	defer apiServer.Close()
+
+	testProcessLifecycle(t, sdk.New(requestsTimeout, apiServer.URL))
+}
+
+func TestWebhooksUsingInMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	currentDateGetter := dates.NewCurrentDateGetter()
+	store := memory.NewStore(currentDateGetter)
+	requestsHandlers := handlers.NewRequestsHandlersMap(handlers.NewStoreDependencies(store, dates.NewCurrentDateGetter(),
+		handlers.ProcessWaitingConfig{MaxWait: time.Second * 5, PollInterval: time.Millisecond * 10},
+		handlers.CallbackURLPolicy{AllowPrivateAddresses: true}))
+	apiServer := httptest.NewServer(server.NewHandler(internalHTTP.NewRouter(requestsHandlers),
+		server.NewResourcePathMatcher(requestsHandlers.ResourcePaths()), server.DefaultMaxRequestBodyBytes))
+	defer apiServer.Close()
+	terminationDetectorSDK := sdk.New(requestsTimeout, apiServer.URL)
+
+	secret := []byte("secret")
+	receivedProcesses := make(chan internalHTTP.Process, 2)
+	receiver := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, err := ioutil.ReadAll(request.Body)
+		assert.NoError(t, err)
+		assert.True(t, webhook.VerifySignature(secret, request.Header.Get(webhook.TimestampHeaderName), body,
+			request.Header.Get(webhook.SignatureHeaderName), time.Now(), webhook.DefaultSignatureTolerance))
+		var receivedProcess internalHTTP.Process
+		assert.NoError(t, json.Unmarshal(body, &receivedProcess))
+		receivedProcesses <- receivedProcess
+		writer.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+	dispatcher := webhook.NewDispatcher(store, store, webhook.NewDeliverer(receiver.Client(), secret,
+		currentDateGetter, webhook.DefaultDeliveryConfig), currentDateGetter, webhook.DefaultDispatcherConfig)
+
+	registrationData := task.RegistrationData{
+		ID:             task.ID{ProcessID: testProcessID, TaskID: "1"},
+		ExpirationTime: time.Now().Add(time.Hour),
+		CallbackURL:    aws.String(receiver.URL),
+	}
+	registrationResult, err := terminationDetectorSDK.Register(ctx, registrationData)
+	assert.NoError(t, err)
+	assert.Equal(t, task.RegistrationResultCreated, registrationResult)
+	updatingResult, err := terminationDetectorSDK.Update(ctx, process.UpdateRequest{
+		ProcessID:   testNotExistProcessID,
+		CallbackURL: aws.String(receiver.URL),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, process.UpdatingResultNotFound, updatingResult)
+
+	assert.NoError(t, dispatcher.Dispatch(ctx))
+	assert.Len(t, receivedProcesses, 0)
+
+	completingResult, err := terminationDetectorSDK.Complete(ctx, task.CompleteRequest{
+		ID:    registrationData.ID,
+		State: task.StateFinished,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.CompletingResultCompleted, completingResult)
+
+	assert.NoError(t, dispatcher.Dispatch(ctx))
+	assert.NoError(t, dispatcher.Dispatch(ctx))
+	assert.Len(t, receivedProcesses, 1)
+	receivedProcess := <-receivedProcesses
+	assert.Equal(t, testProcessID, receivedProcess.ID)
+	assert.Equal(t, process.StateCompleted, receivedProcess.State)
+	assert.Nil(t, receivedProcess.Callback)
+
+	proc, err := terminationDetectorSDK.Get(ctx, testProcessID)
+	assert.NoError(t, err)
+	assert.NotNil(t, proc.Callback)
+	assert.Equal(t, process.CallbackStateDelivered, proc.Callback.State)
+	assert.Equal(t, 1, proc.Callback.Attempts)
+}
+
+func testProcessLifecycle(t *testing.T, terminationDetectorSDK *sdk.SDK) {
+	ctx := context.Background()
 
 	t.Run("not registered process not exists", func(t *testing.T) {
-		proc, err := terminationDetectorSDK.Get(testProcessID)
+		proc, err := terminationDetectorSDK.Get(ctx, testProcessID)
 		assert.NoError(t, err)
 		assert.Nil(t, proc)
 	})
@@ -63,7 +187,7 @@ func TestUsingAWSIAMAuthorizedSDK(t *testing.T) {
 	task1ID := "1"
 	task2ID := "2"
 	t.Run("each task can be registered only once", func(t *testing.T) {
-		registrationResult, err := terminationDetectorSDK.Register(task.RegistrationData{
+		registrationResult, err := terminationDetectorSDK.Register(ctx, task.RegistrationData{
 			ID: task.ID{
 				ProcessID: testProcessID,
 				TaskID:    task1ID,
@@ -73,7 +197,7 @@ func TestUsingAWSIAMAuthorizedSDK(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, task.RegistrationResultCreated, registrationResult)
 
-		registrationResult, err = terminationDetectorSDK.Register(task.RegistrationData{
+		registrationResult, err = terminationDetectorSDK.Register(ctx, task.RegistrationData{
 			ID: task.ID{
 				ProcessID: testProcessID,
 				TaskID:    task2ID,
@@ -83,7 +207,7 @@ func TestUsingAWSIAMAuthorizedSDK(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, task.RegistrationResultCreated, registrationResult)
 
-		registrationResult, err = terminationDetectorSDK.Register(task.RegistrationData{
+		registrationResult, err = terminationDetectorSDK.Register(ctx, task.RegistrationData{
 			ID: task.ID{
 				ProcessID: testProcessID,
 				TaskID:    task1ID,
@@ -94,12 +218,12 @@ func TestUsingAWSIAMAuthorizedSDK(t *testing.T) {
 		assert.Equal(t, task.RegistrationResultAlreadyRegistered, registrationResult)
 	})
 	t.Run("process is completed only when all tasks are completed", func(t *testing.T) {
-		proc, err := terminationDetectorSDK.Get(testProcessID)
+		proc, err := terminationDetectorSDK.Get(ctx, testProcessID)
 		assert.NoError(t, err)
 		assert.NotNil(t, proc)
 		assert.Equal(t, process.StateCreated, proc.State)
 
-		completeResult, err := terminationDetectorSDK.Complete(task.CompleteRequest{
+		completeResult, err := terminationDetectorSDK.Complete(ctx, task.CompleteRequest{
 			ID: task.ID{
 				ProcessID: testProcessID,
 				TaskID:    task1ID,
@@ -109,12 +233,36 @@ func TestUsingAWSIAMAuthorizedSDK(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, task.CompletingResultCompleted, completeResult)
 
-		proc, err = terminationDetectorSDK.Get(testProcessID)
+		completeResult, err = terminationDetectorSDK.Complete(ctx, task.CompleteRequest{
+			ID: task.ID{
+				ProcessID: testProcessID,
+				TaskID:    task1ID,
+			},
+			State: task.StateFinished,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, task.CompletingResultAlreadyCompletedSame, completeResult)
+
+		completeResult, err = terminationDetectorSDK.Complete(ctx, task.CompleteRequest{
+			ID: task.ID{
+				ProcessID: testProcessID,
+				TaskID:    task1ID,
+			},
+			State:   task.StateAborted,
+			Message: aws.String("failure"),
+		})
+		assert.Equal(t, &sdk.CompletingError{
+			TaskID: task.ID{ProcessID: testProcessID, TaskID: task1ID},
+			Result: task.CompletingResultAlreadyCompletedDifferent,
+		}, err)
+		assert.Equal(t, task.CompletingResultAlreadyCompletedDifferent, completeResult)
+
+		proc, err = terminationDetectorSDK.Get(ctx, testProcessID)
 		assert.NoError(t, err)
 		assert.NotNil(t, proc)
 		assert.Equal(t, process.StateCreated, proc.State)
 
-		completeResult, err = terminationDetectorSDK.Complete(task.CompleteRequest{
+		completeResult, err = terminationDetectorSDK.Complete(ctx, task.CompleteRequest{
 			ID: task.ID{
 				ProcessID: testProcessID,
 				TaskID:    task2ID,
@@ -124,15 +272,20 @@ func TestUsingAWSIAMAuthorizedSDK(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, task.CompletingResultCompleted, completeResult)
 
-		proc, err = terminationDetectorSDK.Get(testProcessID)
+		proc, err = terminationDetectorSDK.Get(ctx, testProcessID)
 		assert.NoError(t, err)
 		assert.NotNil(t, proc)
 		assert.Equal(t, process.StateCompleted, proc.State)
 	})
 
 	task3ID := "3"
-	t.Run("process fails if at least one task fails", func(t *testing.T) {
-		registrationStatus, err := terminationDetectorSDK.Register(task.RegistrationData{
+	t.Run("terminated process is sealed and rejects new tasks", func(t *testing.T) {
+		proc, err := terminationDetectorSDK.Get(ctx, testProcessID)
+		assert.NoError(t, err)
+		assert.NotNil(t, proc)
+		assert.True(t, proc.Sealed)
+
+		registrationStatus, err := terminationDetectorSDK.Register(ctx, task.RegistrationData{
 			ID: task.ID{
 				ProcessID: testProcessID,
 				TaskID:    task3ID,
@@ -140,12 +293,62 @@ func TestUsingAWSIAMAuthorizedSDK(t *testing.T) {
 			ExpirationTime: time.Now().Add(time.Hour),
 		})
 		assert.NoError(t, err)
+		assert.Equal(t, task.RegistrationResultProcessSealed, registrationStatus)
+
+		proc, err = terminationDetectorSDK.Get(ctx, testProcessID)
+		assert.NoError(t, err)
+		assert.NotNil(t, proc)
+		assert.Equal(t, process.StateCompleted, proc.State)
+	})
+	t.Run("completed task can be fetched", func(t *testing.T) {
+		foundTask, err := terminationDetectorSDK.GetTask(ctx, task.ID{ProcessID: testProcessID, TaskID: task1ID})
+		assert.NoError(t, err)
+		assert.NotNil(t, foundTask)
+		assert.Equal(t, task.StateFinished, foundTask.State)
+		assert.False(t, foundTask.TimedOut)
+		assert.False(t, foundTask.CreationTime.IsZero())
+
+		foundTask, err = terminationDetectorSDK.GetTask(ctx, task.ID{ProcessID: testProcessID, TaskID: task3ID})
+		assert.NoError(t, err)
+		assert.Nil(t, foundTask)
+	})
+	t.Run("tasks of process can be listed page by page", func(t *testing.T) {
+		firstPage, err := terminationDetectorSDK.List(ctx, task.ListRequest{ProcessID: testProcessID, Limit: 1})
+		assert.NoError(t, err)
+		assert.Len(t, firstPage.Tasks, 1)
+		assert.Equal(t, task1ID, firstPage.Tasks[0].TaskID)
+		assert.Equal(t, task.StateFinished, firstPage.Tasks[0].State)
+		assert.NotEmpty(t, firstPage.NextCursor)
+
+		secondPage, err := terminationDetectorSDK.List(ctx, task.ListRequest{
+			ProcessID: testProcessID,
+			Cursor:    firstPage.NextCursor,
+			Limit:     1,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, secondPage.Tasks, 1)
+		assert.Equal(t, task2ID, secondPage.Tasks[0].TaskID)
+
+		createdState := task.StateCreated
+		createdTasks, err := terminationDetectorSDK.List(ctx, task.ListRequest{ProcessID: testProcessID, State: &createdState})
+		assert.NoError(t, err)
+		assert.Empty(t, createdTasks.Tasks)
+	})
+	t.Run("process fails if at least one task fails", func(t *testing.T) {
+		registrationStatus, err := terminationDetectorSDK.Register(ctx, task.RegistrationData{
+			ID: task.ID{
+				ProcessID: testFailingProcessID,
+				TaskID:    task3ID,
+			},
+			ExpirationTime: time.Now().Add(time.Hour),
+		})
+		assert.NoError(t, err)
 		assert.Equal(t, task.RegistrationResultCreated, registrationStatus)
 
 		failureReason := "failure"
-		completeResult, err := terminationDetectorSDK.Complete(task.CompleteRequest{
+		completeResult, err := terminationDetectorSDK.Complete(ctx, task.CompleteRequest{
 			ID: task.ID{
-				ProcessID: testProcessID,
+				ProcessID: testFailingProcessID,
 				TaskID:    task3ID,
 			},
 			State:   task.StateAborted,
@@ -154,15 +357,132 @@ func TestUsingAWSIAMAuthorizedSDK(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, task.CompletingResultCompleted, completeResult)
 
-		proc, err := terminationDetectorSDK.Get(testProcessID)
+		proc, err := terminationDetectorSDK.Get(ctx, testFailingProcessID)
 		assert.NoError(t, err)
 		assert.NotNil(t, proc)
 		assert.Equal(t, process.StateError, proc.State)
 		assert.Equal(t, &failureReason, proc.StateMessage)
 	})
+	t.Run("waiting for termination returns terminated process", func(t *testing.T) {
+		proc, err := terminationDetectorSDK.WaitForTermination(ctx, testFailingProcessID,
+			sdk.WaitingOptions{Deadline: time.Second * 10})
+		assert.NoError(t, err)
+		assert.Equal(t, process.StateError, proc.State)
+
+		_, err = terminationDetectorSDK.WaitForTermination(ctx, testNotExistProcessID,
+			sdk.WaitingOptions{Deadline: time.Second * 10})
+		waitingErr, isWaitingErr := err.(*sdk.WaitingError)
+		assert.True(t, isWaitingErr)
+		assert.Equal(t, sdk.WaitingErrorReasonNotFound, waitingErr.Reason)
+	})
+	t.Run("explicitly sealed process rejects new tasks", func(t *testing.T) {
+		registrationStatus, err := terminationDetectorSDK.Register(ctx, task.RegistrationData{
+			ID: task.ID{
+				ProcessID: testSealingProcessID,
+				TaskID:    task1ID,
+			},
+			ExpirationTime: time.Now().Add(time.Hour),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, task.RegistrationResultCreated, registrationStatus)
+
+		sealingResult, err := terminationDetectorSDK.Seal(ctx, testSealingProcessID)
+		assert.NoError(t, err)
+		assert.Equal(t, process.SealingResultSealed, sealingResult)
+
+		registrationStatus, err = terminationDetectorSDK.Register(ctx, task.RegistrationData{
+			ID: task.ID{
+				ProcessID: testSealingProcessID,
+				TaskID:    task2ID,
+			},
+			ExpirationTime: time.Now().Add(time.Hour),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, task.RegistrationResultProcessSealed, registrationStatus)
+
+		proc, err := terminationDetectorSDK.Get(ctx, testSealingProcessID)
+		assert.NoError(t, err)
+		assert.NotNil(t, proc)
+		assert.Equal(t, process.StateCreated, proc.State)
+		assert.True(t, proc.Sealed)
+	})
+	t.Run("task lease can be extended only while task is running", func(t *testing.T) {
+		runningTaskID := task.ID{ProcessID: testSealingProcessID, TaskID: task1ID}
+		heartbeatResult, err := terminationDetectorSDK.Heartbeat(ctx, task.HeartbeatRequest{
+			ID:             runningTaskID,
+			ExpirationTime: time.Now().Add(time.Hour * 2),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, task.HeartbeatResultExtended, heartbeatResult)
+
+		completeResult, err := terminationDetectorSDK.Complete(ctx, task.CompleteRequest{
+			ID:    runningTaskID,
+			State: task.StateFinished,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, task.CompletingResultCompleted, completeResult)
+
+		heartbeatResult, err = terminationDetectorSDK.Heartbeat(ctx, task.HeartbeatRequest{
+			ID:             runningTaskID,
+			ExpirationTime: time.Now().Add(time.Hour * 2),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, task.HeartbeatResultConflict, heartbeatResult)
+	})
+	t.Run("completed process rejects new tasks before it is read", func(t *testing.T) {
+		completedTaskID := task.ID{ProcessID: testCompletedProcessID, TaskID: task1ID}
+		registrationStatus, err := terminationDetectorSDK.Register(ctx, task.RegistrationData{
+			ID:             completedTaskID,
+			ExpirationTime: time.Now().Add(time.Hour),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, task.RegistrationResultCreated, registrationStatus)
+
+		completeResult, err := terminationDetectorSDK.Complete(ctx, task.CompleteRequest{
+			ID:    completedTaskID,
+			State: task.StateFinished,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, task.CompletingResultCompleted, completeResult)
+
+		registrationStatus, err = terminationDetectorSDK.Register(ctx, task.RegistrationData{
+			ID:             task.ID{ProcessID: testCompletedProcessID, TaskID: task2ID},
+			ExpirationTime: time.Now().Add(time.Hour),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, task.RegistrationResultProcessSealed, registrationStatus)
+
+		proc, err := terminationDetectorSDK.Get(ctx, testCompletedProcessID)
+		assert.NoError(t, err)
+		assert.Equal(t, process.StateCompleted, proc.State)
+		assert.Equal(t, 1, proc.Progress.TotalTasksCount)
+	})
+	t.Run("processes can be got in batches, including one with the batch-get id", func(t *testing.T) {
+		registrationStatus, err := terminationDetectorSDK.Register(ctx, task.RegistrationData{
+			ID:             task.ID{ProcessID: testBatchGetProcessID, TaskID: task1ID},
+			ExpirationTime: time.Now().Add(time.Hour),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, task.RegistrationResultCreated, registrationStatus)
+
+		proc, err := terminationDetectorSDK.Get(ctx, testBatchGetProcessID)
+		assert.NoError(t, err)
+		assert.Equal(t, process.StateCreated, proc.State)
+
+		processes, err := terminationDetectorSDK.GetMany(ctx, []string{testBatchGetProcessID, testNotExistProcessID})
+		assert.NoError(t, err)
+		assert.Len(t, processes, 2)
+		assert.Equal(t, testBatchGetProcessID, processes[0].ID)
+		assert.Nil(t, processes[1])
+	})
+	t.Run("not registered process can not be sealed", func(t *testing.T) {
+		sealingResult, err := terminationDetectorSDK.Seal(ctx, testNotExistProcessID)
+		assert.NoError(t, err)
+		assert.Equal(t, process.SealingResultNotFound, sealingResult)
+	})
 }
 
-func removeTestDataFromDB(t *testing.T, awsSess *session.Session, tasksTableName string) {
+func removeTestDataFromDB(t *testing.T, awsSess *session.Session, tasksTableName, processID string) {
 	dynamoAPI := dynamodb.New(awsSess)
 	err := dynamoAPI.QueryPages(&dynamodb.QueryInput{
 		ConsistentRead:         aws.Bool(true),
@@ -171,7 +491,7 @@ func removeTestDataFromDB(t *testing.T, awsSess *session.Session, tasksTableName
 			dynamo.ProcessIDAttrAlias: aws.String(dynamo.ProcessIDAttrName),
 		},
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-			dynamo.ProcessIDValuePlaceholder: {S: aws.String(testProcessID)},
+			dynamo.ProcessIDValuePlaceholder: {S: aws.String(processID)},
 		},
 		TableName: &tasksTableName,
 	}, func(page *dynamodb.QueryOutput, isLastPage bool) bool {
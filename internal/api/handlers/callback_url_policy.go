@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"net/url"
+
+	"github.com/artii15/termination-detector/internal/webhook"
+)
+
+type CallbackURLPolicy struct {
+	AllowPrivateAddresses bool
+}
+
+func ReadCallbackURLPolicy() CallbackURLPolicy {
+	return CallbackURLPolicy{
+		AllowPrivateAddresses: webhook.ReadAllowPrivateAddresses(),
+	}
+}
+
+func (policy CallbackURLPolicy) isValid(callbackURL string) bool {
+	parsedURL, err := url.Parse(callbackURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Hostname() == "" {
+		return false
+	}
+	return policy.AllowPrivateAddresses || webhook.IsPublicHost(parsedURL.Hostname())
+}
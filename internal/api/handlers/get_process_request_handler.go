@@ -1,24 +1,62 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/artii15/termination-detector/pkg/dates"
 	internalHTTP "github.com/artii15/termination-detector/pkg/http"
 	"github.com/artii15/termination-detector/pkg/process"
 )
 
+const (
+	InvalidWaitSecondsMsg = "waitSeconds must be a non-negative number"
+	InvalidWhileStateMsg  = "whileState must be one of: CREATED, COMPLETED, ERROR"
+)
+
+var waitableProcessStates = map[process.State]bool{
+	process.StateCreated:   true,
+	process.StateCompleted: true,
+	process.StateError:     true,
+}
+
 type GetProcessRequestHandler struct {
-	processGetter process.Getter
+	processGetter     process.Getter
+	currentDateGetter CurrentDateGetter
+	waitingConfig     ProcessWaitingConfig
 }
 
-func NewGetProcessRequestHandler(processGetter process.Getter) *GetProcessRequestHandler {
+func NewGetProcessRequestHandler(processGetter process.Getter, currentDateGetter CurrentDateGetter,
+	waitingConfig ProcessWaitingConfig) *GetProcessRequestHandler {
 	return &GetProcessRequestHandler{
-		processGetter: processGetter,
+		processGetter:     processGetter,
+		currentDateGetter: currentDateGetter,
+		waitingConfig:     waitingConfig.withDefaults(),
 	}
 }
 
-func (handler *GetProcessRequestHandler) HandleRequest(request internalHTTP.Request) (internalHTTP.Response, error) {
-	foundProcess, err := handler.processGetter.Get(request.PathParameters[internalHTTP.PathParameterProcessID])
+func (handler *GetProcessRequestHandler) HandleRequest(ctx context.Context, request internalHTTP.Request) (
+	internalHTTP.Response, error) {
+	wait, whileState, errorResponse := handler.readWaitingParameters(request)
+	if errorResponse != nil {
+		return *errorResponse, nil
+	}
+
+	processID := request.PathParameters[internalHTTP.PathParameterProcessID]
+	waitingDeadline := handler.readWaitingDeadline(ctx, wait)
+	pollInterval := handler.waitingConfig.PollInterval
+	foundProcess, err := handler.processGetter.Get(ctx, processID)
+	for err == nil && foundProcess != nil && foundProcess.State == whileState {
+		currentDate := handler.currentDateGetter.GetCurrentDate()
+		if !currentDate.Before(waitingDeadline) ||
+			!waitForNextPoll(ctx, dates.MinDuration(pollInterval, waitingDeadline.Sub(currentDate))) {
+			break
+		}
+		pollInterval = dates.MinDuration(pollInterval*2, handler.waitingConfig.MaxPollInterval)
+		foundProcess, err = handler.processGetter.Get(ctx, processID)
+	}
 	if err != nil {
 		return internalHTTP.Response{}, err
 	}
@@ -32,3 +70,48 @@ func (handler *GetProcessRequestHandler) HandleRequest(request internalHTTP.Requ
 		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
 	}, nil
 }
+
+func (handler *GetProcessRequestHandler) readWaitingParameters(request internalHTTP.Request) (
+	time.Duration, process.State, *internalHTTP.Response) {
+	waitSecondsParameter, isWaitDefined := request.QueryParameters[internalHTTP.QueryParameterWaitSeconds]
+	if !isWaitDefined {
+		return 0, "", nil
+	}
+	waitSeconds, err := strconv.Atoi(waitSecondsParameter)
+	if err != nil || waitSeconds < 0 {
+		errorResponse := createTextResponse(http.StatusBadRequest, InvalidWaitSecondsMsg)
+		return 0, "", &errorResponse
+	}
+
+	whileState := process.StateCreated
+	if whileStateParameter, isWhileStateDefined := request.QueryParameters[internalHTTP.QueryParameterWhileState]; isWhileStateDefined {
+		whileState = process.State(whileStateParameter)
+		if !waitableProcessStates[whileState] {
+			errorResponse := createTextResponse(http.StatusBadRequest, InvalidWhileStateMsg)
+			return 0, "", &errorResponse
+		}
+	}
+	return dates.MinDuration(time.Duration(waitSeconds)*time.Second, handler.waitingConfig.MaxWait), whileState, nil
+}
+
+func (handler *GetProcessRequestHandler) readWaitingDeadline(ctx context.Context, wait time.Duration) time.Time {
+	waitingDeadline := handler.currentDateGetter.GetCurrentDate().Add(wait)
+	if ctxDeadline, hasDeadline := ctx.Deadline(); hasDeadline {
+		latestWaitingDeadline := ctxDeadline.Add(-handler.waitingConfig.DeadlineMargin)
+		if latestWaitingDeadline.Before(waitingDeadline) {
+			return latestWaitingDeadline
+		}
+	}
+	return waitingDeadline
+}
+
+func waitForNextPoll(ctx context.Context, pollInterval time.Duration) bool {
+	timer := time.NewTimer(pollInterval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
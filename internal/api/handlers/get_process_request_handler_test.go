@@ -1,9 +1,11 @@
 package handlers_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/artii15/termination-detector/internal/api/handlers"
 	internalHTTP "github.com/artii15/termination-detector/pkg/http"
@@ -17,8 +19,8 @@ type processGetterMock struct {
 	mock.Mock
 }
 
-func (getter *processGetterMock) Get(processID string) (*process.Process, error) {
-	args := getter.Called(processID)
+func (getter *processGetterMock) Get(ctx context.Context, processID string) (*process.Process, error) {
+	args := getter.Called(ctx, processID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -26,24 +28,41 @@ func (getter *processGetterMock) Get(processID string) (*process.Process, error)
 }
 
 type getProcessRequestHandlerWithMocks struct {
-	handler       *handlers.GetProcessRequestHandler
-	processGetter *processGetterMock
-	request       internalHTTP.Request
-	processID     string
+	handler           *handlers.GetProcessRequestHandler
+	processGetter     *processGetterMock
+	currentDateGetter *currentDateGetterMock
+	currentDate       time.Time
+	request           internalHTTP.Request
+	processID         string
 }
 
 func (getterAndMocks *getProcessRequestHandlerWithMocks) assertExpectations(t *testing.T) {
 	getterAndMocks.processGetter.AssertExpectations(t)
+	getterAndMocks.currentDateGetter.AssertExpectations(t)
+}
+
+func (getterAndMocks *getProcessRequestHandlerWithMocks) mockCurrentDates(currentDates ...time.Time) {
+	for _, currentDate := range currentDates {
+		getterAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentDate).Once()
+	}
 }
 
 func newGetProcessRequestHandlerWithMocks() *getProcessRequestHandlerWithMocks {
 	processGetter := new(processGetterMock)
-	handler := handlers.NewGetProcessRequestHandler(processGetter)
+	currentDateGetter := new(currentDateGetterMock)
+	handler := handlers.NewGetProcessRequestHandler(processGetter, currentDateGetter, handlers.ProcessWaitingConfig{
+		MaxWait:         time.Second,
+		PollInterval:    time.Millisecond,
+		MaxPollInterval: time.Millisecond * 100,
+		DeadlineMargin:  time.Second,
+	})
 	processID := "2"
 	return &getProcessRequestHandlerWithMocks{
-		handler:       handler,
-		processGetter: processGetter,
-		processID:     processID,
+		handler:           handler,
+		processGetter:     processGetter,
+		currentDateGetter: currentDateGetter,
+		currentDate:       time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC),
+		processID:         processID,
 		request: internalHTTP.Request{
 			PathParameters: map[internalHTTP.PathParameter]string{internalHTTP.PathParameterProcessID: processID},
 		},
@@ -52,14 +71,15 @@ func newGetProcessRequestHandlerWithMocks() *getProcessRequestHandlerWithMocks {
 
 func TestGetProcessRequestHandler_HandleRequest(t *testing.T) {
 	handlerAndMocks := newGetProcessRequestHandlerWithMocks()
+	handlerAndMocks.mockCurrentDates(handlerAndMocks.currentDate)
 	foundProcess := process.Process{
 		ID:           handlerAndMocks.processID,
 		State:        process.StateError,
 		StateMessage: aws.String("error"),
 	}
-	handlerAndMocks.processGetter.On("Get", handlerAndMocks.processID).Return(&foundProcess, nil)
+	handlerAndMocks.processGetter.On("Get", mock.Anything, handlerAndMocks.processID).Return(&foundProcess, nil)
 
-	response, err := handlerAndMocks.handler.HandleRequest(handlerAndMocks.request)
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
 	assert.NoError(t, err)
 	handlerAndMocks.assertExpectations(t)
 	assert.Equal(t, internalHTTP.Response{
@@ -75,9 +95,10 @@ func TestGetProcessRequestHandler_HandleRequest(t *testing.T) {
 
 func TestGetProcessRequestHandler_HandleRequest_ProcessNotFound(t *testing.T) {
 	handlerAndMocks := newGetProcessRequestHandlerWithMocks()
-	handlerAndMocks.processGetter.On("Get", handlerAndMocks.processID).Return((*process.Process)(nil), nil)
+	handlerAndMocks.mockCurrentDates(handlerAndMocks.currentDate)
+	handlerAndMocks.processGetter.On("Get", mock.Anything, handlerAndMocks.processID).Return((*process.Process)(nil), nil)
 
-	response, err := handlerAndMocks.handler.HandleRequest(handlerAndMocks.request)
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
 	assert.NoError(t, err)
 	handlerAndMocks.assertExpectations(t)
 	assert.Equal(t, internalHTTP.Response{
@@ -89,10 +110,121 @@ func TestGetProcessRequestHandler_HandleRequest_ProcessNotFound(t *testing.T) {
 
 func TestGetProcessRequestHandler_HandleRequest_ProcessGetterError(t *testing.T) {
 	handlerAndMocks := newGetProcessRequestHandlerWithMocks()
-	handlerAndMocks.processGetter.On("Get", handlerAndMocks.processID).
+	handlerAndMocks.mockCurrentDates(handlerAndMocks.currentDate)
+	handlerAndMocks.processGetter.On("Get", mock.Anything, handlerAndMocks.processID).
 		Return((*process.Process)(nil), errors.New("error"))
 
-	_, err := handlerAndMocks.handler.HandleRequest(handlerAndMocks.request)
+	_, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
 	assert.Error(t, err)
 	handlerAndMocks.assertExpectations(t)
 }
+
+func TestGetProcessRequestHandler_HandleRequest_WaitsWhileInState(t *testing.T) {
+	handlerAndMocks := newGetProcessRequestHandlerWithMocks()
+	handlerAndMocks.request.QueryParameters = map[internalHTTP.QueryParameter]string{
+		internalHTTP.QueryParameterWaitSeconds: "10",
+	}
+	handlerAndMocks.mockCurrentDates(handlerAndMocks.currentDate, handlerAndMocks.currentDate,
+		handlerAndMocks.currentDate.Add(time.Millisecond*500))
+	runningProcess := process.Process{ID: handlerAndMocks.processID, State: process.StateCreated}
+	completedProcess := process.Process{ID: handlerAndMocks.processID, State: process.StateCompleted, Sealed: true}
+	handlerAndMocks.processGetter.On("Get", mock.Anything, handlerAndMocks.processID).Return(&runningProcess, nil).Twice()
+	handlerAndMocks.processGetter.On("Get", mock.Anything, handlerAndMocks.processID).Return(&completedProcess, nil).Once()
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, internalHTTP.ConvertInternalToHTTPProcess(completedProcess).JSON(), response.Body)
+}
+
+func TestGetProcessRequestHandler_HandleRequest_WaitElapses(t *testing.T) {
+	handlerAndMocks := newGetProcessRequestHandlerWithMocks()
+	handlerAndMocks.request.QueryParameters = map[internalHTTP.QueryParameter]string{
+		internalHTTP.QueryParameterWaitSeconds: "1",
+		internalHTTP.QueryParameterWhileState:  string(process.StateCreated),
+	}
+	handlerAndMocks.mockCurrentDates(handlerAndMocks.currentDate, handlerAndMocks.currentDate,
+		handlerAndMocks.currentDate.Add(time.Millisecond*500), handlerAndMocks.currentDate.Add(time.Second))
+	runningProcess := process.Process{ID: handlerAndMocks.processID, State: process.StateCreated}
+	handlerAndMocks.processGetter.On("Get", mock.Anything, handlerAndMocks.processID).Return(&runningProcess, nil).
+		Times(3)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, internalHTTP.ConvertInternalToHTTPProcess(runningProcess).JSON(), response.Body)
+}
+
+func TestGetProcessRequestHandler_HandleRequest_WaitingCanceled(t *testing.T) {
+	handlerAndMocks := newGetProcessRequestHandlerWithMocks()
+	handlerAndMocks.request.QueryParameters = map[internalHTTP.QueryParameter]string{
+		internalHTTP.QueryParameterWaitSeconds: "10",
+	}
+	handlerAndMocks.mockCurrentDates(handlerAndMocks.currentDate, handlerAndMocks.currentDate)
+	ctx, cancel := context.WithCancel(context.Background())
+	runningProcess := process.Process{ID: handlerAndMocks.processID, State: process.StateCreated}
+	handlerAndMocks.processGetter.On("Get", ctx, handlerAndMocks.processID).Return(&runningProcess, nil).
+		Run(func(mock.Arguments) { cancel() }).Once()
+
+	response, err := handlerAndMocks.handler.HandleRequest(ctx, handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, internalHTTP.ConvertInternalToHTTPProcess(runningProcess).JSON(), response.Body)
+}
+
+func TestGetProcessRequestHandler_HandleRequest_WaitEndsBeforeContextDeadline(t *testing.T) {
+	handlerAndMocks := newGetProcessRequestHandlerWithMocks()
+	handlerAndMocks.request.QueryParameters = map[internalHTTP.QueryParameter]string{
+		internalHTTP.QueryParameterWaitSeconds: "10",
+	}
+	ctxDeadline := time.Now().Add(time.Hour)
+	ctx, cancel := context.WithDeadline(context.Background(), ctxDeadline)
+	defer cancel()
+	handlerAndMocks.mockCurrentDates(ctxDeadline.Add(-time.Second*2), ctxDeadline.Add(-time.Second))
+	runningProcess := process.Process{ID: handlerAndMocks.processID, State: process.StateCreated}
+	handlerAndMocks.processGetter.On("Get", ctx, handlerAndMocks.processID).Return(&runningProcess, nil).Once()
+
+	response, err := handlerAndMocks.handler.HandleRequest(ctx, handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, internalHTTP.ConvertInternalToHTTPProcess(runningProcess).JSON(), response.Body)
+}
+
+func TestGetProcessRequestHandler_HandleRequest_NotWaitingWhenInOtherState(t *testing.T) {
+	handlerAndMocks := newGetProcessRequestHandlerWithMocks()
+	handlerAndMocks.request.QueryParameters = map[internalHTTP.QueryParameter]string{
+		internalHTTP.QueryParameterWaitSeconds: "10",
+		internalHTTP.QueryParameterWhileState:  string(process.StateError),
+	}
+	handlerAndMocks.mockCurrentDates(handlerAndMocks.currentDate)
+	runningProcess := process.Process{ID: handlerAndMocks.processID, State: process.StateCreated}
+	handlerAndMocks.processGetter.On("Get", mock.Anything, handlerAndMocks.processID).Return(&runningProcess, nil).Once()
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+func TestGetProcessRequestHandler_HandleRequest_InvalidWaitingParameters(t *testing.T) {
+	for invalidParameter, expectedMessage := range map[internalHTTP.QueryParameter]string{
+		internalHTTP.QueryParameterWaitSeconds: handlers.InvalidWaitSecondsMsg,
+		internalHTTP.QueryParameterWhileState:  handlers.InvalidWhileStateMsg,
+	} {
+		handlerAndMocks := newGetProcessRequestHandlerWithMocks()
+		handlerAndMocks.request.QueryParameters = map[internalHTTP.QueryParameter]string{
+			internalHTTP.QueryParameterWaitSeconds: "1",
+		}
+		handlerAndMocks.request.QueryParameters[invalidParameter] = "invalid"
+
+		response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+		assert.NoError(t, err)
+		handlerAndMocks.assertExpectations(t)
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		assert.Equal(t, expectedMessage, response.Body)
+	}
+}
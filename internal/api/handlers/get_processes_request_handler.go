@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+const (
+	MaxListedProcessesCount             = 100
+	InvalidProcessesListStateMsg        = "state must be one of: CREATED, COMPLETED, ERROR"
+	InvalidProcessesListLabelMsg        = "label must have the name:value format"
+	InvalidProcessesListCreatedAfterMsg = "createdAfter must be a RFC3339 date"
+	InvalidProcessesListLimitMsg        = "limit must be a number between 1 and 100"
+)
+
+var listableProcessStates = map[process.State]bool{
+	process.StateCreated:   true,
+	process.StateCompleted: true,
+	process.StateError:     true,
+}
+
+type GetProcessesRequestHandler struct {
+	lister process.Lister
+}
+
+func NewGetProcessesRequestHandler(lister process.Lister) *GetProcessesRequestHandler {
+	return &GetProcessesRequestHandler{
+		lister: lister,
+	}
+}
+
+func (handler *GetProcessesRequestHandler) HandleRequest(ctx context.Context, request internalHTTP.Request) (
+	internalHTTP.Response, error) {
+	listRequest := process.ListRequest{
+		Cursor: request.QueryParameters[internalHTTP.QueryParameterCursor],
+		Limit:  MaxListedProcessesCount,
+	}
+	if stateParameter, isStateDefined := request.QueryParameters[internalHTTP.QueryParameterState]; isStateDefined {
+		state := process.State(stateParameter)
+		if !listableProcessStates[state] {
+			return createTextResponse(http.StatusBadRequest, InvalidProcessesListStateMsg), nil
+		}
+		listRequest.State = &state
+	}
+	if labelParameter, isLabelDefined := request.QueryParameters[internalHTTP.QueryParameterLabel]; isLabelDefined {
+		label, isValidLabel := process.ParseLabel(labelParameter)
+		if !isValidLabel {
+			return createTextResponse(http.StatusBadRequest, InvalidProcessesListLabelMsg), nil
+		}
+		listRequest.Label = &label
+	}
+	if createdAfterParameter, isCreatedAfterDefined :=
+		request.QueryParameters[internalHTTP.QueryParameterCreatedAfter]; isCreatedAfterDefined {
+		createdAfter, err := time.Parse(time.RFC3339, createdAfterParameter)
+		if err != nil {
+			return createTextResponse(http.StatusBadRequest, InvalidProcessesListCreatedAfterMsg), nil
+		}
+		listRequest.CreatedAfter = createdAfter
+	}
+	if limitParameter, isLimitDefined := request.QueryParameters[internalHTTP.QueryParameterLimit]; isLimitDefined {
+		limit, err := strconv.Atoi(limitParameter)
+		if err != nil || limit < 1 || limit > MaxListedProcessesCount {
+			return createTextResponse(http.StatusBadRequest, InvalidProcessesListLimitMsg), nil
+		}
+		listRequest.Limit = limit
+	}
+
+	processesList, err := handler.lister.ListProcesses(ctx, listRequest)
+	if err == process.ErrInvalidCursor {
+		return createTextResponse(http.StatusBadRequest, internalHTTP.InvalidProcessesListCursorMessage), nil
+	}
+	if err != nil {
+		return internalHTTP.Response{}, err
+	}
+
+	return internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body:       internalHTTP.ConvertInternalToHTTPProcessesList(processesList).JSON(),
+		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+	}, nil
+}
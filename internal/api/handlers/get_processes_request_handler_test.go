@@ -0,0 +1,145 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/api/handlers"
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type processListerMock struct {
+	mock.Mock
+}
+
+func (lister *processListerMock) ListProcesses(ctx context.Context, request process.ListRequest) (process.List, error) {
+	args := lister.Called(ctx, request)
+	return args.Get(0).(process.List), args.Error(1)
+}
+
+type getProcessesReqHandlerWithMocks struct {
+	request     internalHTTP.Request
+	listRequest process.ListRequest
+	listerMock  *processListerMock
+	handler     *handlers.GetProcessesRequestHandler
+}
+
+func (handlerAndMocks *getProcessesReqHandlerWithMocks) assertExpectations(t *testing.T) {
+	handlerAndMocks.listerMock.AssertExpectations(t)
+}
+
+func newGetProcessesReqHandlerWithMocks() *getProcessesReqHandlerWithMocks {
+	lister := new(processListerMock)
+	state := process.StateCreated
+	return &getProcessesReqHandlerWithMocks{
+		request: internalHTTP.Request{
+			Method:       internalHTTP.MethodGet,
+			ResourcePath: internalHTTP.ResourcePathProcesses,
+			QueryParameters: map[internalHTTP.QueryParameter]string{
+				internalHTTP.QueryParameterState:        string(state),
+				internalHTTP.QueryParameterLabel:        "team:ingest",
+				internalHTTP.QueryParameterCreatedAfter: "2020-01-02T03:04:05Z",
+				internalHTTP.QueryParameterCursor:       "cursor",
+				internalHTTP.QueryParameterLimit:        "5",
+			},
+		},
+		listRequest: process.ListRequest{
+			State:        &state,
+			Label:        &process.Label{Name: "team", Value: "ingest"},
+			CreatedAfter: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			Cursor:       "cursor",
+			Limit:        5,
+		},
+		listerMock: lister,
+		handler:    handlers.NewGetProcessesRequestHandler(lister),
+	}
+}
+
+func TestGetProcessesRequestHandler_HandleRequest(t *testing.T) {
+	handlerAndMocks := newGetProcessesReqHandlerWithMocks()
+	processesList := process.List{
+		Processes: []process.Process{{
+			ID:           "1",
+			State:        process.StateCreated,
+			Labels:       map[string]string{"team": "ingest"},
+			CreationTime: time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC),
+		}},
+		NextCursor: "next",
+	}
+	handlerAndMocks.listerMock.On("ListProcesses", mock.Anything, handlerAndMocks.listRequest).Return(processesList, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body:       internalHTTP.ConvertInternalToHTTPProcessesList(processesList).JSON(),
+		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+	}, response)
+}
+
+func TestGetProcessesRequestHandler_HandleRequest_DefaultLimit(t *testing.T) {
+	handlerAndMocks := newGetProcessesReqHandlerWithMocks()
+	handlerAndMocks.request.QueryParameters = nil
+	handlerAndMocks.listerMock.On("ListProcesses", mock.Anything, process.ListRequest{Limit: handlers.MaxListedProcessesCount}).
+		Return(process.List{Processes: []process.Process{}}, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.JSONEq(t, `{"processes":[]}`, response.Body)
+}
+
+func TestGetProcessesRequestHandler_HandleRequest_InvalidParameters(t *testing.T) {
+	invalidParameters := []struct {
+		parameter     internalHTTP.QueryParameter
+		value         string
+		expectedError string
+	}{
+		{internalHTTP.QueryParameterState, "FINISHED", handlers.InvalidProcessesListStateMsg},
+		{internalHTTP.QueryParameterLabel, "team", handlers.InvalidProcessesListLabelMsg},
+		{internalHTTP.QueryParameterLabel, ":ingest", handlers.InvalidProcessesListLabelMsg},
+		{internalHTTP.QueryParameterCreatedAfter, "yesterday", handlers.InvalidProcessesListCreatedAfterMsg},
+		{internalHTTP.QueryParameterLimit, "0", handlers.InvalidProcessesListLimitMsg},
+		{internalHTTP.QueryParameterLimit, "101", handlers.InvalidProcessesListLimitMsg},
+	}
+	for _, invalidParameter := range invalidParameters {
+		handlerAndMocks := newGetProcessesReqHandlerWithMocks()
+		handlerAndMocks.request.QueryParameters[invalidParameter.parameter] = invalidParameter.value
+
+		response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+		assert.NoError(t, err)
+		handlerAndMocks.assertExpectations(t)
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		assert.Equal(t, invalidParameter.expectedError, response.Body)
+	}
+}
+
+func TestGetProcessesRequestHandler_HandleRequest_InvalidCursor(t *testing.T) {
+	handlerAndMocks := newGetProcessesReqHandlerWithMocks()
+	handlerAndMocks.listerMock.On("ListProcesses", mock.Anything, handlerAndMocks.listRequest).
+		Return(process.List{}, process.ErrInvalidCursor)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Equal(t, internalHTTP.InvalidProcessesListCursorMessage, response.Body)
+}
+
+func TestGetProcessesRequestHandler_HandleRequest_ListingError(t *testing.T) {
+	handlerAndMocks := newGetProcessesReqHandlerWithMocks()
+	handlerAndMocks.listerMock.On("ListProcesses", mock.Anything, handlerAndMocks.listRequest).
+		Return(process.List{}, errors.New("error"))
+
+	_, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.Error(t, err)
+	handlerAndMocks.assertExpectations(t)
+}
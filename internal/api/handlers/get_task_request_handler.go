@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+type GetTaskRequestHandler struct {
+	taskGetter task.Getter
+}
+
+func NewGetTaskRequestHandler(taskGetter task.Getter) *GetTaskRequestHandler {
+	return &GetTaskRequestHandler{
+		taskGetter: taskGetter,
+	}
+}
+
+func (handler *GetTaskRequestHandler) HandleRequest(ctx context.Context, request internalHTTP.Request) (
+	internalHTTP.Response, error) {
+	foundTask, err := handler.taskGetter.GetTask(ctx, task.ID{
+		ProcessID: request.PathParameters[internalHTTP.PathParameterProcessID],
+		TaskID:    request.PathParameters[internalHTTP.PathParameterTaskID],
+	})
+	if err != nil {
+		return internalHTTP.Response{}, err
+	}
+	if foundTask == nil {
+		return internalHTTP.CreateDefaultTextResponseWithStatus(http.StatusNotFound), nil
+	}
+
+	return internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body:       internalHTTP.ConvertInternalToHTTPTaskDetails(*foundTask).JSON(),
+		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+	}, nil
+}
@@ -0,0 +1,94 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/api/handlers"
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type taskGetterMock struct {
+	mock.Mock
+}
+
+func (getter *taskGetterMock) GetTask(ctx context.Context, id task.ID) (*task.Task, error) {
+	args := getter.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*task.Task), args.Error(1)
+}
+
+type getTaskRequestHandlerWithMocks struct {
+	handler    *handlers.GetTaskRequestHandler
+	taskGetter *taskGetterMock
+	request    internalHTTP.Request
+	taskID     task.ID
+}
+
+func (handlerAndMocks *getTaskRequestHandlerWithMocks) assertExpectations(t *testing.T) {
+	handlerAndMocks.taskGetter.AssertExpectations(t)
+}
+
+func newGetTaskRequestHandlerWithMocks() *getTaskRequestHandlerWithMocks {
+	taskGetter := new(taskGetterMock)
+	taskID := task.ID{ProcessID: "1", TaskID: "2"}
+	return &getTaskRequestHandlerWithMocks{
+		handler:    handlers.NewGetTaskRequestHandler(taskGetter),
+		taskGetter: taskGetter,
+		taskID:     taskID,
+		request: internalHTTP.Request{
+			PathParameters: map[internalHTTP.PathParameter]string{
+				internalHTTP.PathParameterProcessID: taskID.ProcessID,
+				internalHTTP.PathParameterTaskID:    taskID.TaskID,
+			},
+		},
+	}
+}
+
+func TestGetTaskRequestHandler_HandleRequest(t *testing.T) {
+	handlerAndMocks := newGetTaskRequestHandlerWithMocks()
+	foundTask := task.Task{
+		ID:             handlerAndMocks.taskID,
+		State:          task.StateCreated,
+		ExpirationTime: time.Now().UTC(),
+		CreationTime:   time.Now().UTC().Add(-time.Hour),
+		TimedOut:       true,
+	}
+	handlerAndMocks.taskGetter.On("GetTask", mock.Anything, handlerAndMocks.taskID).Return(&foundTask, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body:       internalHTTP.ConvertInternalToHTTPTaskDetails(foundTask).JSON(),
+		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+	}, response)
+}
+
+func TestGetTaskRequestHandler_HandleRequest_NotFound(t *testing.T) {
+	handlerAndMocks := newGetTaskRequestHandlerWithMocks()
+	handlerAndMocks.taskGetter.On("GetTask", mock.Anything, handlerAndMocks.taskID).Return(nil, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, internalHTTP.CreateDefaultTextResponseWithStatus(http.StatusNotFound), response)
+}
+
+func TestGetTaskRequestHandler_HandleRequest_GetterError(t *testing.T) {
+	handlerAndMocks := newGetTaskRequestHandlerWithMocks()
+	handlerAndMocks.taskGetter.On("GetTask", mock.Anything, handlerAndMocks.taskID).Return(nil, errors.New("error"))
+
+	_, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.Error(t, err)
+	handlerAndMocks.assertExpectations(t)
+}
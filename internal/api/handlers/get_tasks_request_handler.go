@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+const (
+	MaxListedTasksCount      = 100
+	InvalidTasksListStateMsg = "state must be one of: CREATED, FINISHED, ABORTED, TIMED_OUT"
+	InvalidTasksListLimitMsg = "limit must be a number between 1 and 100"
+)
+
+var listableTaskStates = map[task.State]bool{
+	task.StateCreated:  true,
+	task.StateFinished: true,
+	task.StateAborted:  true,
+	task.StateTimedOut: true,
+}
+
+type GetTasksRequestHandler struct {
+	lister task.Lister
+}
+
+func NewGetTasksRequestHandler(lister task.Lister) *GetTasksRequestHandler {
+	return &GetTasksRequestHandler{
+		lister: lister,
+	}
+}
+
+func (handler *GetTasksRequestHandler) HandleRequest(ctx context.Context, request internalHTTP.Request) (
+	internalHTTP.Response, error) {
+	listRequest := task.ListRequest{
+		ProcessID: request.PathParameters[internalHTTP.PathParameterProcessID],
+		Cursor:    request.QueryParameters[internalHTTP.QueryParameterCursor],
+		Limit:     MaxListedTasksCount,
+	}
+	if stateParameter, isStateDefined := request.QueryParameters[internalHTTP.QueryParameterState]; isStateDefined {
+		state := task.State(stateParameter)
+		if !listableTaskStates[state] {
+			return createTextResponse(http.StatusBadRequest, InvalidTasksListStateMsg), nil
+		}
+		listRequest.State = &state
+	}
+	if limitParameter, isLimitDefined := request.QueryParameters[internalHTTP.QueryParameterLimit]; isLimitDefined {
+		limit, err := strconv.Atoi(limitParameter)
+		if err != nil || limit < 1 || limit > MaxListedTasksCount {
+			return createTextResponse(http.StatusBadRequest, InvalidTasksListLimitMsg), nil
+		}
+		listRequest.Limit = limit
+	}
+
+	tasksList, err := handler.lister.List(ctx, listRequest)
+	if err == task.ErrInvalidCursor {
+		return createTextResponse(http.StatusBadRequest, internalHTTP.InvalidTasksListCursorMessage), nil
+	}
+	if err != nil {
+		return internalHTTP.Response{}, err
+	}
+
+	return internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body:       internalHTTP.ConvertInternalToHTTPTasksList(tasksList).JSON(),
+		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+	}, nil
+}
@@ -0,0 +1,143 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/api/handlers"
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type taskListerMock struct {
+	mock.Mock
+}
+
+func (lister *taskListerMock) List(ctx context.Context, request task.ListRequest) (task.List, error) {
+	args := lister.Called(ctx, request)
+	return args.Get(0).(task.List), args.Error(1)
+}
+
+type getTasksReqHandlerWithMocks struct {
+	request     internalHTTP.Request
+	listRequest task.ListRequest
+	listerMock  *taskListerMock
+	handler     *handlers.GetTasksRequestHandler
+}
+
+func (handlerAndMocks *getTasksReqHandlerWithMocks) assertExpectations(t *testing.T) {
+	handlerAndMocks.listerMock.AssertExpectations(t)
+}
+
+func newGetTasksReqHandlerWithMocks() *getTasksReqHandlerWithMocks {
+	lister := new(taskListerMock)
+	state := task.StateAborted
+	return &getTasksReqHandlerWithMocks{
+		request: internalHTTP.Request{
+			Method:       internalHTTP.MethodGet,
+			ResourcePath: internalHTTP.ResourcePathTasks,
+			PathParameters: map[internalHTTP.PathParameter]string{
+				internalHTTP.PathParameterProcessID: "1",
+			},
+			QueryParameters: map[internalHTTP.QueryParameter]string{
+				internalHTTP.QueryParameterState:  string(state),
+				internalHTTP.QueryParameterCursor: "cursor",
+				internalHTTP.QueryParameterLimit:  "5",
+			},
+		},
+		listRequest: task.ListRequest{
+			ProcessID: "1",
+			State:     &state,
+			Cursor:    "cursor",
+			Limit:     5,
+		},
+		listerMock: lister,
+		handler:    handlers.NewGetTasksRequestHandler(lister),
+	}
+}
+
+func TestGetTasksRequestHandler_HandleRequest(t *testing.T) {
+	handlerAndMocks := newGetTasksReqHandlerWithMocks()
+	stateMessage := "failure"
+	tasksList := task.List{
+		Tasks: []task.Task{{
+			ID:             task.ID{ProcessID: "1", TaskID: "2"},
+			State:          task.StateAborted,
+			StateMessage:   &stateMessage,
+			ExpirationTime: time.Now().UTC(),
+		}},
+		NextCursor: "next",
+	}
+	handlerAndMocks.listerMock.On("List", mock.Anything, handlerAndMocks.listRequest).Return(tasksList, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body:       internalHTTP.ConvertInternalToHTTPTasksList(tasksList).JSON(),
+		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+	}, response)
+}
+
+func TestGetTasksRequestHandler_HandleRequest_DefaultLimit(t *testing.T) {
+	handlerAndMocks := newGetTasksReqHandlerWithMocks()
+	handlerAndMocks.request.QueryParameters = nil
+	handlerAndMocks.listerMock.On("List", mock.Anything, task.ListRequest{ProcessID: "1", Limit: handlers.MaxListedTasksCount}).
+		Return(task.List{Tasks: []task.Task{}}, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.JSONEq(t, `{"tasks":[]}`, response.Body)
+}
+
+func TestGetTasksRequestHandler_HandleRequest_InvalidState(t *testing.T) {
+	handlerAndMocks := newGetTasksReqHandlerWithMocks()
+	handlerAndMocks.request.QueryParameters[internalHTTP.QueryParameterState] = "UNKNOWN"
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Equal(t, handlers.InvalidTasksListStateMsg, response.Body)
+}
+
+func TestGetTasksRequestHandler_HandleRequest_InvalidLimit(t *testing.T) {
+	for _, limit := range []string{"0", "101", "abc"} {
+		handlerAndMocks := newGetTasksReqHandlerWithMocks()
+		handlerAndMocks.request.QueryParameters[internalHTTP.QueryParameterLimit] = limit
+
+		response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+		assert.NoError(t, err)
+		handlerAndMocks.assertExpectations(t)
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		assert.Equal(t, handlers.InvalidTasksListLimitMsg, response.Body)
+	}
+}
+
+func TestGetTasksRequestHandler_HandleRequest_InvalidCursor(t *testing.T) {
+	handlerAndMocks := newGetTasksReqHandlerWithMocks()
+	handlerAndMocks.listerMock.On("List", mock.Anything, handlerAndMocks.listRequest).Return(task.List{}, task.ErrInvalidCursor)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Equal(t, internalHTTP.InvalidTasksListCursorMessage, response.Body)
+}
+
+func TestGetTasksRequestHandler_HandleRequest_ListingError(t *testing.T) {
+	handlerAndMocks := newGetTasksReqHandlerWithMocks()
+	handlerAndMocks.listerMock.On("List", mock.Anything, handlerAndMocks.listRequest).Return(task.List{}, errors.New("error"))
+
+	_, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.Error(t, err)
+	handlerAndMocks.assertExpectations(t)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+const (
+	MaxBatchGetProcessesCount    = 100
+	InvalidBatchGetProcessIDsMsg = "processIds must contain between 1 and 100 non empty ids"
+
+	maxConcurrentProcessGets = 10
+)
+
+type PostProcessesBatchGetRequestHandler struct {
+	processGetter process.Getter
+}
+
+func NewPostProcessesBatchGetRequestHandler(processGetter process.Getter) *PostProcessesBatchGetRequestHandler {
+	return &PostProcessesBatchGetRequestHandler{
+		processGetter: processGetter,
+	}
+}
+
+func (handler *PostProcessesBatchGetRequestHandler) HandleRequest(ctx context.Context, request internalHTTP.Request) (
+	internalHTTP.Response, error) {
+	if request.PathParameters[internalHTTP.PathParameterProcessesAction] != internalHTTP.ProcessesActionBatchGet {
+		return internalHTTP.CreateDefaultTextResponseWithStatus(http.StatusNotFound), nil
+	}
+	batchGet, err := internalHTTP.UnmarshalProcessesBatchGet(request.Body)
+	if err != nil {
+		return createTextResponse(http.StatusBadRequest, InvalidPayloadErrorMessage), nil
+	}
+	if !areBatchGetProcessIDsValid(batchGet.ProcessIDs) {
+		return createTextResponse(http.StatusBadRequest, InvalidBatchGetProcessIDsMsg), nil
+	}
+
+	processes, err := handler.getProcesses(ctx, batchGet.ProcessIDs)
+	if err != nil {
+		return internalHTTP.Response{}, err
+	}
+
+	return internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+		Body:       internalHTTP.ConvertInternalToHTTPProcessesBatchGetResults(batchGet.ProcessIDs, processes).JSON(),
+	}, nil
+}
+
+func (handler *PostProcessesBatchGetRequestHandler) getProcesses(ctx context.Context,
+	processIDs []string) ([]*process.Process, error) {
+	processes := make([]*process.Process, len(processIDs))
+	errs := make([]error, len(processIDs))
+	concurrencyLimiter := make(chan struct{}, maxConcurrentProcessGets)
+	var waitGroup sync.WaitGroup
+	for index, processID := range processIDs {
+		waitGroup.Add(1)
+		concurrencyLimiter <- struct{}{}
+		go func(index int, processID string) {
+			defer waitGroup.Done()
+			defer func() { <-concurrencyLimiter }()
+			processes[index], errs[index] = handler.processGetter.Get(ctx, processID)
+		}(index, processID)
+	}
+	waitGroup.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return processes, nil
+}
+
+func areBatchGetProcessIDsValid(processIDs []string) bool {
+	if len(processIDs) == 0 || len(processIDs) > MaxBatchGetProcessesCount {
+		return false
+	}
+	for _, processID := range processIDs {
+		if processID == "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,136 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/artii15/termination-detector/internal/api/handlers"
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type postProcessesBatchGetReqHandlerWithMocks struct {
+	request       internalHTTP.Request
+	processGetter *processGetterMock
+	handler       *handlers.PostProcessesBatchGetRequestHandler
+}
+
+func (handlerAndMocks *postProcessesBatchGetReqHandlerWithMocks) assertExpectations(t *testing.T) {
+	handlerAndMocks.processGetter.AssertExpectations(t)
+}
+
+func newPostProcessesBatchGetReqHandlerWithMocks(processIDs ...string) *postProcessesBatchGetReqHandlerWithMocks {
+	processGetter := new(processGetterMock)
+	return &postProcessesBatchGetReqHandlerWithMocks{
+		request: internalHTTP.Request{
+			PathParameters: map[internalHTTP.PathParameter]string{
+				internalHTTP.PathParameterProcessesAction: internalHTTP.ProcessesActionBatchGet,
+			},
+			Body: internalHTTP.ProcessesBatchGet{ProcessIDs: processIDs}.JSON(),
+		},
+		processGetter: processGetter,
+		handler:       handlers.NewPostProcessesBatchGetRequestHandler(processGetter),
+	}
+}
+
+func TestPostProcessesBatchGetRequestHandler_HandleRequest(t *testing.T) {
+	handlerAndMocks := newPostProcessesBatchGetReqHandlerWithMocks("1", "2", "3")
+	firstProcess := &process.Process{ID: "1", State: process.StateCreated}
+	thirdProcess := &process.Process{ID: "3", State: process.StateCompleted}
+	handlerAndMocks.processGetter.On("Get", mock.Anything, "1").Return(firstProcess, nil)
+	handlerAndMocks.processGetter.On("Get", mock.Anything, "2").Return(nil, nil)
+	handlerAndMocks.processGetter.On("Get", mock.Anything, "3").Return(thirdProcess, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+		Body: internalHTTP.ConvertInternalToHTTPProcessesBatchGetResults([]string{"1", "2", "3"},
+			[]*process.Process{firstProcess, nil, thirdProcess}).JSON(),
+	}, response)
+}
+
+func TestPostProcessesBatchGetRequestHandler_HandleRequest_ManyProcesses(t *testing.T) {
+	processIDs := make([]string, 0, handlers.MaxBatchGetProcessesCount)
+	for index := 0; index < handlers.MaxBatchGetProcessesCount; index++ {
+		processIDs = append(processIDs, strconv.Itoa(index))
+	}
+	handlerAndMocks := newPostProcessesBatchGetReqHandlerWithMocks(processIDs...)
+	processes := make([]*process.Process, 0, len(processIDs))
+	for _, processID := range processIDs {
+		foundProcess := &process.Process{ID: processID, State: process.StateCreated}
+		processes = append(processes, foundProcess)
+		handlerAndMocks.processGetter.On("Get", mock.Anything, processID).Return(foundProcess, nil)
+	}
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, internalHTTP.ConvertInternalToHTTPProcessesBatchGetResults(processIDs, processes).JSON(),
+		response.Body)
+}
+
+func TestPostProcessesBatchGetRequestHandler_HandleRequest_InvalidRequests(t *testing.T) {
+	tooManyProcessIDs := make([]string, 0, handlers.MaxBatchGetProcessesCount+1)
+	for index := 0; index <= handlers.MaxBatchGetProcessesCount; index++ {
+		tooManyProcessIDs = append(tooManyProcessIDs, strconv.Itoa(index))
+	}
+	testCases := []struct {
+		name         string
+		body         string
+		expectedBody string
+	}{
+		{name: "invalid payload", body: "[]", expectedBody: handlers.InvalidPayloadErrorMessage},
+		{name: "empty", body: internalHTTP.ProcessesBatchGet{}.JSON(), expectedBody: handlers.InvalidBatchGetProcessIDsMsg},
+		{
+			name:         "empty id",
+			body:         internalHTTP.ProcessesBatchGet{ProcessIDs: []string{"1", ""}}.JSON(),
+			expectedBody: handlers.InvalidBatchGetProcessIDsMsg,
+		},
+		{
+			name:         "too many",
+			body:         internalHTTP.ProcessesBatchGet{ProcessIDs: tooManyProcessIDs}.JSON(),
+			expectedBody: handlers.InvalidBatchGetProcessIDsMsg,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			handlerAndMocks := newPostProcessesBatchGetReqHandlerWithMocks()
+			handlerAndMocks.request.Body = testCase.body
+
+			response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+			assert.NoError(t, err)
+			handlerAndMocks.assertExpectations(t)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			assert.Equal(t, testCase.expectedBody, response.Body)
+		})
+	}
+}
+
+func TestPostProcessesBatchGetRequestHandler_HandleRequest_UnknownAction(t *testing.T) {
+	handlerAndMocks := newPostProcessesBatchGetReqHandlerWithMocks("1")
+	handlerAndMocks.request.PathParameters[internalHTTP.PathParameterProcessesAction] = "batch-get"
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+func TestPostProcessesBatchGetRequestHandler_HandleRequest_GettingError(t *testing.T) {
+	handlerAndMocks := newPostProcessesBatchGetReqHandlerWithMocks("1", "2")
+	handlerAndMocks.processGetter.On("Get", mock.Anything, "1").Return(nil, nil)
+	handlerAndMocks.processGetter.On("Get", mock.Anything, "2").Return(nil, errors.New("error"))
+
+	_, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.Error(t, err)
+	handlerAndMocks.assertExpectations(t)
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/dates"
+	"github.com/artii15/termination-detector/pkg/env"
+)
+
+const (
+	ProcessMaxWaitEnvVar             = "PROCESS_MAX_WAIT"
+	ProcessWaitPollIntervalEnvVar    = "PROCESS_WAIT_POLL_INTERVAL"
+	ProcessWaitMaxPollIntervalEnvVar = "PROCESS_WAIT_MAX_POLL_INTERVAL"
+	ProcessWaitDeadlineMarginEnvVar  = "PROCESS_WAIT_DEADLINE_MARGIN"
+
+	defaultProcessWaitPollInterval    = "1s"
+	defaultProcessWaitMaxPollInterval = "5s"
+	defaultProcessWaitDeadlineMargin  = "1s"
+)
+
+type ProcessWaitingConfig struct {
+	MaxWait         time.Duration
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+	DeadlineMargin  time.Duration
+}
+
+func ReadProcessWaitingConfig(defaultMaxWait string) ProcessWaitingConfig {
+	return ProcessWaitingConfig{
+		MaxWait:         dates.MustParseDuration(env.ReadOrDefault(ProcessMaxWaitEnvVar, defaultMaxWait)),
+		PollInterval:    dates.MustParseDuration(env.ReadOrDefault(ProcessWaitPollIntervalEnvVar, defaultProcessWaitPollInterval)),
+		MaxPollInterval: dates.MustParseDuration(env.ReadOrDefault(ProcessWaitMaxPollIntervalEnvVar, defaultProcessWaitMaxPollInterval)),
+		DeadlineMargin:  dates.MustParseDuration(env.ReadOrDefault(ProcessWaitDeadlineMarginEnvVar, defaultProcessWaitDeadlineMargin)),
+	}
+}
+
+func (config ProcessWaitingConfig) withDefaults() ProcessWaitingConfig {
+	if config.PollInterval <= 0 {
+		config.PollInterval = dates.MustParseDuration(defaultProcessWaitPollInterval)
+	}
+	if config.MaxPollInterval < config.PollInterval {
+		config.MaxPollInterval = config.PollInterval
+	}
+	if config.DeadlineMargin <= 0 {
+		config.DeadlineMargin = dates.MustParseDuration(defaultProcessWaitDeadlineMargin)
+	}
+	return config
+}
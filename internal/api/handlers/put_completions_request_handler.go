@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+const (
+	MaxBatchCompletedTasksCount           = 1000
+	MaxTransactionallyCompletedTasksCount = 99
+)
+
+type PutCompletionsRequestHandler struct {
+	completer task.Completer
+}
+
+func NewPutCompletionsRequestHandler(completer task.Completer) *PutCompletionsRequestHandler {
+	return &PutCompletionsRequestHandler{
+		completer: completer,
+	}
+}
+
+func (handler *PutCompletionsRequestHandler) HandleRequest(ctx context.Context, request internalHTTP.Request) (
+	internalHTTP.Response, error) {
+	batchCompletions, err := internalHTTP.UnmarshalBatchCompletions(request.Body)
+	if err != nil {
+		return createTextResponse(http.StatusBadRequest, InvalidPayloadErrorMessage), nil
+	}
+	isTransactional, isTransactionalValid := readTransactional(request)
+	if !isTransactionalValid {
+		return createTextResponse(http.StatusBadRequest, InvalidTransactionalMsg), nil
+	}
+	if len(batchCompletions) > MaxBatchCompletedTasksCount ||
+		(isTransactional && len(batchCompletions) > MaxTransactionallyCompletedTasksCount) {
+		return createTextResponse(http.StatusBadRequest, TooManyBatchTasksMsg), nil
+	}
+
+	processID := request.PathParameters[internalHTTP.PathParameterProcessID]
+	completeRequests, areTasksValid := readBatchCompletions(processID, batchCompletions)
+	if !areTasksValid {
+		return createTextResponse(http.StatusBadRequest, InvalidBatchTasksMsg), nil
+	}
+	for _, completeRequest := range completeRequests {
+		if completeRequest.State == "" {
+			return createTextResponse(http.StatusBadRequest, UnknownCompletionStateMsg), nil
+		}
+	}
+
+	results, err := handler.completer.BatchComplete(ctx, task.BatchCompleteRequest{
+		ProcessID:     processID,
+		Tasks:         completeRequests,
+		Transactional: isTransactional,
+	})
+	if err != nil {
+		return internalHTTP.Response{}, err
+	}
+
+	return internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+		Body:       internalHTTP.ConvertInternalToHTTPBatchCompletingResults(results).JSON(),
+	}, nil
+}
+
+func readBatchCompletions(processID string, batchCompletions internalHTTP.BatchCompletions) ([]task.CompleteRequest, bool) {
+	if len(batchCompletions) == 0 {
+		return nil, false
+	}
+	completeRequests := make([]task.CompleteRequest, 0, len(batchCompletions))
+	usedTaskIDs := make(map[string]bool)
+	for _, batchCompletion := range batchCompletions {
+		if batchCompletion.TaskID == "" || task.IsReservedTaskID(batchCompletion.TaskID) || usedTaskIDs[batchCompletion.TaskID] {
+			return nil, false
+		}
+		usedTaskIDs[batchCompletion.TaskID] = true
+		completeRequests = append(completeRequests, task.CompleteRequest{
+			ID: task.ID{
+				ProcessID: processID,
+				TaskID:    batchCompletion.TaskID,
+			},
+			State:   completionStateToTaskStateMapping[batchCompletion.State],
+			Message: batchCompletion.ErrorMessage,
+		})
+	}
+	return completeRequests, true
+}
@@ -0,0 +1,149 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/artii15/termination-detector/internal/api/handlers"
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type putCompletionsReqHandlerWithMocks struct {
+	request       internalHTTP.Request
+	completerMock *taskCompleterMock
+	handler       *handlers.PutCompletionsRequestHandler
+}
+
+func (handlerAndMocks *putCompletionsReqHandlerWithMocks) assertExpectations(t *testing.T) {
+	handlerAndMocks.completerMock.AssertExpectations(t)
+}
+
+func newPutCompletionsReqHandlerWithMocks(batchCompletions internalHTTP.BatchCompletions) *putCompletionsReqHandlerWithMocks {
+	completerMock := new(taskCompleterMock)
+	return &putCompletionsReqHandlerWithMocks{
+		request: internalHTTP.Request{
+			PathParameters: map[internalHTTP.PathParameter]string{
+				internalHTTP.PathParameterProcessID: "2",
+			},
+			QueryParameters: map[internalHTTP.QueryParameter]string{},
+			Body:            batchCompletions.JSON(),
+		},
+		completerMock: completerMock,
+		handler:       handlers.NewPutCompletionsRequestHandler(completerMock),
+	}
+}
+
+func newBatchCompletions(taskIDs ...string) internalHTTP.BatchCompletions {
+	batchCompletions := make(internalHTTP.BatchCompletions, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		batchCompletions = append(batchCompletions, internalHTTP.BatchCompletion{
+			TaskID:     taskID,
+			Completion: internalHTTP.Completion{State: internalHTTP.CompletionStateCompleted},
+		})
+	}
+	return batchCompletions
+}
+
+func TestPutCompletionsRequestHandler_HandleRequest(t *testing.T) {
+	batchCompletions := internalHTTP.BatchCompletions{
+		{TaskID: "1", Completion: internalHTTP.Completion{State: internalHTTP.CompletionStateCompleted}},
+		{TaskID: "3", Completion: internalHTTP.Completion{
+			State:        internalHTTP.CompletionStateError,
+			ErrorMessage: aws.String("failure"),
+		}},
+	}
+	handlerAndMocks := newPutCompletionsReqHandlerWithMocks(batchCompletions)
+	handlerAndMocks.request.QueryParameters[internalHTTP.QueryParameterTransactional] = "true"
+	results := []task.BatchCompletingResult{
+		{TaskID: "1", Result: task.CompletingResultCompleted},
+		{TaskID: "3", Result: task.CompletingResultCompleted},
+	}
+	handlerAndMocks.completerMock.On("BatchComplete", mock.Anything, task.BatchCompleteRequest{
+		ProcessID: "2",
+		Tasks: []task.CompleteRequest{
+			{ID: task.ID{ProcessID: "2", TaskID: "1"}, State: task.StateFinished},
+			{ID: task.ID{ProcessID: "2", TaskID: "3"}, State: task.StateAborted, Message: aws.String("failure")},
+		},
+		Transactional: true,
+	}).Return(results, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+		Body:       internalHTTP.ConvertInternalToHTTPBatchCompletingResults(results).JSON(),
+	}, response)
+}
+
+func TestPutCompletionsRequestHandler_HandleRequest_InvalidRequests(t *testing.T) {
+	tooManyTaskIDs := make([]string, 0, handlers.MaxTransactionallyCompletedTasksCount+1)
+	for index := 0; index <= handlers.MaxTransactionallyCompletedTasksCount; index++ {
+		tooManyTaskIDs = append(tooManyTaskIDs, strconv.Itoa(index))
+	}
+	testCases := []struct {
+		name             string
+		batchCompletions internalHTTP.BatchCompletions
+		transactional    string
+		expectedBody     string
+	}{
+		{name: "empty", batchCompletions: internalHTTP.BatchCompletions{}, expectedBody: handlers.InvalidBatchTasksMsg},
+		{name: "duplicated", batchCompletions: newBatchCompletions("1", "1"), expectedBody: handlers.InvalidBatchTasksMsg},
+		{
+			name:             "reserved id",
+			batchCompletions: newBatchCompletions(task.ReservedID),
+			expectedBody:     handlers.InvalidBatchTasksMsg,
+		},
+		{
+			name: "unknown state",
+			batchCompletions: internalHTTP.BatchCompletions{
+				{TaskID: "1", Completion: internalHTTP.Completion{State: "UNKNOWN"}},
+			},
+			expectedBody: handlers.UnknownCompletionStateMsg,
+		},
+		{
+			name:             "too many in transaction",
+			batchCompletions: newBatchCompletions(tooManyTaskIDs...),
+			transactional:    "true",
+			expectedBody:     handlers.TooManyBatchTasksMsg,
+		},
+		{
+			name:             "invalid transactional",
+			batchCompletions: newBatchCompletions("1"),
+			transactional:    "maybe",
+			expectedBody:     handlers.InvalidTransactionalMsg,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			handlerAndMocks := newPutCompletionsReqHandlerWithMocks(testCase.batchCompletions)
+			if testCase.transactional != "" {
+				handlerAndMocks.request.QueryParameters[internalHTTP.QueryParameterTransactional] = testCase.transactional
+			}
+
+			response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+			assert.NoError(t, err)
+			handlerAndMocks.assertExpectations(t)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			assert.Equal(t, testCase.expectedBody, response.Body)
+		})
+	}
+}
+
+func TestPutCompletionsRequestHandler_HandleRequest_CompletingError(t *testing.T) {
+	handlerAndMocks := newPutCompletionsReqHandlerWithMocks(newBatchCompletions("1"))
+	handlerAndMocks.completerMock.On("BatchComplete", mock.Anything, mock.Anything).
+		Return([]task.BatchCompletingResult(nil), errors.New("error"))
+
+	_, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.Error(t, err)
+	handlerAndMocks.assertExpectations(t)
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+const (
+	InvalidCallbackURLMsg = "callbackUrl must be an absolute http or https URL of a public host"
+	InvalidLabelsMsg      = "labels must have at most 50 entries with non-empty names without ':' " +
+		"of at most 128 characters and values of at most 256 characters"
+)
+
+type PutProcessRequestHandler struct {
+	updater           process.Updater
+	callbackURLPolicy CallbackURLPolicy
+}
+
+func NewPutProcessRequestHandler(updater process.Updater, callbackURLPolicy CallbackURLPolicy) *PutProcessRequestHandler {
+	return &PutProcessRequestHandler{
+		updater:           updater,
+		callbackURLPolicy: callbackURLPolicy,
+	}
+}
+
+func (handler *PutProcessRequestHandler) HandleRequest(ctx context.Context, request internalHTTP.Request) (
+	internalHTTP.Response, error) {
+	update, err := internalHTTP.UnmarshalProcessUpdate(request.Body)
+	if err != nil {
+		return createTextResponse(http.StatusBadRequest, InvalidPayloadErrorMessage), nil
+	}
+	if update.CallbackURL != nil && !handler.callbackURLPolicy.isValid(*update.CallbackURL) {
+		return createTextResponse(http.StatusBadRequest, InvalidCallbackURLMsg), nil
+	}
+	if !process.AreValidLabels(update.Labels) {
+		return createTextResponse(http.StatusBadRequest, InvalidLabelsMsg), nil
+	}
+
+	updatingResult, err := handler.updater.Update(ctx, process.UpdateRequest{
+		ProcessID:   request.PathParameters[internalHTTP.PathParameterProcessID],
+		CallbackURL: update.CallbackURL,
+		Deadline:    update.Deadline,
+		Labels:      update.Labels,
+		Description: update.Description,
+	})
+	if err != nil {
+		return internalHTTP.Response{}, err
+	}
+
+	switch updatingResult {
+	case process.UpdatingResultUpdated:
+		return internalHTTP.Response{StatusCode: http.StatusNoContent}, nil
+	case process.UpdatingResultNotFound:
+		return internalHTTP.CreateDefaultTextResponseWithStatus(http.StatusNotFound), nil
+	default:
+		return internalHTTP.Response{}, fmt.Errorf("unknown updating result: %s", updatingResult)
+	}
+}
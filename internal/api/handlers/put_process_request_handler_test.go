@@ -0,0 +1,208 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/api/handlers"
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type processUpdaterMock struct {
+	mock.Mock
+}
+
+func (updater *processUpdaterMock) Update(ctx context.Context, request process.UpdateRequest) (
+	process.UpdatingResult, error) {
+	args := updater.Called(ctx, request)
+	return args.Get(0).(process.UpdatingResult), args.Error(1)
+}
+
+type putProcessRequestHandlerWithMocks struct {
+	handler        *handlers.PutProcessRequestHandler
+	processUpdater *processUpdaterMock
+	request        internalHTTP.Request
+	updateRequest  process.UpdateRequest
+}
+
+func (handlerAndMocks *putProcessRequestHandlerWithMocks) assertExpectations(t *testing.T) {
+	handlerAndMocks.processUpdater.AssertExpectations(t)
+}
+
+func newPutProcessRequestHandlerWithMocks() *putProcessRequestHandlerWithMocks {
+	processUpdater := new(processUpdaterMock)
+	processID := "2"
+	callbackURL := "https://example.com/callbacks"
+	return &putProcessRequestHandlerWithMocks{
+		handler:        handlers.NewPutProcessRequestHandler(processUpdater, handlers.CallbackURLPolicy{}),
+		processUpdater: processUpdater,
+		request: internalHTTP.Request{
+			PathParameters: map[internalHTTP.PathParameter]string{internalHTTP.PathParameterProcessID: processID},
+			Body:           internalHTTP.ProcessUpdate{CallbackURL: &callbackURL}.JSON(),
+		},
+		updateRequest: process.UpdateRequest{
+			ProcessID:   processID,
+			CallbackURL: &callbackURL,
+		},
+	}
+}
+
+func TestPutProcessRequestHandler_HandleRequest(t *testing.T) {
+	handlerAndMocks := newPutProcessRequestHandlerWithMocks()
+	handlerAndMocks.processUpdater.On("Update", mock.Anything, handlerAndMocks.updateRequest).
+		Return(process.UpdatingResultUpdated, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, internalHTTP.Response{StatusCode: http.StatusNoContent}, response)
+}
+
+func TestPutProcessRequestHandler_HandleRequest_Deadline(t *testing.T) {
+	handlerAndMocks := newPutProcessRequestHandlerWithMocks()
+	deadline := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	handlerAndMocks.request.Body = internalHTTP.ProcessUpdate{Deadline: &deadline}.JSON()
+	handlerAndMocks.processUpdater.On("Update", mock.Anything, process.UpdateRequest{
+		ProcessID: handlerAndMocks.updateRequest.ProcessID,
+		Deadline:  &deadline,
+	}).Return(process.UpdatingResultUpdated, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, internalHTTP.Response{StatusCode: http.StatusNoContent}, response)
+}
+
+func TestPutProcessRequestHandler_HandleRequest_Metadata(t *testing.T) {
+	handlerAndMocks := newPutProcessRequestHandlerWithMocks()
+	labels := map[string]string{"team": "payments"}
+	description := "nightly settlement"
+	handlerAndMocks.request.Body = internalHTTP.ProcessUpdate{Labels: labels, Description: &description}.JSON()
+	handlerAndMocks.processUpdater.On("Update", mock.Anything, process.UpdateRequest{
+		ProcessID:   handlerAndMocks.updateRequest.ProcessID,
+		Labels:      labels,
+		Description: &description,
+	}).Return(process.UpdatingResultUpdated, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, internalHTTP.Response{StatusCode: http.StatusNoContent}, response)
+}
+
+func TestPutProcessRequestHandler_HandleRequest_ProcessNotFound(t *testing.T) {
+	handlerAndMocks := newPutProcessRequestHandlerWithMocks()
+	handlerAndMocks.processUpdater.On("Update", mock.Anything, handlerAndMocks.updateRequest).
+		Return(process.UpdatingResultNotFound, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, internalHTTP.CreateDefaultTextResponseWithStatus(http.StatusNotFound), response)
+}
+
+func TestPutProcessRequestHandler_HandleRequest_UnknownUpdatingResult(t *testing.T) {
+	handlerAndMocks := newPutProcessRequestHandlerWithMocks()
+	handlerAndMocks.processUpdater.On("Update", mock.Anything, handlerAndMocks.updateRequest).
+		Return(process.UpdatingResult("unknown"), nil)
+
+	_, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.Error(t, err)
+	handlerAndMocks.assertExpectations(t)
+}
+
+func TestPutProcessRequestHandler_HandleRequest_UpdaterError(t *testing.T) {
+	handlerAndMocks := newPutProcessRequestHandlerWithMocks()
+	handlerAndMocks.processUpdater.On("Update", mock.Anything, handlerAndMocks.updateRequest).
+		Return(process.UpdatingResult(""), errors.New("error"))
+
+	_, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.Error(t, err)
+	handlerAndMocks.assertExpectations(t)
+}
+
+func TestPutProcessRequestHandler_HandleRequest_InvalidBody(t *testing.T) {
+	handlerAndMocks := newPutProcessRequestHandlerWithMocks()
+	handlerAndMocks.request.Body = "{"
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Equal(t, handlers.InvalidPayloadErrorMessage, response.Body)
+}
+
+func TestPutProcessRequestHandler_HandleRequest_InvalidCallbackURL(t *testing.T) {
+	callbackURLs := []string{
+		"/relative/callbacks",
+		"http://localhost:8080/callbacks",
+		"http://127.0.0.1/callbacks",
+		"http://10.1.2.3/callbacks",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[fd00::1]/callbacks",
+	}
+	for _, callbackURL := range callbackURLs {
+		t.Run(callbackURL, func(t *testing.T) {
+			handlerAndMocks := newPutProcessRequestHandlerWithMocks()
+			handlerAndMocks.request.Body = internalHTTP.ProcessUpdate{CallbackURL: aws.String(callbackURL)}.JSON()
+
+			response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+			assert.NoError(t, err)
+			handlerAndMocks.assertExpectations(t)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			assert.Equal(t, handlers.InvalidCallbackURLMsg, response.Body)
+		})
+	}
+}
+
+func TestPutProcessRequestHandler_HandleRequest_PrivateCallbackURLAllowed(t *testing.T) {
+	handlerAndMocks := newPutProcessRequestHandlerWithMocks()
+	handlerAndMocks.handler = handlers.NewPutProcessRequestHandler(handlerAndMocks.processUpdater,
+		handlers.CallbackURLPolicy{AllowPrivateAddresses: true})
+	callbackURL := "http://10.1.2.3/callbacks"
+	handlerAndMocks.request.Body = internalHTTP.ProcessUpdate{CallbackURL: &callbackURL}.JSON()
+	handlerAndMocks.processUpdater.On("Update", mock.Anything, process.UpdateRequest{
+		ProcessID:   handlerAndMocks.updateRequest.ProcessID,
+		CallbackURL: &callbackURL,
+	}).Return(process.UpdatingResultUpdated, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+}
+
+func TestPutProcessRequestHandler_HandleRequest_InvalidLabels(t *testing.T) {
+	tooManyLabels := make(map[string]string, process.MaxLabelsCount+1)
+	for labelIndex := 0; labelIndex <= process.MaxLabelsCount; labelIndex++ {
+		tooManyLabels[strconv.Itoa(labelIndex)] = "value"
+	}
+	testCases := map[string]map[string]string{
+		"empty name":      {"": "value"},
+		"separator":       {"team:name": "payments"},
+		"long name":       {strings.Repeat("n", process.MaxLabelNameLength+1): "value"},
+		"long value":      {"team": strings.Repeat("v", process.MaxLabelValueLength+1)},
+		"too many labels": tooManyLabels,
+	}
+	for name, labels := range testCases {
+		t.Run(name, func(t *testing.T) {
+			handlerAndMocks := newPutProcessRequestHandlerWithMocks()
+			handlerAndMocks.request.Body = internalHTTP.ProcessUpdate{Labels: labels}.JSON()
+
+			response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+			assert.NoError(t, err)
+			handlerAndMocks.assertExpectations(t)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			assert.Equal(t, handlers.InvalidLabelsMsg, response.Body)
+		})
+	}
+}
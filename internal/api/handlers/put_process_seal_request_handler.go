@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+type PutProcessSealRequestHandler struct {
+	sealer process.Sealer
+}
+
+func NewPutProcessSealRequestHandler(sealer process.Sealer) *PutProcessSealRequestHandler {
+	return &PutProcessSealRequestHandler{
+		sealer: sealer,
+	}
+}
+
+func (handler *PutProcessSealRequestHandler) HandleRequest(ctx context.Context, request internalHTTP.Request) (
+	internalHTTP.Response, error) {
+	sealingResult, err := handler.sealer.Seal(ctx, request.PathParameters[internalHTTP.PathParameterProcessID])
+	if err != nil {
+		return internalHTTP.Response{}, err
+	}
+
+	switch sealingResult {
+	case process.SealingResultSealed:
+		return internalHTTP.Response{StatusCode: http.StatusNoContent}, nil
+	case process.SealingResultNotFound:
+		return internalHTTP.CreateDefaultTextResponseWithStatus(http.StatusNotFound), nil
+	default:
+		return internalHTTP.Response{}, fmt.Errorf("unknown sealing result: %s", sealingResult)
+	}
+}
@@ -0,0 +1,86 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/artii15/termination-detector/internal/api/handlers"
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type processSealerMock struct {
+	mock.Mock
+}
+
+func (sealer *processSealerMock) Seal(ctx context.Context, processID string) (process.SealingResult, error) {
+	args := sealer.Called(ctx, processID)
+	return args.Get(0).(process.SealingResult), args.Error(1)
+}
+
+type putProcessSealRequestHandlerWithMocks struct {
+	handler       *handlers.PutProcessSealRequestHandler
+	processSealer *processSealerMock
+	request       internalHTTP.Request
+	processID     string
+}
+
+func (handlerAndMocks *putProcessSealRequestHandlerWithMocks) assertExpectations(t *testing.T) {
+	handlerAndMocks.processSealer.AssertExpectations(t)
+}
+
+func newPutProcessSealRequestHandlerWithMocks() *putProcessSealRequestHandlerWithMocks {
+	processSealer := new(processSealerMock)
+	processID := "2"
+	return &putProcessSealRequestHandlerWithMocks{
+		handler:       handlers.NewPutProcessSealRequestHandler(processSealer),
+		processSealer: processSealer,
+		processID:     processID,
+		request: internalHTTP.Request{
+			PathParameters: map[internalHTTP.PathParameter]string{internalHTTP.PathParameterProcessID: processID},
+		},
+	}
+}
+
+func TestPutProcessSealRequestHandler_HandleRequest(t *testing.T) {
+	handlerAndMocks := newPutProcessSealRequestHandlerWithMocks()
+	handlerAndMocks.processSealer.On("Seal", mock.Anything, handlerAndMocks.processID).Return(process.SealingResultSealed, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, internalHTTP.Response{StatusCode: http.StatusNoContent}, response)
+}
+
+func TestPutProcessSealRequestHandler_HandleRequest_ProcessNotFound(t *testing.T) {
+	handlerAndMocks := newPutProcessSealRequestHandlerWithMocks()
+	handlerAndMocks.processSealer.On("Seal", mock.Anything, handlerAndMocks.processID).Return(process.SealingResultNotFound, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, internalHTTP.CreateDefaultTextResponseWithStatus(http.StatusNotFound), response)
+}
+
+func TestPutProcessSealRequestHandler_HandleRequest_UnknownSealingResult(t *testing.T) {
+	handlerAndMocks := newPutProcessSealRequestHandlerWithMocks()
+	handlerAndMocks.processSealer.On("Seal", mock.Anything, handlerAndMocks.processID).Return(process.SealingResult("unknown"), nil)
+
+	_, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.Error(t, err)
+	handlerAndMocks.assertExpectations(t)
+}
+
+func TestPutProcessSealRequestHandler_HandleRequest_SealerError(t *testing.T) {
+	handlerAndMocks := newPutProcessSealRequestHandlerWithMocks()
+	handlerAndMocks.processSealer.On("Seal", mock.Anything, handlerAndMocks.processID).
+		Return(process.SealingResult(""), errors.New("error"))
+
+	_, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.Error(t, err)
+	handlerAndMocks.assertExpectations(t)
+}
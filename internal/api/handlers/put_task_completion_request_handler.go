@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 
 	internalHTTP "github.com/artii15/termination-detector/pkg/http"
@@ -29,7 +30,8 @@ func NewPutTaskCompletionRequestHandler(completer task.Completer) *PutTaskComple
 	}
 }
 
-func (handler *PutTaskCompletionRequestHandler) HandleRequest(request internalHTTP.Request) (internalHTTP.Response, error) {
+func (handler *PutTaskCompletionRequestHandler) HandleRequest(ctx context.Context, request internalHTTP.Request) (
+	internalHTTP.Response, error) {
 	completion, err := internalHTTP.UnmarshalCompletion(request.Body)
 	if err != nil {
 		return internalHTTP.Response{
@@ -49,7 +51,7 @@ func (handler *PutTaskCompletionRequestHandler) HandleRequest(request internalHT
 		}, nil
 	}
 
-	completingResult, err := handler.completer.Complete(task.CompleteRequest{
+	completingResult, err := handler.completer.Complete(ctx, task.CompleteRequest{
 		ID: task.ID{
 			ProcessID: request.PathParameters[internalHTTP.PathParameterProcessID],
 			TaskID:    request.PathParameters[internalHTTP.PathParameterTaskID],
@@ -66,17 +68,31 @@ func (handler *PutTaskCompletionRequestHandler) HandleRequest(request internalHT
 
 func mapCompletingResultToResponse(request internalHTTP.Request, result task.CompletingResult) internalHTTP.Response {
 	switch result {
+	case task.CompletingResultChildConflict:
+		return createErrorResponse(http.StatusConflict, string(result), internalHTTP.ChildTaskAlreadyRegisteredMessage)
+	case task.CompletingResultProcessSealed:
+		return createErrorResponse(http.StatusGone, string(result), internalHTTP.ProcessSealedMessage)
+	case task.CompletingResultProcessDeadlineExceeded:
+		return createErrorResponse(http.StatusGone, string(result), internalHTTP.ProcessDeadlineExceededMessage)
+	case task.CompletingResultAlreadyCompletedDifferent:
+		return createErrorResponse(http.StatusConflict, string(result), internalHTTP.TaskAlreadyCompletedDifferentlyMessage)
+	case task.CompletingResultExpired:
+		return createErrorResponse(http.StatusConflict, string(result), internalHTTP.TaskExpiredMessage)
+	case task.CompletingResultNotFound:
+		return createErrorResponse(http.StatusNotFound, string(result), http.StatusText(http.StatusNotFound))
 	case task.CompletingResultConflict:
+		return createErrorResponse(http.StatusConflict, string(result), ConflictingTaskCompletionMsg)
+	case task.CompletingResultCompleted:
 		return internalHTTP.Response{
-			StatusCode: http.StatusConflict,
-			Body:       ConflictingTaskCompletionMsg,
+			StatusCode: http.StatusCreated,
+			Body:       request.Body,
 			Headers: map[string]string{
-				internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeTextPlain,
+				internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON,
 			},
 		}
-	case task.CompletingResultCompleted:
+	case task.CompletingResultAlreadyCompletedSame:
 		return internalHTTP.Response{
-			StatusCode: http.StatusCreated,
+			StatusCode: http.StatusOK,
 			Body:       request.Body,
 			Headers: map[string]string{
 				internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON,
@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
@@ -16,11 +17,24 @@ type taskCompleterMock struct {
 	mock.Mock
 }
 
-func (completer *taskCompleterMock) Complete(request task.CompleteRequest) (task.CompletingResult, error) {
-	args := completer.Called(request)
+func (completer *taskCompleterMock) Complete(ctx context.Context, request task.CompleteRequest) (
+	task.CompletingResult, error) {
+	args := completer.Called(ctx, request)
 	return args.Get(0).(task.CompletingResult), args.Error(1)
 }
 
+func (completer *taskCompleterMock) CompleteWithChildren(ctx context.Context, request task.CompleteWithChildrenRequest) (
+	task.CompletingResult, error) {
+	args := completer.Called(ctx, request)
+	return args.Get(0).(task.CompletingResult), args.Error(1)
+}
+
+func (completer *taskCompleterMock) BatchComplete(ctx context.Context, request task.BatchCompleteRequest) (
+	[]task.BatchCompletingResult, error) {
+	args := completer.Called(ctx, request)
+	return args.Get(0).([]task.BatchCompletingResult), args.Error(1)
+}
+
 type putTaskCompletionReqHandlerWithMocks struct {
 	request       internalHTTP.Request
 	completion    internalHTTP.Completion
@@ -57,12 +71,12 @@ func newPutTaskCompletionReqHandlerWithMocks(completion internalHTTP.Completion)
 func TestPutTaskCompletionRequestHandler_HandleRequest(t *testing.T) {
 	completion := internalHTTP.Completion{State: internalHTTP.CompletionStateCompleted}
 	handlerAndMocks := newPutTaskCompletionReqHandlerWithMocks(completion)
-	handlerAndMocks.completerMock.On("Complete", task.CompleteRequest{
+	handlerAndMocks.completerMock.On("Complete", mock.Anything, task.CompleteRequest{
 		ID:    handlerAndMocks.taskID,
 		State: task.StateFinished,
 	}).Return(task.CompletingResultCompleted, nil)
 
-	response, err := handlerAndMocks.handler.HandleRequest(handlerAndMocks.request)
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
 	handlerAndMocks.assertExpectations(t)
 	assert.NoError(t, err)
 	assert.Equal(t, internalHTTP.Response{
@@ -74,7 +88,7 @@ func TestPutTaskCompletionRequestHandler_HandleRequest(t *testing.T) {
 
 func TestPutTaskCompletionRequestHandler_HandleRequest_InvalidPayload(t *testing.T) {
 	handler := handlers.NewPutTaskCompletionRequestHandler(new(taskCompleterMock))
-	response, err := handler.HandleRequest(internalHTTP.Request{
+	response, err := handler.HandleRequest(context.Background(), internalHTTP.Request{
 		Body: "",
 	})
 
@@ -89,7 +103,7 @@ func TestPutTaskCompletionRequestHandler_HandleRequest_InvalidPayload(t *testing
 func TestPutTaskCompletionRequestHandler_HandleRequest_UnknownCompletionState(t *testing.T) {
 	handler := handlers.NewPutTaskCompletionRequestHandler(new(taskCompleterMock))
 	completion := internalHTTP.Completion{State: internalHTTP.CompletionState("invalid")}
-	response, err := handler.HandleRequest(internalHTTP.Request{
+	response, err := handler.HandleRequest(context.Background(), internalHTTP.Request{
 		Body: completion.JSON(),
 	})
 
@@ -105,13 +119,13 @@ func TestPutTaskCompletionRequestHandler_HandleRequest_CompleteWithError(t *test
 	errorMsg := "error"
 	completion := internalHTTP.Completion{State: internalHTTP.CompletionStateError, ErrorMessage: &errorMsg}
 	handlerAndMocks := newPutTaskCompletionReqHandlerWithMocks(completion)
-	handlerAndMocks.completerMock.On("Complete", task.CompleteRequest{
+	handlerAndMocks.completerMock.On("Complete", mock.Anything, task.CompleteRequest{
 		ID:      handlerAndMocks.taskID,
 		State:   task.StateAborted,
 		Message: &errorMsg,
 	}).Return(task.CompletingResultCompleted, nil)
 
-	response, err := handlerAndMocks.handler.HandleRequest(handlerAndMocks.request)
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
 	handlerAndMocks.assertExpectations(t)
 	assert.NoError(t, err)
 	assert.Equal(t, internalHTTP.Response{
@@ -124,30 +138,123 @@ func TestPutTaskCompletionRequestHandler_HandleRequest_CompleteWithError(t *test
 func TestPutTaskCompletionRequestHandler_HandleRequest_TaskStateConflict(t *testing.T) {
 	completion := internalHTTP.Completion{State: internalHTTP.CompletionStateCompleted}
 	handlerAndMocks := newPutTaskCompletionReqHandlerWithMocks(completion)
-	handlerAndMocks.completerMock.On("Complete", task.CompleteRequest{
+	handlerAndMocks.completerMock.On("Complete", mock.Anything, task.CompleteRequest{
 		ID:    handlerAndMocks.taskID,
 		State: task.StateFinished,
 	}).Return(task.CompletingResultConflict, nil)
 
-	response, err := handlerAndMocks.handler.HandleRequest(handlerAndMocks.request)
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
 	handlerAndMocks.assertExpectations(t)
 	assert.NoError(t, err)
 	assert.Equal(t, internalHTTP.Response{
 		StatusCode: http.StatusConflict,
-		Body:       handlers.ConflictingTaskCompletionMsg,
-		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeTextPlain},
+		Body: internalHTTP.ErrorBody{
+			Result:  string(task.CompletingResultConflict),
+			Message: handlers.ConflictingTaskCompletionMsg,
+		}.JSON(),
+		Headers: map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+	}, response)
+}
+
+func TestPutTaskCompletionRequestHandler_HandleRequest_AlreadyCompletedSame(t *testing.T) {
+	completion := internalHTTP.Completion{State: internalHTTP.CompletionStateCompleted}
+	handlerAndMocks := newPutTaskCompletionReqHandlerWithMocks(completion)
+	handlerAndMocks.completerMock.On("Complete", mock.Anything, task.CompleteRequest{
+		ID:    handlerAndMocks.taskID,
+		State: task.StateFinished,
+	}).Return(task.CompletingResultAlreadyCompletedSame, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	handlerAndMocks.assertExpectations(t)
+	assert.NoError(t, err)
+	assert.Equal(t, internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body:       handlerAndMocks.request.Body,
+		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+	}, response)
+}
+
+func TestPutTaskCompletionRequestHandler_HandleRequest_CompletionConflicts(t *testing.T) {
+	expectedResponses := map[task.CompletingResult]internalHTTP.Response{
+		task.CompletingResultAlreadyCompletedDifferent: {
+			StatusCode: http.StatusConflict,
+			Body: internalHTTP.ErrorBody{
+				Result:  string(task.CompletingResultAlreadyCompletedDifferent),
+				Message: internalHTTP.TaskAlreadyCompletedDifferentlyMessage,
+			}.JSON(),
+			Headers: map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+		},
+		task.CompletingResultExpired: {
+			StatusCode: http.StatusConflict,
+			Body: internalHTTP.ErrorBody{
+				Result:  string(task.CompletingResultExpired),
+				Message: internalHTTP.TaskExpiredMessage,
+			}.JSON(),
+			Headers: map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+		},
+		task.CompletingResultNotFound: {
+			StatusCode: http.StatusNotFound,
+			Body: internalHTTP.ErrorBody{
+				Result:  string(task.CompletingResultNotFound),
+				Message: http.StatusText(http.StatusNotFound),
+			}.JSON(),
+			Headers: map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+		},
+		task.CompletingResultProcessDeadlineExceeded: {
+			StatusCode: http.StatusGone,
+			Body: internalHTTP.ErrorBody{
+				Result:  string(task.CompletingResultProcessDeadlineExceeded),
+				Message: internalHTTP.ProcessDeadlineExceededMessage,
+			}.JSON(),
+			Headers: map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+		},
+	}
+
+	for completingResult, expectedResponse := range expectedResponses {
+		completion := internalHTTP.Completion{State: internalHTTP.CompletionStateCompleted}
+		handlerAndMocks := newPutTaskCompletionReqHandlerWithMocks(completion)
+		handlerAndMocks.completerMock.On("Complete", mock.Anything, task.CompleteRequest{
+			ID:    handlerAndMocks.taskID,
+			State: task.StateFinished,
+		}).Return(completingResult, nil)
+
+		response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+		handlerAndMocks.assertExpectations(t)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResponse, response)
+	}
+}
+
+func TestPutTaskCompletionRequestHandler_HandleRequest_ProcessSealed(t *testing.T) {
+	completion := internalHTTP.Completion{State: internalHTTP.CompletionStateCompleted}
+	handlerAndMocks := newPutTaskCompletionReqHandlerWithMocks(completion)
+	handlerAndMocks.completerMock.On("Complete", mock.Anything, task.CompleteRequest{
+		ID:    handlerAndMocks.taskID,
+		State: task.StateFinished,
+	}).Return(task.CompletingResultProcessSealed, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	handlerAndMocks.assertExpectations(t)
+	assert.NoError(t, err)
+	assert.Equal(t, internalHTTP.Response{
+		StatusCode: http.StatusGone,
+		Body: internalHTTP.ErrorBody{
+			Result:  string(task.CompletingResultProcessSealed),
+			Message: internalHTTP.ProcessSealedMessage,
+		}.JSON(),
+		Headers: map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
 	}, response)
 }
 
 func TestPutTaskCompletionRequestHandler_HandleRequest_UnknownCompletionResult(t *testing.T) {
 	completion := internalHTTP.Completion{State: internalHTTP.CompletionStateCompleted}
 	handlerAndMocks := newPutTaskCompletionReqHandlerWithMocks(completion)
-	handlerAndMocks.completerMock.On("Complete", task.CompleteRequest{
+	handlerAndMocks.completerMock.On("Complete", mock.Anything, task.CompleteRequest{
 		ID:    handlerAndMocks.taskID,
 		State: task.StateFinished,
 	}).Return(task.CompletingResult("unknown"), nil)
 
-	response, err := handlerAndMocks.handler.HandleRequest(handlerAndMocks.request)
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
 	handlerAndMocks.assertExpectations(t)
 	assert.NoError(t, err)
 	assert.Equal(t, internalHTTP.Response{
@@ -160,12 +267,12 @@ func TestPutTaskCompletionRequestHandler_HandleRequest_UnknownCompletionResult(t
 func TestPutTaskCompletionRequestHandler_HandleRequest_CompletionError(t *testing.T) {
 	completion := internalHTTP.Completion{State: internalHTTP.CompletionStateCompleted}
 	handlerAndMocks := newPutTaskCompletionReqHandlerWithMocks(completion)
-	handlerAndMocks.completerMock.On("Complete", task.CompleteRequest{
+	handlerAndMocks.completerMock.On("Complete", mock.Anything, task.CompleteRequest{
 		ID:    handlerAndMocks.taskID,
 		State: task.StateFinished,
 	}).Return(task.CompletingResultCompleted, errors.New("error"))
 
-	_, err := handlerAndMocks.handler.HandleRequest(handlerAndMocks.request)
+	_, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
 	handlerAndMocks.assertExpectations(t)
 	assert.Error(t, err)
 }
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+const (
+	MaxChildTasksCount   = 99
+	InvalidChildTasksMsg = "child tasks must have unique, non empty, not reserved ids different from completed task id"
+	TooManyChildTasksMsg = "too many child tasks"
+)
+
+type PutTaskCompletionWithChildrenRequestHandler struct {
+	completer task.Completer
+}
+
+func NewPutTaskCompletionWithChildrenRequestHandler(completer task.Completer) *PutTaskCompletionWithChildrenRequestHandler {
+	return &PutTaskCompletionWithChildrenRequestHandler{
+		completer: completer,
+	}
+}
+
+func (handler *PutTaskCompletionWithChildrenRequestHandler) HandleRequest(ctx context.Context, request internalHTTP.Request) (
+	internalHTTP.Response, error) {
+	completion, err := internalHTTP.UnmarshalCompletionWithChildren(request.Body)
+	if err != nil {
+		return createTextResponse(http.StatusBadRequest, InvalidPayloadErrorMessage), nil
+	}
+	taskCompletionState, isTaskCompletionStateFound := completionStateToTaskStateMapping[completion.State]
+	if !isTaskCompletionStateFound {
+		return createTextResponse(http.StatusBadRequest, UnknownCompletionStateMsg), nil
+	}
+	if len(completion.Children) > MaxChildTasksCount {
+		return createTextResponse(http.StatusBadRequest, TooManyChildTasksMsg), nil
+	}
+
+	taskID := task.ID{
+		ProcessID: request.PathParameters[internalHTTP.PathParameterProcessID],
+		TaskID:    request.PathParameters[internalHTTP.PathParameterTaskID],
+	}
+	children, areChildrenValid := readChildren(taskID, completion.Children)
+	if !areChildrenValid {
+		return createTextResponse(http.StatusBadRequest, InvalidChildTasksMsg), nil
+	}
+
+	completingResult, err := handler.completer.CompleteWithChildren(ctx, task.CompleteWithChildrenRequest{
+		CompleteRequest: task.CompleteRequest{
+			ID:      taskID,
+			State:   taskCompletionState,
+			Message: completion.ErrorMessage,
+		},
+		Children: children,
+	})
+	if err != nil {
+		return internalHTTP.Response{}, err
+	}
+
+	return mapCompletingResultToResponse(request, completingResult), nil
+}
+
+func readChildren(parentID task.ID, childTasks []internalHTTP.ChildTask) ([]task.RegistrationData, bool) {
+	children := make([]task.RegistrationData, 0, len(childTasks))
+	usedTaskIDs := map[string]bool{parentID.TaskID: true}
+	for _, childTask := range childTasks {
+		if childTask.TaskID == "" || task.IsReservedTaskID(childTask.TaskID) || usedTaskIDs[childTask.TaskID] {
+			return nil, false
+		}
+		usedTaskIDs[childTask.TaskID] = true
+		children = append(children, task.RegistrationData{
+			ID: task.ID{
+				ProcessID: parentID.ProcessID,
+				TaskID:    childTask.TaskID,
+			},
+			ExpirationTime: childTask.ExpirationTime,
+		})
+	}
+	return children, true
+}
+
+func createTextResponse(statusCode int, body string) internalHTTP.Response {
+	return internalHTTP.Response{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeTextPlain},
+	}
+}
+
+func createErrorResponse(statusCode int, result, message string) internalHTTP.Response {
+	return internalHTTP.Response{
+		StatusCode: statusCode,
+		Body:       internalHTTP.ErrorBody{Result: result, Message: message}.JSON(),
+		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+	}
+}
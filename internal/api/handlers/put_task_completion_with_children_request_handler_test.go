@@ -0,0 +1,170 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/api/handlers"
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type putTaskCompletionWithChildrenReqHandlerWithMocks struct {
+	request       internalHTTP.Request
+	completerMock *taskCompleterMock
+	taskID        task.ID
+	handler       *handlers.PutTaskCompletionWithChildrenRequestHandler
+}
+
+func newPutTaskCompletionWithChildrenReqHandlerWithMocks(
+	completion internalHTTP.CompletionWithChildren) *putTaskCompletionWithChildrenReqHandlerWithMocks {
+	taskID := task.ID{
+		ProcessID: "2",
+		TaskID:    "1",
+	}
+	completerMock := new(taskCompleterMock)
+	return &putTaskCompletionWithChildrenReqHandlerWithMocks{
+		request: internalHTTP.Request{
+			PathParameters: map[internalHTTP.PathParameter]string{
+				internalHTTP.PathParameterTaskID:    taskID.TaskID,
+				internalHTTP.PathParameterProcessID: taskID.ProcessID,
+			},
+			Body: completion.JSON(),
+		},
+		handler:       handlers.NewPutTaskCompletionWithChildrenRequestHandler(completerMock),
+		completerMock: completerMock,
+		taskID:        taskID,
+	}
+}
+
+func TestPutTaskCompletionWithChildrenRequestHandler_HandleRequest(t *testing.T) {
+	childExpirationTime := time.Now().Add(time.Hour).UTC()
+	completion := internalHTTP.CompletionWithChildren{
+		Completion: internalHTTP.Completion{State: internalHTTP.CompletionStateCompleted},
+		Children:   []internalHTTP.ChildTask{{TaskID: "3", ExpirationTime: childExpirationTime}},
+	}
+	handlerAndMocks := newPutTaskCompletionWithChildrenReqHandlerWithMocks(completion)
+	handlerAndMocks.completerMock.On("CompleteWithChildren", mock.Anything, task.CompleteWithChildrenRequest{
+		CompleteRequest: task.CompleteRequest{
+			ID:    handlerAndMocks.taskID,
+			State: task.StateFinished,
+		},
+		Children: []task.RegistrationData{{
+			ID:             task.ID{ProcessID: handlerAndMocks.taskID.ProcessID, TaskID: "3"},
+			ExpirationTime: childExpirationTime,
+		}},
+	}).Return(task.CompletingResultCompleted, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	handlerAndMocks.completerMock.AssertExpectations(t)
+	assert.NoError(t, err)
+	assert.Equal(t, internalHTTP.Response{
+		StatusCode: http.StatusCreated,
+		Body:       handlerAndMocks.request.Body,
+		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+	}, response)
+}
+
+func TestPutTaskCompletionWithChildrenRequestHandler_HandleRequest_ChildConflict(t *testing.T) {
+	childExpirationTime := time.Now().Add(time.Hour).UTC()
+	completion := internalHTTP.CompletionWithChildren{
+		Completion: internalHTTP.Completion{State: internalHTTP.CompletionStateCompleted},
+		Children:   []internalHTTP.ChildTask{{TaskID: "3", ExpirationTime: childExpirationTime}},
+	}
+	handlerAndMocks := newPutTaskCompletionWithChildrenReqHandlerWithMocks(completion)
+	handlerAndMocks.completerMock.On("CompleteWithChildren", mock.Anything, task.CompleteWithChildrenRequest{
+		CompleteRequest: task.CompleteRequest{
+			ID:    handlerAndMocks.taskID,
+			State: task.StateFinished,
+		},
+		Children: []task.RegistrationData{{
+			ID:             task.ID{ProcessID: handlerAndMocks.taskID.ProcessID, TaskID: "3"},
+			ExpirationTime: childExpirationTime,
+		}},
+	}).Return(task.CompletingResultChildConflict, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	handlerAndMocks.completerMock.AssertExpectations(t)
+	assert.NoError(t, err)
+	assert.Equal(t, internalHTTP.Response{
+		StatusCode: http.StatusConflict,
+		Body: internalHTTP.ErrorBody{
+			Result:  string(task.CompletingResultChildConflict),
+			Message: internalHTTP.ChildTaskAlreadyRegisteredMessage,
+		}.JSON(),
+		Headers: map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+	}, response)
+}
+
+func TestPutTaskCompletionWithChildrenRequestHandler_HandleRequest_InvalidChildren(t *testing.T) {
+	expirationTime := time.Now().Add(time.Hour).UTC()
+	invalidChildrenSets := [][]internalHTTP.ChildTask{
+		{{TaskID: "", ExpirationTime: expirationTime}},
+		{{TaskID: "1", ExpirationTime: expirationTime}},
+		{{TaskID: task.ReservedID, ExpirationTime: expirationTime}},
+		{{TaskID: "3", ExpirationTime: expirationTime}, {TaskID: "3", ExpirationTime: expirationTime}},
+	}
+	for _, children := range invalidChildrenSets {
+		handlerAndMocks := newPutTaskCompletionWithChildrenReqHandlerWithMocks(internalHTTP.CompletionWithChildren{
+			Completion: internalHTTP.Completion{State: internalHTTP.CompletionStateCompleted},
+			Children:   children,
+		})
+
+		response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+		assert.NoError(t, err)
+		assert.Equal(t, internalHTTP.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       handlers.InvalidChildTasksMsg,
+			Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeTextPlain},
+		}, response)
+	}
+}
+
+func TestPutTaskCompletionWithChildrenRequestHandler_HandleRequest_TooManyChildren(t *testing.T) {
+	children := make([]internalHTTP.ChildTask, 0, handlers.MaxChildTasksCount+1)
+	for childNumber := 0; childNumber <= handlers.MaxChildTasksCount; childNumber++ {
+		children = append(children, internalHTTP.ChildTask{TaskID: "child" + strconv.Itoa(childNumber)})
+	}
+	handlerAndMocks := newPutTaskCompletionWithChildrenReqHandlerWithMocks(internalHTTP.CompletionWithChildren{
+		Completion: internalHTTP.Completion{State: internalHTTP.CompletionStateCompleted},
+		Children:   children,
+	})
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Equal(t, handlers.TooManyChildTasksMsg, response.Body)
+}
+
+func TestPutTaskCompletionWithChildrenRequestHandler_HandleRequest_InvalidPayload(t *testing.T) {
+	handler := handlers.NewPutTaskCompletionWithChildrenRequestHandler(new(taskCompleterMock))
+
+	response, err := handler.HandleRequest(context.Background(), internalHTTP.Request{Body: ""})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Equal(t, handlers.InvalidPayloadErrorMessage, response.Body)
+}
+
+func TestPutTaskCompletionWithChildrenRequestHandler_HandleRequest_CompletionError(t *testing.T) {
+	completion := internalHTTP.CompletionWithChildren{
+		Completion: internalHTTP.Completion{State: internalHTTP.CompletionStateCompleted},
+	}
+	handlerAndMocks := newPutTaskCompletionWithChildrenReqHandlerWithMocks(completion)
+	handlerAndMocks.completerMock.On("CompleteWithChildren", mock.Anything, task.CompleteWithChildrenRequest{
+		CompleteRequest: task.CompleteRequest{
+			ID:    handlerAndMocks.taskID,
+			State: task.StateFinished,
+		},
+		Children: []task.RegistrationData{},
+	}).Return(task.CompletingResult(""), errors.New("error"))
+
+	_, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	handlerAndMocks.completerMock.AssertExpectations(t)
+	assert.Error(t, err)
+}
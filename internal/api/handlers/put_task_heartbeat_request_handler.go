@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+const (
+	ConflictingTaskHeartbeatMsg = "task not created, already expired or completed"
+	PastExpirationTimeMsg       = "expirationTime must be in the future"
+)
+
+type CurrentDateGetter interface {
+	GetCurrentDate() time.Time
+}
+
+type PutTaskHeartbeatRequestHandler struct {
+	heartbeater       task.Heartbeater
+	currentDateGetter CurrentDateGetter
+}
+
+func NewPutTaskHeartbeatRequestHandler(heartbeater task.Heartbeater,
+	currentDateGetter CurrentDateGetter) *PutTaskHeartbeatRequestHandler {
+	return &PutTaskHeartbeatRequestHandler{
+		heartbeater:       heartbeater,
+		currentDateGetter: currentDateGetter,
+	}
+}
+
+func (handler *PutTaskHeartbeatRequestHandler) HandleRequest(ctx context.Context, request internalHTTP.Request) (
+	internalHTTP.Response, error) {
+	extendedTask, err := internalHTTP.UnmarshalTask(request.Body)
+	if err != nil {
+		return createTextResponse(http.StatusBadRequest, InvalidPayloadErrorMessage), nil
+	}
+	if !extendedTask.ExpirationTime.After(handler.currentDateGetter.GetCurrentDate()) {
+		return createTextResponse(http.StatusBadRequest, PastExpirationTimeMsg), nil
+	}
+
+	heartbeatResult, err := handler.heartbeater.Heartbeat(ctx, task.HeartbeatRequest{
+		ID: task.ID{
+			ProcessID: request.PathParameters[internalHTTP.PathParameterProcessID],
+			TaskID:    request.PathParameters[internalHTTP.PathParameterTaskID],
+		},
+		ExpirationTime: extendedTask.ExpirationTime,
+	})
+	if err != nil {
+		return internalHTTP.Response{}, err
+	}
+
+	switch heartbeatResult {
+	case task.HeartbeatResultExtended:
+		return internalHTTP.Response{
+			StatusCode: http.StatusOK,
+			Body:       request.Body,
+			Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+		}, nil
+	case task.HeartbeatResultConflict:
+		return createTextResponse(http.StatusConflict, ConflictingTaskHeartbeatMsg), nil
+	default:
+		return internalHTTP.Response{}, fmt.Errorf("unknown heartbeat result: %s", heartbeatResult)
+	}
+}
@@ -0,0 +1,146 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/api/handlers"
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type taskHeartbeaterMock struct {
+	mock.Mock
+}
+
+func (heartbeater *taskHeartbeaterMock) Heartbeat(ctx context.Context, request task.HeartbeatRequest) (
+	task.HeartbeatResult, error) {
+	args := heartbeater.Called(ctx, request)
+	return args.Get(0).(task.HeartbeatResult), args.Error(1)
+}
+
+type currentDateGetterMock struct {
+	mock.Mock
+}
+
+func (getter *currentDateGetterMock) GetCurrentDate() time.Time {
+	return getter.Called().Get(0).(time.Time)
+}
+
+type putTaskHeartbeatReqHandlerWithMocks struct {
+	request               internalHTTP.Request
+	heartbeatRequest      task.HeartbeatRequest
+	heartbeaterMock       *taskHeartbeaterMock
+	currentDateGetterMock *currentDateGetterMock
+	handler               *handlers.PutTaskHeartbeatRequestHandler
+}
+
+func (handlerAndMocks *putTaskHeartbeatReqHandlerWithMocks) assertExpectations(t *testing.T) {
+	handlerAndMocks.heartbeaterMock.AssertExpectations(t)
+	handlerAndMocks.currentDateGetterMock.AssertExpectations(t)
+}
+
+func newPutTaskHeartbeatReqHandlerWithMocks() *putTaskHeartbeatReqHandlerWithMocks {
+	heartbeater := new(taskHeartbeaterMock)
+	currentDateGetter := new(currentDateGetterMock)
+	currentDateGetter.On("GetCurrentDate").Return(time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC))
+	extendedTask := internalHTTP.Task{
+		ExpirationTime: time.Date(2020, 4, 1, 13, 0, 0, 0, time.UTC),
+	}
+	taskID := task.ID{ProcessID: "2", TaskID: "1"}
+	return &putTaskHeartbeatReqHandlerWithMocks{
+		request: internalHTTP.Request{
+			PathParameters: map[internalHTTP.PathParameter]string{
+				internalHTTP.PathParameterTaskID:    taskID.TaskID,
+				internalHTTP.PathParameterProcessID: taskID.ProcessID,
+			},
+			Body: extendedTask.JSON(),
+		},
+		heartbeatRequest: task.HeartbeatRequest{
+			ID:             taskID,
+			ExpirationTime: extendedTask.ExpirationTime,
+		},
+		heartbeaterMock:       heartbeater,
+		currentDateGetterMock: currentDateGetter,
+		handler:               handlers.NewPutTaskHeartbeatRequestHandler(heartbeater, currentDateGetter),
+	}
+}
+
+func TestPutTaskHeartbeatRequestHandler_HandleRequest(t *testing.T) {
+	handlerAndMocks := newPutTaskHeartbeatReqHandlerWithMocks()
+	handlerAndMocks.heartbeaterMock.On("Heartbeat", mock.Anything, handlerAndMocks.heartbeatRequest).
+		Return(task.HeartbeatResultExtended, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body:       handlerAndMocks.request.Body,
+		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+	}, response)
+}
+
+func TestPutTaskHeartbeatRequestHandler_HandleRequest_Conflict(t *testing.T) {
+	handlerAndMocks := newPutTaskHeartbeatReqHandlerWithMocks()
+	handlerAndMocks.heartbeaterMock.On("Heartbeat", mock.Anything, handlerAndMocks.heartbeatRequest).
+		Return(task.HeartbeatResultConflict, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, internalHTTP.Response{
+		StatusCode: http.StatusConflict,
+		Body:       handlers.ConflictingTaskHeartbeatMsg,
+		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeTextPlain},
+	}, response)
+}
+
+func TestPutTaskHeartbeatRequestHandler_HandleRequest_InvalidPayload(t *testing.T) {
+	handlerAndMocks := newPutTaskHeartbeatReqHandlerWithMocks()
+	handlerAndMocks.request.Body = "invalid"
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.heartbeaterMock.AssertExpectations(t)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Equal(t, handlers.InvalidPayloadErrorMessage, response.Body)
+}
+
+func TestPutTaskHeartbeatRequestHandler_HandleRequest_PastExpirationTime(t *testing.T) {
+	handlerAndMocks := newPutTaskHeartbeatReqHandlerWithMocks()
+	handlerAndMocks.request.Body = internalHTTP.Task{
+		ExpirationTime: time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC),
+	}.JSON()
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Equal(t, handlers.PastExpirationTimeMsg, response.Body)
+}
+
+func TestPutTaskHeartbeatRequestHandler_HandleRequest_UnknownHeartbeatResult(t *testing.T) {
+	handlerAndMocks := newPutTaskHeartbeatReqHandlerWithMocks()
+	handlerAndMocks.heartbeaterMock.On("Heartbeat", mock.Anything, handlerAndMocks.heartbeatRequest).
+		Return(task.HeartbeatResult("unknown"), nil)
+
+	_, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.Error(t, err)
+	handlerAndMocks.assertExpectations(t)
+}
+
+func TestPutTaskHeartbeatRequestHandler_HandleRequest_HeartbeatError(t *testing.T) {
+	handlerAndMocks := newPutTaskHeartbeatReqHandlerWithMocks()
+	handlerAndMocks.heartbeaterMock.On("Heartbeat", mock.Anything, handlerAndMocks.heartbeatRequest).
+		Return(task.HeartbeatResult(""), errors.New("error"))
+
+	_, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.Error(t, err)
+	handlerAndMocks.assertExpectations(t)
+}
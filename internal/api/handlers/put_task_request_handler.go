@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -11,19 +12,23 @@ import (
 const (
 	TaskAlreadyCreatedErrorMessage = "task already created"
 	InvalidPayloadErrorMessage     = "invalid payload provided"
+	ReservedTaskIDErrorMessage     = "task id must not be " + task.ReservedID
 )
 
 type PutTaskRequestHandler struct {
-	registerer task.Registerer
+	registerer        task.Registerer
+	callbackURLPolicy CallbackURLPolicy
 }
 
-func NewPutTaskRequestHandler(registerer task.Registerer) *PutTaskRequestHandler {
+func NewPutTaskRequestHandler(registerer task.Registerer, callbackURLPolicy CallbackURLPolicy) *PutTaskRequestHandler {
 	return &PutTaskRequestHandler{
-		registerer: registerer,
+		registerer:        registerer,
+		callbackURLPolicy: callbackURLPolicy,
 	}
 }
 
-func (handler *PutTaskRequestHandler) HandleRequest(request internalHTTP.Request) (internalHTTP.Response, error) {
+func (handler *PutTaskRequestHandler) HandleRequest(ctx context.Context, request internalHTTP.Request) (
+	internalHTTP.Response, error) {
 	unmarshalledTask, err := internalHTTP.UnmarshalTask(request.Body)
 	if err != nil {
 		return internalHTTP.Response{
@@ -33,13 +38,28 @@ func (handler *PutTaskRequestHandler) HandleRequest(request internalHTTP.Request
 		}, nil
 	}
 
-	registrationResult, err := handler.registerer.Register(task.RegistrationData{
+	taskID := request.PathParameters[internalHTTP.PathParameterTaskID]
+	if task.IsReservedTaskID(taskID) {
+		return createTextResponse(http.StatusBadRequest, ReservedTaskIDErrorMessage), nil
+	}
+
+	if unmarshalledTask.CallbackURL != nil && !handler.callbackURLPolicy.isValid(*unmarshalledTask.CallbackURL) {
+		return createTextResponse(http.StatusBadRequest, InvalidCallbackURLMsg), nil
+	}
+
+	registrationData := task.RegistrationData{
 		ID: task.ID{
 			ProcessID: request.PathParameters[internalHTTP.PathParameterProcessID],
-			TaskID:    request.PathParameters[internalHTTP.PathParameterTaskID],
+			TaskID:    taskID,
 		},
 		ExpirationTime: unmarshalledTask.ExpirationTime,
-	})
+		CallbackURL:    unmarshalledTask.CallbackURL,
+		Creator:        request.Principal,
+	}
+	if unmarshalledTask.ProcessDeadline != nil {
+		registrationData.ProcessDeadline = *unmarshalledTask.ProcessDeadline
+	}
+	registrationResult, err := handler.registerer.Register(ctx, registrationData)
 	if err != nil {
 		return internalHTTP.Response{}, err
 	}
@@ -62,6 +82,11 @@ func mapTaskRegistrationStatusToResponse(request internalHTTP.Request,
 			Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeTextPlain},
 			Body:       TaskAlreadyCreatedErrorMessage,
 		}, nil
+	case task.RegistrationResultProcessSealed:
+		return createErrorResponse(http.StatusGone, string(registrationResult), internalHTTP.ProcessSealedMessage), nil
+	case task.RegistrationResultProcessDeadlineExceeded:
+		return createErrorResponse(http.StatusGone, string(registrationResult),
+			internalHTTP.ProcessDeadlineExceededMessage), nil
 	default:
 		return internalHTTP.Response{}, fmt.Errorf("unknown registration result: %s", registrationResult)
 	}
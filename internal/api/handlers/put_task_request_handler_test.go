@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
@@ -17,8 +18,9 @@ type taskRegistererMock struct {
 	mock.Mock
 }
 
-func (registerer *taskRegistererMock) Register(registrationData task.RegistrationData) (task.RegistrationResult, error) {
-	args := registerer.Called(registrationData)
+func (registerer *taskRegistererMock) Register(ctx context.Context, registrationData task.RegistrationData) (
+	task.RegistrationResult, error) {
+	args := registerer.Called(ctx, registrationData)
 	return args.Get(0).(task.RegistrationResult), args.Error(1)
 }
 
@@ -56,17 +58,17 @@ func newPutTaskReqHandlerWithMocks() *putTaskReqHandlerWithMocks {
 			ExpirationTime: apiTask.ExpirationTime,
 		},
 		taskRegistererMock: taskRegisterer,
-		handler:            handlers.NewPutTaskRequestHandler(taskRegisterer),
+		handler:            handlers.NewPutTaskRequestHandler(taskRegisterer, handlers.CallbackURLPolicy{}),
 	}
 }
 
 func TestPutTaskRequestHandler_HandleRequest_TaskCreated(t *testing.T) {
 	handlerAndMocks := newPutTaskReqHandlerWithMocks()
 
-	handlerAndMocks.taskRegistererMock.On("Register", handlerAndMocks.registrationData).
+	handlerAndMocks.taskRegistererMock.On("Register", mock.Anything, handlerAndMocks.registrationData).
 		Return(task.RegistrationResultCreated, nil)
 
-	response, err := handlerAndMocks.handler.HandleRequest(handlerAndMocks.request)
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
 	assert.NoError(t, err)
 	handlerAndMocks.assertExpectations(t)
 	assert.Equal(t, internalHTTP.Response{
@@ -81,10 +83,10 @@ func TestPutTaskRequestHandler_HandleRequest_TaskCreated(t *testing.T) {
 func TestPutTaskRequestHandler_HandleRequest_DuplicatedLastTask(t *testing.T) {
 	handlerAndMocks := newPutTaskReqHandlerWithMocks()
 
-	handlerAndMocks.taskRegistererMock.On("Register", handlerAndMocks.registrationData).
+	handlerAndMocks.taskRegistererMock.On("Register", mock.Anything, handlerAndMocks.registrationData).
 		Return(task.RegistrationResultAlreadyRegistered, nil)
 
-	response, err := handlerAndMocks.handler.HandleRequest(handlerAndMocks.request)
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
 	assert.NoError(t, err)
 	handlerAndMocks.assertExpectations(t)
 	assert.Equal(t, internalHTTP.Response{
@@ -96,13 +98,71 @@ func TestPutTaskRequestHandler_HandleRequest_DuplicatedLastTask(t *testing.T) {
 	}, response)
 }
 
+func TestPutTaskRequestHandler_HandleRequest_ProcessSealed(t *testing.T) {
+	handlerAndMocks := newPutTaskReqHandlerWithMocks()
+
+	handlerAndMocks.taskRegistererMock.On("Register", mock.Anything, handlerAndMocks.registrationData).
+		Return(task.RegistrationResultProcessSealed, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, internalHTTP.Response{
+		StatusCode: http.StatusGone,
+		Headers: map[string]string{
+			internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON,
+		},
+		Body: internalHTTP.ErrorBody{
+			Result:  string(task.RegistrationResultProcessSealed),
+			Message: internalHTTP.ProcessSealedMessage,
+		}.JSON(),
+	}, response)
+}
+
+func TestPutTaskRequestHandler_HandleRequest_ProcessDeadlineExceeded(t *testing.T) {
+	handlerAndMocks := newPutTaskReqHandlerWithMocks()
+
+	handlerAndMocks.taskRegistererMock.On("Register", mock.Anything, handlerAndMocks.registrationData).
+		Return(task.RegistrationResultProcessDeadlineExceeded, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, internalHTTP.Response{
+		StatusCode: http.StatusGone,
+		Headers: map[string]string{
+			internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON,
+		},
+		Body: internalHTTP.ErrorBody{
+			Result:  string(task.RegistrationResultProcessDeadlineExceeded),
+			Message: internalHTTP.ProcessDeadlineExceededMessage,
+		}.JSON(),
+	}, response)
+}
+
+func TestPutTaskRequestHandler_HandleRequest_ReservedTaskID(t *testing.T) {
+	handlerAndMocks := newPutTaskReqHandlerWithMocks()
+	handlerAndMocks.request.PathParameters[internalHTTP.PathParameterTaskID] = task.ReservedID
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, internalHTTP.Response{
+		StatusCode: http.StatusBadRequest,
+		Headers: map[string]string{
+			internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeTextPlain,
+		},
+		Body: handlers.ReservedTaskIDErrorMessage,
+	}, response)
+}
+
 func TestPutTaskRequestHandler_HandleRequest_UnknownRegistrationResult(t *testing.T) {
 	handlerAndMocks := newPutTaskReqHandlerWithMocks()
 
-	handlerAndMocks.taskRegistererMock.On("Register", handlerAndMocks.registrationData).
+	handlerAndMocks.taskRegistererMock.On("Register", mock.Anything, handlerAndMocks.registrationData).
 		Return(task.RegistrationResult("unknown"), nil)
 
-	_, err := handlerAndMocks.handler.HandleRequest(handlerAndMocks.request)
+	_, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
 	handlerAndMocks.assertExpectations(t)
 	assert.Error(t, err)
 }
@@ -110,10 +170,10 @@ func TestPutTaskRequestHandler_HandleRequest_UnknownRegistrationResult(t *testin
 func TestPutTaskRequestHandler_HandleRequest_RegistrationFailure(t *testing.T) {
 	handlerAndMocks := newPutTaskReqHandlerWithMocks()
 
-	handlerAndMocks.taskRegistererMock.On("Register", handlerAndMocks.registrationData).
+	handlerAndMocks.taskRegistererMock.On("Register", mock.Anything, handlerAndMocks.registrationData).
 		Return(task.RegistrationResult(""), errors.New("error"))
 
-	_, err := handlerAndMocks.handler.HandleRequest(handlerAndMocks.request)
+	_, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
 	handlerAndMocks.assertExpectations(t)
 	assert.Error(t, err)
 }
@@ -122,7 +182,7 @@ func TestPutTaskRequestHandler_HandleRequest_InvalidBody(t *testing.T) {
 	handlerAndMocks := newPutTaskReqHandlerWithMocks()
 	handlerAndMocks.request.Body = ""
 
-	response, err := handlerAndMocks.handler.HandleRequest(handlerAndMocks.request)
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
 	assert.NoError(t, err)
 	assert.Equal(t, internalHTTP.Response{
 		StatusCode: http.StatusBadRequest,
@@ -132,3 +192,66 @@ func TestPutTaskRequestHandler_HandleRequest_InvalidBody(t *testing.T) {
 		},
 	}, response)
 }
+
+func TestPutTaskRequestHandler_HandleRequest_WithCallbackURL(t *testing.T) {
+	handlerAndMocks := newPutTaskReqHandlerWithMocks()
+	callbackURL := "https://example.com/callbacks"
+	handlerAndMocks.request.Body = internalHTTP.Task{
+		ExpirationTime: handlerAndMocks.registrationData.ExpirationTime,
+		CallbackURL:    &callbackURL,
+	}.JSON()
+	handlerAndMocks.registrationData.CallbackURL = &callbackURL
+	handlerAndMocks.taskRegistererMock.On("Register", mock.Anything, handlerAndMocks.registrationData).
+		Return(task.RegistrationResultCreated, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+}
+
+func TestPutTaskRequestHandler_HandleRequest_WithProcessDeadline(t *testing.T) {
+	handlerAndMocks := newPutTaskReqHandlerWithMocks()
+	processDeadline := handlerAndMocks.registrationData.ExpirationTime.Add(time.Hour)
+	handlerAndMocks.request.Body = internalHTTP.Task{
+		ExpirationTime:  handlerAndMocks.registrationData.ExpirationTime,
+		ProcessDeadline: &processDeadline,
+	}.JSON()
+	handlerAndMocks.registrationData.ProcessDeadline = processDeadline
+	handlerAndMocks.taskRegistererMock.On("Register", mock.Anything, handlerAndMocks.registrationData).
+		Return(task.RegistrationResultCreated, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+}
+
+func TestPutTaskRequestHandler_HandleRequest_WithPrincipal(t *testing.T) {
+	handlerAndMocks := newPutTaskReqHandlerWithMocks()
+	principal := "arn:aws:iam::123456789012:user/creator"
+	handlerAndMocks.request.Principal = &principal
+	handlerAndMocks.registrationData.Creator = &principal
+	handlerAndMocks.taskRegistererMock.On("Register", mock.Anything, handlerAndMocks.registrationData).
+		Return(task.RegistrationResultCreated, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+}
+
+func TestPutTaskRequestHandler_HandleRequest_PrivateCallbackURL(t *testing.T) {
+	handlerAndMocks := newPutTaskReqHandlerWithMocks()
+	callbackURL := "http://192.168.0.10/callbacks"
+	handlerAndMocks.request.Body = internalHTTP.Task{
+		ExpirationTime: handlerAndMocks.registrationData.ExpirationTime,
+		CallbackURL:    &callbackURL,
+	}.JSON()
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Equal(t, handlers.InvalidCallbackURLMsg, response.Body)
+}
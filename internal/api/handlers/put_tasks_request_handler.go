@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+const (
+	MaxBatchRegisteredTasksCount           = 1000
+	MaxTransactionallyRegisteredTasksCount = 99
+	InvalidBatchTasksMsg                   = "tasks must have unique, non empty, not reserved ids"
+	TooManyBatchTasksMsg                   = "too many tasks"
+	InvalidTransactionalMsg                = "transactional must be true or false"
+)
+
+type PutTasksRequestHandler struct {
+	registerer        task.BatchRegisterer
+	currentDateGetter CurrentDateGetter
+}
+
+func NewPutTasksRequestHandler(registerer task.BatchRegisterer,
+	currentDateGetter CurrentDateGetter) *PutTasksRequestHandler {
+	return &PutTasksRequestHandler{
+		registerer:        registerer,
+		currentDateGetter: currentDateGetter,
+	}
+}
+
+func (handler *PutTasksRequestHandler) HandleRequest(ctx context.Context, request internalHTTP.Request) (
+	internalHTTP.Response, error) {
+	batchTasks, err := internalHTTP.UnmarshalBatchTasks(request.Body)
+	if err != nil {
+		return createTextResponse(http.StatusBadRequest, InvalidPayloadErrorMessage), nil
+	}
+	isTransactional, isTransactionalValid := readTransactional(request)
+	if !isTransactionalValid {
+		return createTextResponse(http.StatusBadRequest, InvalidTransactionalMsg), nil
+	}
+	if len(batchTasks) > MaxBatchRegisteredTasksCount ||
+		(isTransactional && len(batchTasks) > MaxTransactionallyRegisteredTasksCount) {
+		return createTextResponse(http.StatusBadRequest, TooManyBatchTasksMsg), nil
+	}
+
+	if !haveFutureExpirationTimes(batchTasks, handler.currentDateGetter.GetCurrentDate()) {
+		return createTextResponse(http.StatusBadRequest, PastExpirationTimeMsg), nil
+	}
+
+	processID := request.PathParameters[internalHTTP.PathParameterProcessID]
+	tasksRegistrationData, areTasksValid := readBatchTasks(processID, batchTasks, request.Principal)
+	if !areTasksValid {
+		return createTextResponse(http.StatusBadRequest, InvalidBatchTasksMsg), nil
+	}
+
+	results, err := handler.registerer.BatchRegister(ctx, task.BatchRegistrationRequest{
+		ProcessID:     processID,
+		Tasks:         tasksRegistrationData,
+		Transactional: isTransactional,
+	})
+	if err != nil {
+		return internalHTTP.Response{}, err
+	}
+
+	return internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+		Body:       internalHTTP.ConvertInternalToHTTPBatchRegistrationResults(results).JSON(),
+	}, nil
+}
+
+func readBatchTasks(processID string, batchTasks internalHTTP.BatchTasks,
+	creator *string) ([]task.RegistrationData, bool) {
+	if len(batchTasks) == 0 {
+		return nil, false
+	}
+	tasksRegistrationData := make([]task.RegistrationData, 0, len(batchTasks))
+	usedTaskIDs := make(map[string]bool)
+	for _, batchTask := range batchTasks {
+		if batchTask.TaskID == "" || task.IsReservedTaskID(batchTask.TaskID) || usedTaskIDs[batchTask.TaskID] {
+			return nil, false
+		}
+		usedTaskIDs[batchTask.TaskID] = true
+		tasksRegistrationData = append(tasksRegistrationData, task.RegistrationData{
+			ID: task.ID{
+				ProcessID: processID,
+				TaskID:    batchTask.TaskID,
+			},
+			ExpirationTime: batchTask.ExpirationTime,
+			Creator:        creator,
+		})
+	}
+	return tasksRegistrationData, true
+}
+
+func haveFutureExpirationTimes(batchTasks internalHTTP.BatchTasks, currentDate time.Time) bool {
+	for _, batchTask := range batchTasks {
+		if !batchTask.ExpirationTime.After(currentDate) {
+			return false
+		}
+	}
+	return true
+}
+
+func readTransactional(request internalHTTP.Request) (bool, bool) {
+	transactional, isTransactionalDefined := request.QueryParameters[internalHTTP.QueryParameterTransactional]
+	if !isTransactionalDefined {
+		return false, true
+	}
+	isTransactional, err := strconv.ParseBool(transactional)
+	return isTransactional, err == nil
+}
@@ -0,0 +1,190 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/api/handlers"
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type taskBatchRegistererMock struct {
+	mock.Mock
+}
+
+func (registerer *taskBatchRegistererMock) BatchRegister(ctx context.Context,
+	request task.BatchRegistrationRequest) ([]task.BatchRegistrationResult, error) {
+	args := registerer.Called(ctx, request)
+	return args.Get(0).([]task.BatchRegistrationResult), args.Error(1)
+}
+
+type putTasksReqHandlerWithMocks struct {
+	request             internalHTTP.Request
+	registrationRequest task.BatchRegistrationRequest
+	registererMock      *taskBatchRegistererMock
+	handler             *handlers.PutTasksRequestHandler
+}
+
+func (handlerAndMocks *putTasksReqHandlerWithMocks) assertExpectations(t *testing.T) {
+	handlerAndMocks.registererMock.AssertExpectations(t)
+}
+
+func newPutTasksReqHandlerWithMocks(batchTasks internalHTTP.BatchTasks) *putTasksReqHandlerWithMocks {
+	registererMock := new(taskBatchRegistererMock)
+	currentDateGetter := new(currentDateGetterMock)
+	currentDateGetter.On("GetCurrentDate").Return(time.Now().UTC())
+	processID := "2"
+	creator := aws.String("arn:aws:iam::123456789012:user/creator")
+	registrationRequest := task.BatchRegistrationRequest{ProcessID: processID}
+	for _, batchTask := range batchTasks {
+		registrationRequest.Tasks = append(registrationRequest.Tasks, task.RegistrationData{
+			ID:             task.ID{ProcessID: processID, TaskID: batchTask.TaskID},
+			ExpirationTime: batchTask.ExpirationTime,
+			Creator:        creator,
+		})
+	}
+	return &putTasksReqHandlerWithMocks{
+		request: internalHTTP.Request{
+			PathParameters: map[internalHTTP.PathParameter]string{
+				internalHTTP.PathParameterProcessID: processID,
+			},
+			QueryParameters: map[internalHTTP.QueryParameter]string{},
+			Body:            batchTasks.JSON(),
+			Principal:       creator,
+		},
+		registrationRequest: registrationRequest,
+		registererMock:      registererMock,
+		handler:             handlers.NewPutTasksRequestHandler(registererMock, currentDateGetter),
+	}
+}
+
+func newBatchTasks(count int) internalHTTP.BatchTasks {
+	expirationTime := time.Now().Add(time.Hour).UTC()
+	batchTasks := make(internalHTTP.BatchTasks, 0, count)
+	for index := 0; index < count; index++ {
+		batchTasks = append(batchTasks, internalHTTP.BatchTask{TaskID: strconv.Itoa(index), ExpirationTime: expirationTime})
+	}
+	return batchTasks
+}
+
+func TestPutTasksRequestHandler_HandleRequest(t *testing.T) {
+	handlerAndMocks := newPutTasksReqHandlerWithMocks(newBatchTasks(2))
+	results := []task.BatchRegistrationResult{
+		{TaskID: "0", Result: task.RegistrationResultCreated},
+		{TaskID: "1", Result: task.RegistrationResultAlreadyRegistered},
+	}
+	handlerAndMocks.registererMock.On("BatchRegister", mock.Anything, handlerAndMocks.registrationRequest).
+		Return(results, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+		Body:       internalHTTP.ConvertInternalToHTTPBatchRegistrationResults(results).JSON(),
+	}, response)
+}
+
+func TestPutTasksRequestHandler_HandleRequest_Transactional(t *testing.T) {
+	handlerAndMocks := newPutTasksReqHandlerWithMocks(newBatchTasks(1))
+	handlerAndMocks.request.QueryParameters[internalHTTP.QueryParameterTransactional] = "true"
+	handlerAndMocks.registrationRequest.Transactional = true
+	results := []task.BatchRegistrationResult{{TaskID: "0", Result: task.RegistrationResultCreated}}
+	handlerAndMocks.registererMock.On("BatchRegister", mock.Anything, handlerAndMocks.registrationRequest).
+		Return(results, nil)
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	handlerAndMocks.assertExpectations(t)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+func TestPutTasksRequestHandler_HandleRequest_InvalidRequests(t *testing.T) {
+	duplicatedTasks := newBatchTasks(1)
+	duplicatedTasks = append(duplicatedTasks, duplicatedTasks[0])
+	testCases := []struct {
+		name          string
+		batchTasks    internalHTTP.BatchTasks
+		transactional string
+		expectedBody  string
+	}{
+		{name: "empty", batchTasks: internalHTTP.BatchTasks{}, expectedBody: handlers.InvalidBatchTasksMsg},
+		{name: "duplicated", batchTasks: duplicatedTasks, expectedBody: handlers.InvalidBatchTasksMsg},
+		{
+			name:         "reserved id",
+			batchTasks:   internalHTTP.BatchTasks{{TaskID: task.ReservedID, ExpirationTime: time.Now().Add(time.Hour)}},
+			expectedBody: handlers.InvalidBatchTasksMsg,
+		},
+		{
+			name:         "missing expiration time",
+			batchTasks:   append(newBatchTasks(1), internalHTTP.BatchTask{TaskID: "1"}),
+			expectedBody: handlers.PastExpirationTimeMsg,
+		},
+		{
+			name: "past expiration time",
+			batchTasks: append(newBatchTasks(1),
+				internalHTTP.BatchTask{TaskID: "1", ExpirationTime: time.Now().Add(-time.Hour).UTC()}),
+			expectedBody: handlers.PastExpirationTimeMsg,
+		},
+		{
+			name:         "too many",
+			batchTasks:   newBatchTasks(handlers.MaxBatchRegisteredTasksCount + 1),
+			expectedBody: handlers.TooManyBatchTasksMsg,
+		},
+		{
+			name:          "too many in transaction",
+			batchTasks:    newBatchTasks(handlers.MaxTransactionallyRegisteredTasksCount + 1),
+			transactional: "true",
+			expectedBody:  handlers.TooManyBatchTasksMsg,
+		},
+		{
+			name:          "invalid transactional",
+			batchTasks:    newBatchTasks(1),
+			transactional: "yes please",
+			expectedBody:  handlers.InvalidTransactionalMsg,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			handlerAndMocks := newPutTasksReqHandlerWithMocks(testCase.batchTasks)
+			if testCase.transactional != "" {
+				handlerAndMocks.request.QueryParameters[internalHTTP.QueryParameterTransactional] = testCase.transactional
+			}
+
+			response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+			assert.NoError(t, err)
+			handlerAndMocks.assertExpectations(t)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			assert.Equal(t, testCase.expectedBody, response.Body)
+		})
+	}
+}
+
+func TestPutTasksRequestHandler_HandleRequest_InvalidPayload(t *testing.T) {
+	handlerAndMocks := newPutTasksReqHandlerWithMocks(newBatchTasks(1))
+	handlerAndMocks.request.Body = "{}"
+
+	response, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.Equal(t, handlers.InvalidPayloadErrorMessage, response.Body)
+}
+
+func TestPutTasksRequestHandler_HandleRequest_RegistrationError(t *testing.T) {
+	handlerAndMocks := newPutTasksReqHandlerWithMocks(newBatchTasks(1))
+	handlerAndMocks.registererMock.On("BatchRegister", mock.Anything, handlerAndMocks.registrationRequest).
+		Return([]task.BatchRegistrationResult(nil), errors.New("error"))
+
+	_, err := handlerAndMocks.handler.HandleRequest(context.Background(), handlerAndMocks.request)
+	assert.Error(t, err)
+	handlerAndMocks.assertExpectations(t)
+}
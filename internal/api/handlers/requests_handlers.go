@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+type Store interface {
+	task.Registerer
+	task.BatchRegisterer
+	task.Completer
+	task.Heartbeater
+	task.Lister
+	task.Getter
+	process.Getter
+	process.Lister
+	process.Sealer
+	process.Updater
+}
+
+type RequestsHandlersDependencies struct {
+	TaskRegisterer       task.Registerer
+	TaskBatchRegisterer  task.BatchRegisterer
+	TaskCompleter        task.Completer
+	TaskHeartbeater      task.Heartbeater
+	TaskLister           task.Lister
+	TaskGetter           task.Getter
+	ProcessGetter        process.Getter
+	ProcessLister        process.Lister
+	ProcessSealer        process.Sealer
+	ProcessUpdater       process.Updater
+	CurrentDateGetter    CurrentDateGetter
+	ProcessWaitingConfig ProcessWaitingConfig
+	CallbackURLPolicy    CallbackURLPolicy
+}
+
+func NewStoreDependencies(store Store, currentDateGetter CurrentDateGetter, processWaitingConfig ProcessWaitingConfig,
+	callbackURLPolicy CallbackURLPolicy) RequestsHandlersDependencies {
+	return RequestsHandlersDependencies{
+		TaskRegisterer:       store,
+		TaskBatchRegisterer:  store,
+		TaskCompleter:        store,
+		TaskHeartbeater:      store,
+		TaskLister:           store,
+		TaskGetter:           store,
+		ProcessGetter:        store,
+		ProcessLister:        store,
+		ProcessSealer:        store,
+		ProcessUpdater:       store,
+		CurrentDateGetter:    currentDateGetter,
+		ProcessWaitingConfig: processWaitingConfig,
+		CallbackURLPolicy:    callbackURLPolicy,
+	}
+}
+
+func NewRequestsHandlersMap(dependencies RequestsHandlersDependencies) internalHTTP.RequestsHandlersMap {
+	return internalHTTP.RequestsHandlersMap{
+		internalHTTP.ResourcePathTask: {
+			internalHTTP.MethodPut: NewPutTaskRequestHandler(dependencies.TaskRegisterer, dependencies.CallbackURLPolicy),
+			internalHTTP.MethodGet: NewGetTaskRequestHandler(dependencies.TaskGetter),
+		},
+		internalHTTP.ResourcePathTaskCompletion: {
+			internalHTTP.MethodPut: NewPutTaskCompletionRequestHandler(dependencies.TaskCompleter),
+		},
+		internalHTTP.ResourcePathTaskCompletionWithChildren: {
+			internalHTTP.MethodPut: NewPutTaskCompletionWithChildrenRequestHandler(dependencies.TaskCompleter),
+		},
+		internalHTTP.ResourcePathTaskHeartbeat: {
+			internalHTTP.MethodPut: NewPutTaskHeartbeatRequestHandler(dependencies.TaskHeartbeater,
+				dependencies.CurrentDateGetter),
+		},
+		internalHTTP.ResourcePathTasks: {
+			internalHTTP.MethodGet: NewGetTasksRequestHandler(dependencies.TaskLister),
+			internalHTTP.MethodPut: NewPutTasksRequestHandler(dependencies.TaskBatchRegisterer,
+				dependencies.CurrentDateGetter),
+		},
+		internalHTTP.ResourcePathCompletions: {
+			internalHTTP.MethodPut: NewPutCompletionsRequestHandler(dependencies.TaskCompleter),
+		},
+		internalHTTP.ResourcePathProcesses: {
+			internalHTTP.MethodGet: NewGetProcessesRequestHandler(dependencies.ProcessLister),
+		},
+		internalHTTP.ResourcePathProcessesAction: {
+			internalHTTP.MethodPost: NewPostProcessesBatchGetRequestHandler(dependencies.ProcessGetter),
+		},
+		internalHTTP.ResourcePathProcess: {
+			internalHTTP.MethodGet: NewGetProcessRequestHandler(dependencies.ProcessGetter,
+				dependencies.CurrentDateGetter, dependencies.ProcessWaitingConfig),
+			internalHTTP.MethodPut: NewPutProcessRequestHandler(dependencies.ProcessUpdater,
+				dependencies.CallbackURLPolicy),
+		},
+		internalHTTP.ResourcePathProcessSeal: {
+			internalHTTP.MethodPut: NewPutProcessSealRequestHandler(dependencies.ProcessSealer),
+		},
+	}
+}
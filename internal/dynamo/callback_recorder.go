@@ -0,0 +1,133 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+const processCallbackStateIndex = "callbackStateIndex"
+
+var (
+	listPendingCallbacksKeyCondExpression = fmt.Sprintf("%s = %s", processCallbackStateAttrAlias,
+		processCallbackStateValuePlaceholder)
+	listPendingCallbacksAfterProcessKeyCondExpression = fmt.Sprintf("%s and %s > %s",
+		listPendingCallbacksKeyCondExpression, ProcessIDAttrAlias, ProcessIDValuePlaceholder)
+	recordCallbackDeliveryConditionExpr = fmt.Sprintf("attribute_exists(%s)", processCallbackURLAttrAlias)
+)
+
+type CallbackRecorder struct {
+	dynamoAPI      dynamodbiface.DynamoDBAPI
+	tasksTableName string
+}
+
+func NewCallbackRecorder(dynamoAPI dynamodbiface.DynamoDBAPI, tasksTableName string) *CallbackRecorder {
+	return &CallbackRecorder{
+		dynamoAPI:      dynamoAPI,
+		tasksTableName: tasksTableName,
+	}
+}
+
+func (recorder *CallbackRecorder) ListPendingCallbacks(ctx context.Context, afterProcessID string, limit int) (
+	[]string, error) {
+	out, err := recorder.dynamoAPI.QueryWithContext(ctx,
+		BuildListPendingCallbacksQueryInput(recorder.tasksTableName, afterProcessID, limit))
+	if err != nil || out == nil {
+		return nil, err
+	}
+	processIDs := make([]string, 0, len(out.Items))
+	for _, item := range out.Items {
+		processIDAttr, isProcessIDDefined := item[ProcessIDAttrName]
+		if !isProcessIDDefined || processIDAttr.S == nil {
+			return nil, fmt.Errorf("item does not contain process id attribute: %+v", item)
+		}
+		processIDs = append(processIDs, *processIDAttr.S)
+	}
+	return processIDs, nil
+}
+
+func (recorder *CallbackRecorder) RecordCallbackDelivery(ctx context.Context, delivery process.CallbackDelivery) error {
+	_, err := recorder.dynamoAPI.UpdateItemWithContext(ctx,
+		BuildRecordCallbackDeliveryUpdateItemInput(recorder.tasksTableName, delivery))
+	if awsErr, isAWSErr := err.(awserr.Error); isAWSErr && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+		return nil
+	}
+	return err
+}
+
+func BuildListPendingCallbacksQueryInput(tableName string, afterProcessID string, limit int) *dynamodb.QueryInput {
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames: map[string]*string{
+			processCallbackStateAttrAlias: aws.String(ProcessCallbackStateAttrName),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			processCallbackStateValuePlaceholder: {S: aws.String(string(process.CallbackStatePending))},
+		},
+		IndexName:              aws.String(processCallbackStateIndex),
+		KeyConditionExpression: &listPendingCallbacksKeyCondExpression,
+		Limit:                  aws.Int64(int64(limit)),
+		TableName:              &tableName,
+	}
+	if afterProcessID != "" {
+		queryInput.KeyConditionExpression = &listPendingCallbacksAfterProcessKeyCondExpression
+		queryInput.ExpressionAttributeNames[ProcessIDAttrAlias] = aws.String(ProcessIDAttrName)
+		queryInput.ExpressionAttributeValues[ProcessIDValuePlaceholder] = &dynamodb.AttributeValue{S: &afterProcessID}
+	}
+	return queryInput
+}
+
+func BuildRecordCallbackDeliveryUpdateItemInput(tableName string, delivery process.CallbackDelivery) *dynamodb.UpdateItemInput {
+	attemptsString := strconv.Itoa(delivery.Attempts)
+	setExpressions := []string{
+		fmt.Sprintf("%s = %s", processCallbackStateAttrAlias, processCallbackStateValuePlaceholder),
+		fmt.Sprintf("%s = %s", processCallbackAttemptsAttrAlias, processCallbackAttemptsValuePlaceholder),
+	}
+	var removedAttributes []string
+	expressionAttributeValues := map[string]*dynamodb.AttributeValue{
+		processCallbackStateValuePlaceholder:    {S: aws.String(string(delivery.State))},
+		processCallbackAttemptsValuePlaceholder: {N: &attemptsString},
+	}
+	if delivery.LastError != nil {
+		setExpressions = append(setExpressions, fmt.Sprintf("%s = %s", processCallbackLastErrorAttrAlias,
+			processCallbackLastErrorValuePlaceholder))
+		expressionAttributeValues[processCallbackLastErrorValuePlaceholder] = &dynamodb.AttributeValue{S: delivery.LastError}
+	} else {
+		removedAttributes = append(removedAttributes, processCallbackLastErrorAttrAlias)
+	}
+	if !delivery.DeliveryTime.IsZero() {
+		setExpressions = append(setExpressions, fmt.Sprintf("%s = %s", processCallbackDeliveryTimeAttrAlias,
+			processCallbackDeliveryTimeValuePlaceholder))
+		expressionAttributeValues[processCallbackDeliveryTimeValuePlaceholder] = &dynamodb.AttributeValue{
+			S: aws.String(delivery.DeliveryTime.Format(time.RFC3339)),
+		}
+	} else {
+		removedAttributes = append(removedAttributes, processCallbackDeliveryTimeAttrAlias)
+	}
+	updateExpr := "SET " + strings.Join(setExpressions, ", ")
+	if len(removedAttributes) > 0 {
+		updateExpr += " REMOVE " + strings.Join(removedAttributes, ", ")
+	}
+
+	return &dynamodb.UpdateItemInput{
+		ConditionExpression: &recordCallbackDeliveryConditionExpr,
+		ExpressionAttributeNames: map[string]*string{
+			processCallbackURLAttrAlias:          aws.String(ProcessCallbackURLAttrName),
+			processCallbackStateAttrAlias:        aws.String(ProcessCallbackStateAttrName),
+			processCallbackAttemptsAttrAlias:     aws.String(ProcessCallbackAttemptsAttrName),
+			processCallbackLastErrorAttrAlias:    aws.String(ProcessCallbackLastErrorAttrName),
+			processCallbackDeliveryTimeAttrAlias: aws.String(ProcessCallbackDeliveryTimeAttrName),
+		},
+		ExpressionAttributeValues: expressionAttributeValues,
+		Key:                       buildProcessItemKey(delivery.ProcessID),
+		TableName:                 &tableName,
+		UpdateExpression:          &updateExpr,
+	}
+}
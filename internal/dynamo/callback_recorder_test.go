@@ -0,0 +1,105 @@
+package dynamo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/dynamo"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type callbackRecorderWithMocks struct {
+	recorder  *dynamo.CallbackRecorder
+	dynamoAPI *dynamoAPIMock
+}
+
+func newCallbackRecorderWithMocks() *callbackRecorderWithMocks {
+	dynamoAPI := new(dynamoAPIMock)
+	return &callbackRecorderWithMocks{
+		recorder:  dynamo.NewCallbackRecorder(dynamoAPI, tasksTableName),
+		dynamoAPI: dynamoAPI,
+	}
+}
+
+func TestCallbackRecorder_ListPendingCallbacks(t *testing.T) {
+	recorderAndMocks := newCallbackRecorderWithMocks()
+	recorderAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything,
+		dynamo.BuildListPendingCallbacksQueryInput(tasksTableName, "", 10)).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			{dynamo.ProcessIDAttrName: {S: aws.String("1")}},
+			{dynamo.ProcessIDAttrName: {S: aws.String("2")}},
+		},
+	}, nil)
+
+	processIDs, err := recorderAndMocks.recorder.ListPendingCallbacks(context.Background(), "", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, processIDs)
+	recorderAndMocks.dynamoAPI.AssertExpectations(t)
+}
+
+func TestCallbackRecorder_ListPendingCallbacks_AfterProcessID(t *testing.T) {
+	recorderAndMocks := newCallbackRecorderWithMocks()
+	queryInput := dynamo.BuildListPendingCallbacksQueryInput(tasksTableName, "1", 10)
+	recorderAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, queryInput).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{{dynamo.ProcessIDAttrName: {S: aws.String("2")}}},
+	}, nil)
+
+	processIDs, err := recorderAndMocks.recorder.ListPendingCallbacks(context.Background(), "1", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2"}, processIDs)
+	assert.Equal(t, aws.String("1"), queryInput.ExpressionAttributeValues[dynamo.ProcessIDValuePlaceholder].S)
+	recorderAndMocks.dynamoAPI.AssertExpectations(t)
+}
+
+func TestCallbackRecorder_ListPendingCallbacks_InvalidItem(t *testing.T) {
+	recorderAndMocks := newCallbackRecorderWithMocks()
+	recorderAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything,
+		dynamo.BuildListPendingCallbacksQueryInput(tasksTableName, "", 10)).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{{}},
+	}, nil)
+
+	_, err := recorderAndMocks.recorder.ListPendingCallbacks(context.Background(), "", 10)
+	assert.Error(t, err)
+}
+
+func TestCallbackRecorder_RecordCallbackDelivery(t *testing.T) {
+	recorderAndMocks := newCallbackRecorderWithMocks()
+	delivery := process.CallbackDelivery{
+		ProcessID:    "1",
+		State:        process.CallbackStateDelivered,
+		Attempts:     1,
+		DeliveryTime: time.Now().UTC(),
+	}
+	updateItemInput := dynamo.BuildRecordCallbackDeliveryUpdateItemInput(tasksTableName, delivery)
+	recorderAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything, updateItemInput).
+		Return(&dynamodb.UpdateItemOutput{}, nil)
+
+	err := recorderAndMocks.recorder.RecordCallbackDelivery(context.Background(), delivery)
+	assert.NoError(t, err)
+	assert.Equal(t, "SET #callbackState = :callbackState, #callbackAttempts = :callbackAttempts, "+
+		"#callbackDeliveryTime = :callbackDeliveryTime REMOVE #callbackLastError", *updateItemInput.UpdateExpression)
+	recorderAndMocks.dynamoAPI.AssertExpectations(t)
+}
+
+func TestCallbackRecorder_RecordCallbackDelivery_CallbackNotConfigured(t *testing.T) {
+	recorderAndMocks := newCallbackRecorderWithMocks()
+	delivery := process.CallbackDelivery{
+		ProcessID: "1",
+		State:     process.CallbackStatePending,
+		Attempts:  1,
+		LastError: aws.String("unexpected status code: 503"),
+	}
+	recorderAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything,
+		dynamo.BuildRecordCallbackDeliveryUpdateItemInput(tasksTableName, delivery)).
+		Return((*dynamodb.UpdateItemOutput)(nil), awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil))
+
+	err := recorderAndMocks.recorder.RecordCallbackDelivery(context.Background(), delivery)
+	assert.NoError(t, err)
+	recorderAndMocks.dynamoAPI.AssertExpectations(t)
+}
@@ -3,6 +3,8 @@ package dynamo_test
 import (
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/stretchr/testify/mock"
@@ -15,22 +17,52 @@ type dynamoAPIMock struct {
 	dynamodbiface.DynamoDBAPI
 }
 
-func (api *dynamoAPIMock) Query(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
-	args := api.Called(input)
+func (api *dynamoAPIMock) QueryWithContext(ctx aws.Context, input *dynamodb.QueryInput,
+	_ ...request.Option) (*dynamodb.QueryOutput, error) {
+	args := api.Called(ctx, input)
 	if args.Get(0) == 0 {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dynamodb.QueryOutput), args.Error(1)
 }
 
-func (api *dynamoAPIMock) UpdateItem(input *dynamodb.UpdateItemInput) (*dynamodb.UpdateItemOutput, error) {
-	args := api.Called(input)
+func (api *dynamoAPIMock) GetItemWithContext(ctx aws.Context, input *dynamodb.GetItemInput,
+	_ ...request.Option) (*dynamodb.GetItemOutput, error) {
+	args := api.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dynamodb.GetItemOutput), args.Error(1)
+}
+
+func (api *dynamoAPIMock) UpdateItemWithContext(ctx aws.Context, input *dynamodb.UpdateItemInput,
+	_ ...request.Option) (*dynamodb.UpdateItemOutput, error) {
+	args := api.Called(ctx, input)
 	if args.Get(0) == 0 {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dynamodb.UpdateItemOutput), args.Error(1)
 }
 
+func (api *dynamoAPIMock) ScanWithContext(ctx aws.Context, input *dynamodb.ScanInput,
+	_ ...request.Option) (*dynamodb.ScanOutput, error) {
+	args := api.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dynamodb.ScanOutput), args.Error(1)
+}
+
+func (api *dynamoAPIMock) TransactWriteItemsWithContext(ctx aws.Context,
+	input *dynamodb.TransactWriteItemsInput, _ ...request.Option) (
+	*dynamodb.TransactWriteItemsOutput, error) {
+	args := api.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dynamodb.TransactWriteItemsOutput), args.Error(1)
+}
+
 type currentDateGetterMock struct {
 	mock.Mock
 }
@@ -1,7 +1,9 @@
 package dynamo
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/artii15/termination-detector/pkg/process"
 	"github.com/artii15/termination-detector/pkg/task"
@@ -36,17 +38,63 @@ func NewProcessGetter(dynamoAPI dynamodbiface.DynamoDBAPI,
 	}
 }
 
-func (getter *ProcessGetter) Get(processID string) (*process.Process, error) {
-	if processExists, err := getter.exists(processID); err != nil || !processExists {
+func (getter *ProcessGetter) Get(ctx context.Context, processID string) (*process.Process, error) {
+	foundProcessItem, err := getter.getProcessItem(ctx, processID)
+	if err != nil {
+		return nil, err
+	}
+	if foundProcessItem == nil {
+		if processExists, err := getter.exists(ctx, processID); err != nil || !processExists {
+			return nil, err
+		}
+		foundProcessItem = &processItem{}
+	}
+
+	foundProcess, err := getter.evaluateProcess(ctx, processID, foundProcessItem)
+	if err != nil {
+		return nil, err
+	}
+	currentTime := getter.currentDateGetter.GetCurrentDate()
+	foundProcess.Sealed = foundProcessItem.isClosed(currentTime)
+	foundProcess.Callback = foundProcessItem.callback
+	foundProcess.Deadline = foundProcessItem.deadline
+	foundProcessItem.fillMetadata(&foundProcess)
+	if foundProcessItem.summary != nil {
+		foundProcess.Progress = foundProcessItem.summary.progress(*foundProcessItem.registrationsCount, currentTime)
+	}
+	return &foundProcess, nil
+}
+
+func (getter *ProcessGetter) evaluateProcess(ctx context.Context, processID string,
+	foundProcessItem *processItem) (process.Process, error) {
+	summary := foundProcessItem.summary
+	if summary != nil {
+		currentTime := getter.currentDateGetter.GetCurrentDate()
+		if summarizedProcess, isConclusive := summary.evaluate(processID, foundProcessItem.deadline, currentTime); isConclusive {
+			return summarizedProcess, nil
+		}
+	}
+	foundProcess, earliestExpirationTime, err := getter.getProcess(ctx, processID, foundProcessItem.deadline)
+	if err == nil && summary != nil && !earliestExpirationTime.IsZero() {
+		summary.earliestExpirationTime = earliestExpirationTime
+	}
+	return foundProcess, err
+}
+
+func (getter *ProcessGetter) getProcessItem(ctx context.Context, processID string) (*processItem, error) {
+	out, err := getter.dynamoAPI.GetItemWithContext(ctx, BuildGetProcessItemInput(getter.tasksTableName, processID))
+	if err != nil || out == nil {
 		return nil, err
 	}
+	return readProcessItem(out.Item)
+}
 
-	foundProcess, err := getter.getProcess(processID)
-	return &foundProcess, err
+func (getter *ProcessGetter) exists(ctx context.Context, processID string) (bool, error) {
+	return checkIfProcessExists(ctx, getter.dynamoAPI, getter.tasksTableName, processID)
 }
 
-func (getter *ProcessGetter) exists(processID string) (bool, error) {
-	out, err := getter.dynamoAPI.Query(BuildCheckIfProcessExistsQueryInput(getter.tasksTableName, processID))
+func checkIfProcessExists(ctx context.Context, dynamoAPI dynamodbiface.DynamoDBAPI, tableName, processID string) (bool, error) {
+	out, err := dynamoAPI.QueryWithContext(ctx, BuildCheckIfProcessExistsQueryInput(tableName, processID))
 	if err != nil {
 		return false, err
 	}
@@ -68,15 +116,46 @@ func BuildCheckIfProcessExistsQueryInput(tableName, processID string) *dynamodb.
 	}
 }
 
-func (getter *ProcessGetter) getProcess(processID string) (process.Process, error) {
-	queryResult, err := getter.dynamoAPI.Query(BuildGetProcessQueryInput(getter.tasksTableName, processID))
+func (getter *ProcessGetter) getProcess(ctx context.Context, processID string,
+	deadline time.Time) (process.Process, time.Time, error) {
+	queryResult, err := getter.dynamoAPI.QueryWithContext(ctx, BuildGetProcessQueryInput(getter.tasksTableName, processID))
 	if err != nil {
-		return process.Process{}, err
+		return process.Process{}, time.Time{}, err
 	}
 	if queryResult == nil || len(queryResult.Items) == 0 {
-		return process.Process{ID: processID, State: process.StateCompleted}, nil
+		return process.Process{ID: processID, State: process.StateCompleted}, time.Time{}, nil
 	}
-	return getter.readNotCompletedProcess(processID, queryResult.Items[0])
+	firstBadTask := queryResult.Items[0]
+	isDeadlineExceeded, err := getter.isDeadlineExceededBefore(deadline, firstBadTask)
+	if err != nil {
+		return process.Process{}, time.Time{}, err
+	}
+	if isDeadlineExceeded {
+		return process.Process{
+			ID:           processID,
+			State:        process.StateError,
+			StateMessage: aws.String(process.DeadlineExceededErrorMessage),
+		}, time.Time{}, nil
+	}
+	notCompletedProcess, err := getter.readNotCompletedProcess(processID, firstBadTask)
+	if err != nil || notCompletedProcess.State != process.StateCreated {
+		return notCompletedProcess, time.Time{}, err
+	}
+	earliestExpirationTime, err := readTaskBadStateEnterTime(firstBadTask)
+	return notCompletedProcess, earliestExpirationTime, err
+}
+
+func (getter *ProcessGetter) isDeadlineExceededBefore(deadline time.Time,
+	firstBadTask map[string]*dynamodb.AttributeValue) (bool, error) {
+	if deadline.IsZero() {
+		return false, nil
+	}
+	currentTime := getter.currentDateGetter.GetCurrentDate()
+	badStateEnterTime, err := readTaskBadStateEnterTime(firstBadTask)
+	if err != nil {
+		return false, err
+	}
+	return process.IsDeadlineExceededBefore(deadline, badStateEnterTime, currentTime), nil
 }
 
 func (getter *ProcessGetter) readNotCompletedProcess(processID string, dynamoTask map[string]*dynamodb.AttributeValue) (
@@ -85,6 +164,13 @@ func (getter *ProcessGetter) readNotCompletedProcess(processID string, dynamoTas
 	if err != nil {
 		return process.Process{}, err
 	}
+	if taskState == task.StateTimedOut {
+		return process.Process{
+			ID:           processID,
+			State:        process.StateError,
+			StateMessage: aws.String(process.TimedOutErrorMessage),
+		}, nil
+	}
 	if taskState == task.StateAborted {
 		return process.Process{
 			ID:           processID,
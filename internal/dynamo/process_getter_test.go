@@ -1,7 +1,9 @@
 package dynamo_test
 
 import (
+	"context"
 	"errors"
+	"strconv"
 	"testing"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 type processGetterWithMocks struct {
@@ -34,15 +37,29 @@ func newProcessGetterWithMocks() *processGetterWithMocks {
 	}
 }
 
+func (getterAndMocks *processGetterWithMocks) mockProcessItem(procID string, registrationsCount int64) {
+	registrationsCountString := strconv.FormatInt(registrationsCount, 10)
+	getProcessItemInput := dynamo.BuildGetProcessItemInput(tasksTableName, procID)
+	getterAndMocks.dynamoAPI.On("GetItemWithContext", mock.Anything, getProcessItemInput).Return(&dynamodb.GetItemOutput{
+		Item: map[string]*dynamodb.AttributeValue{
+			dynamo.ProcessIDAttrName:                 {S: &procID},
+			dynamo.TaskIDAttrName:                    {S: aws.String(dynamo.ProcessItemTaskID)},
+			dynamo.ProcessRegistrationsCountAttrName: {N: &registrationsCountString},
+		},
+	}, nil)
+}
+
 func TestProcessGetter_Get_ProcessNotExists(t *testing.T) {
 	procGetterAndMocks := newProcessGetterWithMocks()
 	procID := "1"
+	procGetterAndMocks.dynamoAPI.On("GetItemWithContext", mock.Anything, dynamo.BuildGetProcessItemInput(tasksTableName, procID)).
+		Return(&dynamodb.GetItemOutput{}, nil)
 	checkIfProcExistsQueryInput := dynamo.BuildCheckIfProcessExistsQueryInput(tasksTableName, procID)
-	procGetterAndMocks.dynamoAPI.On("Query", checkIfProcExistsQueryInput).Return(&dynamodb.QueryOutput{
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, checkIfProcExistsQueryInput).Return(&dynamodb.QueryOutput{
 		Items: nil,
 	}, nil)
 
-	proc, err := procGetterAndMocks.processGetter.Get(procID)
+	proc, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
 	assert.NoError(t, err)
 	assert.Nil(t, proc)
 	procGetterAndMocks.assertExpectations(t)
@@ -51,11 +68,10 @@ func TestProcessGetter_Get_ProcessNotExists(t *testing.T) {
 func TestProcessGetter_Get_ErrorDuringProcSearching(t *testing.T) {
 	procGetterAndMocks := newProcessGetterWithMocks()
 	procID := "1"
-	checkIfProcExistsQueryInput := dynamo.BuildCheckIfProcessExistsQueryInput(tasksTableName, procID)
-	procGetterAndMocks.dynamoAPI.On("Query", checkIfProcExistsQueryInput).
-		Return((*dynamodb.QueryOutput)(nil), errors.New("error"))
+	procGetterAndMocks.dynamoAPI.On("GetItemWithContext", mock.Anything, dynamo.BuildGetProcessItemInput(tasksTableName, procID)).
+		Return(nil, errors.New("error"))
 
-	_, err := procGetterAndMocks.processGetter.Get(procID)
+	_, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
 	assert.Error(t, err)
 	procGetterAndMocks.assertExpectations(t)
 }
@@ -63,18 +79,16 @@ func TestProcessGetter_Get_ErrorDuringProcSearching(t *testing.T) {
 func TestProcessGetter_Get_CompletedProcess(t *testing.T) {
 	procGetterAndMocks := newProcessGetterWithMocks()
 	procID := "1"
-	checkIfProcExistsQueryInput := dynamo.BuildCheckIfProcessExistsQueryInput(tasksTableName, procID)
-	procGetterAndMocks.dynamoAPI.On("Query", checkIfProcExistsQueryInput).Return(&dynamodb.QueryOutput{
-		Items: []map[string]*dynamodb.AttributeValue{
-			{dynamo.ProcessIDAttrName: {S: &procID}},
-		},
-	}, nil)
+	registrationsCount := int64(2)
+	procGetterAndMocks.mockProcessItem(procID, registrationsCount)
 	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
-	procGetterAndMocks.dynamoAPI.On("Query", getProcessQueryInput).Return(&dynamodb.QueryOutput{
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).Return(&dynamodb.QueryOutput{
 		Items: nil,
 	}, nil)
+	currentTime := time.Now().UTC()
+	procGetterAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentTime)
 
-	proc, err := procGetterAndMocks.processGetter.Get(procID)
+	proc, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
 	assert.NoError(t, err)
 	assert.NotNil(t, proc)
 	assert.Equal(t, process.Process{
@@ -88,17 +102,13 @@ func TestProcessGetter_Get_CompletedProcess(t *testing.T) {
 func TestProcessGetter_Get_ErrorWhileGettingProcess(t *testing.T) {
 	procGetterAndMocks := newProcessGetterWithMocks()
 	procID := "1"
-	checkIfProcExistsQueryInput := dynamo.BuildCheckIfProcessExistsQueryInput(tasksTableName, procID)
-	procGetterAndMocks.dynamoAPI.On("Query", checkIfProcExistsQueryInput).Return(&dynamodb.QueryOutput{
-		Items: []map[string]*dynamodb.AttributeValue{
-			{dynamo.ProcessIDAttrName: {S: &procID}},
-		},
-	}, nil)
+	registrationsCount := int64(2)
+	procGetterAndMocks.mockProcessItem(procID, registrationsCount)
 	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
-	procGetterAndMocks.dynamoAPI.On("Query", getProcessQueryInput).
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).
 		Return((*dynamodb.QueryOutput)(nil), errors.New("error"))
 
-	_, err := procGetterAndMocks.processGetter.Get(procID)
+	_, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
 	assert.Error(t, err)
 	procGetterAndMocks.assertExpectations(t)
 }
@@ -106,16 +116,12 @@ func TestProcessGetter_Get_ErrorWhileGettingProcess(t *testing.T) {
 func TestProcessGetter_Get_AbortedProcess(t *testing.T) {
 	procGetterAndMocks := newProcessGetterWithMocks()
 	procID := "1"
-	checkIfProcExistsQueryInput := dynamo.BuildCheckIfProcessExistsQueryInput(tasksTableName, procID)
-	procGetterAndMocks.dynamoAPI.On("Query", checkIfProcExistsQueryInput).Return(&dynamodb.QueryOutput{
-		Items: []map[string]*dynamodb.AttributeValue{
-			{dynamo.ProcessIDAttrName: {S: &procID}},
-		},
-	}, nil)
+	registrationsCount := int64(2)
+	procGetterAndMocks.mockProcessItem(procID, registrationsCount)
 
 	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
 	processFailureReason := "failure"
-	procGetterAndMocks.dynamoAPI.On("Query", getProcessQueryInput).Return(&dynamodb.QueryOutput{
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).Return(&dynamodb.QueryOutput{
 		Items: []map[string]*dynamodb.AttributeValue{
 			{
 				dynamo.ProcessIDAttrName:        {S: &procID},
@@ -124,8 +130,10 @@ func TestProcessGetter_Get_AbortedProcess(t *testing.T) {
 			},
 		},
 	}, nil)
+	currentTime := time.Now().UTC()
+	procGetterAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentTime)
 
-	proc, err := procGetterAndMocks.processGetter.Get(procID)
+	proc, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
 	assert.NoError(t, err)
 	assert.NotNil(t, proc)
 	assert.Equal(t, process.Process{
@@ -136,19 +144,45 @@ func TestProcessGetter_Get_AbortedProcess(t *testing.T) {
 	procGetterAndMocks.assertExpectations(t)
 }
 
-func TestProcessGetter_Get_ProcessInInvalidState(t *testing.T) {
+func TestProcessGetter_Get_ReapedProcess(t *testing.T) {
 	procGetterAndMocks := newProcessGetterWithMocks()
 	procID := "1"
-	checkIfProcExistsQueryInput := dynamo.BuildCheckIfProcessExistsQueryInput(tasksTableName, procID)
-	procGetterAndMocks.dynamoAPI.On("Query", checkIfProcExistsQueryInput).Return(&dynamodb.QueryOutput{
+	registrationsCount := int64(1)
+	procGetterAndMocks.mockProcessItem(procID, registrationsCount)
+
+	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).Return(&dynamodb.QueryOutput{
 		Items: []map[string]*dynamodb.AttributeValue{
-			{dynamo.ProcessIDAttrName: {S: &procID}},
+			{
+				dynamo.ProcessIDAttrName:        {S: &procID},
+				dynamo.TaskStateAttrName:        {S: aws.String(string(task.StateTimedOut))},
+				dynamo.TaskStateMessageAttrName: {S: aws.String(process.TimedOutErrorMessage)},
+			},
 		},
 	}, nil)
+	currentTime := time.Now().UTC()
+	procGetterAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentTime)
+
+	proc, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
+	assert.NoError(t, err)
+	assert.NotNil(t, proc)
+	assert.Equal(t, process.Process{
+		ID:           procID,
+		State:        process.StateError,
+		StateMessage: aws.String(process.TimedOutErrorMessage),
+	}, *proc)
+	procGetterAndMocks.assertExpectations(t)
+}
+
+func TestProcessGetter_Get_ProcessInInvalidState(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	procID := "1"
+	registrationsCount := int64(2)
+	procGetterAndMocks.mockProcessItem(procID, registrationsCount)
 
 	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
 	badStateEnterTime := time.Now().UTC().Format(time.RFC3339)
-	procGetterAndMocks.dynamoAPI.On("Query", getProcessQueryInput).Return(&dynamodb.QueryOutput{
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).Return(&dynamodb.QueryOutput{
 		Items: []map[string]*dynamodb.AttributeValue{
 			{
 				dynamo.ProcessIDAttrName:             {S: &procID},
@@ -158,7 +192,7 @@ func TestProcessGetter_Get_ProcessInInvalidState(t *testing.T) {
 		},
 	}, nil)
 
-	_, err := procGetterAndMocks.processGetter.Get(procID)
+	_, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
 	assert.Error(t, err)
 	procGetterAndMocks.assertExpectations(t)
 }
@@ -166,15 +200,11 @@ func TestProcessGetter_Get_ProcessInInvalidState(t *testing.T) {
 func TestProcessGetter_Get_UndefinedBadStateEnterTime(t *testing.T) {
 	procGetterAndMocks := newProcessGetterWithMocks()
 	procID := "1"
-	checkIfProcExistsQueryInput := dynamo.BuildCheckIfProcessExistsQueryInput(tasksTableName, procID)
-	procGetterAndMocks.dynamoAPI.On("Query", checkIfProcExistsQueryInput).Return(&dynamodb.QueryOutput{
-		Items: []map[string]*dynamodb.AttributeValue{
-			{dynamo.ProcessIDAttrName: {S: &procID}},
-		},
-	}, nil)
+	registrationsCount := int64(2)
+	procGetterAndMocks.mockProcessItem(procID, registrationsCount)
 
 	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
-	procGetterAndMocks.dynamoAPI.On("Query", getProcessQueryInput).Return(&dynamodb.QueryOutput{
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).Return(&dynamodb.QueryOutput{
 		Items: []map[string]*dynamodb.AttributeValue{
 			{
 				dynamo.ProcessIDAttrName: {S: &procID},
@@ -183,7 +213,7 @@ func TestProcessGetter_Get_UndefinedBadStateEnterTime(t *testing.T) {
 		},
 	}, nil)
 
-	_, err := procGetterAndMocks.processGetter.Get(procID)
+	_, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
 	assert.Error(t, err)
 	procGetterAndMocks.assertExpectations(t)
 }
@@ -191,18 +221,14 @@ func TestProcessGetter_Get_UndefinedBadStateEnterTime(t *testing.T) {
 func TestProcessGetter_Get_ProcessTimedOut(t *testing.T) {
 	procGetterAndMocks := newProcessGetterWithMocks()
 	procID := "1"
-	checkIfProcExistsQueryInput := dynamo.BuildCheckIfProcessExistsQueryInput(tasksTableName, procID)
-	procGetterAndMocks.dynamoAPI.On("Query", checkIfProcExistsQueryInput).Return(&dynamodb.QueryOutput{
-		Items: []map[string]*dynamodb.AttributeValue{
-			{dynamo.ProcessIDAttrName: {S: &procID}},
-		},
-	}, nil)
+	registrationsCount := int64(2)
+	procGetterAndMocks.mockProcessItem(procID, registrationsCount)
 
 	currentTime := time.Now().UTC()
 	procGetterAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentTime)
 	taskBadStateEnterTimeString := currentTime.Add(-time.Hour).Format(time.RFC3339)
 	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
-	procGetterAndMocks.dynamoAPI.On("Query", getProcessQueryInput).Return(&dynamodb.QueryOutput{
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).Return(&dynamodb.QueryOutput{
 		Items: []map[string]*dynamodb.AttributeValue{
 			{
 				dynamo.ProcessIDAttrName:             {S: &procID},
@@ -212,7 +238,7 @@ func TestProcessGetter_Get_ProcessTimedOut(t *testing.T) {
 		},
 	}, nil)
 
-	proc, err := procGetterAndMocks.processGetter.Get(procID)
+	proc, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
 	assert.NoError(t, err)
 	assert.NotNil(t, proc)
 	assert.Equal(t, &process.Process{
@@ -226,18 +252,14 @@ func TestProcessGetter_Get_ProcessTimedOut(t *testing.T) {
 func TestProcessGetter_Get_ProcessIsWaiting(t *testing.T) {
 	procGetterAndMocks := newProcessGetterWithMocks()
 	procID := "1"
-	checkIfProcExistsQueryInput := dynamo.BuildCheckIfProcessExistsQueryInput(tasksTableName, procID)
-	procGetterAndMocks.dynamoAPI.On("Query", checkIfProcExistsQueryInput).Return(&dynamodb.QueryOutput{
-		Items: []map[string]*dynamodb.AttributeValue{
-			{dynamo.ProcessIDAttrName: {S: &procID}},
-		},
-	}, nil)
+	registrationsCount := int64(2)
+	procGetterAndMocks.mockProcessItem(procID, registrationsCount)
 
 	currentTime := time.Now().UTC()
 	procGetterAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentTime)
 	taskBadStateEnterTimeString := currentTime.Add(time.Hour).Format(time.RFC3339)
 	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
-	procGetterAndMocks.dynamoAPI.On("Query", getProcessQueryInput).Return(&dynamodb.QueryOutput{
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).Return(&dynamodb.QueryOutput{
 		Items: []map[string]*dynamodb.AttributeValue{
 			{
 				dynamo.ProcessIDAttrName:             {S: &procID},
@@ -247,7 +269,7 @@ func TestProcessGetter_Get_ProcessIsWaiting(t *testing.T) {
 		},
 	}, nil)
 
-	proc, err := procGetterAndMocks.processGetter.Get(procID)
+	proc, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
 	assert.NoError(t, err)
 	assert.NotNil(t, proc)
 	assert.Equal(t, &process.Process{
@@ -260,23 +282,454 @@ func TestProcessGetter_Get_ProcessIsWaiting(t *testing.T) {
 func TestProcessGetter_Get_UndefinedProcessState(t *testing.T) {
 	procGetterAndMocks := newProcessGetterWithMocks()
 	procID := "1"
+	registrationsCount := int64(2)
+	procGetterAndMocks.mockProcessItem(procID, registrationsCount)
+
+	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			{
+				dynamo.ProcessIDAttrName: {S: &procID},
+			},
+		},
+	}, nil)
+
+	_, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
+	assert.Error(t, err)
+	procGetterAndMocks.assertExpectations(t)
+}
+
+func TestProcessGetter_Get_AlreadySealedProcess(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	procID := "1"
+	sealingTime := time.Now().UTC().Format(time.RFC3339)
+	procGetterAndMocks.dynamoAPI.On("GetItemWithContext", mock.Anything, dynamo.BuildGetProcessItemInput(tasksTableName, procID)).
+		Return(&dynamodb.GetItemOutput{
+			Item: map[string]*dynamodb.AttributeValue{
+				dynamo.ProcessIDAttrName:         {S: &procID},
+				dynamo.TaskIDAttrName:            {S: aws.String(dynamo.ProcessItemTaskID)},
+				dynamo.ProcessSealedTimeAttrName: {S: &sealingTime},
+			},
+		}, nil)
+	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).Return(&dynamodb.QueryOutput{
+		Items: nil,
+	}, nil)
+	procGetterAndMocks.currentDateGetter.On("GetCurrentDate").Return(time.Now().UTC())
+
+	proc, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:     procID,
+		State:  process.StateCompleted,
+		Sealed: true,
+	}, proc)
+	procGetterAndMocks.assertExpectations(t)
+}
+
+func TestProcessGetter_Get_ProcessWithCallback(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	procID := "1"
+	deliveryTime := time.Now().UTC().Truncate(time.Second)
+	procGetterAndMocks.dynamoAPI.On("GetItemWithContext", mock.Anything, dynamo.BuildGetProcessItemInput(tasksTableName, procID)).
+		Return(&dynamodb.GetItemOutput{
+			Item: map[string]*dynamodb.AttributeValue{
+				dynamo.ProcessIDAttrName:                   {S: &procID},
+				dynamo.TaskIDAttrName:                      {S: aws.String(dynamo.ProcessItemTaskID)},
+				dynamo.ProcessSealedTimeAttrName:           {S: aws.String(deliveryTime.Format(time.RFC3339))},
+				dynamo.ProcessCallbackURLAttrName:          {S: aws.String("https://example.com/callback")},
+				dynamo.ProcessCallbackStateAttrName:        {S: aws.String(string(process.CallbackStateDelivered))},
+				dynamo.ProcessCallbackAttemptsAttrName:     {N: aws.String("2")},
+				dynamo.ProcessCallbackLastErrorAttrName:    {S: aws.String("unexpected status code: 503")},
+				dynamo.ProcessCallbackDeliveryTimeAttrName: {S: aws.String(deliveryTime.Format(time.RFC3339))},
+			},
+		}, nil)
+	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).Return(&dynamodb.QueryOutput{
+		Items: nil,
+	}, nil)
+	procGetterAndMocks.currentDateGetter.On("GetCurrentDate").Return(time.Now().UTC())
+
+	proc, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:     procID,
+		State:  process.StateCompleted,
+		Sealed: true,
+		Callback: &process.Callback{
+			URL:          "https://example.com/callback",
+			State:        process.CallbackStateDelivered,
+			Attempts:     2,
+			LastError:    aws.String("unexpected status code: 503"),
+			DeliveryTime: deliveryTime,
+		},
+	}, proc)
+	procGetterAndMocks.assertExpectations(t)
+}
+
+func TestProcessGetter_Get_ProcessWithMetadata(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	procID := "1"
+	creationTime := time.Now().UTC().Truncate(time.Second)
+	procGetterAndMocks.dynamoAPI.On("GetItemWithContext", mock.Anything, dynamo.BuildGetProcessItemInput(tasksTableName, procID)).
+		Return(&dynamodb.GetItemOutput{
+			Item: map[string]*dynamodb.AttributeValue{
+				dynamo.ProcessIDAttrName:           {S: &procID},
+				dynamo.TaskIDAttrName:              {S: aws.String(dynamo.ProcessItemTaskID)},
+				dynamo.ProcessSealedTimeAttrName:   {S: aws.String(creationTime.Format(time.RFC3339))},
+				dynamo.ProcessCreationTimeAttrName: {S: aws.String(creationTime.Format(time.RFC3339))},
+				dynamo.ProcessCreatorAttrName:      {S: aws.String("arn:aws:iam::123456789012:user/creator")},
+				dynamo.ProcessDescriptionAttrName:  {S: aws.String("nightly settlement")},
+				dynamo.ProcessLabelsAttrName: {M: map[string]*dynamodb.AttributeValue{
+					"team": {S: aws.String("payments")},
+				}},
+			},
+		}, nil)
+	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).Return(&dynamodb.QueryOutput{
+		Items: nil,
+	}, nil)
+	procGetterAndMocks.currentDateGetter.On("GetCurrentDate").Return(time.Now().UTC())
+
+	proc, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           procID,
+		State:        process.StateCompleted,
+		Sealed:       true,
+		Labels:       map[string]string{"team": "payments"},
+		Description:  aws.String("nightly settlement"),
+		CreationTime: creationTime,
+		Creator:      aws.String("arn:aws:iam::123456789012:user/creator"),
+	}, proc)
+	procGetterAndMocks.assertExpectations(t)
+}
+
+func TestProcessGetter_Get_ProcessDeadlineExceeded(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	procID := "1"
+	currentTime := time.Now().UTC()
+	deadline := currentTime.Add(-time.Minute).Truncate(time.Second)
+	procGetterAndMocks.dynamoAPI.On("GetItemWithContext", mock.Anything, dynamo.BuildGetProcessItemInput(tasksTableName, procID)).
+		Return(&dynamodb.GetItemOutput{
+			Item: map[string]*dynamodb.AttributeValue{
+				dynamo.ProcessIDAttrName:         {S: &procID},
+				dynamo.TaskIDAttrName:            {S: aws.String(dynamo.ProcessItemTaskID)},
+				dynamo.ProcessSealedTimeAttrName: {S: aws.String(currentTime.Format(time.RFC3339))},
+				dynamo.ProcessDeadlineAttrName:   {S: aws.String(deadline.Format(time.RFC3339))},
+			},
+		}, nil)
+	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			{
+				dynamo.ProcessIDAttrName:             {S: &procID},
+				dynamo.TaskStateAttrName:             {S: aws.String(string(task.StateCreated))},
+				dynamo.TaskBadStateEnterTimeAttrName: {S: aws.String(currentTime.Add(time.Hour).Format(time.RFC3339))},
+			},
+		},
+	}, nil)
+	procGetterAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentTime)
+
+	proc, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           procID,
+		State:        process.StateError,
+		StateMessage: aws.String(process.DeadlineExceededErrorMessage),
+		Sealed:       true,
+		Deadline:     deadline,
+	}, proc)
+	procGetterAndMocks.assertExpectations(t)
+}
+
+func TestProcessGetter_Get_LegacyProcessWithoutProcessItem(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	procID := "1"
+	procGetterAndMocks.dynamoAPI.On("GetItemWithContext", mock.Anything, dynamo.BuildGetProcessItemInput(tasksTableName, procID)).
+		Return(&dynamodb.GetItemOutput{}, nil)
 	checkIfProcExistsQueryInput := dynamo.BuildCheckIfProcessExistsQueryInput(tasksTableName, procID)
-	procGetterAndMocks.dynamoAPI.On("Query", checkIfProcExistsQueryInput).Return(&dynamodb.QueryOutput{
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, checkIfProcExistsQueryInput).Return(&dynamodb.QueryOutput{
 		Items: []map[string]*dynamodb.AttributeValue{
 			{dynamo.ProcessIDAttrName: {S: &procID}},
 		},
 	}, nil)
+	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).Return(&dynamodb.QueryOutput{
+		Items: nil,
+	}, nil)
+	currentTime := time.Now().UTC()
+	procGetterAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentTime)
+
+	proc, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:    procID,
+		State: process.StateCompleted,
+	}, proc)
+	procGetterAndMocks.assertExpectations(t)
+	procGetterAndMocks.dynamoAPI.AssertNotCalled(t, "UpdateItemWithContext", mock.Anything, mock.Anything)
+}
+
+type processSummaryItem struct {
+	registrationsCount        string
+	summaryRegistrationsCount string
+	openTasksCount            string
+	finishedTasksCount        string
+	abortedTasksCount         string
+	timedOutTasksCount        string
+	earliestExpirationTime    string
+}
+
+func (getterAndMocks *processGetterWithMocks) mockSummarizedProcessItem(procID string, summary processSummaryItem) {
+	item := map[string]*dynamodb.AttributeValue{
+		dynamo.ProcessIDAttrName:                        {S: &procID},
+		dynamo.TaskIDAttrName:                           {S: aws.String(dynamo.ProcessItemTaskID)},
+		dynamo.ProcessRegistrationsCountAttrName:        {N: &summary.registrationsCount},
+		dynamo.ProcessSummaryRegistrationsCountAttrName: {N: &summary.summaryRegistrationsCount},
+		dynamo.ProcessOpenTasksCountAttrName:            {N: &summary.openTasksCount},
+		dynamo.ProcessAbortedTasksCountAttrName:         {N: &summary.abortedTasksCount},
+		dynamo.ProcessEarliestExpirationTimeAttrName:    {S: &summary.earliestExpirationTime},
+	}
+	if summary.finishedTasksCount != "" {
+		item[dynamo.ProcessFinishedTasksCountAttrName] = &dynamodb.AttributeValue{N: &summary.finishedTasksCount}
+	}
+	if summary.timedOutTasksCount != "" {
+		item[dynamo.ProcessTimedOutTasksCountAttrName] = &dynamodb.AttributeValue{N: &summary.timedOutTasksCount}
+	}
+	getProcessItemInput := dynamo.BuildGetProcessItemInput(tasksTableName, procID)
+	getterAndMocks.dynamoAPI.On("GetItemWithContext", mock.Anything, getProcessItemInput).Return(&dynamodb.GetItemOutput{
+		Item: item,
+	}, nil)
+}
+
+func TestProcessGetter_Get_WaitingProcessFromSummary(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	procID := "1"
+	currentTime := time.Now().UTC()
+	procGetterAndMocks.mockSummarizedProcessItem(procID, processSummaryItem{
+		registrationsCount:        "2",
+		summaryRegistrationsCount: "2",
+		openTasksCount:            "1",
+		finishedTasksCount:        "1",
+		abortedTasksCount:         "0",
+		earliestExpirationTime:    currentTime.Add(time.Hour).Format(time.RFC3339),
+	})
+	procGetterAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentTime)
+
+	proc, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:    procID,
+		State: process.StateCreated,
+		Progress: &process.Progress{
+			TotalTasksCount:               2,
+			CreatedTasksCount:             1,
+			FinishedTasksCount:            1,
+			EarliestPendingExpirationTime: currentTime.Add(time.Hour).Truncate(time.Second),
+		},
+	}, proc)
+	procGetterAndMocks.assertExpectations(t)
+	procGetterAndMocks.dynamoAPI.AssertNotCalled(t, "QueryWithContext", mock.Anything, mock.Anything)
+}
+
+func TestProcessGetter_Get_CompletedProcessFromSummary(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	procID := "1"
+	currentTime := time.Now().UTC()
+	procGetterAndMocks.mockSummarizedProcessItem(procID, processSummaryItem{
+		registrationsCount:        "2",
+		summaryRegistrationsCount: "2",
+		openTasksCount:            "0",
+		finishedTasksCount:        "2",
+		abortedTasksCount:         "0",
+		earliestExpirationTime:    "0",
+	})
+	procGetterAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentTime)
+
+	proc, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:       procID,
+		State:    process.StateCompleted,
+		Sealed:   true,
+		Progress: &process.Progress{TotalTasksCount: 2, FinishedTasksCount: 2},
+	}, proc)
+	procGetterAndMocks.assertExpectations(t)
+	procGetterAndMocks.dynamoAPI.AssertNotCalled(t, "QueryWithContext", mock.Anything, mock.Anything)
+}
 
+func TestProcessGetter_Get_EvaluatesInvalidatedSummaryFromTasks(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	procID := "1"
+	currentTime := time.Now().UTC()
+	procGetterAndMocks.mockSummarizedProcessItem(procID, processSummaryItem{
+		registrationsCount:        "2",
+		summaryRegistrationsCount: "2",
+		openTasksCount:            "2",
+		abortedTasksCount:         "0",
+		earliestExpirationTime:    "0",
+	})
+	procGetterAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentTime)
+	earliestExpirationTime := currentTime.Add(time.Hour).Truncate(time.Second)
 	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
-	procGetterAndMocks.dynamoAPI.On("Query", getProcessQueryInput).Return(&dynamodb.QueryOutput{
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).Return(&dynamodb.QueryOutput{
 		Items: []map[string]*dynamodb.AttributeValue{
 			{
-				dynamo.ProcessIDAttrName: {S: &procID},
+				dynamo.ProcessIDAttrName:             {S: &procID},
+				dynamo.TaskStateAttrName:             {S: aws.String(string(task.StateCreated))},
+				dynamo.TaskBadStateEnterTimeAttrName: {S: aws.String(earliestExpirationTime.Format(time.RFC3339))},
 			},
 		},
 	}, nil)
 
-	_, err := procGetterAndMocks.processGetter.Get(procID)
-	assert.Error(t, err)
+	proc, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:    procID,
+		State: process.StateCreated,
+		Progress: &process.Progress{
+			TotalTasksCount:               2,
+			CreatedTasksCount:             2,
+			EarliestPendingExpirationTime: earliestExpirationTime,
+		},
+	}, proc)
+	procGetterAndMocks.assertExpectations(t)
+}
+
+func TestProcessGetter_Get_AbortedTaskInSummary(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	procID := "1"
+	currentTime := time.Now().UTC()
+	procGetterAndMocks.mockSummarizedProcessItem(procID, processSummaryItem{
+		registrationsCount:        "2",
+		summaryRegistrationsCount: "2",
+		openTasksCount:            "1",
+		abortedTasksCount:         "1",
+		earliestExpirationTime:    currentTime.Add(time.Hour).Format(time.RFC3339),
+	})
+	procGetterAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentTime)
+	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			{
+				dynamo.ProcessIDAttrName:             {S: &procID},
+				dynamo.TaskStateAttrName:             {S: aws.String(string(task.StateAborted))},
+				dynamo.TaskStateMessageAttrName:      {S: aws.String("failure")},
+				dynamo.TaskBadStateEnterTimeAttrName: {S: aws.String(currentTime.Format(time.RFC3339))},
+			},
+		},
+	}, nil)
+
+	proc, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           procID,
+		State:        process.StateError,
+		StateMessage: aws.String("failure"),
+		Sealed:       true,
+		Progress: &process.Progress{
+			TotalTasksCount:               2,
+			CreatedTasksCount:             1,
+			AbortedTasksCount:             1,
+			EarliestPendingExpirationTime: currentTime.Add(time.Hour).Truncate(time.Second),
+		},
+	}, proc)
+	procGetterAndMocks.assertExpectations(t)
+}
+
+func TestProcessGetter_Get_TimedOutTaskInSummary(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	procID := "1"
+	currentTime := time.Now().UTC()
+	procGetterAndMocks.mockSummarizedProcessItem(procID, processSummaryItem{
+		registrationsCount:        "1",
+		summaryRegistrationsCount: "1",
+		openTasksCount:            "0",
+		abortedTasksCount:         "0",
+		timedOutTasksCount:        "1",
+		earliestExpirationTime:    currentTime.Add(-time.Hour).Format(time.RFC3339),
+	})
+	procGetterAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentTime)
+	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			{
+				dynamo.ProcessIDAttrName:             {S: &procID},
+				dynamo.TaskStateAttrName:             {S: aws.String(string(task.StateTimedOut))},
+				dynamo.TaskBadStateEnterTimeAttrName: {S: aws.String(currentTime.Add(-time.Hour).Format(time.RFC3339))},
+			},
+		},
+	}, nil)
+
+	proc, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           procID,
+		State:        process.StateError,
+		StateMessage: aws.String(process.TimedOutErrorMessage),
+		Sealed:       true,
+		Progress:     &process.Progress{TotalTasksCount: 1, TimedOutTasksCount: 1},
+	}, proc)
+	procGetterAndMocks.assertExpectations(t)
+}
+
+func TestProcessGetter_Get_SummaryOfLegacyProcessIsIgnored(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	procID := "1"
+	currentTime := time.Now().UTC()
+	procGetterAndMocks.mockSummarizedProcessItem(procID, processSummaryItem{
+		registrationsCount:        "3",
+		summaryRegistrationsCount: "1",
+		openTasksCount:            "0",
+		abortedTasksCount:         "0",
+		earliestExpirationTime:    "0",
+	})
+	procGetterAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentTime)
+	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			{
+				dynamo.ProcessIDAttrName:             {S: &procID},
+				dynamo.TaskStateAttrName:             {S: aws.String(string(task.StateCreated))},
+				dynamo.TaskBadStateEnterTimeAttrName: {S: aws.String(currentTime.Add(time.Hour).Format(time.RFC3339))},
+			},
+		},
+	}, nil)
+
+	proc, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{ID: procID, State: process.StateCreated}, proc)
+	procGetterAndMocks.assertExpectations(t)
+	procGetterAndMocks.dynamoAPI.AssertNotCalled(t, "UpdateItemWithContext", mock.Anything, mock.Anything)
+}
+
+func TestProcessGetter_Get_ProcessRegisteredOverPreUpgradeTasksIsNotCompleted(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	procID := "1"
+	currentTime := time.Now().UTC()
+	procGetterAndMocks.dynamoAPI.On("GetItemWithContext", mock.Anything, dynamo.BuildGetProcessItemInput(tasksTableName, procID)).
+		Return(&dynamodb.GetItemOutput{Item: map[string]*dynamodb.AttributeValue{
+			dynamo.ProcessIDAttrName:                 {S: &procID},
+			dynamo.TaskIDAttrName:                    {S: aws.String(dynamo.ProcessItemTaskID)},
+			dynamo.ProcessRegistrationsCountAttrName: {N: aws.String("1")},
+			dynamo.ProcessOpenTasksCountAttrName:     {N: aws.String("0")},
+			dynamo.ProcessFinishedTasksCountAttrName: {N: aws.String("1")},
+		}}, nil)
+	procGetterAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentTime)
+	getProcessQueryInput := dynamo.BuildGetProcessQueryInput(tasksTableName, procID)
+	procGetterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, getProcessQueryInput).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			{
+				dynamo.ProcessIDAttrName:             {S: &procID},
+				dynamo.TaskStateAttrName:             {S: aws.String(string(task.StateCreated))},
+				dynamo.TaskBadStateEnterTimeAttrName: {S: aws.String(currentTime.Add(time.Hour).Format(time.RFC3339))},
+			},
+		},
+	}, nil)
+
+	proc, err := procGetterAndMocks.processGetter.Get(context.Background(), procID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{ID: procID, State: process.StateCreated}, proc)
 	procGetterAndMocks.assertExpectations(t)
 }
@@ -0,0 +1,363 @@
+package dynamo
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+const (
+	ProcessItemTaskID = task.ReservedID
+	ProcessItemType   = "PROCESS"
+
+	ProcessListShardsCount    = 8
+	processListShardSeparator = "#"
+
+	ProcessSealedTimeAttrName           = "sealed_time"
+	ProcessRegistrationsCountAttrName   = "registrations_count"
+	ProcessCallbackURLAttrName          = "callback_url"
+	ProcessCallbackStateAttrName        = "callback_state"
+	ProcessCallbackAttemptsAttrName     = "callback_attempts"
+	ProcessCallbackLastErrorAttrName    = "callback_last_error"
+	ProcessCallbackDeliveryTimeAttrName = "callback_delivery_time"
+	ProcessDeadlineAttrName             = "deadline"
+	ProcessLabelsAttrName               = "labels"
+	ProcessDescriptionAttrName          = "description"
+	ProcessCreationTimeAttrName         = "creation_time"
+	ProcessCreatorAttrName              = "creator"
+	ProcessItemTypeAttrName             = "item_type"
+
+	processSealedTimeAttrAlias           = "#sealedTime"
+	processRegistrationsCountAttrAlias   = "#registrationsCount"
+	processCallbackURLAttrAlias          = "#callbackURL"
+	processCallbackStateAttrAlias        = "#callbackState"
+	processCallbackAttemptsAttrAlias     = "#callbackAttempts"
+	processCallbackLastErrorAttrAlias    = "#callbackLastError"
+	processCallbackDeliveryTimeAttrAlias = "#callbackDeliveryTime"
+	processDeadlineAttrAlias             = "#deadline"
+	processLabelsAttrAlias               = "#labels"
+	processDescriptionAttrAlias          = "#description"
+	processCreationTimeAttrAlias         = "#processCreationTime"
+	processCreatorAttrAlias              = "#creator"
+	processItemTypeAttrAlias             = "#itemType"
+
+	processSealedTimeValuePlaceholder           = ":sealedTime"
+	registrationsCountIncrementPlaceholder      = ":registrationsCountIncrement"
+	processCallbackURLValuePlaceholder          = ":callbackURL"
+	processCallbackStateValuePlaceholder        = ":callbackState"
+	processCallbackAttemptsValuePlaceholder     = ":callbackAttempts"
+	processCallbackLastErrorValuePlaceholder    = ":callbackLastError"
+	processCallbackDeliveryTimeValuePlaceholder = ":callbackDeliveryTime"
+	processDeadlineValuePlaceholder             = ":deadline"
+	processLabelsValuePlaceholder               = ":labels"
+	processDescriptionValuePlaceholder          = ":description"
+	processCreatorValuePlaceholder              = ":creator"
+	processItemTypeValuePlaceholder             = ":itemType"
+)
+
+var (
+	processDeadlineNotExceededConditionExpr = fmt.Sprintf("(attribute_not_exists(%s) or %s > %s)",
+		processDeadlineAttrAlias, processDeadlineAttrAlias, currentTimeValuePlaceholder)
+	registerInProcessConditionExpr = fmt.Sprintf("attribute_not_exists(%s) and %s and %s", processSealedTimeAttrAlias,
+		processDeadlineNotExceededConditionExpr, processNotTerminatedBySummaryConditionExpr)
+	registerInProcessTTLUpdateExpr = fmt.Sprintf("%s = %s, %s = %s", taskTTLAttrAlias, taskTTLValuePlaceholder,
+		processItemTypeAttrAlias, processItemTypeValuePlaceholder)
+	registerInProcessCallbackUpdateExpr = fmt.Sprintf("%s = if_not_exists(%s, %s), %s = if_not_exists(%s, %s)",
+		processCallbackURLAttrAlias, processCallbackURLAttrAlias, processCallbackURLValuePlaceholder,
+		processCallbackStateAttrAlias, processCallbackStateAttrAlias, processCallbackStateValuePlaceholder)
+	registerInProcessDeadlineUpdateExpr = fmt.Sprintf("%s = if_not_exists(%s, %s)", processDeadlineAttrAlias,
+		processDeadlineAttrAlias, processDeadlineValuePlaceholder)
+	registerInProcessCreationTimeUpdateExpr = fmt.Sprintf("%s = if_not_exists(%s, %s)", processCreationTimeAttrAlias,
+		processCreationTimeAttrAlias, currentTimeValuePlaceholder)
+	registerInProcessCreatorUpdateExpr = fmt.Sprintf("%s = if_not_exists(%s, %s)", processCreatorAttrAlias,
+		processCreatorAttrAlias, processCreatorValuePlaceholder)
+	registerInProcessIncrementExpr = fmt.Sprintf("%s %s", processRegistrationsCountAttrAlias,
+		registrationsCountIncrementPlaceholder)
+	registerInProcessSummaryIncrementExpr = fmt.Sprintf("%s %s", processSummaryRegistrationsCountAttrAlias,
+		registrationsCountIncrementPlaceholder)
+	registrationsCountExistsConditionExpr = fmt.Sprintf("attribute_exists(%s)",
+		processRegistrationsCountAttrAlias)
+	registrationsCountNotExistsConditionExpr = fmt.Sprintf("attribute_not_exists(%s)",
+		processRegistrationsCountAttrAlias)
+	sealExistingProcessConditionExpr = fmt.Sprintf("attribute_exists(%s)", ProcessIDAttrAlias)
+	sealProcessUpdateExpr            = fmt.Sprintf("SET %s = if_not_exists(%s, %s)", processSealedTimeAttrAlias,
+		processSealedTimeAttrAlias, processSealedTimeValuePlaceholder)
+)
+
+type processItem struct {
+	registrationsCount *int64
+	isSealed           bool
+	callback           *process.Callback
+	deadline           time.Time
+	labels             map[string]string
+	description        *string
+	creationTime       time.Time
+	creator            *string
+	summary            *processSummary
+}
+
+func (item *processItem) isDeadlineExceeded(currentTime time.Time) bool {
+	return item != nil && process.IsDeadlineExceeded(item.deadline, currentTime)
+}
+
+func (item *processItem) isClosed(currentTime time.Time) bool {
+	return item != nil && (item.isSealed || item.isDeadlineExceeded(currentTime) || item.summary.isTerminated())
+}
+
+func readProcessItem(dynamoItem map[string]*dynamodb.AttributeValue) (*processItem, error) {
+	if len(dynamoItem) == 0 {
+		return nil, nil
+	}
+	item := &processItem{}
+	if registrationsCountAttr, isDefined := dynamoItem[ProcessRegistrationsCountAttrName]; isDefined && registrationsCountAttr.N != nil {
+		registrationsCount, err := strconv.ParseInt(*registrationsCountAttr.N, decimalBase, 64)
+		if err != nil {
+			return nil, err
+		}
+		item.registrationsCount = &registrationsCount
+	}
+	summary, err := readProcessSummary(dynamoItem, item.registrationsCount)
+	if err != nil {
+		return nil, err
+	}
+	item.summary = summary
+	sealedTimeAttr, isSealedTimeDefined := dynamoItem[ProcessSealedTimeAttrName]
+	item.isSealed = isSealedTimeDefined && sealedTimeAttr.S != nil
+	callback, err := readProcessCallback(dynamoItem)
+	if err != nil {
+		return nil, err
+	}
+	item.callback = callback
+	if deadlineAttr, isDefined := dynamoItem[ProcessDeadlineAttrName]; isDefined && deadlineAttr.S != nil {
+		deadline, err := time.Parse(time.RFC3339, *deadlineAttr.S)
+		if err != nil {
+			return nil, err
+		}
+		item.deadline = deadline
+	}
+	if err := readProcessMetadata(dynamoItem, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func readProcessMetadata(dynamoItem map[string]*dynamodb.AttributeValue, item *processItem) error {
+	if labelsAttr, isDefined := dynamoItem[ProcessLabelsAttrName]; isDefined && labelsAttr.M != nil {
+		item.labels = make(map[string]string, len(labelsAttr.M))
+		for labelName, labelValueAttr := range labelsAttr.M {
+			item.labels[labelName] = aws.StringValue(labelValueAttr.S)
+		}
+	}
+	if descriptionAttr, isDefined := dynamoItem[ProcessDescriptionAttrName]; isDefined && descriptionAttr.S != nil {
+		item.description = descriptionAttr.S
+	}
+	if creatorAttr, isDefined := dynamoItem[ProcessCreatorAttrName]; isDefined && creatorAttr.S != nil {
+		item.creator = creatorAttr.S
+	}
+	if creationTimeAttr, isDefined := dynamoItem[ProcessCreationTimeAttrName]; isDefined && creationTimeAttr.S != nil {
+		creationTime, err := time.Parse(time.RFC3339, *creationTimeAttr.S)
+		if err != nil {
+			return err
+		}
+		item.creationTime = creationTime
+	}
+	return nil
+}
+
+func (item *processItem) fillMetadata(proc *process.Process) {
+	proc.Labels = item.labels
+	proc.Description = item.description
+	proc.CreationTime = item.creationTime
+	proc.Creator = item.creator
+}
+
+func readProcessCallback(dynamoItem map[string]*dynamodb.AttributeValue) (*process.Callback, error) {
+	callbackURLAttr, isCallbackURLDefined := dynamoItem[ProcessCallbackURLAttrName]
+	if !isCallbackURLDefined || callbackURLAttr.S == nil {
+		return nil, nil
+	}
+	callback := &process.Callback{URL: *callbackURLAttr.S}
+	if callbackStateAttr, isDefined := dynamoItem[ProcessCallbackStateAttrName]; isDefined && callbackStateAttr.S != nil {
+		callback.State = process.CallbackState(*callbackStateAttr.S)
+	}
+	if attemptsAttr, isDefined := dynamoItem[ProcessCallbackAttemptsAttrName]; isDefined && attemptsAttr.N != nil {
+		attempts, err := strconv.Atoi(*attemptsAttr.N)
+		if err != nil {
+			return nil, err
+		}
+		callback.Attempts = attempts
+	}
+	if lastErrorAttr, isDefined := dynamoItem[ProcessCallbackLastErrorAttrName]; isDefined && lastErrorAttr.S != nil {
+		callback.LastError = lastErrorAttr.S
+	}
+	if deliveryTimeAttr, isDefined := dynamoItem[ProcessCallbackDeliveryTimeAttrName]; isDefined && deliveryTimeAttr.S != nil {
+		deliveryTime, err := time.Parse(time.RFC3339, *deliveryTimeAttr.S)
+		if err != nil {
+			return nil, err
+		}
+		callback.DeliveryTime = deliveryTime
+	}
+	return callback, nil
+}
+
+func ProcessListShard(processID string) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(processID))
+	return int(hash.Sum32() % ProcessListShardsCount)
+}
+
+func FormatProcessItemType(listShard int) string {
+	return ProcessItemType + processListShardSeparator + strconv.Itoa(listShard)
+}
+
+func buildProcessItemKey(processID string) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		ProcessIDAttrName: {S: aws.String(processID)},
+		TaskIDAttrName:    {S: aws.String(ProcessItemTaskID)},
+	}
+}
+
+func BuildGetProcessItemInput(tableName, processID string) *dynamodb.GetItemInput {
+	return &dynamodb.GetItemInput{
+		ConsistentRead: aws.Bool(true),
+		Key:            buildProcessItemKey(processID),
+		TableName:      &tableName,
+	}
+}
+
+type ProcessSummaryTracking string
+
+const (
+	ProcessSummaryTracked     ProcessSummaryTracking = "TRACKED"
+	ProcessSummaryInitialized ProcessSummaryTracking = "INITIALIZED"
+	ProcessSummaryUntracked   ProcessSummaryTracking = "UNTRACKED"
+)
+
+type TasksToRegisterInProcess struct {
+	ProcessID                    string
+	TasksCount                   int
+	CreationTime                 time.Time
+	StoringDuration              time.Duration
+	CallbackURL                  *string
+	Deadline                     time.Time
+	Creator                      *string
+	FinishedTasksCount           int
+	AbortedTasksCount            int
+	EarliestExpirationTime       time.Time
+	EarliestExpirationTimeUpdate EarliestExpirationTimeUpdate
+	SummaryTracking              ProcessSummaryTracking
+}
+
+func BuildRegisterInProcessUpdateItemInput(tableName string, tasksToRegister TasksToRegisterInProcess) *dynamodb.UpdateItemInput {
+	ttl := tasksToRegister.CreationTime.Add(tasksToRegister.StoringDuration).UTC().Unix()
+	ttlString := strconv.FormatInt(ttl, decimalBase)
+	tasksCountString := strconv.Itoa(tasksToRegister.TasksCount)
+	itemType := FormatProcessItemType(ProcessListShard(tasksToRegister.ProcessID))
+	updateItemInput := &dynamodb.UpdateItemInput{
+		ExpressionAttributeNames: map[string]*string{
+			processSealedTimeAttrAlias:                aws.String(ProcessSealedTimeAttrName),
+			processRegistrationsCountAttrAlias:        aws.String(ProcessRegistrationsCountAttrName),
+			processDeadlineAttrAlias:                  aws.String(ProcessDeadlineAttrName),
+			processCreationTimeAttrAlias:              aws.String(ProcessCreationTimeAttrName),
+			processItemTypeAttrAlias:                  aws.String(ProcessItemTypeAttrName),
+			taskTTLAttrAlias:                          aws.String(taskTTLAttributeName),
+			processSummaryRegistrationsCountAttrAlias: aws.String(ProcessSummaryRegistrationsCountAttrName),
+			processOpenTasksCountAttrAlias:            aws.String(ProcessOpenTasksCountAttrName),
+			processAbortedTasksCountAttrAlias:         aws.String(ProcessAbortedTasksCountAttrName),
+			processTimedOutTasksCountAttrAlias:        aws.String(ProcessTimedOutTasksCountAttrName),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			currentTimeValuePlaceholder:            {S: aws.String(tasksToRegister.CreationTime.Format(time.RFC3339))},
+			taskTTLValuePlaceholder:                {N: &ttlString},
+			processItemTypeValuePlaceholder:        {S: &itemType},
+			registrationsCountIncrementPlaceholder: {N: &tasksCountString},
+			zeroTasksCountPlaceholder:              {N: aws.String(zeroTasksCount)},
+		},
+		Key:       buildProcessItemKey(tasksToRegister.ProcessID),
+		TableName: &tableName,
+	}
+	earliestExpirationTimeSetExpr, earliestExpirationTimeConditionExpr := addEarliestExpirationTimeUpdate(
+		updateItemInput, tasksToRegister.EarliestExpirationTime, tasksToRegister.EarliestExpirationTimeUpdate)
+	registrationsCountConditionExpr := registrationsCountExistsConditionExpr
+	addExprs := []string{registerInProcessIncrementExpr, registerInProcessSummaryIncrementExpr}
+	switch tasksToRegister.SummaryTracking {
+	case ProcessSummaryInitialized:
+		registrationsCountConditionExpr = registrationsCountNotExistsConditionExpr
+	case ProcessSummaryUntracked:
+		registrationsCountConditionExpr = registrationsCountNotExistsConditionExpr
+		addExprs = []string{registerInProcessIncrementExpr}
+	}
+	conditionExpr := fmt.Sprintf("%s and %s", registerInProcessConditionExpr, registrationsCountConditionExpr)
+	if earliestExpirationTimeConditionExpr != "" {
+		conditionExpr = fmt.Sprintf("%s and %s", conditionExpr, earliestExpirationTimeConditionExpr)
+	}
+	updateItemInput.ConditionExpression = &conditionExpr
+	setExprs := []string{registerInProcessTTLUpdateExpr, registerInProcessCreationTimeUpdateExpr,
+		earliestExpirationTimeSetExpr}
+	if tasksToRegister.CallbackURL != nil {
+		setExprs = append(setExprs, registerInProcessCallbackUpdateExpr)
+		updateItemInput.ExpressionAttributeNames[processCallbackURLAttrAlias] = aws.String(ProcessCallbackURLAttrName)
+		updateItemInput.ExpressionAttributeNames[processCallbackStateAttrAlias] = aws.String(ProcessCallbackStateAttrName)
+		updateItemInput.ExpressionAttributeValues[processCallbackURLValuePlaceholder] = &dynamodb.AttributeValue{
+			S: tasksToRegister.CallbackURL,
+		}
+		updateItemInput.ExpressionAttributeValues[processCallbackStateValuePlaceholder] = &dynamodb.AttributeValue{
+			S: aws.String(string(process.CallbackStatePending)),
+		}
+	}
+	if !tasksToRegister.Deadline.IsZero() {
+		setExprs = append(setExprs, registerInProcessDeadlineUpdateExpr)
+		updateItemInput.ExpressionAttributeValues[processDeadlineValuePlaceholder] = &dynamodb.AttributeValue{
+			S: aws.String(tasksToRegister.Deadline.UTC().Format(time.RFC3339)),
+		}
+	}
+	if tasksToRegister.Creator != nil {
+		setExprs = append(setExprs, registerInProcessCreatorUpdateExpr)
+		updateItemInput.ExpressionAttributeNames[processCreatorAttrAlias] = aws.String(ProcessCreatorAttrName)
+		updateItemInput.ExpressionAttributeValues[processCreatorValuePlaceholder] = &dynamodb.AttributeValue{
+			S: tasksToRegister.Creator,
+		}
+	}
+	addExprs = append(addExprs, addTasksSummaryCounters(updateItemInput, tasksSummaryIncrements{
+		openTasksCount: tasksToRegister.TasksCount - tasksToRegister.FinishedTasksCount -
+			tasksToRegister.AbortedTasksCount,
+		finishedTasksCount: tasksToRegister.FinishedTasksCount,
+		abortedTasksCount:  tasksToRegister.AbortedTasksCount,
+	})...)
+	updateItemInput.UpdateExpression = aws.String(fmt.Sprintf("SET %s ADD %s", strings.Join(setExprs, ", "),
+		strings.Join(addExprs, ", ")))
+	return updateItemInput
+}
+
+type ProcessToSeal struct {
+	ProcessID   string
+	SealingTime time.Time
+}
+
+func BuildSealExistingProcessUpdateItemInput(tableName string, processToSeal ProcessToSeal) *dynamodb.UpdateItemInput {
+	updateItemInput := BuildSealProcessUpdateItemInput(tableName, processToSeal)
+	updateItemInput.ConditionExpression = &sealExistingProcessConditionExpr
+	updateItemInput.ExpressionAttributeNames[ProcessIDAttrAlias] = aws.String(ProcessIDAttrName)
+	return updateItemInput
+}
+
+func BuildSealProcessUpdateItemInput(tableName string, processToSeal ProcessToSeal) *dynamodb.UpdateItemInput {
+	return &dynamodb.UpdateItemInput{
+		ExpressionAttributeNames: map[string]*string{
+			processSealedTimeAttrAlias: aws.String(ProcessSealedTimeAttrName),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			processSealedTimeValuePlaceholder: {S: aws.String(processToSeal.SealingTime.Format(time.RFC3339))},
+		},
+		Key:              buildProcessItemKey(processToSeal.ProcessID),
+		TableName:        &tableName,
+		UpdateExpression: &sealProcessUpdateExpr,
+	}
+}
@@ -0,0 +1,168 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+const (
+	backfilledCreationTimeValuePlaceholder = ":backfilledCreationTime"
+	backfilledTTLValuePlaceholder          = ":backfilledTTL"
+)
+
+var (
+	backfillProcessListScanProjectionExpr = fmt.Sprintf("%s, %s, %s", ProcessIDAttrAlias,
+		processCreationTimeAttrAlias, taskTTLAttrAlias)
+	backfillProcessCreationTimeUpdateExpr = fmt.Sprintf("SET %s = %s, %s = %s", processItemTypeAttrAlias,
+		processItemTypeValuePlaceholder, processCreationTimeAttrAlias, backfilledCreationTimeValuePlaceholder)
+	backfillProcessTTLUpdateExpr = fmt.Sprintf("%s = if_not_exists(%s, %s)", taskTTLAttrAlias, taskTTLAttrAlias,
+		backfilledTTLValuePlaceholder)
+	backfillProcessCreationTimeConditionExpr = fmt.Sprintf("attribute_not_exists(%s) or %s > %s",
+		processCreationTimeAttrAlias, processCreationTimeAttrAlias, backfilledCreationTimeValuePlaceholder)
+	backfillProcessItemTypeUpdateExpr = fmt.Sprintf("SET %s = %s", processItemTypeAttrAlias,
+		processItemTypeValuePlaceholder)
+	backfillProcessItemTypeConditionExpr = fmt.Sprintf("attribute_not_exists(%s) or %s <> %s",
+		processItemTypeAttrAlias, processItemTypeAttrAlias, processItemTypeValuePlaceholder)
+)
+
+type ProcessListBackfiller struct {
+	dynamoAPI      dynamodbiface.DynamoDBAPI
+	tasksTableName string
+}
+
+func NewProcessListBackfiller(dynamoAPI dynamodbiface.DynamoDBAPI, tasksTableName string) *ProcessListBackfiller {
+	return &ProcessListBackfiller{
+		dynamoAPI:      dynamoAPI,
+		tasksTableName: tasksTableName,
+	}
+}
+
+func (backfiller *ProcessListBackfiller) Backfill(ctx context.Context) (int, error) {
+	var exclusiveStartKey map[string]*dynamodb.AttributeValue
+	scannedItemsCount := 0
+	for {
+		out, err := backfiller.dynamoAPI.ScanWithContext(ctx,
+			BuildBackfillProcessListScanInput(backfiller.tasksTableName, exclusiveStartKey))
+		if err != nil {
+			return scannedItemsCount, err
+		}
+		if out == nil {
+			return scannedItemsCount, nil
+		}
+		for _, item := range out.Items {
+			if err := backfiller.backfillItem(ctx, item); err != nil {
+				return scannedItemsCount, err
+			}
+			scannedItemsCount++
+		}
+		if len(out.LastEvaluatedKey) == 0 {
+			return scannedItemsCount, nil
+		}
+		exclusiveStartKey = out.LastEvaluatedKey
+	}
+}
+
+func (backfiller *ProcessListBackfiller) backfillItem(ctx context.Context, item map[string]*dynamodb.AttributeValue) error {
+	processIDAttr, isProcessIDDefined := item[ProcessIDAttrName]
+	if !isProcessIDDefined || processIDAttr.S == nil {
+		return fmt.Errorf("item does not contain process id attribute: %+v", item)
+	}
+	processListing := ProcessListing{
+		ProcessID:    *processIDAttr.S,
+		CreationTime: time.Unix(0, 0).UTC().Format(time.RFC3339),
+	}
+	if creationTimeAttr, isCreationTimeDefined := item[ProcessCreationTimeAttrName]; isCreationTimeDefined &&
+		creationTimeAttr.S != nil {
+		processListing.CreationTime = *creationTimeAttr.S
+	}
+	if ttlAttr, isTTLDefined := item[taskTTLAttributeName]; isTTLDefined && ttlAttr.N != nil {
+		processListing.TTL = ttlAttr.N
+	}
+
+	_, err := backfiller.dynamoAPI.UpdateItemWithContext(ctx,
+		BuildBackfillProcessCreationTimeUpdateItemInput(backfiller.tasksTableName, processListing))
+	if !isConditionalCheckFailedError(err) {
+		return err
+	}
+	_, err = backfiller.dynamoAPI.UpdateItemWithContext(ctx,
+		BuildBackfillProcessItemTypeUpdateItemInput(backfiller.tasksTableName, processListing.ProcessID))
+	if isConditionalCheckFailedError(err) {
+		return nil
+	}
+	return err
+}
+
+func isConditionalCheckFailedError(err error) bool {
+	awsErr, isAWSErr := err.(awserr.Error)
+	return isAWSErr && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException
+}
+
+type ProcessListing struct {
+	ProcessID    string
+	CreationTime string
+	TTL          *string
+}
+
+func BuildBackfillProcessListScanInput(tableName string,
+	exclusiveStartKey map[string]*dynamodb.AttributeValue) *dynamodb.ScanInput {
+	return &dynamodb.ScanInput{
+		ExclusiveStartKey: exclusiveStartKey,
+		ExpressionAttributeNames: map[string]*string{
+			ProcessIDAttrAlias:           aws.String(ProcessIDAttrName),
+			processCreationTimeAttrAlias: aws.String(ProcessCreationTimeAttrName),
+			taskTTLAttrAlias:             aws.String(taskTTLAttributeName),
+		},
+		ProjectionExpression: &backfillProcessListScanProjectionExpr,
+		TableName:            &tableName,
+	}
+}
+
+func BuildBackfillProcessCreationTimeUpdateItemInput(tableName string,
+	processListing ProcessListing) *dynamodb.UpdateItemInput {
+	itemType := FormatProcessItemType(ProcessListShard(processListing.ProcessID))
+	updateItemInput := &dynamodb.UpdateItemInput{
+		ConditionExpression: &backfillProcessCreationTimeConditionExpr,
+		ExpressionAttributeNames: map[string]*string{
+			processItemTypeAttrAlias:     aws.String(ProcessItemTypeAttrName),
+			processCreationTimeAttrAlias: aws.String(ProcessCreationTimeAttrName),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			processItemTypeValuePlaceholder:        {S: &itemType},
+			backfilledCreationTimeValuePlaceholder: {S: aws.String(processListing.CreationTime)},
+		},
+		Key:              buildProcessItemKey(processListing.ProcessID),
+		TableName:        &tableName,
+		UpdateExpression: &backfillProcessCreationTimeUpdateExpr,
+	}
+	if processListing.TTL != nil {
+		updateItemInput.UpdateExpression = aws.String(fmt.Sprintf("%s, %s", backfillProcessCreationTimeUpdateExpr,
+			backfillProcessTTLUpdateExpr))
+		updateItemInput.ExpressionAttributeNames[taskTTLAttrAlias] = aws.String(taskTTLAttributeName)
+		updateItemInput.ExpressionAttributeValues[backfilledTTLValuePlaceholder] = &dynamodb.AttributeValue{
+			N: processListing.TTL,
+		}
+	}
+	return updateItemInput
+}
+
+func BuildBackfillProcessItemTypeUpdateItemInput(tableName, processID string) *dynamodb.UpdateItemInput {
+	itemType := FormatProcessItemType(ProcessListShard(processID))
+	return &dynamodb.UpdateItemInput{
+		ConditionExpression: &backfillProcessItemTypeConditionExpr,
+		ExpressionAttributeNames: map[string]*string{
+			processItemTypeAttrAlias: aws.String(ProcessItemTypeAttrName),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			processItemTypeValuePlaceholder: {S: &itemType},
+		},
+		Key:              buildProcessItemKey(processID),
+		TableName:        &tableName,
+		UpdateExpression: &backfillProcessItemTypeUpdateExpr,
+	}
+}
@@ -0,0 +1,100 @@
+package dynamo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/artii15/termination-detector/internal/dynamo"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type processListBackfillerWithMocks struct {
+	backfiller *dynamo.ProcessListBackfiller
+	dynamoAPI  *dynamoAPIMock
+}
+
+func (backfillerAndMocks *processListBackfillerWithMocks) assertExpectations(t *testing.T) {
+	backfillerAndMocks.dynamoAPI.AssertExpectations(t)
+}
+
+func newProcessListBackfillerWithMocks() *processListBackfillerWithMocks {
+	dynamoAPI := new(dynamoAPIMock)
+	return &processListBackfillerWithMocks{
+		backfiller: dynamo.NewProcessListBackfiller(dynamoAPI, tasksTableName),
+		dynamoAPI:  dynamoAPI,
+	}
+}
+
+func TestProcessListBackfiller_Backfill(t *testing.T) {
+	backfillerAndMocks := newProcessListBackfillerWithMocks()
+	legacyTaskItem := map[string]*dynamodb.AttributeValue{
+		dynamo.ProcessIDAttrName: {S: aws.String("1")},
+		"ttl":                    {N: aws.String("1577934245")},
+	}
+	processItem := map[string]*dynamodb.AttributeValue{
+		dynamo.ProcessIDAttrName:           {S: aws.String("2")},
+		dynamo.ProcessCreationTimeAttrName: {S: aws.String("2020-01-02T03:04:05Z")},
+	}
+	backfillerAndMocks.dynamoAPI.On("ScanWithContext", mock.Anything,
+		dynamo.BuildBackfillProcessListScanInput(tasksTableName, nil)).Return(&dynamodb.ScanOutput{
+		Items:            []map[string]*dynamodb.AttributeValue{legacyTaskItem},
+		LastEvaluatedKey: legacyTaskItem,
+	}, nil)
+	backfillerAndMocks.dynamoAPI.On("ScanWithContext", mock.Anything,
+		dynamo.BuildBackfillProcessListScanInput(tasksTableName, legacyTaskItem)).Return(&dynamodb.ScanOutput{
+		Items: []map[string]*dynamodb.AttributeValue{processItem},
+	}, nil)
+	backfillerAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything,
+		dynamo.BuildBackfillProcessCreationTimeUpdateItemInput(tasksTableName, dynamo.ProcessListing{
+			ProcessID:    "1",
+			CreationTime: "1970-01-01T00:00:00Z",
+			TTL:          aws.String("1577934245"),
+		})).Return(&dynamodb.UpdateItemOutput{}, nil)
+	conditionalCheckFailedErr := awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil)
+	backfillerAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything,
+		dynamo.BuildBackfillProcessCreationTimeUpdateItemInput(tasksTableName, dynamo.ProcessListing{
+			ProcessID:    "2",
+			CreationTime: "2020-01-02T03:04:05Z",
+		})).Return((*dynamodb.UpdateItemOutput)(nil), conditionalCheckFailedErr)
+	backfillerAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything,
+		dynamo.BuildBackfillProcessItemTypeUpdateItemInput(tasksTableName, "2")).
+		Return((*dynamodb.UpdateItemOutput)(nil), conditionalCheckFailedErr)
+
+	scannedItemsCount, err := backfillerAndMocks.backfiller.Backfill(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, scannedItemsCount)
+	backfillerAndMocks.assertExpectations(t)
+}
+
+func TestProcessListBackfiller_Backfill_UpdateError(t *testing.T) {
+	backfillerAndMocks := newProcessListBackfillerWithMocks()
+	backfillerAndMocks.dynamoAPI.On("ScanWithContext", mock.Anything, mock.Anything).Return(&dynamodb.ScanOutput{
+		Items: []map[string]*dynamodb.AttributeValue{{dynamo.ProcessIDAttrName: {S: aws.String("1")}}},
+	}, nil)
+	errToReturn := errors.New("update failed")
+	backfillerAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything, mock.Anything).
+		Return((*dynamodb.UpdateItemOutput)(nil), errToReturn)
+
+	scannedItemsCount, err := backfillerAndMocks.backfiller.Backfill(context.Background())
+	assert.Equal(t, errToReturn, err)
+	assert.Equal(t, 0, scannedItemsCount)
+}
+
+func TestBuildBackfillProcessCreationTimeUpdateItemInput(t *testing.T) {
+	updateItemInput := dynamo.BuildBackfillProcessCreationTimeUpdateItemInput(tasksTableName, dynamo.ProcessListing{
+		ProcessID:    "1",
+		CreationTime: "2020-01-02T03:04:05Z",
+		TTL:          aws.String("1577934245"),
+	})
+	assert.Equal(t, "SET #itemType = :itemType, #processCreationTime = :backfilledCreationTime, "+
+		"#ttl = if_not_exists(#ttl, :backfilledTTL)", *updateItemInput.UpdateExpression)
+	assert.Equal(t, "attribute_not_exists(#processCreationTime) or #processCreationTime > :backfilledCreationTime",
+		*updateItemInput.ConditionExpression)
+	assert.Equal(t, aws.String(dynamo.FormatProcessItemType(dynamo.ProcessListShard("1"))),
+		updateItemInput.ExpressionAttributeValues[":itemType"].S)
+}
@@ -0,0 +1,227 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+const (
+	processCreationTimeIndex    = "processCreationTimeIndex"
+	createdFromValuePlaceholder = ":createdFrom"
+)
+
+var (
+	listProcessesKeyCondExpr            = fmt.Sprintf("%s = %s", processItemTypeAttrAlias, processItemTypeValuePlaceholder)
+	listProcessesCreatedFromKeyCondExpr = fmt.Sprintf("%s = %s and %s >= %s", processItemTypeAttrAlias,
+		processItemTypeValuePlaceholder, processCreationTimeAttrAlias, createdFromValuePlaceholder)
+)
+
+type ProcessLister struct {
+	dynamoAPI      dynamodbiface.DynamoDBAPI
+	tasksTableName string
+	processGetter  process.Getter
+}
+
+func NewProcessLister(dynamoAPI dynamodbiface.DynamoDBAPI, tasksTableName string,
+	processGetter process.Getter) *ProcessLister {
+	return &ProcessLister{
+		dynamoAPI:      dynamoAPI,
+		tasksTableName: tasksTableName,
+		processGetter:  processGetter,
+	}
+}
+
+func (lister *ProcessLister) ListProcesses(ctx context.Context, request process.ListRequest) (process.List, error) {
+	var lastScannedPosition *process.ListPosition
+	if request.Cursor != "" {
+		lastPosition, err := process.DecodeListCursor(request.Cursor)
+		if err != nil {
+			return process.List{}, err
+		}
+		lastScannedPosition = &lastPosition
+	}
+
+	shardReaders := lister.newShardReaders(request, lastScannedPosition)
+	processesList := process.List{Processes: []process.Process{}}
+	for scannedCount := 0; ; scannedCount++ {
+		position, err := lister.readNextPosition(ctx, shardReaders)
+		if err != nil {
+			return process.List{}, err
+		}
+		if position == nil {
+			return processesList, nil
+		}
+		if scannedCount == process.MaxScannedProcessesPerPage {
+			processesList.NextCursor = process.EncodeListCursor(*lastScannedPosition)
+			return processesList, nil
+		}
+		listedProcess, err := lister.processGetter.Get(ctx, position.ProcessID)
+		if err != nil {
+			return process.List{}, err
+		}
+		if listedProcess != nil && request.Matches(*listedProcess) {
+			if len(processesList.Processes) == request.Limit {
+				processesList.NextCursor = process.EncodeListCursor(*lastScannedPosition)
+				return processesList, nil
+			}
+			processesList.Processes = append(processesList.Processes, *listedProcess)
+		}
+		lastScannedPosition = position
+	}
+}
+
+func (lister *ProcessLister) newShardReaders(request process.ListRequest,
+	lastPosition *process.ListPosition) []*processListShardReader {
+	createdFrom := request.CreatedAfter
+	if lastPosition != nil && lastPosition.CreationTime.After(createdFrom) {
+		createdFrom = lastPosition.CreationTime
+	}
+	shardReaders := make([]*processListShardReader, ProcessListShardsCount)
+	for listShard := range shardReaders {
+		shardReaders[listShard] = &processListShardReader{
+			request: ListProcessesRequest{
+				ListShard:   listShard,
+				CreatedFrom: createdFrom,
+				Limit:       request.Limit + 1,
+			},
+			createdAfter: request.CreatedAfter,
+			lastPosition: lastPosition,
+		}
+	}
+	return shardReaders
+}
+
+func (lister *ProcessLister) readNextPosition(ctx context.Context,
+	shardReaders []*processListShardReader) (*process.ListPosition, error) {
+	var nextShardReader *processListShardReader
+	for _, shardReader := range shardReaders {
+		position, err := shardReader.peek(ctx, lister.dynamoAPI, lister.tasksTableName)
+		if err != nil {
+			return nil, err
+		}
+		if position != nil && (nextShardReader == nil || nextShardReader.positions[0].IsAfter(*position)) {
+			nextShardReader = shardReader
+		}
+	}
+	if nextShardReader == nil {
+		return nil, nil
+	}
+	position := nextShardReader.positions[0]
+	nextShardReader.positions = nextShardReader.positions[1:]
+	return &position, nil
+}
+
+type processListShardReader struct {
+	request      ListProcessesRequest
+	createdAfter time.Time
+	lastPosition *process.ListPosition
+	positions    []process.ListPosition
+	isDrained    bool
+}
+
+func (shardReader *processListShardReader) peek(ctx context.Context, dynamoAPI dynamodbiface.DynamoDBAPI,
+	tableName string) (*process.ListPosition, error) {
+	for !shardReader.isDrained && !shardReader.hasCompleteHead() {
+		if err := shardReader.read(ctx, dynamoAPI, tableName); err != nil {
+			return nil, err
+		}
+	}
+	if len(shardReader.positions) == 0 {
+		return nil, nil
+	}
+	return &shardReader.positions[0], nil
+}
+
+func (shardReader *processListShardReader) hasCompleteHead() bool {
+	positionsCount := len(shardReader.positions)
+	return positionsCount > 0 &&
+		shardReader.positions[positionsCount-1].CreationTime.After(shardReader.positions[0].CreationTime)
+}
+
+func (shardReader *processListShardReader) read(ctx context.Context, dynamoAPI dynamodbiface.DynamoDBAPI,
+	tableName string) error {
+	out, err := dynamoAPI.QueryWithContext(ctx, BuildListProcessesQueryInput(tableName, shardReader.request))
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		shardReader.isDrained = true
+		return nil
+	}
+	for _, processItem := range out.Items {
+		position, err := readProcessListPosition(processItem)
+		if err != nil {
+			return err
+		}
+		if shardReader.isListed(position) {
+			shardReader.positions = append(shardReader.positions, position)
+		}
+	}
+	sort.Slice(shardReader.positions, func(i, j int) bool {
+		return shardReader.positions[j].IsAfter(shardReader.positions[i])
+	})
+	shardReader.request.ExclusiveStartKey = out.LastEvaluatedKey
+	shardReader.isDrained = len(out.LastEvaluatedKey) == 0
+	return nil
+}
+
+func (shardReader *processListShardReader) isListed(position process.ListPosition) bool {
+	if !position.CreationTime.After(shardReader.createdAfter) {
+		return false
+	}
+	return shardReader.lastPosition == nil || position.IsAfter(*shardReader.lastPosition)
+}
+
+func readProcessListPosition(processItem map[string]*dynamodb.AttributeValue) (process.ListPosition, error) {
+	processIDAttr, isProcessIDDefined := processItem[ProcessIDAttrName]
+	if !isProcessIDDefined || processIDAttr.S == nil {
+		return process.ListPosition{}, fmt.Errorf("item does not contain process id attribute: %+v", processItem)
+	}
+	creationTimeAttr, isCreationTimeDefined := processItem[ProcessCreationTimeAttrName]
+	if !isCreationTimeDefined || creationTimeAttr.S == nil {
+		return process.ListPosition{}, fmt.Errorf("item does not contain creation time attribute: %+v", processItem)
+	}
+	creationTime, err := time.Parse(time.RFC3339, *creationTimeAttr.S)
+	if err != nil {
+		return process.ListPosition{}, err
+	}
+	return process.ListPosition{CreationTime: creationTime, ProcessID: *processIDAttr.S}, nil
+}
+
+type ListProcessesRequest struct {
+	ListShard         int
+	CreatedFrom       time.Time
+	ExclusiveStartKey map[string]*dynamodb.AttributeValue
+	Limit             int
+}
+
+func BuildListProcessesQueryInput(tableName string, request ListProcessesRequest) *dynamodb.QueryInput {
+	queryInput := &dynamodb.QueryInput{
+		ExclusiveStartKey: request.ExclusiveStartKey,
+		ExpressionAttributeNames: map[string]*string{
+			processItemTypeAttrAlias: aws.String(ProcessItemTypeAttrName),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			processItemTypeValuePlaceholder: {S: aws.String(FormatProcessItemType(request.ListShard))},
+		},
+		IndexName:              aws.String(processCreationTimeIndex),
+		KeyConditionExpression: &listProcessesKeyCondExpr,
+		Limit:                  aws.Int64(int64(request.Limit)),
+		TableName:              &tableName,
+	}
+	if !request.CreatedFrom.IsZero() {
+		queryInput.KeyConditionExpression = &listProcessesCreatedFromKeyCondExpr
+		queryInput.ExpressionAttributeNames[processCreationTimeAttrAlias] = aws.String(ProcessCreationTimeAttrName)
+		queryInput.ExpressionAttributeValues[createdFromValuePlaceholder] = &dynamodb.AttributeValue{
+			S: aws.String(request.CreatedFrom.UTC().Format(time.RFC3339)),
+		}
+	}
+	return queryInput
+}
@@ -0,0 +1,244 @@
+package dynamo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/dynamo"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type processGetterMock struct {
+	mock.Mock
+}
+
+func (getter *processGetterMock) Get(ctx context.Context, processID string) (*process.Process, error) {
+	args := getter.Called(ctx, processID)
+	return args.Get(0).(*process.Process), args.Error(1)
+}
+
+type processListerWithMocks struct {
+	lister        *dynamo.ProcessLister
+	dynamoAPI     *dynamoAPIMock
+	processGetter *processGetterMock
+}
+
+func (listerAndMocks *processListerWithMocks) assertExpectations(t *testing.T) {
+	listerAndMocks.dynamoAPI.AssertExpectations(t)
+	listerAndMocks.processGetter.AssertExpectations(t)
+}
+
+func newProcessListerWithMocks() *processListerWithMocks {
+	dynamoAPI := new(dynamoAPIMock)
+	processGetter := new(processGetterMock)
+	return &processListerWithMocks{
+		lister:        dynamo.NewProcessLister(dynamoAPI, tasksTableName, processGetter),
+		dynamoAPI:     dynamoAPI,
+		processGetter: processGetter,
+	}
+}
+
+func (listerAndMocks *processListerWithMocks) mockProcess(proc process.Process) {
+	listerAndMocks.processGetter.On("Get", mock.Anything, proc.ID).Return(&proc, nil)
+}
+
+func (listerAndMocks *processListerWithMocks) mockListShard(request dynamo.ListProcessesRequest,
+	out *dynamodb.QueryOutput) {
+	listerAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything,
+		dynamo.BuildListProcessesQueryInput(tasksTableName, request)).Return(out, nil).Once()
+}
+
+func (listerAndMocks *processListerWithMocks) mockListShards(createdFrom time.Time, limit int,
+	positions ...process.ListPosition) {
+	for listShard := 0; listShard < dynamo.ProcessListShardsCount; listShard++ {
+		listerAndMocks.mockListShard(dynamo.ListProcessesRequest{ListShard: listShard, CreatedFrom: createdFrom, Limit: limit},
+			newListShardQueryOutput(listShard, positions...))
+	}
+}
+
+func newListShardQueryOutput(listShard int, positions ...process.ListPosition) *dynamodb.QueryOutput {
+	out := &dynamodb.QueryOutput{Items: []map[string]*dynamodb.AttributeValue{}}
+	for _, position := range positions {
+		if dynamo.ProcessListShard(position.ProcessID) == listShard {
+			out.Items = append(out.Items, newProcessListItem(position.ProcessID, position.CreationTime))
+		}
+	}
+	return out
+}
+
+func newProcessListItem(processID string, creationTime time.Time) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		dynamo.ProcessIDAttrName:           {S: aws.String(processID)},
+		dynamo.TaskIDAttrName:              {S: aws.String(dynamo.ProcessItemTaskID)},
+		dynamo.ProcessItemTypeAttrName:     {S: aws.String(dynamo.FormatProcessItemType(dynamo.ProcessListShard(processID)))},
+		dynamo.ProcessCreationTimeAttrName: {S: aws.String(creationTime.Format(time.RFC3339))},
+	}
+}
+
+func TestProcessLister_ListProcesses(t *testing.T) {
+	listerAndMocks := newProcessListerWithMocks()
+	creationTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	firstProcess := process.Process{ID: "1", State: process.StateCreated, CreationTime: creationTime}
+	secondProcess := process.Process{ID: "2", State: process.StateCompleted, CreationTime: creationTime}
+	listerAndMocks.mockProcess(firstProcess)
+	listerAndMocks.mockProcess(secondProcess)
+	listerAndMocks.mockListShards(time.Time{}, 2,
+		process.ListPosition{CreationTime: creationTime.Add(time.Second), ProcessID: secondProcess.ID},
+		process.ListPosition{CreationTime: creationTime, ProcessID: firstProcess.ID})
+
+	processesList, err := listerAndMocks.lister.ListProcesses(context.Background(), process.ListRequest{Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []process.Process{firstProcess}, processesList.Processes)
+	assert.Equal(t, process.EncodeListCursor(process.ListPosition{CreationTime: creationTime, ProcessID: "1"}),
+		processesList.NextCursor)
+	listerAndMocks.assertExpectations(t)
+}
+
+func TestProcessLister_ListProcesses_SameCreationTimeAcrossPages(t *testing.T) {
+	listerAndMocks := newProcessListerWithMocks()
+	creationTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	var processes []process.Process
+	for processIndex := 0; len(processes) < 4; processIndex++ {
+		processID := fmt.Sprintf("%02d", processIndex)
+		if dynamo.ProcessListShard(processID) == 0 {
+			processes = append(processes, process.Process{ID: processID, State: process.StateCreated, CreationTime: creationTime})
+			listerAndMocks.mockProcess(processes[len(processes)-1])
+		}
+	}
+	firstShardPage := &dynamodb.QueryOutput{Items: []map[string]*dynamodb.AttributeValue{
+		newProcessListItem(processes[3].ID, creationTime),
+		newProcessListItem(processes[1].ID, creationTime),
+	}}
+	firstShardPage.LastEvaluatedKey = firstShardPage.Items[1]
+	secondShardPage := &dynamodb.QueryOutput{Items: []map[string]*dynamodb.AttributeValue{
+		newProcessListItem(processes[0].ID, creationTime),
+		newProcessListItem(processes[2].ID, creationTime),
+	}}
+	for _, createdFrom := range []time.Time{{}, creationTime} {
+		listerAndMocks.mockListShard(dynamo.ListProcessesRequest{CreatedFrom: createdFrom, Limit: 3}, firstShardPage)
+		listerAndMocks.mockListShard(dynamo.ListProcessesRequest{CreatedFrom: createdFrom,
+			ExclusiveStartKey: firstShardPage.LastEvaluatedKey, Limit: 3}, secondShardPage)
+		for listShard := 1; listShard < dynamo.ProcessListShardsCount; listShard++ {
+			listerAndMocks.mockListShard(dynamo.ListProcessesRequest{ListShard: listShard, CreatedFrom: createdFrom, Limit: 3},
+				&dynamodb.QueryOutput{})
+		}
+	}
+
+	firstPage, err := listerAndMocks.lister.ListProcesses(context.Background(), process.ListRequest{Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, processes[:2], firstPage.Processes)
+	secondPage, err := listerAndMocks.lister.ListProcesses(context.Background(), process.ListRequest{
+		Cursor: firstPage.NextCursor,
+		Limit:  2,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, process.List{Processes: processes[2:]}, secondPage)
+	listerAndMocks.assertExpectations(t)
+}
+
+func TestProcessLister_ListProcesses_Filtered(t *testing.T) {
+	listerAndMocks := newProcessListerWithMocks()
+	creationTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	createdAfter := creationTime.Add(-time.Hour)
+	lastPosition := process.ListPosition{CreationTime: creationTime, ProcessID: "1"}
+	completedProcess := process.Process{ID: "2", State: process.StateCompleted, CreationTime: creationTime}
+	failedProcess := process.Process{ID: "3", State: process.StateError, CreationTime: creationTime}
+	listerAndMocks.mockProcess(completedProcess)
+	listerAndMocks.mockProcess(failedProcess)
+	listerAndMocks.processGetter.On("Get", mock.Anything, "4").Return((*process.Process)(nil), nil)
+	positions := []process.ListPosition{
+		lastPosition,
+		{CreationTime: creationTime, ProcessID: completedProcess.ID},
+		{CreationTime: creationTime, ProcessID: "4"},
+	}
+	failedProcessShard := dynamo.ProcessListShard(failedProcess.ID)
+	for listShard := 0; listShard < dynamo.ProcessListShardsCount; listShard++ {
+		if listShard != failedProcessShard {
+			listerAndMocks.mockListShard(dynamo.ListProcessesRequest{ListShard: listShard, CreatedFrom: creationTime, Limit: 11},
+				newListShardQueryOutput(listShard, positions...))
+		}
+	}
+	failedProcessShardFirstPage := newListShardQueryOutput(failedProcessShard, positions...)
+	failedProcessShardFirstPage.LastEvaluatedKey = newProcessListItem(failedProcess.ID, creationTime)
+	failedProcessShardFirstPage.Items = append(failedProcessShardFirstPage.Items, failedProcessShardFirstPage.LastEvaluatedKey)
+	listerAndMocks.mockListShard(dynamo.ListProcessesRequest{ListShard: failedProcessShard, CreatedFrom: creationTime,
+		Limit: 11}, failedProcessShardFirstPage)
+	listerAndMocks.mockListShard(dynamo.ListProcessesRequest{ListShard: failedProcessShard, CreatedFrom: creationTime,
+		ExclusiveStartKey: failedProcessShardFirstPage.LastEvaluatedKey, Limit: 11}, &dynamodb.QueryOutput{})
+	errorState := process.StateError
+
+	processesList, err := listerAndMocks.lister.ListProcesses(context.Background(), process.ListRequest{
+		State:        &errorState,
+		CreatedAfter: createdAfter,
+		Cursor:       process.EncodeListCursor(lastPosition),
+		Limit:        10,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, process.List{Processes: []process.Process{failedProcess}}, processesList)
+	listerAndMocks.assertExpectations(t)
+}
+
+func TestProcessLister_ListProcesses_ScannedProcessesLimitReached(t *testing.T) {
+	listerAndMocks := newProcessListerWithMocks()
+	creationTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	var positions []process.ListPosition
+	for processIndex := 0; processIndex <= process.MaxScannedProcessesPerPage; processIndex++ {
+		position := process.ListPosition{CreationTime: creationTime, ProcessID: fmt.Sprintf("%04d", processIndex)}
+		positions = append(positions, position)
+		if processIndex < process.MaxScannedProcessesPerPage {
+			listerAndMocks.mockProcess(process.Process{ID: position.ProcessID, State: process.StateCompleted})
+		}
+	}
+	listerAndMocks.mockListShards(time.Time{}, 11, positions...)
+	errorState := process.StateError
+
+	processesList, err := listerAndMocks.lister.ListProcesses(context.Background(), process.ListRequest{
+		State: &errorState,
+		Limit: 10,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, processesList.Processes)
+	assert.Equal(t, process.EncodeListCursor(positions[process.MaxScannedProcessesPerPage-1]), processesList.NextCursor)
+	listerAndMocks.assertExpectations(t)
+}
+
+func TestProcessLister_ListProcesses_InvalidCursor(t *testing.T) {
+	listerAndMocks := newProcessListerWithMocks()
+
+	_, err := listerAndMocks.lister.ListProcesses(context.Background(), process.ListRequest{Cursor: "!", Limit: 10})
+	assert.Equal(t, process.ErrInvalidCursor, err)
+}
+
+func TestProcessLister_ListProcesses_QueryError(t *testing.T) {
+	listerAndMocks := newProcessListerWithMocks()
+	errToReturn := errors.New("query failed")
+	listerAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, mock.Anything).
+		Return((*dynamodb.QueryOutput)(nil), errToReturn)
+
+	_, err := listerAndMocks.lister.ListProcesses(context.Background(), process.ListRequest{Limit: 10})
+	assert.Equal(t, errToReturn, err)
+}
+
+func TestBuildListProcessesQueryInput(t *testing.T) {
+	creationTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+	exclusiveStartKey := newProcessListItem("1", creationTime.UTC())
+	queryInput := dynamo.BuildListProcessesQueryInput(tasksTableName, dynamo.ListProcessesRequest{
+		ListShard:         3,
+		CreatedFrom:       creationTime,
+		ExclusiveStartKey: exclusiveStartKey,
+		Limit:             5,
+	})
+	assert.Equal(t, "processCreationTimeIndex", *queryInput.IndexName)
+	assert.Equal(t, "#itemType = :itemType and #processCreationTime >= :createdFrom", *queryInput.KeyConditionExpression)
+	assert.Equal(t, &dynamodb.AttributeValue{S: aws.String("PROCESS#3")}, queryInput.ExpressionAttributeValues[":itemType"])
+	assert.Equal(t, &dynamodb.AttributeValue{S: aws.String("2020-01-02T02:04:05Z")},
+		queryInput.ExpressionAttributeValues[":createdFrom"])
+	assert.Equal(t, exclusiveStartKey, queryInput.ExclusiveStartKey)
+}
@@ -0,0 +1,56 @@
+package dynamo
+
+import (
+	"context"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+type ProcessSealer struct {
+	dynamoAPI         dynamodbiface.DynamoDBAPI
+	tasksTableName    string
+	currentDateGetter currentDateGetter
+}
+
+func NewProcessSealer(dynamoAPI dynamodbiface.DynamoDBAPI, tasksTableName string,
+	currentDateGetter currentDateGetter) *ProcessSealer {
+	return &ProcessSealer{
+		dynamoAPI:         dynamoAPI,
+		tasksTableName:    tasksTableName,
+		currentDateGetter: currentDateGetter,
+	}
+}
+
+func (sealer *ProcessSealer) Seal(ctx context.Context, processID string) (process.SealingResult, error) {
+	processToSeal := ProcessToSeal{
+		ProcessID:   processID,
+		SealingTime: sealer.currentDateGetter.GetCurrentDate(),
+	}
+	_, err := sealer.dynamoAPI.UpdateItemWithContext(ctx,
+		BuildSealExistingProcessUpdateItemInput(sealer.tasksTableName, processToSeal))
+	if err == nil {
+		return process.SealingResultSealed, nil
+	}
+	if awsErr, isAWSErr := err.(awserr.Error); !isAWSErr || awsErr.Code() != dynamodb.ErrCodeConditionalCheckFailedException {
+		return "", err
+	}
+	return sealer.sealLegacyProcess(ctx, processToSeal)
+}
+
+func (sealer *ProcessSealer) sealLegacyProcess(ctx context.Context, processToSeal ProcessToSeal) (process.SealingResult, error) {
+	processExists, err := checkIfProcessExists(ctx, sealer.dynamoAPI, sealer.tasksTableName, processToSeal.ProcessID)
+	if err != nil {
+		return "", err
+	}
+	if !processExists {
+		return process.SealingResultNotFound, nil
+	}
+	updateItemInput := BuildSealProcessUpdateItemInput(sealer.tasksTableName, processToSeal)
+	if _, err := sealer.dynamoAPI.UpdateItemWithContext(ctx, updateItemInput); err != nil {
+		return "", err
+	}
+	return process.SealingResultSealed, nil
+}
@@ -0,0 +1,117 @@
+package dynamo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/dynamo"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type processSealerWithMocks struct {
+	sealer            *dynamo.ProcessSealer
+	dynamoAPI         *dynamoAPIMock
+	currentDateGetter *currentDateGetterMock
+}
+
+func (sealerAndMocks *processSealerWithMocks) assertExpectations(t *testing.T) {
+	sealerAndMocks.dynamoAPI.AssertExpectations(t)
+	sealerAndMocks.currentDateGetter.AssertExpectations(t)
+}
+
+func newProcessSealerWithMocks() *processSealerWithMocks {
+	dynamoAPI := new(dynamoAPIMock)
+	currentDateGetter := new(currentDateGetterMock)
+	return &processSealerWithMocks{
+		sealer:            dynamo.NewProcessSealer(dynamoAPI, tasksTableName, currentDateGetter),
+		dynamoAPI:         dynamoAPI,
+		currentDateGetter: currentDateGetter,
+	}
+}
+
+func TestProcessSealer_Seal(t *testing.T) {
+	sealerAndMocks := newProcessSealerWithMocks()
+	procID := "1"
+	currentTime := time.Now().UTC()
+	sealerAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentTime)
+	sealInput := dynamo.BuildSealExistingProcessUpdateItemInput(tasksTableName, dynamo.ProcessToSeal{
+		ProcessID:   procID,
+		SealingTime: currentTime,
+	})
+	sealerAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything, sealInput).Return(&dynamodb.UpdateItemOutput{}, nil)
+
+	sealingResult, err := sealerAndMocks.sealer.Seal(context.Background(), procID)
+	assert.NoError(t, err)
+	assert.Equal(t, process.SealingResultSealed, sealingResult)
+	sealerAndMocks.assertExpectations(t)
+}
+
+func TestProcessSealer_Seal_LegacyProcess(t *testing.T) {
+	sealerAndMocks := newProcessSealerWithMocks()
+	procID := "1"
+	currentTime := time.Now().UTC()
+	sealerAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentTime)
+	processToSeal := dynamo.ProcessToSeal{
+		ProcessID:   procID,
+		SealingTime: currentTime,
+	}
+	sealExistingInput := dynamo.BuildSealExistingProcessUpdateItemInput(tasksTableName, processToSeal)
+	sealerAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything, sealExistingInput).Return((*dynamodb.UpdateItemOutput)(nil),
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil))
+	checkIfProcExistsQueryInput := dynamo.BuildCheckIfProcessExistsQueryInput(tasksTableName, procID)
+	sealerAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, checkIfProcExistsQueryInput).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			{dynamo.ProcessIDAttrName: {S: &procID}},
+		},
+	}, nil)
+	sealInput := dynamo.BuildSealProcessUpdateItemInput(tasksTableName, processToSeal)
+	sealerAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything, sealInput).Return(&dynamodb.UpdateItemOutput{}, nil)
+
+	sealingResult, err := sealerAndMocks.sealer.Seal(context.Background(), procID)
+	assert.NoError(t, err)
+	assert.Equal(t, process.SealingResultSealed, sealingResult)
+	sealerAndMocks.assertExpectations(t)
+}
+
+func TestProcessSealer_Seal_ProcessNotExists(t *testing.T) {
+	sealerAndMocks := newProcessSealerWithMocks()
+	procID := "1"
+	currentTime := time.Now().UTC()
+	sealerAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentTime)
+	sealExistingInput := dynamo.BuildSealExistingProcessUpdateItemInput(tasksTableName, dynamo.ProcessToSeal{
+		ProcessID:   procID,
+		SealingTime: currentTime,
+	})
+	sealerAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything, sealExistingInput).Return((*dynamodb.UpdateItemOutput)(nil),
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil))
+	checkIfProcExistsQueryInput := dynamo.BuildCheckIfProcessExistsQueryInput(tasksTableName, procID)
+	sealerAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, checkIfProcExistsQueryInput).Return(&dynamodb.QueryOutput{}, nil)
+
+	sealingResult, err := sealerAndMocks.sealer.Seal(context.Background(), procID)
+	assert.NoError(t, err)
+	assert.Equal(t, process.SealingResultNotFound, sealingResult)
+	sealerAndMocks.assertExpectations(t)
+}
+
+func TestProcessSealer_Seal_UnexpectedError(t *testing.T) {
+	sealerAndMocks := newProcessSealerWithMocks()
+	procID := "1"
+	currentTime := time.Now().UTC()
+	sealerAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentTime)
+	sealExistingInput := dynamo.BuildSealExistingProcessUpdateItemInput(tasksTableName, dynamo.ProcessToSeal{
+		ProcessID:   procID,
+		SealingTime: currentTime,
+	})
+	sealerAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything, sealExistingInput).Return((*dynamodb.UpdateItemOutput)(nil),
+		errors.New("error"))
+
+	_, err := sealerAndMocks.sealer.Seal(context.Background(), procID)
+	assert.Error(t, err)
+	sealerAndMocks.assertExpectations(t)
+}
@@ -0,0 +1,319 @@
+package dynamo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+const (
+	ProcessSummaryRegistrationsCountAttrName = "summary_registrations_count"
+	ProcessOpenTasksCountAttrName            = "open_tasks_count"
+	ProcessFinishedTasksCountAttrName        = "finished_tasks_count"
+	ProcessAbortedTasksCountAttrName         = "aborted_tasks_count"
+	ProcessTimedOutTasksCountAttrName        = "timed_out_tasks_count"
+	ProcessEarliestExpirationTimeAttrName    = "earliest_expiration_time"
+
+	processSummaryRegistrationsCountAttrAlias = "#summaryRegistrationsCount"
+	processOpenTasksCountAttrAlias            = "#openTasksCount"
+	processFinishedTasksCountAttrAlias        = "#finishedTasksCount"
+	processAbortedTasksCountAttrAlias         = "#abortedTasksCount"
+	processTimedOutTasksCountAttrAlias        = "#timedOutTasksCount"
+	processEarliestExpirationTimeAttrAlias    = "#earliestExpirationTime"
+
+	openTasksCountIncrementPlaceholder            = ":openTasksCountIncrement"
+	finishedTasksCountIncrementPlaceholder        = ":finishedTasksCountIncrement"
+	abortedTasksCountIncrementPlaceholder         = ":abortedTasksCountIncrement"
+	timedOutTasksCountIncrementPlaceholder        = ":timedOutTasksCountIncrement"
+	processEarliestExpirationTimeValuePlaceholder = ":earliestExpirationTime"
+	zeroTasksCountPlaceholder                     = ":zeroTasksCount"
+
+	processEarliestExpirationTimeUnknownValue = "0"
+	zeroTasksCount                            = "0"
+)
+
+var (
+	setEarliestExpirationTimeUpdateExpr = fmt.Sprintf("%s = %s", processEarliestExpirationTimeAttrAlias,
+		processEarliestExpirationTimeValuePlaceholder)
+	setEarliestExpirationTimeIfNotExistsUpdateExpr = fmt.Sprintf("%s = if_not_exists(%s, %s)",
+		processEarliestExpirationTimeAttrAlias, processEarliestExpirationTimeAttrAlias,
+		processEarliestExpirationTimeValuePlaceholder)
+	processItemExistsConditionExpr          = fmt.Sprintf("attribute_exists(%s)", ProcessIDAttrAlias)
+	earliestExpirationTimeKeptConditionExpr = fmt.Sprintf("(attribute_not_exists(%s) or %s <= %s)",
+		processEarliestExpirationTimeAttrAlias, processEarliestExpirationTimeAttrAlias,
+		processEarliestExpirationTimeValuePlaceholder)
+	earliestExpirationTimeLoweredConditionExpr = fmt.Sprintf("%s > %s", processEarliestExpirationTimeAttrAlias,
+		processEarliestExpirationTimeValuePlaceholder)
+	lowerEarliestExpirationTimeConditionExpr = fmt.Sprintf("%s and %s", processItemExistsConditionExpr,
+		earliestExpirationTimeLoweredConditionExpr)
+	lowerEarliestExpirationTimeUpdateExpr = "SET " + setEarliestExpirationTimeUpdateExpr
+	completeInProcessConditionExpr        = fmt.Sprintf("%s = %s and %s", processSummaryRegistrationsCountAttrAlias,
+		processRegistrationsCountAttrAlias, processDeadlineNotExceededConditionExpr)
+	processNotTerminatedBySummaryConditionExpr = fmt.Sprintf(
+		"(attribute_not_exists(%s) or %s <> %s or (%s > %s and %s and %s))",
+		processSummaryRegistrationsCountAttrAlias, processSummaryRegistrationsCountAttrAlias,
+		processRegistrationsCountAttrAlias, processOpenTasksCountAttrAlias, zeroTasksCountPlaceholder,
+		buildZeroTasksCountConditionExpr(processAbortedTasksCountAttrAlias),
+		buildZeroTasksCountConditionExpr(processTimedOutTasksCountAttrAlias))
+)
+
+type EarliestExpirationTimeUpdate string
+
+const (
+	EarliestExpirationTimeKept        EarliestExpirationTimeUpdate = "KEPT"
+	EarliestExpirationTimeLowered     EarliestExpirationTimeUpdate = "LOWERED"
+	EarliestExpirationTimeInvalidated EarliestExpirationTimeUpdate = "INVALIDATED"
+)
+
+var earliestExpirationTimeUpdates = []EarliestExpirationTimeUpdate{
+	EarliestExpirationTimeKept,
+	EarliestExpirationTimeLowered,
+	EarliestExpirationTimeInvalidated,
+}
+
+func buildZeroTasksCountConditionExpr(tasksCountAttrAlias string) string {
+	return fmt.Sprintf("(attribute_not_exists(%s) or %s = %s)", tasksCountAttrAlias, tasksCountAttrAlias,
+		zeroTasksCountPlaceholder)
+}
+
+type processSummary struct {
+	openTasksCount         int64
+	finishedTasksCount     int64
+	abortedTasksCount      int64
+	timedOutTasksCount     int64
+	earliestExpirationTime time.Time
+}
+
+func (summary *processSummary) evaluate(processID string, deadline, currentTime time.Time) (process.Process, bool) {
+	if summary.abortedTasksCount != 0 || summary.timedOutTasksCount != 0 || summary.openTasksCount < 0 {
+		return process.Process{}, false
+	}
+	if summary.openTasksCount == 0 {
+		return process.Process{ID: processID, State: process.StateCompleted}, true
+	}
+	if !currentTime.Before(summary.earliestExpirationTime) {
+		return process.Process{}, false
+	}
+	if process.IsDeadlineExceeded(deadline, currentTime) {
+		return process.Process{
+			ID:           processID,
+			State:        process.StateError,
+			StateMessage: aws.String(process.DeadlineExceededErrorMessage),
+		}, true
+	}
+	return process.Process{ID: processID, State: process.StateCreated}, true
+}
+
+func (summary *processSummary) isTerminated() bool {
+	return summary != nil && (summary.openTasksCount <= 0 || summary.abortedTasksCount != 0 ||
+		summary.timedOutTasksCount != 0)
+}
+
+func (summary *processSummary) progress(totalTasksCount int64, currentTime time.Time) *process.Progress {
+	progress := &process.Progress{
+		TotalTasksCount:    int(totalTasksCount),
+		CreatedTasksCount:  int(summary.openTasksCount),
+		FinishedTasksCount: int(summary.finishedTasksCount),
+		AbortedTasksCount:  int(summary.abortedTasksCount),
+		TimedOutTasksCount: int(summary.timedOutTasksCount),
+	}
+	if summary.openTasksCount > 0 && currentTime.Before(summary.earliestExpirationTime) {
+		progress.EarliestPendingExpirationTime = summary.earliestExpirationTime
+	}
+	return progress
+}
+
+func readProcessSummary(dynamoItem map[string]*dynamodb.AttributeValue,
+	registrationsCount *int64) (*processSummary, error) {
+	summaryRegistrationsCount, err := readInt64Attr(dynamoItem, ProcessSummaryRegistrationsCountAttrName)
+	if err != nil || summaryRegistrationsCount == nil || registrationsCount == nil ||
+		*summaryRegistrationsCount != *registrationsCount {
+		return nil, err
+	}
+	summary := &processSummary{}
+	counters := map[string]*int64{
+		ProcessOpenTasksCountAttrName:     &summary.openTasksCount,
+		ProcessFinishedTasksCountAttrName: &summary.finishedTasksCount,
+		ProcessAbortedTasksCountAttrName:  &summary.abortedTasksCount,
+		ProcessTimedOutTasksCountAttrName: &summary.timedOutTasksCount,
+	}
+	for attrName, counter := range counters {
+		value, err := readInt64Attr(dynamoItem, attrName)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			*counter = *value
+		}
+	}
+	earliestExpirationTimeAttr, isDefined := dynamoItem[ProcessEarliestExpirationTimeAttrName]
+	if isDefined && earliestExpirationTimeAttr.S != nil &&
+		*earliestExpirationTimeAttr.S != processEarliestExpirationTimeUnknownValue {
+		earliestExpirationTime, err := time.Parse(time.RFC3339, *earliestExpirationTimeAttr.S)
+		if err != nil {
+			return nil, err
+		}
+		summary.earliestExpirationTime = earliestExpirationTime
+	}
+	return summary, nil
+}
+
+func readInt64Attr(dynamoItem map[string]*dynamodb.AttributeValue, attrName string) (*int64, error) {
+	attr, isDefined := dynamoItem[attrName]
+	if !isDefined || attr.N == nil {
+		return nil, nil
+	}
+	value, err := strconv.ParseInt(*attr.N, decimalBase, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+type TasksToCompleteInProcess struct {
+	ProcessID          string
+	CompletionTime     time.Time
+	FinishedTasksCount int
+	AbortedTasksCount  int
+}
+
+func BuildCompleteInProcessUpdateItemInput(tableName string,
+	tasksToComplete TasksToCompleteInProcess) *dynamodb.UpdateItemInput {
+	updateItemInput := &dynamodb.UpdateItemInput{
+		ConditionExpression: &completeInProcessConditionExpr,
+		ExpressionAttributeNames: map[string]*string{
+			processDeadlineAttrAlias:                  aws.String(ProcessDeadlineAttrName),
+			processSummaryRegistrationsCountAttrAlias: aws.String(ProcessSummaryRegistrationsCountAttrName),
+			processRegistrationsCountAttrAlias:        aws.String(ProcessRegistrationsCountAttrName),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			currentTimeValuePlaceholder: {S: aws.String(tasksToComplete.CompletionTime.Format(time.RFC3339))},
+		},
+		Key:       buildProcessItemKey(tasksToComplete.ProcessID),
+		TableName: &tableName,
+	}
+	addExprs := addTasksSummaryCounters(updateItemInput, tasksSummaryIncrements{
+		openTasksCount:     -tasksToComplete.FinishedTasksCount - tasksToComplete.AbortedTasksCount,
+		finishedTasksCount: tasksToComplete.FinishedTasksCount,
+		abortedTasksCount:  tasksToComplete.AbortedTasksCount,
+	})
+	updateItemInput.UpdateExpression = aws.String("ADD " + strings.Join(addExprs, ", "))
+	return updateItemInput
+}
+
+func BuildCheckProcessDeadlineConditionCheck(tableName, processID string, currentTime time.Time) *dynamodb.ConditionCheck {
+	return &dynamodb.ConditionCheck{
+		ConditionExpression: &processDeadlineNotExceededConditionExpr,
+		ExpressionAttributeNames: map[string]*string{
+			processDeadlineAttrAlias: aws.String(ProcessDeadlineAttrName),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			currentTimeValuePlaceholder: {S: aws.String(currentTime.Format(time.RFC3339))},
+		},
+		Key:                                 buildProcessItemKey(processID),
+		ReturnValuesOnConditionCheckFailure: aws.String(dynamodb.ReturnValuesOnConditionCheckFailureAllOld),
+		TableName:                           &tableName,
+	}
+}
+
+type tasksSummaryIncrements struct {
+	openTasksCount     int
+	finishedTasksCount int
+	abortedTasksCount  int
+	timedOutTasksCount int
+}
+
+func addTasksSummaryCounters(updateItemInput *dynamodb.UpdateItemInput, increments tasksSummaryIncrements) []string {
+	addExprs := []string{addTasksSummaryCounter(updateItemInput, processOpenTasksCountAttrAlias,
+		ProcessOpenTasksCountAttrName, openTasksCountIncrementPlaceholder, increments.openTasksCount)}
+	if increments.finishedTasksCount > 0 {
+		addExprs = append(addExprs, addTasksSummaryCounter(updateItemInput, processFinishedTasksCountAttrAlias,
+			ProcessFinishedTasksCountAttrName, finishedTasksCountIncrementPlaceholder, increments.finishedTasksCount))
+	}
+	if increments.abortedTasksCount > 0 {
+		addExprs = append(addExprs, addTasksSummaryCounter(updateItemInput, processAbortedTasksCountAttrAlias,
+			ProcessAbortedTasksCountAttrName, abortedTasksCountIncrementPlaceholder, increments.abortedTasksCount))
+	}
+	if increments.timedOutTasksCount > 0 {
+		addExprs = append(addExprs, addTasksSummaryCounter(updateItemInput, processTimedOutTasksCountAttrAlias,
+			ProcessTimedOutTasksCountAttrName, timedOutTasksCountIncrementPlaceholder, increments.timedOutTasksCount))
+	}
+	return addExprs
+}
+
+func addTasksSummaryCounter(updateItemInput *dynamodb.UpdateItemInput, attrAlias, attrName, incrementPlaceholder string,
+	increment int) string {
+	updateItemInput.ExpressionAttributeNames[attrAlias] = aws.String(attrName)
+	updateItemInput.ExpressionAttributeValues[incrementPlaceholder] = &dynamodb.AttributeValue{
+		N: aws.String(strconv.Itoa(increment)),
+	}
+	return fmt.Sprintf("%s %s", attrAlias, incrementPlaceholder)
+}
+
+func BuildTimeOutInProcessUpdateItemInput(tableName, processID string) *dynamodb.UpdateItemInput {
+	updateItemInput := &dynamodb.UpdateItemInput{
+		ConditionExpression: &processItemExistsConditionExpr,
+		ExpressionAttributeNames: map[string]*string{
+			ProcessIDAttrAlias: aws.String(ProcessIDAttrName),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{},
+		Key:                       buildProcessItemKey(processID),
+		TableName:                 &tableName,
+	}
+	addExprs := addTasksSummaryCounters(updateItemInput, tasksSummaryIncrements{
+		openTasksCount:     -1,
+		timedOutTasksCount: 1,
+	})
+	updateItemInput.UpdateExpression = aws.String("ADD " + strings.Join(addExprs, ", "))
+	return updateItemInput
+}
+
+type EarliestExpirationTimeToLower struct {
+	ProcessID              string
+	EarliestExpirationTime time.Time
+}
+
+func BuildLowerEarliestExpirationTimeUpdateItemInput(tableName string,
+	toLower EarliestExpirationTimeToLower) *dynamodb.UpdateItemInput {
+	return &dynamodb.UpdateItemInput{
+		ConditionExpression: &lowerEarliestExpirationTimeConditionExpr,
+		ExpressionAttributeNames: map[string]*string{
+			ProcessIDAttrAlias:                     aws.String(ProcessIDAttrName),
+			processEarliestExpirationTimeAttrAlias: aws.String(ProcessEarliestExpirationTimeAttrName),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			processEarliestExpirationTimeValuePlaceholder: {S: aws.String(formatEarliestExpirationTime(
+				toLower.EarliestExpirationTime))},
+		},
+		Key:              buildProcessItemKey(toLower.ProcessID),
+		TableName:        &tableName,
+		UpdateExpression: &lowerEarliestExpirationTimeUpdateExpr,
+	}
+}
+
+func addEarliestExpirationTimeUpdate(updateItemInput *dynamodb.UpdateItemInput, earliestExpirationTime time.Time,
+	update EarliestExpirationTimeUpdate) (string, string) {
+	updateItemInput.ExpressionAttributeNames[processEarliestExpirationTimeAttrAlias] = aws.String(
+		ProcessEarliestExpirationTimeAttrName)
+	earliestExpirationTimeValue := &dynamodb.AttributeValue{S: aws.String(formatEarliestExpirationTime(
+		earliestExpirationTime))}
+	updateItemInput.ExpressionAttributeValues[processEarliestExpirationTimeValuePlaceholder] = earliestExpirationTimeValue
+	switch update {
+	case EarliestExpirationTimeLowered:
+		return setEarliestExpirationTimeUpdateExpr, earliestExpirationTimeLoweredConditionExpr
+	case EarliestExpirationTimeInvalidated:
+		earliestExpirationTimeValue.S = aws.String(processEarliestExpirationTimeUnknownValue)
+		return setEarliestExpirationTimeUpdateExpr, ""
+	default:
+		return setEarliestExpirationTimeIfNotExistsUpdateExpr, earliestExpirationTimeKeptConditionExpr
+	}
+}
+
+func formatEarliestExpirationTime(earliestExpirationTime time.Time) string {
+	return earliestExpirationTime.UTC().Format(time.RFC3339)
+}
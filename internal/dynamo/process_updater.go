@@ -0,0 +1,139 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+var (
+	updateProcessCallbackSetExpr = fmt.Sprintf("%s = %s, %s = %s, %s = %s",
+		processCallbackURLAttrAlias, processCallbackURLValuePlaceholder,
+		processCallbackStateAttrAlias, processCallbackStateValuePlaceholder,
+		processCallbackAttemptsAttrAlias, processCallbackAttemptsValuePlaceholder)
+	updateProcessCallbackRemoveExpr = fmt.Sprintf(" REMOVE %s, %s",
+		processCallbackLastErrorAttrAlias, processCallbackDeliveryTimeAttrAlias)
+	updateProcessDeadlineSetExpr    = fmt.Sprintf("%s = %s", processDeadlineAttrAlias, processDeadlineValuePlaceholder)
+	updateProcessLabelsSetExpr      = fmt.Sprintf("%s = %s", processLabelsAttrAlias, processLabelsValuePlaceholder)
+	updateProcessDescriptionSetExpr = fmt.Sprintf("%s = %s", processDescriptionAttrAlias,
+		processDescriptionValuePlaceholder)
+)
+
+type ProcessUpdater struct {
+	dynamoAPI      dynamodbiface.DynamoDBAPI
+	tasksTableName string
+}
+
+func NewProcessUpdater(dynamoAPI dynamodbiface.DynamoDBAPI, tasksTableName string) *ProcessUpdater {
+	return &ProcessUpdater{
+		dynamoAPI:      dynamoAPI,
+		tasksTableName: tasksTableName,
+	}
+}
+
+func (updater *ProcessUpdater) Update(ctx context.Context, request process.UpdateRequest) (process.UpdatingResult, error) {
+	if request.CallbackURL == nil && request.Deadline == nil && request.Labels == nil && request.Description == nil {
+		return updater.checkIfProcessUpdatable(ctx, request.ProcessID)
+	}
+	_, err := updater.dynamoAPI.UpdateItemWithContext(ctx,
+		BuildUpdateExistingProcessUpdateItemInput(updater.tasksTableName, request))
+	if err == nil {
+		return process.UpdatingResultUpdated, nil
+	}
+	if awsErr, isAWSErr := err.(awserr.Error); !isAWSErr || awsErr.Code() != dynamodb.ErrCodeConditionalCheckFailedException {
+		return "", err
+	}
+	return updater.updateLegacyProcess(ctx, request)
+}
+
+func (updater *ProcessUpdater) checkIfProcessUpdatable(ctx context.Context, processID string) (process.UpdatingResult, error) {
+	processExists, err := checkIfProcessExists(ctx, updater.dynamoAPI, updater.tasksTableName, processID)
+	if err != nil {
+		return "", err
+	}
+	if !processExists {
+		return process.UpdatingResultNotFound, nil
+	}
+	return process.UpdatingResultUpdated, nil
+}
+
+func (updater *ProcessUpdater) updateLegacyProcess(ctx context.Context, request process.UpdateRequest) (
+	process.UpdatingResult, error) {
+	updatingResult, err := updater.checkIfProcessUpdatable(ctx, request.ProcessID)
+	if err != nil || updatingResult != process.UpdatingResultUpdated {
+		return updatingResult, err
+	}
+	updateItemInput := BuildUpdateProcessUpdateItemInput(updater.tasksTableName, request)
+	if _, err := updater.dynamoAPI.UpdateItemWithContext(ctx, updateItemInput); err != nil {
+		return "", err
+	}
+	return process.UpdatingResultUpdated, nil
+}
+
+func BuildUpdateExistingProcessUpdateItemInput(tableName string, request process.UpdateRequest) *dynamodb.UpdateItemInput {
+	updateItemInput := BuildUpdateProcessUpdateItemInput(tableName, request)
+	updateItemInput.ConditionExpression = &sealExistingProcessConditionExpr
+	updateItemInput.ExpressionAttributeNames[ProcessIDAttrAlias] = aws.String(ProcessIDAttrName)
+	return updateItemInput
+}
+
+func BuildUpdateProcessUpdateItemInput(tableName string, request process.UpdateRequest) *dynamodb.UpdateItemInput {
+	updateItemInput := &dynamodb.UpdateItemInput{
+		ExpressionAttributeNames:  map[string]*string{},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{},
+		Key:                       buildProcessItemKey(request.ProcessID),
+		TableName:                 &tableName,
+	}
+	var setExprs []string
+	removeExpr := ""
+	if request.CallbackURL != nil {
+		setExprs = append(setExprs, updateProcessCallbackSetExpr)
+		removeExpr = updateProcessCallbackRemoveExpr
+		updateItemInput.ExpressionAttributeNames[processCallbackURLAttrAlias] = aws.String(ProcessCallbackURLAttrName)
+		updateItemInput.ExpressionAttributeNames[processCallbackStateAttrAlias] = aws.String(ProcessCallbackStateAttrName)
+		updateItemInput.ExpressionAttributeNames[processCallbackAttemptsAttrAlias] = aws.String(ProcessCallbackAttemptsAttrName)
+		updateItemInput.ExpressionAttributeNames[processCallbackLastErrorAttrAlias] = aws.String(ProcessCallbackLastErrorAttrName)
+		updateItemInput.ExpressionAttributeNames[processCallbackDeliveryTimeAttrAlias] = aws.String(ProcessCallbackDeliveryTimeAttrName)
+		updateItemInput.ExpressionAttributeValues[processCallbackURLValuePlaceholder] = &dynamodb.AttributeValue{S: request.CallbackURL}
+		updateItemInput.ExpressionAttributeValues[processCallbackStateValuePlaceholder] = &dynamodb.AttributeValue{
+			S: aws.String(string(process.CallbackStatePending)),
+		}
+		updateItemInput.ExpressionAttributeValues[processCallbackAttemptsValuePlaceholder] = &dynamodb.AttributeValue{N: aws.String("0")}
+	}
+	if request.Deadline != nil {
+		setExprs = append(setExprs, updateProcessDeadlineSetExpr)
+		updateItemInput.ExpressionAttributeNames[processDeadlineAttrAlias] = aws.String(ProcessDeadlineAttrName)
+		updateItemInput.ExpressionAttributeValues[processDeadlineValuePlaceholder] = &dynamodb.AttributeValue{
+			S: aws.String(request.Deadline.UTC().Format(time.RFC3339)),
+		}
+	}
+	if request.Labels != nil {
+		setExprs = append(setExprs, updateProcessLabelsSetExpr)
+		updateItemInput.ExpressionAttributeNames[processLabelsAttrAlias] = aws.String(ProcessLabelsAttrName)
+		updateItemInput.ExpressionAttributeValues[processLabelsValuePlaceholder] = buildLabelsAttributeValue(request.Labels)
+	}
+	if request.Description != nil {
+		setExprs = append(setExprs, updateProcessDescriptionSetExpr)
+		updateItemInput.ExpressionAttributeNames[processDescriptionAttrAlias] = aws.String(ProcessDescriptionAttrName)
+		updateItemInput.ExpressionAttributeValues[processDescriptionValuePlaceholder] = &dynamodb.AttributeValue{
+			S: request.Description,
+		}
+	}
+	updateItemInput.UpdateExpression = aws.String("SET " + strings.Join(setExprs, ", ") + removeExpr)
+	return updateItemInput
+}
+
+func buildLabelsAttributeValue(labels map[string]string) *dynamodb.AttributeValue {
+	labelsAttrs := make(map[string]*dynamodb.AttributeValue, len(labels))
+	for labelName, labelValue := range labels {
+		labelsAttrs[labelName] = &dynamodb.AttributeValue{S: aws.String(labelValue)}
+	}
+	return &dynamodb.AttributeValue{M: labelsAttrs}
+}
@@ -0,0 +1,139 @@
+package dynamo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/dynamo"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type processUpdaterWithMocks struct {
+	updater   *dynamo.ProcessUpdater
+	dynamoAPI *dynamoAPIMock
+}
+
+func newProcessUpdaterWithMocks() *processUpdaterWithMocks {
+	dynamoAPI := new(dynamoAPIMock)
+	return &processUpdaterWithMocks{
+		updater:   dynamo.NewProcessUpdater(dynamoAPI, tasksTableName),
+		dynamoAPI: dynamoAPI,
+	}
+}
+
+func (updaterAndMocks *processUpdaterWithMocks) mockProcessExists(procID string, exists bool) {
+	queryOutput := &dynamodb.QueryOutput{}
+	if exists {
+		queryOutput.Items = []map[string]*dynamodb.AttributeValue{{dynamo.ProcessIDAttrName: {S: &procID}}}
+	}
+	updaterAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything,
+		dynamo.BuildCheckIfProcessExistsQueryInput(tasksTableName, procID)).Return(queryOutput, nil)
+}
+
+func TestProcessUpdater_Update(t *testing.T) {
+	updaterAndMocks := newProcessUpdaterWithMocks()
+	request := process.UpdateRequest{ProcessID: "1", CallbackURL: aws.String("https://example.com/callback")}
+	updaterAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything,
+		dynamo.BuildUpdateExistingProcessUpdateItemInput(tasksTableName, request)).Return(&dynamodb.UpdateItemOutput{}, nil)
+
+	updatingResult, err := updaterAndMocks.updater.Update(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, process.UpdatingResultUpdated, updatingResult)
+	updaterAndMocks.dynamoAPI.AssertExpectations(t)
+}
+
+func TestProcessUpdater_Update_Deadline(t *testing.T) {
+	updaterAndMocks := newProcessUpdaterWithMocks()
+	deadline := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	request := process.UpdateRequest{ProcessID: "1", Deadline: &deadline}
+	updateItemInput := dynamo.BuildUpdateExistingProcessUpdateItemInput(tasksTableName, request)
+	updaterAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything, updateItemInput).
+		Return(&dynamodb.UpdateItemOutput{}, nil)
+
+	updatingResult, err := updaterAndMocks.updater.Update(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, process.UpdatingResultUpdated, updatingResult)
+	assert.Equal(t, "SET #deadline = :deadline", *updateItemInput.UpdateExpression)
+	assert.Equal(t, &dynamodb.AttributeValue{S: aws.String("2020-01-02T03:04:05Z")},
+		updateItemInput.ExpressionAttributeValues[":deadline"])
+	updaterAndMocks.dynamoAPI.AssertExpectations(t)
+}
+
+func TestProcessUpdater_Update_Metadata(t *testing.T) {
+	updaterAndMocks := newProcessUpdaterWithMocks()
+	request := process.UpdateRequest{
+		ProcessID:   "1",
+		Labels:      map[string]string{"team": "payments"},
+		Description: aws.String("nightly settlement"),
+	}
+	updateItemInput := dynamo.BuildUpdateExistingProcessUpdateItemInput(tasksTableName, request)
+	updaterAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything, updateItemInput).
+		Return(&dynamodb.UpdateItemOutput{}, nil)
+
+	updatingResult, err := updaterAndMocks.updater.Update(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, process.UpdatingResultUpdated, updatingResult)
+	assert.Equal(t, "SET #labels = :labels, #description = :description", *updateItemInput.UpdateExpression)
+	assert.Equal(t, &dynamodb.AttributeValue{M: map[string]*dynamodb.AttributeValue{"team": {S: aws.String("payments")}}},
+		updateItemInput.ExpressionAttributeValues[":labels"])
+	updaterAndMocks.dynamoAPI.AssertExpectations(t)
+}
+
+func TestProcessUpdater_Update_LegacyProcess(t *testing.T) {
+	updaterAndMocks := newProcessUpdaterWithMocks()
+	request := process.UpdateRequest{ProcessID: "1", CallbackURL: aws.String("https://example.com/callback")}
+	updaterAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything,
+		dynamo.BuildUpdateExistingProcessUpdateItemInput(tasksTableName, request)).Return((*dynamodb.UpdateItemOutput)(nil),
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil))
+	updaterAndMocks.mockProcessExists(request.ProcessID, true)
+	updaterAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything,
+		dynamo.BuildUpdateProcessUpdateItemInput(tasksTableName, request)).Return(&dynamodb.UpdateItemOutput{}, nil)
+
+	updatingResult, err := updaterAndMocks.updater.Update(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, process.UpdatingResultUpdated, updatingResult)
+	updaterAndMocks.dynamoAPI.AssertExpectations(t)
+}
+
+func TestProcessUpdater_Update_ProcessNotExists(t *testing.T) {
+	updaterAndMocks := newProcessUpdaterWithMocks()
+	request := process.UpdateRequest{ProcessID: "1", CallbackURL: aws.String("https://example.com/callback")}
+	updaterAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything,
+		dynamo.BuildUpdateExistingProcessUpdateItemInput(tasksTableName, request)).Return((*dynamodb.UpdateItemOutput)(nil),
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil))
+	updaterAndMocks.mockProcessExists(request.ProcessID, false)
+
+	updatingResult, err := updaterAndMocks.updater.Update(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, process.UpdatingResultNotFound, updatingResult)
+	updaterAndMocks.dynamoAPI.AssertExpectations(t)
+}
+
+func TestProcessUpdater_Update_WithoutChanges(t *testing.T) {
+	updaterAndMocks := newProcessUpdaterWithMocks()
+	updaterAndMocks.mockProcessExists("1", true)
+
+	updatingResult, err := updaterAndMocks.updater.Update(context.Background(), process.UpdateRequest{ProcessID: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, process.UpdatingResultUpdated, updatingResult)
+	updaterAndMocks.dynamoAPI.AssertExpectations(t)
+}
+
+func TestProcessUpdater_Update_UnexpectedError(t *testing.T) {
+	updaterAndMocks := newProcessUpdaterWithMocks()
+	request := process.UpdateRequest{ProcessID: "1", CallbackURL: aws.String("https://example.com/callback")}
+	updaterAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything,
+		dynamo.BuildUpdateExistingProcessUpdateItemInput(tasksTableName, request)).Return((*dynamodb.UpdateItemOutput)(nil),
+		errors.New("error"))
+
+	_, err := updaterAndMocks.updater.Update(context.Background(), request)
+	assert.Error(t, err)
+	updaterAndMocks.dynamoAPI.AssertExpectations(t)
+}
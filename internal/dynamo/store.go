@@ -0,0 +1,41 @@
+package dynamo
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+type Store struct {
+	*TaskRegisterer
+	*TaskCompleter
+	*TaskHeartbeater
+	*TaskLister
+	*TaskGetter
+	*TaskReaper
+	*ProcessGetter
+	*ProcessLister
+	*ProcessSealer
+	*ProcessUpdater
+	*CallbackRecorder
+	*TerminationEventRecorder
+}
+
+func NewStore(dynamoAPI dynamodbiface.DynamoDBAPI, tasksTableName string,
+	currentDateGetter currentDateGetter, tasksStoringDuration time.Duration) *Store {
+	processGetter := NewProcessGetter(dynamoAPI, tasksTableName, currentDateGetter)
+	return &Store{
+		TaskRegisterer:           NewTaskRegisterer(dynamoAPI, tasksTableName, currentDateGetter, tasksStoringDuration),
+		TaskCompleter:            NewTaskCompleter(dynamoAPI, tasksTableName, currentDateGetter, tasksStoringDuration),
+		TaskHeartbeater:          NewTaskHeartbeater(dynamoAPI, tasksTableName, currentDateGetter),
+		TaskLister:               NewTaskLister(dynamoAPI, tasksTableName, currentDateGetter),
+		TaskGetter:               NewTaskGetter(dynamoAPI, tasksTableName, currentDateGetter),
+		TaskReaper:               NewTaskReaper(dynamoAPI, tasksTableName, currentDateGetter),
+		ProcessGetter:            processGetter,
+		ProcessLister:            NewProcessLister(dynamoAPI, tasksTableName, processGetter),
+		ProcessSealer:            NewProcessSealer(dynamoAPI, tasksTableName, currentDateGetter),
+		ProcessUpdater:           NewProcessUpdater(dynamoAPI, tasksTableName),
+		CallbackRecorder:         NewCallbackRecorder(dynamoAPI, tasksTableName),
+		TerminationEventRecorder: NewTerminationEventRecorder(dynamoAPI, tasksTableName, currentDateGetter),
+	}
+}
@@ -2,6 +2,8 @@ package dynamo
 
 import (
 	"fmt"
+	"hash/fnv"
+	"strconv"
 	"time"
 
 	"github.com/artii15/termination-detector/pkg/task"
@@ -15,23 +17,39 @@ const (
 	TaskStateAttrName             = "state"
 	TaskStateMessageAttrName      = "state_message"
 	taskExpirationTimeAttrName    = "expiration_time"
+	taskCreationTimeAttrName      = "creation_time"
 	taskTTLAttributeName          = "ttl"
+	taskReapShardAttrName         = "reap_shard"
 
 	ProcessIDAttrAlias             = "#processID"
 	taskIDAttrAlias                = "#taskID"
 	taskBadStateEnterTimeAttrAlias = "#badStateEnterTime"
 	taskStateAttrAlias             = "#state"
 	taskExpirationTimeAttrAlias    = "#expirationTime"
+	taskCreationTimeAttrAlias      = "#creationTime"
 	taskTTLAttrAlias               = "#ttl"
 	taskStateMessageAttrAlias      = "#stateMessage"
+	taskReapShardAttrAlias         = "#reapShard"
 
 	ProcessIDValuePlaceholder             = ":processID"
 	taskStateCreatedValuePlaceholder      = ":stateCreated"
 	taskBadStateEnterTimeValuePlaceholder = ":badStateEnterTime"
+	taskReapShardValuePlaceholder         = ":reapShard"
 
 	taskBadStateEnterTimeZeroValue = "0"
+	TaskReapShardsCount            = 16
 )
 
+func TaskReapShard(taskID task.ID) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(taskID.ProcessID + "/" + taskID.TaskID))
+	return int(hash.Sum32() % TaskReapShardsCount)
+}
+
+func formatTaskReapShard(reapShard int) string {
+	return strconv.Itoa(reapShard)
+}
+
 func readTaskBadStateEnterTime(dynamoTask map[string]*dynamodb.AttributeValue) (time.Time, error) {
 	badStateEnterTimeAttr, isBadStateEnterTimeDefined := dynamoTask[TaskBadStateEnterTimeAttrName]
 	if !isBadStateEnterTimeDefined || badStateEnterTimeAttr.S == nil {
@@ -59,3 +77,54 @@ func readTaskStateMessage(dynamoTask map[string]*dynamodb.AttributeValue) *strin
 	}
 	return stateMsgAttr.S
 }
+
+func readTaskExpirationTime(dynamoTask map[string]*dynamodb.AttributeValue) (time.Time, error) {
+	expirationTimeAttr, isExpirationTimeDefined := dynamoTask[taskExpirationTimeAttrName]
+	if !isExpirationTimeDefined || expirationTimeAttr.S == nil {
+		return time.Time{}, fmt.Errorf("item does not contain expiration time attribute: %+v", dynamoTask)
+	}
+	return time.Parse(time.RFC3339, *expirationTimeAttr.S)
+}
+
+func readTaskID(dynamoTask map[string]*dynamodb.AttributeValue) (string, error) {
+	taskIDAttr, isTaskIDDefined := dynamoTask[TaskIDAttrName]
+	if !isTaskIDDefined || taskIDAttr.S == nil {
+		return "", fmt.Errorf("item does not contain task id attribute: %+v", dynamoTask)
+	}
+	return *taskIDAttr.S, nil
+}
+
+func readTaskCreationTime(dynamoTask map[string]*dynamodb.AttributeValue) (time.Time, error) {
+	creationTimeAttr, isCreationTimeDefined := dynamoTask[taskCreationTimeAttrName]
+	if !isCreationTimeDefined || creationTimeAttr.S == nil {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, *creationTimeAttr.S)
+}
+
+func readTask(processID string, dynamoTask map[string]*dynamodb.AttributeValue, currentTime time.Time) (task.Task, error) {
+	taskID, err := readTaskID(dynamoTask)
+	if err != nil {
+		return task.Task{}, err
+	}
+	taskState, err := readTaskState(dynamoTask)
+	if err != nil {
+		return task.Task{}, err
+	}
+	expirationTime, err := readTaskExpirationTime(dynamoTask)
+	if err != nil {
+		return task.Task{}, err
+	}
+	creationTime, err := readTaskCreationTime(dynamoTask)
+	if err != nil {
+		return task.Task{}, err
+	}
+	return task.Task{
+		ID:             task.ID{ProcessID: processID, TaskID: taskID},
+		State:          taskState,
+		StateMessage:   readTaskStateMessage(dynamoTask),
+		ExpirationTime: expirationTime,
+		CreationTime:   creationTime,
+		TimedOut:       task.IsTimedOut(taskState, expirationTime, currentTime),
+	}, nil
+}
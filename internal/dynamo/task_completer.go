@@ -1,12 +1,12 @@
 package dynamo
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/artii15/termination-detector/pkg/task"
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 )
@@ -15,49 +15,358 @@ const (
 	currentTimeValuePlaceholder         = ":currentTime"
 	newTaskStateValuePlaceholder        = ":newState"
 	newTaskStateMessageValuePlaceholder = ":newStateMessage"
+	maxTasksCompletedInTransaction      = 99
 )
 
 var (
-	completeTaskUpdateExpr = fmt.Sprintf("SET %s = %s, %s = %s, %s = %s",
+	completeTaskUpdateExpr = fmt.Sprintf("SET %s = %s, %s = %s, %s = %s REMOVE %s",
 		taskStateAttrAlias, newTaskStateValuePlaceholder,
 		taskStateMessageAttrAlias, newTaskStateMessageValuePlaceholder,
-		taskBadStateEnterTimeAttrAlias, taskBadStateEnterTimeValuePlaceholder)
+		taskBadStateEnterTimeAttrAlias, taskBadStateEnterTimeValuePlaceholder, taskReapShardAttrAlias)
 	completeTaskConditionExpr = fmt.Sprintf("attribute_exists(%s) and attribute_exists(%s) and %s > %s and %s = %s",
 		ProcessIDAttrAlias, taskIDAttrAlias, taskExpirationTimeAttrAlias, currentTimeValuePlaceholder,
 		taskStateAttrAlias, taskStateCreatedValuePlaceholder)
 )
 
 type TaskCompleter struct {
-	dynamoAPI         dynamodbiface.DynamoDBAPI
-	tasksTableName    string
-	currentDateGetter currentDateGetter
+	dynamoAPI            dynamodbiface.DynamoDBAPI
+	tasksTableName       string
+	currentDateGetter    currentDateGetter
+	tasksStoringDuration time.Duration
 }
 
 func NewTaskCompleter(dynamoAPI dynamodbiface.DynamoDBAPI, tasksTableName string,
-	currentDateGetter currentDateGetter) *TaskCompleter {
+	currentDateGetter currentDateGetter, tasksStoringDuration time.Duration) *TaskCompleter {
 	return &TaskCompleter{
-		dynamoAPI:         dynamoAPI,
-		tasksTableName:    tasksTableName,
-		currentDateGetter: currentDateGetter,
+		dynamoAPI:            dynamoAPI,
+		tasksTableName:       tasksTableName,
+		currentDateGetter:    currentDateGetter,
+		tasksStoringDuration: tasksStoringDuration,
 	}
 }
 
-func (completer *TaskCompleter) Complete(request task.CompleteRequest) (task.CompletingResult, error) {
-	updateItemInput := BuildCompleteTaskUpdateItemInput(completer.tasksTableName, CompleteTaskRequest{
-		CompletionTime: completer.currentDateGetter.GetCurrentDate(),
+func (completer *TaskCompleter) Complete(ctx context.Context, request task.CompleteRequest) (task.CompletingResult, error) {
+	return completer.CompleteWithChildren(ctx, task.CompleteWithChildrenRequest{CompleteRequest: request})
+}
+
+func (completer *TaskCompleter) CompleteWithChildren(ctx context.Context,
+	request task.CompleteWithChildrenRequest) (task.CompletingResult, error) {
+	if task.IsReservedTaskID(request.TaskID) {
+		return task.CompletingResultNotFound, nil
+	}
+	completionTime := completer.currentDateGetter.GetCurrentDate()
+	children := make([]TaskToRegister, 0, len(request.Children))
+	for _, child := range request.Children {
+		children = append(children, TaskToRegister{
+			CreationTime:     completionTime,
+			StoringDuration:  completer.tasksStoringDuration,
+			RegistrationData: child,
+		})
+	}
+	completeTaskRequest := CompleteTaskRequest{
+		CompletionTime: completionTime,
 		TerminalState:  request.State,
 		Message:        request.Message,
 		ProcessID:      request.ProcessID,
 		TaskID:         request.TaskID,
-	})
-	_, err := completer.dynamoAPI.UpdateItem(updateItemInput)
-	if err != nil {
-		if awsErr, isAWSErr := err.(awserr.Error); isAWSErr && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
-			return task.CompletingResultConflict, nil
+	}
+	if len(children) == 0 {
+		return completer.complete(ctx, request.CompleteRequest, completeTaskRequest)
+	}
+	summaryTracking := ProcessSummaryTracked
+	var canceledErr *dynamodb.TransactionCanceledException
+	for earliestExpirationTimeUpdateIndex := 0; earliestExpirationTimeUpdateIndex < len(earliestExpirationTimeUpdates); {
+		err := transactWriteItemsRetryingConflicts(ctx, completer.dynamoAPI,
+			BuildCompleteTaskWithChildrenTransactWriteItemsInput(completer.tasksTableName, completeTaskRequest, children,
+				earliestExpirationTimeUpdates[earliestExpirationTimeUpdateIndex], summaryTracking))
+		if err == nil {
+			return task.CompletingResultCompleted, nil
+		}
+		var isCanceledErr bool
+		if canceledErr, isCanceledErr = err.(*dynamodb.TransactionCanceledException); !isCanceledErr {
+			return "", err
+		}
+		completingResult, err := completer.readCompleteWithChildrenCancellationResult(request.CompleteRequest,
+			completionTime, canceledErr)
+		if err != nil || completingResult != "" {
+			return completingResult, err
+		}
+		item, err := readProcessItem(canceledErr.CancellationReasons[1].Item)
+		if err != nil {
+			return "", err
+		}
+		if summaryTracking == ProcessSummaryTracked && (item == nil || item.registrationsCount == nil) {
+			summaryTracking = ProcessSummaryUntracked
+			continue
+		}
+		earliestExpirationTimeUpdateIndex++
+	}
+	return "", canceledErr
+}
+
+func (completer *TaskCompleter) complete(ctx context.Context, request task.CompleteRequest,
+	completeTaskRequest CompleteTaskRequest) (task.CompletingResult, error) {
+	isProcessSummaryTracked := true
+	for {
+		transactWriteItemsInput := BuildCompleteTaskWithChildrenTransactWriteItemsInput(completer.tasksTableName,
+			completeTaskRequest, nil, EarliestExpirationTimeKept, ProcessSummaryTracked)
+		if !isProcessSummaryTracked {
+			transactWriteItemsInput = BuildCompleteTasksWithoutSummaryTransactWriteItemsInput(completer.tasksTableName,
+				completeTaskRequest.ProcessID, []CompleteTaskRequest{completeTaskRequest})
+		}
+		err := transactWriteItemsRetryingConflicts(ctx, completer.dynamoAPI, transactWriteItemsInput)
+		if err == nil {
+			return task.CompletingResultCompleted, nil
+		}
+		canceledErr, isCanceledErr := err.(*dynamodb.TransactionCanceledException)
+		if !isCanceledErr {
+			return "", err
+		}
+		reasons := canceledErr.CancellationReasons
+		if len(reasons) > 0 && isConditionalCheckFailed(reasons[0]) {
+			return completer.readCompletingConflictResultFromItem(request, reasons[0].Item)
+		}
+		if len(reasons) < 2 || !isConditionalCheckFailed(reasons[1]) {
+			return "", canceledErr
+		}
+		isDeadlineExceeded, err := isProcessDeadlineExceeded(reasons[1], completeTaskRequest.CompletionTime)
+		if err != nil {
+			return "", err
+		}
+		if isDeadlineExceeded || !isProcessSummaryTracked {
+			return task.CompletingResultProcessDeadlineExceeded, nil
+		}
+		isProcessSummaryTracked = false
+	}
+}
+
+func (completer *TaskCompleter) BatchComplete(ctx context.Context,
+	request task.BatchCompleteRequest) ([]task.BatchCompletingResult, error) {
+	completionTime := completer.currentDateGetter.GetCurrentDate()
+	results := task.NewBatchCompletingResults(request, task.CompletingResultCompleted)
+	for index, completeRequest := range request.Tasks {
+		if task.IsReservedTaskID(completeRequest.TaskID) {
+			results[index].Result = task.CompletingResultNotFound
+		}
+	}
+	if request.Transactional {
+		_, err := completer.completeChunk(ctx, request.ProcessID, request.Tasks, results, completionTime, true)
+		return results, err
+	}
+	for chunkStart := 0; chunkStart < len(request.Tasks); chunkStart += maxTasksCompletedInTransaction {
+		chunkEnd := chunkStart + maxTasksCompletedInTransaction
+		if chunkEnd > len(request.Tasks) {
+			chunkEnd = len(request.Tasks)
+		}
+		isDeadlineExceeded, err := completer.completeChunk(ctx, request.ProcessID, request.Tasks[chunkStart:chunkEnd],
+			results[chunkStart:chunkEnd], completionTime, false)
+		if err != nil && chunkStart == 0 {
+			return nil, err
+		}
+		if err != nil {
+			task.ReplaceCompletingResults(results[chunkStart:], task.CompletingResultCompleted,
+				task.CompletingResultFailed)
+			break
+		}
+		if isDeadlineExceeded {
+			task.ReplaceCompletingResults(results[chunkEnd:], task.CompletingResultCompleted,
+				task.CompletingResultProcessDeadlineExceeded)
+			break
+		}
+	}
+	return results, nil
+}
+
+func (completer *TaskCompleter) completeChunk(ctx context.Context, processID string,
+	completeRequests []task.CompleteRequest, results []task.BatchCompletingResult, completionTime time.Time,
+	isTransactional bool) (bool, error) {
+	transactionConflictRetries := 0
+	isProcessSummaryTracked := true
+	for {
+		pendingIndexes := make([]int, 0, len(completeRequests))
+		pendingTasks := make([]CompleteTaskRequest, 0, len(completeRequests))
+		for index, completeRequest := range completeRequests {
+			if results[index].Result == task.CompletingResultCompleted {
+				pendingIndexes = append(pendingIndexes, index)
+				pendingTasks = append(pendingTasks, CompleteTaskRequest{
+					CompletionTime: completionTime,
+					TerminalState:  completeRequest.State,
+					Message:        completeRequest.Message,
+					ProcessID:      completeRequest.ProcessID,
+					TaskID:         completeRequest.TaskID,
+				})
+			}
+		}
+		if len(pendingTasks) == 0 {
+			return false, nil
+		}
+		transactWriteItemsInput := BuildCompleteTasksTransactWriteItemsInput(completer.tasksTableName, processID,
+			pendingTasks)
+		if !isProcessSummaryTracked {
+			transactWriteItemsInput = BuildCompleteTasksWithoutSummaryTransactWriteItemsInput(completer.tasksTableName,
+				processID, pendingTasks)
 		}
+		_, err := completer.dynamoAPI.TransactWriteItemsWithContext(ctx, transactWriteItemsInput)
+		if err == nil {
+			return false, nil
+		}
+		canceledErr, isCanceledErr := err.(*dynamodb.TransactionCanceledException)
+		if !isCanceledErr {
+			return false, err
+		}
+		reasons := canceledErr.CancellationReasons
+		isAnyTaskConflicting := false
+		for position, index := range pendingIndexes {
+			if len(reasons) > position && isConditionalCheckFailed(reasons[position]) {
+				results[index].Result, err = completer.readCompletingConflictResultFromItem(completeRequests[index],
+					reasons[position].Item)
+				if err != nil {
+					return false, err
+				}
+				isAnyTaskConflicting = true
+			}
+		}
+		if len(reasons) > len(pendingIndexes) && isConditionalCheckFailed(reasons[len(pendingIndexes)]) {
+			isDeadlineExceeded, err := isProcessDeadlineExceeded(reasons[len(pendingIndexes)], completionTime)
+			if err != nil {
+				return false, err
+			}
+			if isDeadlineExceeded || !isProcessSummaryTracked {
+				task.ReplaceCompletingResults(results, task.CompletingResultCompleted,
+					task.CompletingResultProcessDeadlineExceeded)
+				return true, nil
+			}
+			if !isAnyTaskConflicting {
+				isProcessSummaryTracked = false
+				continue
+			}
+		}
+		if !isAnyTaskConflicting {
+			if !isTransactionConflicted(canceledErr) || transactionConflictRetries == maxTransactionConflictRetries {
+				return false, canceledErr
+			}
+			if err := waitForTransactionConflictRetry(ctx, transactionConflictRetries); err != nil {
+				return false, err
+			}
+			transactionConflictRetries++
+			continue
+		}
+		if isTransactional && !task.AreCompletingSucceeded(results) {
+			task.ReplaceCompletingResults(results, task.CompletingResultCompleted, task.CompletingResultCanceled)
+			return false, nil
+		}
+	}
+}
+
+func (completer *TaskCompleter) readCompletingConflictResultFromItem(request task.CompleteRequest,
+	dynamoTask map[string]*dynamodb.AttributeValue) (task.CompletingResult, error) {
+	if _, hasState := dynamoTask[TaskStateAttrName]; !hasState {
+		return task.ReadCompletingConflictResult(nil, request), nil
+	}
+	existingTask, err := readTask(request.ProcessID, dynamoTask, completer.currentDateGetter.GetCurrentDate())
+	if err != nil {
 		return "", err
 	}
-	return task.CompletingResultCompleted, nil
+	return task.ReadCompletingConflictResult(&existingTask, request), nil
+}
+
+func (completer *TaskCompleter) readCompleteWithChildrenCancellationResult(request task.CompleteRequest,
+	completionTime time.Time, canceledErr *dynamodb.TransactionCanceledException) (task.CompletingResult, error) {
+	reasons := canceledErr.CancellationReasons
+	if len(reasons) > 0 && isConditionalCheckFailed(reasons[0]) {
+		return completer.readCompletingConflictResultFromItem(request, reasons[0].Item)
+	}
+	if len(reasons) > 1 && isConditionalCheckFailed(reasons[1]) {
+		isDeadlineExceeded, err := isProcessDeadlineExceeded(reasons[1], completionTime)
+		if err != nil {
+			return "", err
+		}
+		if isDeadlineExceeded {
+			return task.CompletingResultProcessDeadlineExceeded, nil
+		}
+		if isClosed, err := isProcessClosed(reasons[1], completionTime); err != nil || !isClosed {
+			return "", err
+		}
+		return task.CompletingResultProcessSealed, nil
+	}
+	for _, reason := range reasons {
+		if isConditionalCheckFailed(reason) {
+			return task.CompletingResultChildConflict, nil
+		}
+	}
+	return "", canceledErr
+}
+
+func BuildCompleteTaskWithChildrenTransactWriteItemsInput(tableName string, completeTaskRequest CompleteTaskRequest,
+	children []TaskToRegister, earliestExpirationTimeUpdate EarliestExpirationTimeUpdate,
+	summaryTracking ProcessSummaryTracking) *dynamodb.TransactWriteItemsInput {
+	transactItems := []*dynamodb.TransactWriteItem{
+		newTransactUpdateReturningOldValues(BuildCompleteTaskUpdateItemInput(tableName, completeTaskRequest)),
+	}
+	tasksToComplete := newTasksToCompleteInProcess(completeTaskRequest.ProcessID,
+		[]CompleteTaskRequest{completeTaskRequest})
+	if len(children) == 0 {
+		transactItems = append(transactItems,
+			newTransactUpdateReturningOldValues(BuildCompleteInProcessUpdateItemInput(tableName, tasksToComplete)))
+		return &dynamodb.TransactWriteItemsInput{TransactItems: transactItems}
+	}
+	transactItems = append(transactItems, newTransactUpdateReturningOldValues(BuildRegisterInProcessUpdateItemInput(tableName,
+		TasksToRegisterInProcess{
+			ProcessID:                    completeTaskRequest.ProcessID,
+			TasksCount:                   len(children),
+			CreationTime:                 children[0].CreationTime,
+			StoringDuration:              children[0].StoringDuration,
+			FinishedTasksCount:           tasksToComplete.FinishedTasksCount,
+			AbortedTasksCount:            tasksToComplete.AbortedTasksCount,
+			EarliestExpirationTime:       findEarliestExpirationTime(children),
+			EarliestExpirationTimeUpdate: earliestExpirationTimeUpdate,
+			SummaryTracking:              summaryTracking,
+		})))
+	for _, child := range children {
+		transactItems = append(transactItems, newTransactUpdate(BuildRegisterTaskUpdateItemInput(tableName, child)))
+	}
+	return &dynamodb.TransactWriteItemsInput{TransactItems: transactItems}
+}
+
+func BuildCompleteTasksTransactWriteItemsInput(tableName, processID string,
+	completeTaskRequests []CompleteTaskRequest) *dynamodb.TransactWriteItemsInput {
+	transactItems := make([]*dynamodb.TransactWriteItem, 0, len(completeTaskRequests)+1)
+	for _, completeTaskRequest := range completeTaskRequests {
+		transactItems = append(transactItems,
+			newTransactUpdateReturningOldValues(BuildCompleteTaskUpdateItemInput(tableName, completeTaskRequest)))
+	}
+	transactItems = append(transactItems, newTransactUpdateReturningOldValues(BuildCompleteInProcessUpdateItemInput(
+		tableName, newTasksToCompleteInProcess(processID, completeTaskRequests))))
+	return &dynamodb.TransactWriteItemsInput{TransactItems: transactItems}
+}
+
+func BuildCompleteTasksWithoutSummaryTransactWriteItemsInput(tableName, processID string,
+	completeTaskRequests []CompleteTaskRequest) *dynamodb.TransactWriteItemsInput {
+	transactItems := make([]*dynamodb.TransactWriteItem, 0, len(completeTaskRequests)+1)
+	for _, completeTaskRequest := range completeTaskRequests {
+		transactItems = append(transactItems,
+			newTransactUpdateReturningOldValues(BuildCompleteTaskUpdateItemInput(tableName, completeTaskRequest)))
+	}
+	transactItems = append(transactItems, &dynamodb.TransactWriteItem{
+		ConditionCheck: BuildCheckProcessDeadlineConditionCheck(tableName, processID,
+			completeTaskRequests[0].CompletionTime),
+	})
+	return &dynamodb.TransactWriteItemsInput{TransactItems: transactItems}
+}
+
+func newTasksToCompleteInProcess(processID string, completeTaskRequests []CompleteTaskRequest) TasksToCompleteInProcess {
+	tasksToComplete := TasksToCompleteInProcess{
+		ProcessID:      processID,
+		CompletionTime: completeTaskRequests[0].CompletionTime,
+	}
+	for _, completeTaskRequest := range completeTaskRequests {
+		if completeTaskRequest.TerminalState == task.StateAborted {
+			tasksToComplete.AbortedTasksCount++
+		} else {
+			tasksToComplete.FinishedTasksCount++
+		}
+	}
+	return tasksToComplete
 }
 
 type CompleteTaskRequest struct {
@@ -92,6 +401,7 @@ func BuildCompleteTaskUpdateItemInput(tableName string, completeTaskRequest Comp
 			taskStateAttrAlias:             aws.String(TaskStateAttrName),
 			taskStateMessageAttrAlias:      aws.String(TaskStateMessageAttrName),
 			taskBadStateEnterTimeAttrAlias: aws.String(TaskBadStateEnterTimeAttrName),
+			taskReapShardAttrAlias:         aws.String(taskReapShardAttrName),
 		},
 		ExpressionAttributeValues: expressionAttributeValues,
 		Key: map[string]*dynamodb.AttributeValue{
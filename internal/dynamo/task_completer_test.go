@@ -1,22 +1,25 @@
 package dynamo_test
 
 import (
+	"context"
 	"errors"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/artii15/termination-detector/internal/dynamo"
 	"github.com/artii15/termination-detector/pkg/task"
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 type taskCompleterWithMocks struct {
-	completer         *dynamo.TaskCompleter
-	dynamoAPI         *dynamoAPIMock
-	currentDateGetter *currentDateGetterMock
+	completer            *dynamo.TaskCompleter
+	dynamoAPI            *dynamoAPIMock
+	currentDateGetter    *currentDateGetterMock
+	tasksStoringDuration time.Duration
 }
 
 func (completerAndMocks *taskCompleterWithMocks) assertExpectations(t *testing.T) {
@@ -27,10 +30,12 @@ func (completerAndMocks *taskCompleterWithMocks) assertExpectations(t *testing.T
 func newTaskCompleterWithMocks() *taskCompleterWithMocks {
 	dynamoAPI := new(dynamoAPIMock)
 	currentDateGetter := new(currentDateGetterMock)
+	tasksStoringDuration := time.Hour * 24 * 7
 	return &taskCompleterWithMocks{
-		completer:         dynamo.NewTaskCompleter(dynamoAPI, tasksTableName, currentDateGetter),
-		dynamoAPI:         dynamoAPI,
-		currentDateGetter: currentDateGetter,
+		completer:            dynamo.NewTaskCompleter(dynamoAPI, tasksTableName, currentDateGetter, tasksStoringDuration),
+		dynamoAPI:            dynamoAPI,
+		currentDateGetter:    currentDateGetter,
+		tasksStoringDuration: tasksStoringDuration,
 	}
 }
 
@@ -46,23 +51,32 @@ func TestTaskCompleter_Complete(t *testing.T) {
 	}
 	completionTime := time.Now().UTC()
 	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
-	updateItemInput := dynamo.BuildCompleteTaskUpdateItemInput(tasksTableName, dynamo.CompleteTaskRequest{
-		CompletionTime: completionTime,
-		TerminalState:  completeTaskRequest.State,
-		Message:        completeTaskRequest.Message,
-		ProcessID:      completeTaskRequest.ProcessID,
-		TaskID:         completeTaskRequest.TaskID,
-	})
-	completerAndMocks.dynamoAPI.On("UpdateItem", updateItemInput).Return(&dynamodb.UpdateItemOutput{}, nil)
+	transactWriteItemsInput := completerAndMocks.buildCompleteWithChildrenInput(completionTime,
+		task.CompleteWithChildrenRequest{CompleteRequest: completeTaskRequest})
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, transactWriteItemsInput).
+		Return(&dynamodb.TransactWriteItemsOutput{}, nil)
 
-	taskCompletionResult, err := completerAndMocks.completer.Complete(completeTaskRequest)
+	taskCompletionResult, err := completerAndMocks.completer.Complete(context.Background(), completeTaskRequest)
 	assert.NoError(t, err)
 	completerAndMocks.assertExpectations(t)
 	assert.Equal(t, task.CompletingResultCompleted, taskCompletionResult)
+	assert.Len(t, transactWriteItemsInput.TransactItems, 2)
 }
 
-func TestTaskCompleter_Complete_TaskAlreadyCompleted(t *testing.T) {
-	completerAndMocks := newTaskCompleterWithMocks()
+func newDynamoTask(id task.ID, state task.State, message *string, expirationTime time.Time) map[string]*dynamodb.AttributeValue {
+	dynamoTask := map[string]*dynamodb.AttributeValue{
+		dynamo.ProcessIDAttrName: {S: aws.String(id.ProcessID)},
+		dynamo.TaskIDAttrName:    {S: aws.String(id.TaskID)},
+		dynamo.TaskStateAttrName: {S: aws.String(string(state))},
+		"expiration_time":        {S: aws.String(expirationTime.Format(time.RFC3339))},
+	}
+	if message != nil {
+		dynamoTask[dynamo.TaskStateMessageAttrName] = &dynamodb.AttributeValue{S: message}
+	}
+	return dynamoTask
+}
+
+func TestTaskCompleter_Complete_ConditionalCheckFailed(t *testing.T) {
 	completeTaskRequest := task.CompleteRequest{
 		ID: task.ID{
 			ProcessID: "2",
@@ -72,21 +86,159 @@ func TestTaskCompleter_Complete_TaskAlreadyCompleted(t *testing.T) {
 		Message: aws.String("failed to execute task"),
 	}
 	completionTime := time.Now().UTC()
+	expirationTime := completionTime.Add(time.Hour)
+	existingTasks := map[task.CompletingResult]map[string]*dynamodb.AttributeValue{
+		task.CompletingResultAlreadyCompletedSame: newDynamoTask(completeTaskRequest.ID, task.StateAborted,
+			completeTaskRequest.Message, expirationTime),
+		task.CompletingResultAlreadyCompletedDifferent: newDynamoTask(completeTaskRequest.ID, task.StateFinished,
+			nil, expirationTime),
+		task.CompletingResultExpired: newDynamoTask(completeTaskRequest.ID, task.StateCreated,
+			nil, completionTime.Add(-time.Hour)),
+		task.CompletingResultNotFound: {},
+	}
+
+	for expectedResult, existingTask := range existingTasks {
+		completerAndMocks := newTaskCompleterWithMocks()
+		completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
+		transactWriteItemsInput := completerAndMocks.buildCompleteWithChildrenInput(completionTime,
+			task.CompleteWithChildrenRequest{CompleteRequest: completeTaskRequest})
+		completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, transactWriteItemsInput).
+			Return(nil, &dynamodb.TransactionCanceledException{
+				CancellationReasons: []*dynamodb.CancellationReason{
+					{Code: aws.String("ConditionalCheckFailed"), Item: existingTask},
+					{Code: aws.String("None")},
+				},
+			})
+
+		taskCompletionResult, err := completerAndMocks.completer.Complete(context.Background(), completeTaskRequest)
+		assert.NoError(t, err)
+		completerAndMocks.assertExpectations(t)
+		assert.Equal(t, expectedResult, taskCompletionResult)
+	}
+}
+
+func TestTaskCompleter_Complete_ProcessDeadlineExceeded(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	completeTaskRequest := task.CompleteRequest{
+		ID: task.ID{
+			ProcessID: "2",
+			TaskID:    "1",
+		},
+		State: task.StateFinished,
+	}
+	completionTime := time.Now().UTC()
 	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
-	updateItemInput := dynamo.BuildCompleteTaskUpdateItemInput(tasksTableName, dynamo.CompleteTaskRequest{
-		CompletionTime: completionTime,
-		TerminalState:  completeTaskRequest.State,
-		Message:        completeTaskRequest.Message,
-		ProcessID:      completeTaskRequest.ProcessID,
-		TaskID:         completeTaskRequest.TaskID,
-	})
-	updateErr := awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil)
-	completerAndMocks.dynamoAPI.On("UpdateItem", updateItemInput).Return(&dynamodb.UpdateItemOutput{}, updateErr)
+	transactWriteItemsInput := completerAndMocks.buildCompleteWithChildrenInput(completionTime,
+		task.CompleteWithChildrenRequest{CompleteRequest: completeTaskRequest})
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, transactWriteItemsInput).
+		Return(nil, &dynamodb.TransactionCanceledException{
+			CancellationReasons: []*dynamodb.CancellationReason{
+				{Code: aws.String("None")},
+				{
+					Code: aws.String("ConditionalCheckFailed"),
+					Item: map[string]*dynamodb.AttributeValue{
+						dynamo.ProcessDeadlineAttrName: {S: aws.String(completionTime.Add(-time.Minute).Format(time.RFC3339))},
+					},
+				},
+			},
+		})
+
+	taskCompletionResult, err := completerAndMocks.completer.Complete(context.Background(), completeTaskRequest)
+	assert.NoError(t, err)
+	completerAndMocks.assertExpectations(t)
+	assert.Equal(t, task.CompletingResultProcessDeadlineExceeded, taskCompletionResult)
+}
+
+func TestTaskCompleter_Complete_ProcessSummaryNotTracked(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	completeTaskRequest := task.CompleteRequest{
+		ID:    task.ID{ProcessID: "2", TaskID: "1"},
+		State: task.StateFinished,
+	}
+	completionTime := time.Now().UTC()
+	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		completerAndMocks.buildCompleteWithChildrenInput(completionTime,
+			task.CompleteWithChildrenRequest{CompleteRequest: completeTaskRequest})).
+		Return(nil, &dynamodb.TransactionCanceledException{
+			CancellationReasons: []*dynamodb.CancellationReason{
+				{Code: aws.String("None")},
+				{Code: aws.String("ConditionalCheckFailed")},
+			},
+		}).Once()
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildCompleteTasksWithoutSummaryTransactWriteItemsInput(tasksTableName, "2", []dynamo.CompleteTaskRequest{{
+			CompletionTime: completionTime,
+			TerminalState:  task.StateFinished,
+			ProcessID:      "2",
+			TaskID:         "1",
+		}})).
+		Return(&dynamodb.TransactWriteItemsOutput{}, nil).Once()
+
+	taskCompletionResult, err := completerAndMocks.completer.Complete(context.Background(), completeTaskRequest)
+	assert.NoError(t, err)
+	completerAndMocks.assertExpectations(t)
+	assert.Equal(t, task.CompletingResultCompleted, taskCompletionResult)
+}
+
+func TestTaskCompleter_Complete_TransactionConflict(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	completeTaskRequest := task.CompleteRequest{
+		ID:    task.ID{ProcessID: "2", TaskID: "1"},
+		State: task.StateFinished,
+	}
+	completionTime := time.Now().UTC()
+	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
+	transactWriteItemsInput := completerAndMocks.buildCompleteWithChildrenInput(completionTime,
+		task.CompleteWithChildrenRequest{CompleteRequest: completeTaskRequest})
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, transactWriteItemsInput).
+		Return(nil, &dynamodb.TransactionCanceledException{
+			CancellationReasons: []*dynamodb.CancellationReason{
+				{Code: aws.String("None")},
+				{Code: aws.String("TransactionConflict")},
+			},
+		}).Twice()
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, transactWriteItemsInput).
+		Return(&dynamodb.TransactWriteItemsOutput{}, nil).Once()
+
+	taskCompletionResult, err := completerAndMocks.completer.Complete(context.Background(), completeTaskRequest)
+	assert.NoError(t, err)
+	completerAndMocks.assertExpectations(t)
+	assert.Equal(t, task.CompletingResultCompleted, taskCompletionResult)
+}
 
-	taskCompletionResult, err := completerAndMocks.completer.Complete(completeTaskRequest)
+func TestTaskCompleter_Complete_TransactionConflictRetryCanceled(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	completeTaskRequest := task.CompleteRequest{
+		ID:    task.ID{ProcessID: "2", TaskID: "1"},
+		State: task.StateFinished,
+	}
+	completionTime := time.Now().UTC()
+	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
+	ctx, cancel := context.WithCancel(context.Background())
+	transactWriteItemsInput := completerAndMocks.buildCompleteWithChildrenInput(completionTime,
+		task.CompleteWithChildrenRequest{CompleteRequest: completeTaskRequest})
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", ctx, transactWriteItemsInput).
+		Run(func(mock.Arguments) { cancel() }).
+		Return(nil, &dynamodb.TransactionCanceledException{
+			CancellationReasons: []*dynamodb.CancellationReason{{Code: aws.String("TransactionConflict")}},
+		}).Once()
+
+	_, err := completerAndMocks.completer.Complete(ctx, completeTaskRequest)
+	assert.Equal(t, context.Canceled, err)
+	completerAndMocks.assertExpectations(t)
+}
+
+func TestTaskCompleter_Complete_ReservedTaskID(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+
+	taskCompletionResult, err := completerAndMocks.completer.Complete(context.Background(), task.CompleteRequest{
+		ID:    task.ID{ProcessID: "2", TaskID: dynamo.ProcessItemTaskID},
+		State: task.StateFinished,
+	})
 	assert.NoError(t, err)
 	completerAndMocks.assertExpectations(t)
-	assert.Equal(t, task.CompletingResultConflict, taskCompletionResult)
+	assert.Equal(t, task.CompletingResultNotFound, taskCompletionResult)
 }
 
 func TestTaskCompleter_Complete_UnexpectedError(t *testing.T) {
@@ -101,16 +253,427 @@ func TestTaskCompleter_Complete_UnexpectedError(t *testing.T) {
 	}
 	completionTime := time.Now().UTC()
 	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
-	updateItemInput := dynamo.BuildCompleteTaskUpdateItemInput(tasksTableName, dynamo.CompleteTaskRequest{
+	transactWriteItemsInput := completerAndMocks.buildCompleteWithChildrenInput(completionTime,
+		task.CompleteWithChildrenRequest{CompleteRequest: completeTaskRequest})
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, transactWriteItemsInput).
+		Return(nil, errors.New("error"))
+
+	_, err := completerAndMocks.completer.Complete(context.Background(), completeTaskRequest)
+	assert.Error(t, err)
+	completerAndMocks.assertExpectations(t)
+}
+
+func newCompleteWithChildrenRequest(completionTime time.Time) task.CompleteWithChildrenRequest {
+	return task.CompleteWithChildrenRequest{
+		CompleteRequest: task.CompleteRequest{
+			ID: task.ID{
+				ProcessID: "2",
+				TaskID:    "1",
+			},
+			State: task.StateFinished,
+		},
+		Children: []task.RegistrationData{
+			{ID: task.ID{ProcessID: "2", TaskID: "3"}, ExpirationTime: completionTime.Add(time.Hour)},
+			{ID: task.ID{ProcessID: "2", TaskID: "4"}, ExpirationTime: completionTime.Add(time.Hour)},
+		},
+	}
+}
+
+func (completerAndMocks *taskCompleterWithMocks) buildCompleteWithChildrenInput(completionTime time.Time,
+	request task.CompleteWithChildrenRequest) *dynamodb.TransactWriteItemsInput {
+	return completerAndMocks.buildCompleteWithChildrenInputWithSummaryTracking(completionTime, request,
+		dynamo.ProcessSummaryTracked)
+}
+
+func (completerAndMocks *taskCompleterWithMocks) buildCompleteWithChildrenInputWithSummaryTracking(
+	completionTime time.Time, request task.CompleteWithChildrenRequest,
+	summaryTracking dynamo.ProcessSummaryTracking) *dynamodb.TransactWriteItemsInput {
+	children := make([]dynamo.TaskToRegister, 0, len(request.Children))
+	for _, child := range request.Children {
+		children = append(children, dynamo.TaskToRegister{
+			CreationTime:     completionTime,
+			StoringDuration:  completerAndMocks.tasksStoringDuration,
+			RegistrationData: child,
+		})
+	}
+	return dynamo.BuildCompleteTaskWithChildrenTransactWriteItemsInput(tasksTableName, dynamo.CompleteTaskRequest{
 		CompletionTime: completionTime,
-		TerminalState:  completeTaskRequest.State,
-		Message:        completeTaskRequest.Message,
-		ProcessID:      completeTaskRequest.ProcessID,
-		TaskID:         completeTaskRequest.TaskID,
-	})
-	completerAndMocks.dynamoAPI.On("UpdateItem", updateItemInput).Return(&dynamodb.UpdateItemOutput{}, errors.New("error"))
+		TerminalState:  request.State,
+		Message:        request.Message,
+		ProcessID:      request.ProcessID,
+		TaskID:         request.TaskID,
+	}, children, dynamo.EarliestExpirationTimeKept, summaryTracking)
+}
+
+func TestTaskCompleter_CompleteWithChildren(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	completionTime := time.Now().UTC()
+	request := newCompleteWithChildrenRequest(completionTime)
+	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
+	transactWriteItemsInput := completerAndMocks.buildCompleteWithChildrenInput(completionTime, request)
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, transactWriteItemsInput).
+		Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+
+	completingResult, err := completerAndMocks.completer.CompleteWithChildren(context.Background(), request)
+	assert.NoError(t, err)
+	completerAndMocks.assertExpectations(t)
+	assert.Equal(t, task.CompletingResultCompleted, completingResult)
+	assert.Len(t, transactWriteItemsInput.TransactItems, len(request.Children)+2)
+}
+
+func TestTaskCompleter_CompleteWithChildren_ProcessWithPreUpgradeTasks(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	completionTime := time.Now().UTC()
+	request := newCompleteWithChildrenRequest(completionTime)
+	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		completerAndMocks.buildCompleteWithChildrenInput(completionTime, request)).
+		Return(nil, &dynamodb.TransactionCanceledException{
+			CancellationReasons: []*dynamodb.CancellationReason{
+				{Code: aws.String("None")},
+				{Code: aws.String("ConditionalCheckFailed")},
+				{Code: aws.String("None")},
+				{Code: aws.String("None")},
+			},
+		}).Once()
+	untrackedInput := completerAndMocks.buildCompleteWithChildrenInputWithSummaryTracking(completionTime, request,
+		dynamo.ProcessSummaryUntracked)
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, untrackedInput).
+		Return(&dynamodb.TransactWriteItemsOutput{}, nil).Once()
+
+	completingResult, err := completerAndMocks.completer.CompleteWithChildren(context.Background(), request)
+	assert.NoError(t, err)
+	completerAndMocks.assertExpectations(t)
+	assert.Equal(t, task.CompletingResultCompleted, completingResult)
+	assert.NotContains(t, *untrackedInput.TransactItems[1].Update.UpdateExpression, "#summaryRegistrationsCount")
+}
+
+func TestTaskCompleter_CompleteWithChildren_ParentConflict(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	completionTime := time.Now().UTC()
+	request := newCompleteWithChildrenRequest(completionTime)
+	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
+	transactWriteItemsInput := completerAndMocks.buildCompleteWithChildrenInput(completionTime, request)
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, transactWriteItemsInput).
+		Return(nil, &dynamodb.TransactionCanceledException{
+			CancellationReasons: []*dynamodb.CancellationReason{
+				{
+					Code: aws.String("ConditionalCheckFailed"),
+					Item: newDynamoTask(request.ID, request.State, request.Message, completionTime.Add(time.Hour)),
+				},
+				{Code: aws.String("None")},
+				{Code: aws.String("ConditionalCheckFailed")},
+			},
+		})
+
+	completingResult, err := completerAndMocks.completer.CompleteWithChildren(context.Background(), request)
+	assert.NoError(t, err)
+	completerAndMocks.assertExpectations(t)
+	assert.Equal(t, task.CompletingResultAlreadyCompletedSame, completingResult)
+}
+
+func TestTaskCompleter_CompleteWithChildren_ParentNotFound(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	completionTime := time.Now().UTC()
+	request := newCompleteWithChildrenRequest(completionTime)
+	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
+	transactWriteItemsInput := completerAndMocks.buildCompleteWithChildrenInput(completionTime, request)
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, transactWriteItemsInput).
+		Return(nil, &dynamodb.TransactionCanceledException{
+			CancellationReasons: []*dynamodb.CancellationReason{
+				{Code: aws.String("ConditionalCheckFailed")},
+				{Code: aws.String("None")},
+				{Code: aws.String("None")},
+			},
+		})
+
+	completingResult, err := completerAndMocks.completer.CompleteWithChildren(context.Background(), request)
+	assert.NoError(t, err)
+	completerAndMocks.assertExpectations(t)
+	assert.Equal(t, task.CompletingResultNotFound, completingResult)
+}
+
+func TestTaskCompleter_CompleteWithChildren_ProcessSealed(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	completionTime := time.Now().UTC()
+	request := newCompleteWithChildrenRequest(completionTime)
+	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
+	transactWriteItemsInput := completerAndMocks.buildCompleteWithChildrenInput(completionTime, request)
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, transactWriteItemsInput).
+		Return(nil, &dynamodb.TransactionCanceledException{
+			CancellationReasons: []*dynamodb.CancellationReason{
+				{Code: aws.String("None")},
+				{
+					Code: aws.String("ConditionalCheckFailed"),
+					Item: map[string]*dynamodb.AttributeValue{
+						dynamo.ProcessSealedTimeAttrName: {S: aws.String(completionTime.Format(time.RFC3339))},
+					},
+				},
+				{Code: aws.String("None")},
+			},
+		})
+
+	completingResult, err := completerAndMocks.completer.CompleteWithChildren(context.Background(), request)
+	assert.NoError(t, err)
+	completerAndMocks.assertExpectations(t)
+	assert.Equal(t, task.CompletingResultProcessSealed, completingResult)
+}
+
+func TestTaskCompleter_CompleteWithChildren_ChildConflict(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	completionTime := time.Now().UTC()
+	request := newCompleteWithChildrenRequest(completionTime)
+	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
+	transactWriteItemsInput := completerAndMocks.buildCompleteWithChildrenInput(completionTime, request)
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, transactWriteItemsInput).
+		Return(nil, &dynamodb.TransactionCanceledException{
+			CancellationReasons: []*dynamodb.CancellationReason{
+				{Code: aws.String("None")},
+				{Code: aws.String("None")},
+				{Code: aws.String("ConditionalCheckFailed")},
+			},
+		})
+
+	completingResult, err := completerAndMocks.completer.CompleteWithChildren(context.Background(), request)
+	assert.NoError(t, err)
+	completerAndMocks.assertExpectations(t)
+	assert.Equal(t, task.CompletingResultChildConflict, completingResult)
+}
+
+func TestTaskCompleter_CompleteWithChildren_TransactionConflict(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	completionTime := time.Now().UTC()
+	request := newCompleteWithChildrenRequest(completionTime)
+	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
+	transactWriteItemsInput := completerAndMocks.buildCompleteWithChildrenInput(completionTime, request)
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, transactWriteItemsInput).
+		Return(nil, &dynamodb.TransactionCanceledException{
+			CancellationReasons: []*dynamodb.CancellationReason{
+				{Code: aws.String("TransactionConflict")},
+				{Code: aws.String("None")},
+				{Code: aws.String("None")},
+			},
+		})
+
+	_, err := completerAndMocks.completer.CompleteWithChildren(context.Background(), request)
+	assert.Error(t, err)
+	completerAndMocks.assertExpectations(t)
+}
+
+func newCompleteTaskRequests(completionTime time.Time, request task.BatchCompleteRequest) []dynamo.CompleteTaskRequest {
+	completeTaskRequests := make([]dynamo.CompleteTaskRequest, 0, len(request.Tasks))
+	for _, completeRequest := range request.Tasks {
+		completeTaskRequests = append(completeTaskRequests, dynamo.CompleteTaskRequest{
+			CompletionTime: completionTime,
+			TerminalState:  completeRequest.State,
+			Message:        completeRequest.Message,
+			ProcessID:      completeRequest.ProcessID,
+			TaskID:         completeRequest.TaskID,
+		})
+	}
+	return completeTaskRequests
+}
+
+func newBatchCompleteRequest(transactional bool, taskIDs ...string) task.BatchCompleteRequest {
+	request := task.BatchCompleteRequest{ProcessID: "2", Transactional: transactional}
+	for _, taskID := range taskIDs {
+		request.Tasks = append(request.Tasks, task.CompleteRequest{
+			ID:    task.ID{ProcessID: request.ProcessID, TaskID: taskID},
+			State: task.StateFinished,
+		})
+	}
+	return request
+}
+
+func TestTaskCompleter_BatchComplete(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	completionTime := time.Now().UTC()
+	request := newBatchCompleteRequest(false, "1", "2", "3")
+	completeTaskRequests := newCompleteTaskRequests(completionTime, request)
+	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
+	errToReturn := &dynamodb.TransactionCanceledException{
+		CancellationReasons: []*dynamodb.CancellationReason{
+			{Code: aws.String("None")},
+			{
+				Code: aws.String("ConditionalCheckFailed"),
+				Item: newDynamoTask(request.Tasks[1].ID, task.StateFinished, nil, completionTime.Add(time.Hour)),
+			},
+			{Code: aws.String("ConditionalCheckFailed")},
+			{Code: aws.String("None")},
+		},
+	}
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildCompleteTasksTransactWriteItemsInput(tasksTableName, request.ProcessID, completeTaskRequests)).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), errToReturn).Once()
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildCompleteTasksTransactWriteItemsInput(tasksTableName, request.ProcessID, completeTaskRequests[:1])).
+		Return(&dynamodb.TransactWriteItemsOutput{}, nil).Once()
+
+	results, err := completerAndMocks.completer.BatchComplete(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, []task.BatchCompletingResult{
+		{TaskID: "1", Result: task.CompletingResultCompleted},
+		{TaskID: "2", Result: task.CompletingResultAlreadyCompletedSame},
+		{TaskID: "3", Result: task.CompletingResultNotFound},
+	}, results)
+	completerAndMocks.assertExpectations(t)
+}
+
+func TestTaskCompleter_BatchComplete_Transactional(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	completionTime := time.Now().UTC()
+	request := newBatchCompleteRequest(true, "1", "2")
+	completeTaskRequests := newCompleteTaskRequests(completionTime, request)
+	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
+	errToReturn := &dynamodb.TransactionCanceledException{
+		CancellationReasons: []*dynamodb.CancellationReason{
+			{Code: aws.String("None")},
+			{
+				Code: aws.String("ConditionalCheckFailed"),
+				Item: newDynamoTask(request.Tasks[1].ID, task.StateCreated, nil, completionTime.Add(-time.Hour)),
+			},
+			{Code: aws.String("None")},
+		},
+	}
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildCompleteTasksTransactWriteItemsInput(tasksTableName, request.ProcessID, completeTaskRequests)).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), errToReturn).Once()
+
+	results, err := completerAndMocks.completer.BatchComplete(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, []task.BatchCompletingResult{
+		{TaskID: "1", Result: task.CompletingResultCanceled},
+		{TaskID: "2", Result: task.CompletingResultExpired},
+	}, results)
+	completerAndMocks.assertExpectations(t)
+}
+
+func TestTaskCompleter_BatchComplete_ProcessDeadlineExceeded(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	completionTime := time.Now().UTC()
+	request := newBatchCompleteRequest(false, "1", "2")
+	completeTaskRequests := newCompleteTaskRequests(completionTime, request)
+	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
+	errToReturn := &dynamodb.TransactionCanceledException{
+		CancellationReasons: []*dynamodb.CancellationReason{
+			{Code: aws.String("None")},
+			{Code: aws.String("None")},
+			{
+				Code: aws.String("ConditionalCheckFailed"),
+				Item: map[string]*dynamodb.AttributeValue{
+					dynamo.ProcessDeadlineAttrName: {S: aws.String(completionTime.Add(-time.Minute).Format(time.RFC3339))},
+				},
+			},
+		},
+	}
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildCompleteTasksTransactWriteItemsInput(tasksTableName, request.ProcessID, completeTaskRequests)).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), errToReturn).Once()
+
+	results, err := completerAndMocks.completer.BatchComplete(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, []task.BatchCompletingResult{
+		{TaskID: "1", Result: task.CompletingResultProcessDeadlineExceeded},
+		{TaskID: "2", Result: task.CompletingResultProcessDeadlineExceeded},
+	}, results)
+	completerAndMocks.assertExpectations(t)
+}
+
+func TestTaskCompleter_BatchComplete_ProcessSummaryNotTracked(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	completionTime := time.Now().UTC()
+	request := newBatchCompleteRequest(false, "1", "2")
+	completeTaskRequests := newCompleteTaskRequests(completionTime, request)
+	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildCompleteTasksTransactWriteItemsInput(tasksTableName, request.ProcessID, completeTaskRequests)).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), &dynamodb.TransactionCanceledException{
+			CancellationReasons: []*dynamodb.CancellationReason{
+				{Code: aws.String("None")},
+				{Code: aws.String("None")},
+				{Code: aws.String("ConditionalCheckFailed")},
+			},
+		}).Once()
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildCompleteTasksWithoutSummaryTransactWriteItemsInput(tasksTableName, request.ProcessID,
+			completeTaskRequests)).Return(&dynamodb.TransactWriteItemsOutput{}, nil).Once()
 
-	_, err := completerAndMocks.completer.Complete(completeTaskRequest)
+	results, err := completerAndMocks.completer.BatchComplete(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, []task.BatchCompletingResult{
+		{TaskID: "1", Result: task.CompletingResultCompleted},
+		{TaskID: "2", Result: task.CompletingResultCompleted},
+	}, results)
+	completerAndMocks.assertExpectations(t)
+}
+
+func TestTaskCompleter_BatchComplete_LaterChunkFailed(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	completionTime := time.Now().UTC()
+	taskIDs := make([]string, 0, 100)
+	for taskIndex := 0; taskIndex < 100; taskIndex++ {
+		taskIDs = append(taskIDs, strconv.Itoa(taskIndex))
+	}
+	request := newBatchCompleteRequest(false, taskIDs...)
+	completeTaskRequests := newCompleteTaskRequests(completionTime, request)
+	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
+	firstChunkInput := dynamo.BuildCompleteTasksTransactWriteItemsInput(tasksTableName, request.ProcessID,
+		completeTaskRequests[:99])
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, firstChunkInput).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), &dynamodb.TransactionCanceledException{
+			CancellationReasons: []*dynamodb.CancellationReason{{Code: aws.String("TransactionConflict")}},
+		}).Once()
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, firstChunkInput).
+		Return(&dynamodb.TransactWriteItemsOutput{}, nil).Once()
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildCompleteTasksTransactWriteItemsInput(tasksTableName, request.ProcessID, completeTaskRequests[99:])).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), errors.New("throttled")).Once()
+
+	results, err := completerAndMocks.completer.BatchComplete(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Len(t, results, 100)
+	assert.Equal(t, task.BatchCompletingResult{TaskID: "0", Result: task.CompletingResultCompleted}, results[0])
+	assert.Equal(t, task.BatchCompletingResult{TaskID: "99", Result: task.CompletingResultFailed}, results[99])
+	completerAndMocks.assertExpectations(t)
+}
+
+func TestTaskCompleter_BatchComplete_UnexpectedError(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	completionTime := time.Now().UTC()
+	completerAndMocks.currentDateGetter.On("GetCurrentDate").Return(completionTime)
+	completerAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, mock.Anything).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), errors.New("error")).Once()
+
+	_, err := completerAndMocks.completer.BatchComplete(context.Background(), newBatchCompleteRequest(false, "1"))
 	assert.Error(t, err)
 	completerAndMocks.assertExpectations(t)
 }
+
+func TestBuildCompleteInProcessUpdateItemInput_RequiresTrackedSummary(t *testing.T) {
+	updateItemInput := dynamo.BuildCompleteInProcessUpdateItemInput(tasksTableName, dynamo.TasksToCompleteInProcess{
+		ProcessID:          "2",
+		CompletionTime:     time.Now().UTC(),
+		FinishedTasksCount: 1,
+	})
+	assert.Equal(t, "#summaryRegistrationsCount = #registrationsCount and "+
+		"(attribute_not_exists(#deadline) or #deadline > :currentTime)", *updateItemInput.ConditionExpression)
+}
+
+func TestBuildCompleteTasksTransactWriteItemsInput_UpdatesProcessSummary(t *testing.T) {
+	completionTime := time.Now().UTC()
+	transactWriteItemsInput := dynamo.BuildCompleteTasksTransactWriteItemsInput(tasksTableName, "2",
+		[]dynamo.CompleteTaskRequest{
+			{CompletionTime: completionTime, TerminalState: task.StateFinished, ProcessID: "2", TaskID: "1"},
+			{CompletionTime: completionTime, TerminalState: task.StateAborted, ProcessID: "2", TaskID: "3"},
+			{CompletionTime: completionTime, TerminalState: task.StateFinished, ProcessID: "2", TaskID: "4"},
+		})
+
+	processItemUpdate := transactWriteItemsInput.TransactItems[3].Update
+	assert.Equal(t, "ADD #openTasksCount :openTasksCountIncrement, #finishedTasksCount :finishedTasksCountIncrement, "+
+		"#abortedTasksCount :abortedTasksCountIncrement", *processItemUpdate.UpdateExpression)
+	assert.Equal(t, aws.String("-3"), processItemUpdate.ExpressionAttributeValues[":openTasksCountIncrement"].N)
+	assert.Equal(t, aws.String("2"), processItemUpdate.ExpressionAttributeValues[":finishedTasksCountIncrement"].N)
+	assert.Equal(t, aws.String("1"), processItemUpdate.ExpressionAttributeValues[":abortedTasksCountIncrement"].N)
+	assert.Equal(t, aws.String(dynamodb.ReturnValuesOnConditionCheckFailureAllOld),
+		processItemUpdate.ReturnValuesOnConditionCheckFailure)
+}
@@ -0,0 +1,51 @@
+package dynamo
+
+import (
+	"context"
+
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+type TaskGetter struct {
+	dynamoAPI         dynamodbiface.DynamoDBAPI
+	tasksTableName    string
+	currentDateGetter currentDateGetter
+}
+
+func NewTaskGetter(dynamoAPI dynamodbiface.DynamoDBAPI, tasksTableName string,
+	currentDateGetter currentDateGetter) *TaskGetter {
+	return &TaskGetter{
+		dynamoAPI:         dynamoAPI,
+		tasksTableName:    tasksTableName,
+		currentDateGetter: currentDateGetter,
+	}
+}
+
+func (getter *TaskGetter) GetTask(ctx context.Context, id task.ID) (*task.Task, error) {
+	if task.IsReservedTaskID(id.TaskID) {
+		return nil, nil
+	}
+	out, err := getter.dynamoAPI.GetItemWithContext(ctx, BuildGetTaskInput(getter.tasksTableName, id))
+	if err != nil || out == nil || len(out.Item) == 0 {
+		return nil, err
+	}
+	foundTask, err := readTask(id.ProcessID, out.Item, getter.currentDateGetter.GetCurrentDate())
+	if err != nil {
+		return nil, err
+	}
+	return &foundTask, nil
+}
+
+func BuildGetTaskInput(tableName string, id task.ID) *dynamodb.GetItemInput {
+	return &dynamodb.GetItemInput{
+		ConsistentRead: aws.Bool(true),
+		Key: map[string]*dynamodb.AttributeValue{
+			ProcessIDAttrName: {S: aws.String(id.ProcessID)},
+			TaskIDAttrName:    {S: aws.String(id.TaskID)},
+		},
+		TableName: &tableName,
+	}
+}
@@ -0,0 +1,119 @@
+package dynamo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/dynamo"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type taskGetterWithMocks struct {
+	getter            *dynamo.TaskGetter
+	dynamoAPI         *dynamoAPIMock
+	currentDateGetter *currentDateGetterMock
+	currentDate       time.Time
+	taskID            task.ID
+}
+
+func (getterAndMocks *taskGetterWithMocks) assertExpectations(t *testing.T) {
+	getterAndMocks.dynamoAPI.AssertExpectations(t)
+}
+
+func newTaskGetterWithMocks() *taskGetterWithMocks {
+	dynamoAPI := new(dynamoAPIMock)
+	currentDateGetter := new(currentDateGetterMock)
+	currentDate := time.Now().UTC().Truncate(time.Second)
+	currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	return &taskGetterWithMocks{
+		getter:            dynamo.NewTaskGetter(dynamoAPI, tasksTableName, currentDateGetter),
+		dynamoAPI:         dynamoAPI,
+		currentDateGetter: currentDateGetter,
+		currentDate:       currentDate,
+		taskID:            task.ID{ProcessID: "1", TaskID: "2"},
+	}
+}
+
+func TestTaskGetter_GetTask(t *testing.T) {
+	getterAndMocks := newTaskGetterWithMocks()
+	creationTime := getterAndMocks.currentDate.Add(-time.Hour)
+	expirationTime := getterAndMocks.currentDate.Add(time.Hour)
+	getterAndMocks.dynamoAPI.On("GetItemWithContext", mock.Anything, dynamo.BuildGetTaskInput(tasksTableName, getterAndMocks.taskID)).
+		Return(&dynamodb.GetItemOutput{Item: map[string]*dynamodb.AttributeValue{
+			dynamo.ProcessIDAttrName:        {S: aws.String(getterAndMocks.taskID.ProcessID)},
+			dynamo.TaskIDAttrName:           {S: aws.String(getterAndMocks.taskID.TaskID)},
+			dynamo.TaskStateAttrName:        {S: aws.String(string(task.StateAborted))},
+			dynamo.TaskStateMessageAttrName: {S: aws.String("failure")},
+			"expiration_time":               {S: aws.String(expirationTime.Format(time.RFC3339))},
+			"creation_time":                 {S: aws.String(creationTime.Format(time.RFC3339))},
+		}}, nil)
+
+	foundTask, err := getterAndMocks.getter.GetTask(context.Background(), getterAndMocks.taskID)
+	assert.NoError(t, err)
+	getterAndMocks.assertExpectations(t)
+	assert.Equal(t, &task.Task{
+		ID:             getterAndMocks.taskID,
+		State:          task.StateAborted,
+		StateMessage:   aws.String("failure"),
+		ExpirationTime: expirationTime,
+		CreationTime:   creationTime,
+	}, foundTask)
+}
+
+func TestTaskGetter_GetTask_TimedOut(t *testing.T) {
+	getterAndMocks := newTaskGetterWithMocks()
+	expirationTime := getterAndMocks.currentDate.Add(-time.Hour)
+	getterAndMocks.dynamoAPI.On("GetItemWithContext", mock.Anything, dynamo.BuildGetTaskInput(tasksTableName, getterAndMocks.taskID)).
+		Return(&dynamodb.GetItemOutput{Item: map[string]*dynamodb.AttributeValue{
+			dynamo.ProcessIDAttrName: {S: aws.String(getterAndMocks.taskID.ProcessID)},
+			dynamo.TaskIDAttrName:    {S: aws.String(getterAndMocks.taskID.TaskID)},
+			dynamo.TaskStateAttrName: {S: aws.String(string(task.StateCreated))},
+			"expiration_time":        {S: aws.String(expirationTime.Format(time.RFC3339))},
+		}}, nil)
+
+	foundTask, err := getterAndMocks.getter.GetTask(context.Background(), getterAndMocks.taskID)
+	assert.NoError(t, err)
+	getterAndMocks.assertExpectations(t)
+	assert.Equal(t, &task.Task{
+		ID:             getterAndMocks.taskID,
+		State:          task.StateCreated,
+		ExpirationTime: expirationTime,
+		TimedOut:       true,
+	}, foundTask)
+}
+
+func TestTaskGetter_GetTask_NotFound(t *testing.T) {
+	getterAndMocks := newTaskGetterWithMocks()
+	getterAndMocks.dynamoAPI.On("GetItemWithContext", mock.Anything, dynamo.BuildGetTaskInput(tasksTableName, getterAndMocks.taskID)).
+		Return(&dynamodb.GetItemOutput{}, nil)
+
+	foundTask, err := getterAndMocks.getter.GetTask(context.Background(), getterAndMocks.taskID)
+	assert.NoError(t, err)
+	getterAndMocks.assertExpectations(t)
+	assert.Nil(t, foundTask)
+}
+
+func TestTaskGetter_GetTask_ReservedID(t *testing.T) {
+	getterAndMocks := newTaskGetterWithMocks()
+
+	foundTask, err := getterAndMocks.getter.GetTask(context.Background(), task.ID{ProcessID: "1", TaskID: dynamo.ProcessItemTaskID})
+	assert.NoError(t, err)
+	getterAndMocks.assertExpectations(t)
+	assert.Nil(t, foundTask)
+}
+
+func TestTaskGetter_GetTask_GetItemError(t *testing.T) {
+	getterAndMocks := newTaskGetterWithMocks()
+	getterAndMocks.dynamoAPI.On("GetItemWithContext", mock.Anything, dynamo.BuildGetTaskInput(tasksTableName, getterAndMocks.taskID)).
+		Return(nil, errors.New("error"))
+
+	_, err := getterAndMocks.getter.GetTask(context.Background(), getterAndMocks.taskID)
+	assert.Error(t, err)
+	getterAndMocks.assertExpectations(t)
+}
@@ -0,0 +1,141 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+var (
+	heartbeatTaskUpdateExpr = fmt.Sprintf("SET %s = %s, %s = %s",
+		taskExpirationTimeAttrAlias, taskExpirationTimeValuePlaceholder,
+		taskBadStateEnterTimeAttrAlias, taskBadStateEnterTimeValuePlaceholder)
+	extendTaskConditionExpr = fmt.Sprintf("%s and %s <= %s", completeTaskConditionExpr,
+		taskExpirationTimeAttrAlias, taskExpirationTimeValuePlaceholder)
+)
+
+type TaskHeartbeater struct {
+	dynamoAPI         dynamodbiface.DynamoDBAPI
+	tasksTableName    string
+	currentDateGetter currentDateGetter
+}
+
+func NewTaskHeartbeater(dynamoAPI dynamodbiface.DynamoDBAPI, tasksTableName string,
+	currentDateGetter currentDateGetter) *TaskHeartbeater {
+	return &TaskHeartbeater{
+		dynamoAPI:         dynamoAPI,
+		tasksTableName:    tasksTableName,
+		currentDateGetter: currentDateGetter,
+	}
+}
+
+func (heartbeater *TaskHeartbeater) Heartbeat(ctx context.Context,
+	request task.HeartbeatRequest) (task.HeartbeatResult, error) {
+	heartbeatTaskRequest := HeartbeatTaskRequest{
+		HeartbeatTime:  heartbeater.currentDateGetter.GetCurrentDate(),
+		ExpirationTime: request.ExpirationTime,
+		ProcessID:      request.ProcessID,
+		TaskID:         request.TaskID,
+	}
+	_, err := heartbeater.dynamoAPI.UpdateItemWithContext(ctx,
+		BuildExtendTaskUpdateItemInput(heartbeater.tasksTableName, heartbeatTaskRequest))
+	if err == nil {
+		return task.HeartbeatResultExtended, nil
+	}
+	if awsErr, isAWSErr := err.(awserr.Error); !isAWSErr || awsErr.Code() != dynamodb.ErrCodeConditionalCheckFailedException {
+		return "", err
+	}
+	return heartbeater.shorten(ctx, heartbeatTaskRequest)
+}
+
+func (heartbeater *TaskHeartbeater) shorten(ctx context.Context,
+	heartbeatTaskRequest HeartbeatTaskRequest) (task.HeartbeatResult, error) {
+	_, err := heartbeater.dynamoAPI.TransactWriteItemsWithContext(ctx,
+		BuildShortenTaskTransactWriteItemsInput(heartbeater.tasksTableName, heartbeatTaskRequest))
+	if err == nil {
+		return task.HeartbeatResultExtended, nil
+	}
+	canceledErr, isCanceledErr := err.(*dynamodb.TransactionCanceledException)
+	if !isCanceledErr {
+		return "", err
+	}
+	reasons := canceledErr.CancellationReasons
+	if len(reasons) > 0 && isConditionalCheckFailed(reasons[0]) {
+		return task.HeartbeatResultConflict, nil
+	}
+	if len(reasons) > 1 && isConditionalCheckFailed(reasons[1]) {
+		return heartbeater.shortenWithoutProcessItem(ctx, heartbeatTaskRequest)
+	}
+	return "", canceledErr
+}
+
+func (heartbeater *TaskHeartbeater) shortenWithoutProcessItem(ctx context.Context,
+	heartbeatTaskRequest HeartbeatTaskRequest) (task.HeartbeatResult, error) {
+	_, err := heartbeater.dynamoAPI.UpdateItemWithContext(ctx,
+		BuildHeartbeatTaskUpdateItemInput(heartbeater.tasksTableName, heartbeatTaskRequest))
+	if err != nil {
+		if awsErr, isAWSErr := err.(awserr.Error); isAWSErr && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return task.HeartbeatResultConflict, nil
+		}
+		return "", err
+	}
+	return task.HeartbeatResultExtended, nil
+}
+
+type HeartbeatTaskRequest struct {
+	HeartbeatTime  time.Time
+	ExpirationTime time.Time
+	ProcessID      string
+	TaskID         string
+}
+
+func BuildHeartbeatTaskUpdateItemInput(tableName string, request HeartbeatTaskRequest) *dynamodb.UpdateItemInput {
+	expirationTimeString := request.ExpirationTime.Format(time.RFC3339)
+	return &dynamodb.UpdateItemInput{
+		ConditionExpression: &completeTaskConditionExpr,
+		ExpressionAttributeNames: map[string]*string{
+			ProcessIDAttrAlias:             aws.String(ProcessIDAttrName),
+			taskIDAttrAlias:                aws.String(TaskIDAttrName),
+			taskExpirationTimeAttrAlias:    aws.String(taskExpirationTimeAttrName),
+			taskStateAttrAlias:             aws.String(TaskStateAttrName),
+			taskBadStateEnterTimeAttrAlias: aws.String(TaskBadStateEnterTimeAttrName),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			currentTimeValuePlaceholder:           {S: aws.String(request.HeartbeatTime.Format(time.RFC3339))},
+			taskStateCreatedValuePlaceholder:      {S: aws.String(string(task.StateCreated))},
+			taskExpirationTimeValuePlaceholder:    {S: &expirationTimeString},
+			taskBadStateEnterTimeValuePlaceholder: {S: &expirationTimeString},
+		},
+		Key: map[string]*dynamodb.AttributeValue{
+			ProcessIDAttrName: {S: &request.ProcessID},
+			TaskIDAttrName:    {S: &request.TaskID},
+		},
+		TableName:        &tableName,
+		UpdateExpression: &heartbeatTaskUpdateExpr,
+	}
+}
+
+func BuildExtendTaskUpdateItemInput(tableName string, request HeartbeatTaskRequest) *dynamodb.UpdateItemInput {
+	updateItemInput := BuildHeartbeatTaskUpdateItemInput(tableName, request)
+	updateItemInput.ConditionExpression = &extendTaskConditionExpr
+	return updateItemInput
+}
+
+func BuildShortenTaskTransactWriteItemsInput(tableName string,
+	request HeartbeatTaskRequest) *dynamodb.TransactWriteItemsInput {
+	return &dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			newTransactUpdate(BuildHeartbeatTaskUpdateItemInput(tableName, request)),
+			newTransactUpdate(BuildLowerEarliestExpirationTimeUpdateItemInput(tableName, EarliestExpirationTimeToLower{
+				ProcessID:              request.ProcessID,
+				EarliestExpirationTime: request.ExpirationTime,
+			})),
+		},
+	}
+}
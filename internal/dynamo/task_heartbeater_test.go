@@ -0,0 +1,139 @@
+package dynamo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/dynamo"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type taskHeartbeaterWithMocks struct {
+	heartbeater       *dynamo.TaskHeartbeater
+	dynamoAPI         *dynamoAPIMock
+	currentDateGetter *currentDateGetterMock
+	heartbeatTime     time.Time
+	request           task.HeartbeatRequest
+}
+
+func (heartbeaterAndMocks *taskHeartbeaterWithMocks) assertExpectations(t *testing.T) {
+	heartbeaterAndMocks.dynamoAPI.AssertExpectations(t)
+	heartbeaterAndMocks.currentDateGetter.AssertExpectations(t)
+}
+
+func (heartbeaterAndMocks *taskHeartbeaterWithMocks) buildHeartbeatTaskRequest() dynamo.HeartbeatTaskRequest {
+	return dynamo.HeartbeatTaskRequest{
+		HeartbeatTime:  heartbeaterAndMocks.heartbeatTime,
+		ExpirationTime: heartbeaterAndMocks.request.ExpirationTime,
+		ProcessID:      heartbeaterAndMocks.request.ProcessID,
+		TaskID:         heartbeaterAndMocks.request.TaskID,
+	}
+}
+
+func (heartbeaterAndMocks *taskHeartbeaterWithMocks) buildUpdateItemInput() *dynamodb.UpdateItemInput {
+	return dynamo.BuildExtendTaskUpdateItemInput(tasksTableName, heartbeaterAndMocks.buildHeartbeatTaskRequest())
+}
+
+func (heartbeaterAndMocks *taskHeartbeaterWithMocks) mockNotExtended() {
+	updateErr := awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil)
+	heartbeaterAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything, heartbeaterAndMocks.buildUpdateItemInput()).
+		Return((*dynamodb.UpdateItemOutput)(nil), updateErr)
+}
+
+func (heartbeaterAndMocks *taskHeartbeaterWithMocks) mockShortening(err error) {
+	transactWriteItemsInput := dynamo.BuildShortenTaskTransactWriteItemsInput(tasksTableName,
+		heartbeaterAndMocks.buildHeartbeatTaskRequest())
+	heartbeaterAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, transactWriteItemsInput).
+		Return(&dynamodb.TransactWriteItemsOutput{}, err)
+}
+
+func newTaskHeartbeaterWithMocks() *taskHeartbeaterWithMocks {
+	dynamoAPI := new(dynamoAPIMock)
+	currentDateGetter := new(currentDateGetterMock)
+	heartbeatTime := time.Now().UTC()
+	currentDateGetter.On("GetCurrentDate").Return(heartbeatTime)
+	return &taskHeartbeaterWithMocks{
+		heartbeater:       dynamo.NewTaskHeartbeater(dynamoAPI, tasksTableName, currentDateGetter),
+		dynamoAPI:         dynamoAPI,
+		currentDateGetter: currentDateGetter,
+		heartbeatTime:     heartbeatTime,
+		request: task.HeartbeatRequest{
+			ID:             task.ID{ProcessID: "2", TaskID: "1"},
+			ExpirationTime: heartbeatTime.Add(time.Hour),
+		},
+	}
+}
+
+func TestTaskHeartbeater_Heartbeat(t *testing.T) {
+	heartbeaterAndMocks := newTaskHeartbeaterWithMocks()
+	heartbeaterAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything, heartbeaterAndMocks.buildUpdateItemInput()).
+		Return(&dynamodb.UpdateItemOutput{}, nil)
+
+	heartbeatResult, err := heartbeaterAndMocks.heartbeater.Heartbeat(context.Background(), heartbeaterAndMocks.request)
+	assert.NoError(t, err)
+	heartbeaterAndMocks.assertExpectations(t)
+	assert.Equal(t, task.HeartbeatResultExtended, heartbeatResult)
+}
+
+func TestTaskHeartbeater_Heartbeat_Shortened(t *testing.T) {
+	heartbeaterAndMocks := newTaskHeartbeaterWithMocks()
+	heartbeaterAndMocks.mockNotExtended()
+	heartbeaterAndMocks.mockShortening(nil)
+
+	heartbeatResult, err := heartbeaterAndMocks.heartbeater.Heartbeat(context.Background(), heartbeaterAndMocks.request)
+	assert.NoError(t, err)
+	heartbeaterAndMocks.assertExpectations(t)
+	assert.Equal(t, task.HeartbeatResultExtended, heartbeatResult)
+}
+
+func TestTaskHeartbeater_Heartbeat_ShortenedWithoutProcessItem(t *testing.T) {
+	heartbeaterAndMocks := newTaskHeartbeaterWithMocks()
+	heartbeaterAndMocks.mockNotExtended()
+	heartbeaterAndMocks.mockShortening(&dynamodb.TransactionCanceledException{
+		CancellationReasons: []*dynamodb.CancellationReason{
+			{Code: aws.String("None")},
+			{Code: aws.String("ConditionalCheckFailed")},
+		},
+	})
+	heartbeaterAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything,
+		dynamo.BuildHeartbeatTaskUpdateItemInput(tasksTableName, heartbeaterAndMocks.buildHeartbeatTaskRequest())).
+		Return(&dynamodb.UpdateItemOutput{}, nil)
+
+	heartbeatResult, err := heartbeaterAndMocks.heartbeater.Heartbeat(context.Background(), heartbeaterAndMocks.request)
+	assert.NoError(t, err)
+	heartbeaterAndMocks.assertExpectations(t)
+	assert.Equal(t, task.HeartbeatResultExtended, heartbeatResult)
+}
+
+func TestTaskHeartbeater_Heartbeat_Conflict(t *testing.T) {
+	heartbeaterAndMocks := newTaskHeartbeaterWithMocks()
+	heartbeaterAndMocks.mockNotExtended()
+	heartbeaterAndMocks.mockShortening(&dynamodb.TransactionCanceledException{
+		CancellationReasons: []*dynamodb.CancellationReason{
+			{Code: aws.String("ConditionalCheckFailed")},
+			{Code: aws.String("None")},
+		},
+	})
+
+	heartbeatResult, err := heartbeaterAndMocks.heartbeater.Heartbeat(context.Background(), heartbeaterAndMocks.request)
+	assert.NoError(t, err)
+	heartbeaterAndMocks.assertExpectations(t)
+	assert.Equal(t, task.HeartbeatResultConflict, heartbeatResult)
+}
+
+func TestTaskHeartbeater_Heartbeat_UnexpectedError(t *testing.T) {
+	heartbeaterAndMocks := newTaskHeartbeaterWithMocks()
+	heartbeaterAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything, heartbeaterAndMocks.buildUpdateItemInput()).
+		Return((*dynamodb.UpdateItemOutput)(nil), errors.New("error"))
+
+	_, err := heartbeaterAndMocks.heartbeater.Heartbeat(context.Background(), heartbeaterAndMocks.request)
+	assert.Error(t, err)
+	heartbeaterAndMocks.assertExpectations(t)
+}
@@ -0,0 +1,117 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+const taskStateValuePlaceholder = ":state"
+
+var (
+	listTasksFilterExpr        = fmt.Sprintf("attribute_exists(%s)", taskStateAttrAlias)
+	listTasksInStateFilterExpr = fmt.Sprintf("%s = %s", taskStateAttrAlias, taskStateValuePlaceholder)
+	listTasksKeyCondExpr       = fmt.Sprintf("%s = %s", ProcessIDAttrAlias, ProcessIDValuePlaceholder)
+)
+
+type TaskLister struct {
+	dynamoAPI         dynamodbiface.DynamoDBAPI
+	tasksTableName    string
+	currentDateGetter currentDateGetter
+}
+
+func NewTaskLister(dynamoAPI dynamodbiface.DynamoDBAPI, tasksTableName string,
+	currentDateGetter currentDateGetter) *TaskLister {
+	return &TaskLister{
+		dynamoAPI:         dynamoAPI,
+		tasksTableName:    tasksTableName,
+		currentDateGetter: currentDateGetter,
+	}
+}
+
+func (lister *TaskLister) List(ctx context.Context, request task.ListRequest) (task.List, error) {
+	listTasksRequest := ListTasksRequest{
+		ProcessID: request.ProcessID,
+		State:     request.State,
+	}
+	if request.Cursor != "" {
+		lastTaskID, err := task.DecodeListCursor(request.Cursor)
+		if err != nil {
+			return task.List{}, err
+		}
+		listTasksRequest.LastTaskID = &lastTaskID
+	}
+
+	currentTime := lister.currentDateGetter.GetCurrentDate()
+	tasksList := task.List{Tasks: []task.Task{}}
+	for {
+		listTasksRequest.Limit = request.Limit - len(tasksList.Tasks)
+		out, err := lister.dynamoAPI.QueryWithContext(ctx, BuildListTasksQueryInput(lister.tasksTableName, listTasksRequest))
+		if err != nil {
+			return task.List{}, err
+		}
+		if out == nil {
+			return tasksList, nil
+		}
+		for _, dynamoTask := range out.Items {
+			listedTask, err := readTask(request.ProcessID, dynamoTask, currentTime)
+			if err != nil {
+				return task.List{}, err
+			}
+			tasksList.Tasks = append(tasksList.Tasks, listedTask)
+		}
+		if len(out.LastEvaluatedKey) == 0 {
+			return tasksList, nil
+		}
+		lastTaskID, err := readTaskID(out.LastEvaluatedKey)
+		if err != nil {
+			return task.List{}, err
+		}
+		if len(tasksList.Tasks) >= request.Limit {
+			tasksList.NextCursor = task.EncodeListCursor(lastTaskID)
+			return tasksList, nil
+		}
+		listTasksRequest.LastTaskID = &lastTaskID
+	}
+}
+
+type ListTasksRequest struct {
+	ProcessID  string
+	State      *task.State
+	LastTaskID *string
+	Limit      int
+}
+
+func BuildListTasksQueryInput(tableName string, request ListTasksRequest) *dynamodb.QueryInput {
+	queryInput := &dynamodb.QueryInput{
+		ConsistentRead: aws.Bool(true),
+		ExpressionAttributeNames: map[string]*string{
+			ProcessIDAttrAlias: aws.String(ProcessIDAttrName),
+			taskStateAttrAlias: aws.String(TaskStateAttrName),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			ProcessIDValuePlaceholder: {S: aws.String(request.ProcessID)},
+		},
+		FilterExpression:       &listTasksFilterExpr,
+		KeyConditionExpression: &listTasksKeyCondExpr,
+		Limit:                  aws.Int64(int64(request.Limit)),
+		TableName:              &tableName,
+	}
+	if request.State != nil {
+		queryInput.FilterExpression = &listTasksInStateFilterExpr
+		queryInput.ExpressionAttributeValues[taskStateValuePlaceholder] = &dynamodb.AttributeValue{
+			S: aws.String(string(*request.State)),
+		}
+	}
+	if request.LastTaskID != nil {
+		queryInput.ExclusiveStartKey = map[string]*dynamodb.AttributeValue{
+			ProcessIDAttrName: {S: aws.String(request.ProcessID)},
+			TaskIDAttrName:    {S: request.LastTaskID},
+		}
+	}
+	return queryInput
+}
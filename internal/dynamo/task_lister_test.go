@@ -0,0 +1,151 @@
+package dynamo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/dynamo"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type taskListerWithMocks struct {
+	lister            *dynamo.TaskLister
+	dynamoAPI         *dynamoAPIMock
+	currentDateGetter *currentDateGetterMock
+	currentDate       time.Time
+}
+
+func (listerAndMocks *taskListerWithMocks) assertExpectations(t *testing.T) {
+	listerAndMocks.dynamoAPI.AssertExpectations(t)
+}
+
+func newTaskListerWithMocks() *taskListerWithMocks {
+	dynamoAPI := new(dynamoAPIMock)
+	currentDateGetter := new(currentDateGetterMock)
+	currentDate := time.Now().UTC()
+	currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	return &taskListerWithMocks{
+		lister:            dynamo.NewTaskLister(dynamoAPI, tasksTableName, currentDateGetter),
+		dynamoAPI:         dynamoAPI,
+		currentDateGetter: currentDateGetter,
+		currentDate:       currentDate,
+	}
+}
+
+func buildDynamoListedTask(taskID string, state task.State, expirationTime time.Time) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		dynamo.ProcessIDAttrName: {S: aws.String("1")},
+		dynamo.TaskIDAttrName:    {S: aws.String(taskID)},
+		dynamo.TaskStateAttrName: {S: aws.String(string(state))},
+		"expiration_time":        {S: aws.String(expirationTime.Format(time.RFC3339))},
+	}
+}
+
+func TestTaskLister_List(t *testing.T) {
+	listerAndMocks := newTaskListerWithMocks()
+	expirationTime := listerAndMocks.currentDate.Add(time.Hour).Truncate(time.Second)
+	listerAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, dynamo.BuildListTasksQueryInput(tasksTableName, dynamo.ListTasksRequest{
+		ProcessID: "1",
+		Limit:     10,
+	})).Return(&dynamodb.QueryOutput{Items: []map[string]*dynamodb.AttributeValue{
+		buildDynamoListedTask("1", task.StateCreated, expirationTime),
+		buildDynamoListedTask("2", task.StateFinished, expirationTime),
+	}}, nil)
+
+	tasksList, err := listerAndMocks.lister.List(context.Background(), task.ListRequest{ProcessID: "1", Limit: 10})
+	assert.NoError(t, err)
+	listerAndMocks.assertExpectations(t)
+	assert.Equal(t, task.List{Tasks: []task.Task{
+		{ID: task.ID{ProcessID: "1", TaskID: "1"}, State: task.StateCreated, ExpirationTime: expirationTime},
+		{ID: task.ID{ProcessID: "1", TaskID: "2"}, State: task.StateFinished, ExpirationTime: expirationTime},
+	}}, tasksList)
+}
+
+func TestTaskLister_List_NextPage(t *testing.T) {
+	listerAndMocks := newTaskListerWithMocks()
+	expirationTime := listerAndMocks.currentDate.Add(time.Hour).Truncate(time.Second)
+	state := task.StateCreated
+	listerAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, dynamo.BuildListTasksQueryInput(tasksTableName, dynamo.ListTasksRequest{
+		ProcessID:  "1",
+		State:      &state,
+		LastTaskID: aws.String("1"),
+		Limit:      1,
+	})).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			buildDynamoListedTask("2", task.StateCreated, expirationTime),
+		},
+		LastEvaluatedKey: map[string]*dynamodb.AttributeValue{
+			dynamo.ProcessIDAttrName: {S: aws.String("1")},
+			dynamo.TaskIDAttrName:    {S: aws.String("2")},
+		},
+	}, nil)
+
+	tasksList, err := listerAndMocks.lister.List(context.Background(), task.ListRequest{
+		ProcessID: "1",
+		State:     &state,
+		Cursor:    task.EncodeListCursor("1"),
+		Limit:     1,
+	})
+	assert.NoError(t, err)
+	listerAndMocks.assertExpectations(t)
+	assert.Equal(t, task.List{
+		Tasks: []task.Task{
+			{ID: task.ID{ProcessID: "1", TaskID: "2"}, State: task.StateCreated, ExpirationTime: expirationTime},
+		},
+		NextCursor: task.EncodeListCursor("2"),
+	}, tasksList)
+}
+
+func TestTaskLister_List_PageFilteredOut(t *testing.T) {
+	listerAndMocks := newTaskListerWithMocks()
+	expirationTime := listerAndMocks.currentDate.Add(time.Hour).Truncate(time.Second)
+	listerAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, dynamo.BuildListTasksQueryInput(tasksTableName, dynamo.ListTasksRequest{
+		ProcessID: "1",
+		Limit:     1,
+	})).Return(&dynamodb.QueryOutput{
+		LastEvaluatedKey: map[string]*dynamodb.AttributeValue{
+			dynamo.ProcessIDAttrName: {S: aws.String("1")},
+			dynamo.TaskIDAttrName:    {S: aws.String(dynamo.ProcessItemTaskID)},
+		},
+	}, nil).Once()
+	listerAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, dynamo.BuildListTasksQueryInput(tasksTableName, dynamo.ListTasksRequest{
+		ProcessID:  "1",
+		LastTaskID: aws.String(dynamo.ProcessItemTaskID),
+		Limit:      1,
+	})).Return(&dynamodb.QueryOutput{Items: []map[string]*dynamodb.AttributeValue{
+		buildDynamoListedTask("1", task.StateAborted, expirationTime),
+	}}, nil).Once()
+
+	tasksList, err := listerAndMocks.lister.List(context.Background(), task.ListRequest{ProcessID: "1", Limit: 1})
+	assert.NoError(t, err)
+	listerAndMocks.assertExpectations(t)
+	assert.Equal(t, task.List{Tasks: []task.Task{
+		{ID: task.ID{ProcessID: "1", TaskID: "1"}, State: task.StateAborted, ExpirationTime: expirationTime},
+	}}, tasksList)
+}
+
+func TestTaskLister_List_InvalidCursor(t *testing.T) {
+	listerAndMocks := newTaskListerWithMocks()
+
+	_, err := listerAndMocks.lister.List(context.Background(), task.ListRequest{ProcessID: "1", Cursor: "!", Limit: 1})
+	assert.Equal(t, task.ErrInvalidCursor, err)
+	listerAndMocks.assertExpectations(t)
+}
+
+func TestTaskLister_List_QueryError(t *testing.T) {
+	listerAndMocks := newTaskListerWithMocks()
+	listerAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, dynamo.BuildListTasksQueryInput(tasksTableName, dynamo.ListTasksRequest{
+		ProcessID: "1",
+		Limit:     1,
+	})).Return(0, errors.New("error"))
+
+	_, err := listerAndMocks.lister.List(context.Background(), task.ListRequest{ProcessID: "1", Limit: 1})
+	assert.Error(t, err)
+	listerAndMocks.assertExpectations(t)
+}
@@ -0,0 +1,107 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+var (
+	backfillTaskReapShardScanProjectionExpr = fmt.Sprintf("%s, %s", ProcessIDAttrAlias, taskIDAttrAlias)
+	unshardedCreatedTaskConditionExpr       = fmt.Sprintf("%s = %s and attribute_not_exists(%s)", taskStateAttrAlias,
+		taskStateCreatedValuePlaceholder, taskReapShardAttrAlias)
+	backfillTaskReapShardUpdateExpr = fmt.Sprintf("SET %s = %s", taskReapShardAttrAlias,
+		taskReapShardValuePlaceholder)
+)
+
+type TaskReapShardBackfiller struct {
+	dynamoAPI      dynamodbiface.DynamoDBAPI
+	tasksTableName string
+}
+
+func NewTaskReapShardBackfiller(dynamoAPI dynamodbiface.DynamoDBAPI, tasksTableName string) *TaskReapShardBackfiller {
+	return &TaskReapShardBackfiller{
+		dynamoAPI:      dynamoAPI,
+		tasksTableName: tasksTableName,
+	}
+}
+
+func (backfiller *TaskReapShardBackfiller) Backfill(ctx context.Context) (int, error) {
+	var exclusiveStartKey map[string]*dynamodb.AttributeValue
+	backfilledTasksCount := 0
+	for {
+		out, err := backfiller.dynamoAPI.ScanWithContext(ctx,
+			BuildBackfillTaskReapShardScanInput(backfiller.tasksTableName, exclusiveStartKey))
+		if err != nil {
+			return backfilledTasksCount, err
+		}
+		if out == nil {
+			return backfilledTasksCount, nil
+		}
+		for _, item := range out.Items {
+			taskID, err := readTaskKey(item)
+			if err != nil {
+				return backfilledTasksCount, err
+			}
+			if taskID.TaskID == ProcessItemTaskID {
+				continue
+			}
+			_, err = backfiller.dynamoAPI.UpdateItemWithContext(ctx,
+				BuildBackfillTaskReapShardUpdateItemInput(backfiller.tasksTableName, taskID))
+			if isConditionalCheckFailedError(err) {
+				continue
+			}
+			if err != nil {
+				return backfilledTasksCount, err
+			}
+			backfilledTasksCount++
+		}
+		if len(out.LastEvaluatedKey) == 0 {
+			return backfilledTasksCount, nil
+		}
+		exclusiveStartKey = out.LastEvaluatedKey
+	}
+}
+
+func BuildBackfillTaskReapShardScanInput(tableName string,
+	exclusiveStartKey map[string]*dynamodb.AttributeValue) *dynamodb.ScanInput {
+	return &dynamodb.ScanInput{
+		ExclusiveStartKey: exclusiveStartKey,
+		ExpressionAttributeNames: map[string]*string{
+			ProcessIDAttrAlias:     aws.String(ProcessIDAttrName),
+			taskIDAttrAlias:        aws.String(TaskIDAttrName),
+			taskStateAttrAlias:     aws.String(TaskStateAttrName),
+			taskReapShardAttrAlias: aws.String(taskReapShardAttrName),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			taskStateCreatedValuePlaceholder: {S: aws.String(string(task.StateCreated))},
+		},
+		FilterExpression:     &unshardedCreatedTaskConditionExpr,
+		ProjectionExpression: &backfillTaskReapShardScanProjectionExpr,
+		TableName:            &tableName,
+	}
+}
+
+func BuildBackfillTaskReapShardUpdateItemInput(tableName string, taskID task.ID) *dynamodb.UpdateItemInput {
+	return &dynamodb.UpdateItemInput{
+		ConditionExpression: &unshardedCreatedTaskConditionExpr,
+		ExpressionAttributeNames: map[string]*string{
+			taskStateAttrAlias:     aws.String(TaskStateAttrName),
+			taskReapShardAttrAlias: aws.String(taskReapShardAttrName),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			taskStateCreatedValuePlaceholder: {S: aws.String(string(task.StateCreated))},
+			taskReapShardValuePlaceholder:    {S: aws.String(formatTaskReapShard(TaskReapShard(taskID)))},
+		},
+		Key: map[string]*dynamodb.AttributeValue{
+			ProcessIDAttrName: {S: &taskID.ProcessID},
+			TaskIDAttrName:    {S: &taskID.TaskID},
+		},
+		TableName:        &tableName,
+		UpdateExpression: &backfillTaskReapShardUpdateExpr,
+	}
+}
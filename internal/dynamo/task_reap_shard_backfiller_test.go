@@ -0,0 +1,92 @@
+package dynamo_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/artii15/termination-detector/internal/dynamo"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type taskReapShardBackfillerWithMocks struct {
+	backfiller *dynamo.TaskReapShardBackfiller
+	dynamoAPI  *dynamoAPIMock
+}
+
+func (backfillerAndMocks *taskReapShardBackfillerWithMocks) assertExpectations(t *testing.T) {
+	backfillerAndMocks.dynamoAPI.AssertExpectations(t)
+}
+
+func newTaskReapShardBackfillerWithMocks() *taskReapShardBackfillerWithMocks {
+	dynamoAPI := new(dynamoAPIMock)
+	return &taskReapShardBackfillerWithMocks{
+		backfiller: dynamo.NewTaskReapShardBackfiller(dynamoAPI, tasksTableName),
+		dynamoAPI:  dynamoAPI,
+	}
+}
+
+func newTaskKeyItem(taskID task.ID) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		dynamo.ProcessIDAttrName: {S: aws.String(taskID.ProcessID)},
+		dynamo.TaskIDAttrName:    {S: aws.String(taskID.TaskID)},
+	}
+}
+
+func TestTaskReapShardBackfiller_Backfill(t *testing.T) {
+	backfillerAndMocks := newTaskReapShardBackfillerWithMocks()
+	legacyTaskID := task.ID{ProcessID: "1", TaskID: "1"}
+	finishedTaskID := task.ID{ProcessID: "1", TaskID: "2"}
+	processItem := newTaskKeyItem(task.ID{ProcessID: "1", TaskID: dynamo.ProcessItemTaskID})
+	backfillerAndMocks.dynamoAPI.On("ScanWithContext", mock.Anything,
+		dynamo.BuildBackfillTaskReapShardScanInput(tasksTableName, nil)).Return(&dynamodb.ScanOutput{
+		Items:            []map[string]*dynamodb.AttributeValue{newTaskKeyItem(legacyTaskID), processItem},
+		LastEvaluatedKey: processItem,
+	}, nil)
+	backfillerAndMocks.dynamoAPI.On("ScanWithContext", mock.Anything,
+		dynamo.BuildBackfillTaskReapShardScanInput(tasksTableName, processItem)).Return(&dynamodb.ScanOutput{
+		Items: []map[string]*dynamodb.AttributeValue{newTaskKeyItem(finishedTaskID)},
+	}, nil)
+	backfillerAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything,
+		dynamo.BuildBackfillTaskReapShardUpdateItemInput(tasksTableName, legacyTaskID)).
+		Return(&dynamodb.UpdateItemOutput{}, nil)
+	backfillerAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything,
+		dynamo.BuildBackfillTaskReapShardUpdateItemInput(tasksTableName, finishedTaskID)).
+		Return((*dynamodb.UpdateItemOutput)(nil),
+			awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil))
+
+	backfilledTasksCount, err := backfillerAndMocks.backfiller.Backfill(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, backfilledTasksCount)
+	backfillerAndMocks.assertExpectations(t)
+}
+
+func TestTaskReapShardBackfiller_Backfill_UpdateError(t *testing.T) {
+	backfillerAndMocks := newTaskReapShardBackfillerWithMocks()
+	backfillerAndMocks.dynamoAPI.On("ScanWithContext", mock.Anything, mock.Anything).Return(&dynamodb.ScanOutput{
+		Items: []map[string]*dynamodb.AttributeValue{newTaskKeyItem(task.ID{ProcessID: "1", TaskID: "1"})},
+	}, nil)
+	errToReturn := errors.New("update failed")
+	backfillerAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything, mock.Anything).
+		Return((*dynamodb.UpdateItemOutput)(nil), errToReturn)
+
+	backfilledTasksCount, err := backfillerAndMocks.backfiller.Backfill(context.Background())
+	assert.Equal(t, errToReturn, err)
+	assert.Equal(t, 0, backfilledTasksCount)
+}
+
+func TestBuildBackfillTaskReapShardUpdateItemInput(t *testing.T) {
+	taskID := task.ID{ProcessID: "1", TaskID: "1"}
+	updateItemInput := dynamo.BuildBackfillTaskReapShardUpdateItemInput(tasksTableName, taskID)
+	assert.Equal(t, "SET #reapShard = :reapShard", *updateItemInput.UpdateExpression)
+	assert.Equal(t, "#state = :stateCreated and attribute_not_exists(#reapShard)",
+		*updateItemInput.ConditionExpression)
+	assert.Equal(t, aws.String(strconv.Itoa(dynamo.TaskReapShard(taskID))),
+		updateItemInput.ExpressionAttributeValues[":reapShard"].S)
+}
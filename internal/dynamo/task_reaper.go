@@ -0,0 +1,173 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+const taskReapShardExpirationTimeIndex = "reapShardExpirationTimeIndex"
+
+var (
+	listTimedOutTasksKeyCondExpression = fmt.Sprintf("%s = %s and %s <= %s", taskReapShardAttrAlias,
+		taskReapShardValuePlaceholder, taskExpirationTimeAttrAlias, currentTimeValuePlaceholder)
+	reapTimedOutTaskUpdateExpr = fmt.Sprintf("SET %s = %s, %s = %s REMOVE %s", taskStateAttrAlias,
+		newTaskStateValuePlaceholder, taskStateMessageAttrAlias, newTaskStateMessageValuePlaceholder,
+		taskReapShardAttrAlias)
+	reapTimedOutTaskConditionExpr = fmt.Sprintf("%s = %s and %s <= %s", taskStateAttrAlias,
+		taskStateCreatedValuePlaceholder, taskExpirationTimeAttrAlias, currentTimeValuePlaceholder)
+)
+
+type TaskReaper struct {
+	dynamoAPI         dynamodbiface.DynamoDBAPI
+	tasksTableName    string
+	currentDateGetter currentDateGetter
+}
+
+func NewTaskReaper(dynamoAPI dynamodbiface.DynamoDBAPI, tasksTableName string,
+	currentDateGetter currentDateGetter) *TaskReaper {
+	return &TaskReaper{
+		dynamoAPI:         dynamoAPI,
+		tasksTableName:    tasksTableName,
+		currentDateGetter: currentDateGetter,
+	}
+}
+
+func (reaper *TaskReaper) ReapTimedOut(ctx context.Context, limit int) ([]task.ID, error) {
+	currentTime := reaper.currentDateGetter.GetCurrentDate()
+	reapedTaskIDs := make([]task.ID, 0, limit)
+	for reapShard := 0; reapShard < TaskReapShardsCount && len(reapedTaskIDs) < limit; reapShard++ {
+		var err error
+		reapedTaskIDs, err = reaper.reapShard(ctx, reapShard, currentTime, limit-len(reapedTaskIDs), reapedTaskIDs)
+		if err != nil {
+			return reapedTaskIDs, err
+		}
+	}
+	return reapedTaskIDs, nil
+}
+
+func (reaper *TaskReaper) reapShard(ctx context.Context, reapShard int, currentTime time.Time, limit int,
+	reapedTaskIDs []task.ID) ([]task.ID, error) {
+	out, err := reaper.dynamoAPI.QueryWithContext(ctx,
+		BuildListTimedOutTasksQueryInput(reaper.tasksTableName, reapShard, currentTime, limit))
+	if err != nil || out == nil {
+		return reapedTaskIDs, err
+	}
+	for _, item := range out.Items {
+		taskID, err := readTaskKey(item)
+		if err != nil {
+			return reapedTaskIDs, err
+		}
+		isReaped, err := reaper.reap(ctx, taskID, currentTime)
+		if err != nil {
+			return reapedTaskIDs, err
+		}
+		if isReaped {
+			reapedTaskIDs = append(reapedTaskIDs, taskID)
+		}
+	}
+	return reapedTaskIDs, nil
+}
+
+func (reaper *TaskReaper) reap(ctx context.Context, taskID task.ID, currentTime time.Time) (bool, error) {
+	_, err := reaper.dynamoAPI.TransactWriteItemsWithContext(ctx,
+		BuildReapTimedOutTaskTransactWriteItemsInput(reaper.tasksTableName, taskID, currentTime))
+	if err == nil {
+		return true, nil
+	}
+	canceledErr, isCanceledErr := err.(*dynamodb.TransactionCanceledException)
+	if !isCanceledErr {
+		return false, err
+	}
+	reasons := canceledErr.CancellationReasons
+	if len(reasons) > 0 && isConditionalCheckFailed(reasons[0]) {
+		return false, nil
+	}
+	if len(reasons) > 1 && isConditionalCheckFailed(reasons[1]) {
+		return reaper.reapWithoutProcessItem(ctx, taskID, currentTime)
+	}
+	return false, canceledErr
+}
+
+func (reaper *TaskReaper) reapWithoutProcessItem(ctx context.Context, taskID task.ID, currentTime time.Time) (bool, error) {
+	_, err := reaper.dynamoAPI.UpdateItemWithContext(ctx,
+		BuildReapTimedOutTaskUpdateItemInput(reaper.tasksTableName, taskID, currentTime))
+	if err != nil {
+		if awsErr, isAWSErr := err.(awserr.Error); isAWSErr && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func readTaskKey(item map[string]*dynamodb.AttributeValue) (task.ID, error) {
+	processIDAttr, isProcessIDDefined := item[ProcessIDAttrName]
+	if !isProcessIDDefined || processIDAttr.S == nil {
+		return task.ID{}, fmt.Errorf("item does not contain process id attribute: %+v", item)
+	}
+	taskID, err := readTaskID(item)
+	if err != nil {
+		return task.ID{}, err
+	}
+	return task.ID{ProcessID: *processIDAttr.S, TaskID: taskID}, nil
+}
+
+func BuildListTimedOutTasksQueryInput(tableName string, reapShard int, currentTime time.Time,
+	limit int) *dynamodb.QueryInput {
+	return &dynamodb.QueryInput{
+		ExpressionAttributeNames: map[string]*string{
+			taskReapShardAttrAlias:      aws.String(taskReapShardAttrName),
+			taskExpirationTimeAttrAlias: aws.String(taskExpirationTimeAttrName),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			taskReapShardValuePlaceholder: {S: aws.String(formatTaskReapShard(reapShard))},
+			currentTimeValuePlaceholder:   {S: aws.String(currentTime.Format(time.RFC3339))},
+		},
+		IndexName:              aws.String(taskReapShardExpirationTimeIndex),
+		KeyConditionExpression: &listTimedOutTasksKeyCondExpression,
+		Limit:                  aws.Int64(int64(limit)),
+		TableName:              &tableName,
+	}
+}
+
+func BuildReapTimedOutTaskUpdateItemInput(tableName string, taskID task.ID, currentTime time.Time) *dynamodb.UpdateItemInput {
+	return &dynamodb.UpdateItemInput{
+		ConditionExpression: &reapTimedOutTaskConditionExpr,
+		ExpressionAttributeNames: map[string]*string{
+			taskStateAttrAlias:          aws.String(TaskStateAttrName),
+			taskStateMessageAttrAlias:   aws.String(TaskStateMessageAttrName),
+			taskExpirationTimeAttrAlias: aws.String(taskExpirationTimeAttrName),
+			taskReapShardAttrAlias:      aws.String(taskReapShardAttrName),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			currentTimeValuePlaceholder:         {S: aws.String(currentTime.Format(time.RFC3339))},
+			taskStateCreatedValuePlaceholder:    {S: aws.String(string(task.StateCreated))},
+			newTaskStateValuePlaceholder:        {S: aws.String(string(task.StateTimedOut))},
+			newTaskStateMessageValuePlaceholder: {S: aws.String(process.TimedOutErrorMessage)},
+		},
+		Key: map[string]*dynamodb.AttributeValue{
+			ProcessIDAttrName: {S: &taskID.ProcessID},
+			TaskIDAttrName:    {S: &taskID.TaskID},
+		},
+		TableName:        &tableName,
+		UpdateExpression: &reapTimedOutTaskUpdateExpr,
+	}
+}
+
+func BuildReapTimedOutTaskTransactWriteItemsInput(tableName string, taskID task.ID,
+	currentTime time.Time) *dynamodb.TransactWriteItemsInput {
+	return &dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			newTransactUpdate(BuildReapTimedOutTaskUpdateItemInput(tableName, taskID, currentTime)),
+			newTransactUpdate(BuildTimeOutInProcessUpdateItemInput(tableName, taskID.ProcessID)),
+		},
+	}
+}
@@ -0,0 +1,175 @@
+package dynamo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/dynamo"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type taskReaperWithMocks struct {
+	reaper            *dynamo.TaskReaper
+	dynamoAPI         *dynamoAPIMock
+	currentDateGetter *currentDateGetterMock
+	currentTime       time.Time
+	limit             int
+}
+
+func (reaperAndMocks *taskReaperWithMocks) assertExpectations(t *testing.T) {
+	reaperAndMocks.dynamoAPI.AssertExpectations(t)
+	reaperAndMocks.currentDateGetter.AssertExpectations(t)
+}
+
+func (reaperAndMocks *taskReaperWithMocks) mockTimedOutTasks(reapShard, limit int, taskIDs ...task.ID) {
+	items := make([]map[string]*dynamodb.AttributeValue, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		items = append(items, map[string]*dynamodb.AttributeValue{
+			dynamo.ProcessIDAttrName: {S: aws.String(taskID.ProcessID)},
+			dynamo.TaskIDAttrName:    {S: aws.String(taskID.TaskID)},
+		})
+	}
+	reaperAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, dynamo.BuildListTimedOutTasksQueryInput(
+		tasksTableName, reapShard, reaperAndMocks.currentTime, limit)).
+		Return(&dynamodb.QueryOutput{Items: items}, nil)
+}
+
+func (reaperAndMocks *taskReaperWithMocks) mockEmptyShards(firstReapShard, limit int) {
+	for reapShard := firstReapShard; reapShard < dynamo.TaskReapShardsCount; reapShard++ {
+		reaperAndMocks.mockTimedOutTasks(reapShard, limit)
+	}
+}
+
+func (reaperAndMocks *taskReaperWithMocks) mockReaping(taskID task.ID, err error) {
+	output := &dynamodb.TransactWriteItemsOutput{}
+	if err != nil {
+		output = nil
+	}
+	reaperAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildReapTimedOutTaskTransactWriteItemsInput(tasksTableName, taskID, reaperAndMocks.currentTime)).
+		Return(output, err)
+}
+
+func (reaperAndMocks *taskReaperWithMocks) mockReapingWithoutProcessItem(taskID task.ID, err error) {
+	output := &dynamodb.UpdateItemOutput{}
+	if err != nil {
+		output = nil
+	}
+	reaperAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything, dynamo.BuildReapTimedOutTaskUpdateItemInput(
+		tasksTableName, taskID, reaperAndMocks.currentTime)).Return(output, err)
+}
+
+func newTaskReaperWithMocks() *taskReaperWithMocks {
+	dynamoAPI := new(dynamoAPIMock)
+	currentDateGetter := new(currentDateGetterMock)
+	currentTime := time.Now().UTC()
+	currentDateGetter.On("GetCurrentDate").Return(currentTime)
+	return &taskReaperWithMocks{
+		reaper:            dynamo.NewTaskReaper(dynamoAPI, tasksTableName, currentDateGetter),
+		dynamoAPI:         dynamoAPI,
+		currentDateGetter: currentDateGetter,
+		currentTime:       currentTime,
+		limit:             10,
+	}
+}
+
+func TestTaskReaper_ReapTimedOut(t *testing.T) {
+	reaperAndMocks := newTaskReaperWithMocks()
+	reapedTaskID := task.ID{ProcessID: "1", TaskID: "1"}
+	completedTaskID := task.ID{ProcessID: "2", TaskID: "1"}
+	reaperAndMocks.mockTimedOutTasks(0, reaperAndMocks.limit, reapedTaskID, completedTaskID)
+	reaperAndMocks.mockEmptyShards(1, reaperAndMocks.limit-1)
+	reaperAndMocks.mockReaping(reapedTaskID, nil)
+	reaperAndMocks.mockReaping(completedTaskID, &dynamodb.TransactionCanceledException{
+		CancellationReasons: []*dynamodb.CancellationReason{
+			{Code: aws.String("ConditionalCheckFailed")},
+			{Code: aws.String("None")},
+		},
+	})
+
+	reapedTaskIDs, err := reaperAndMocks.reaper.ReapTimedOut(context.Background(), reaperAndMocks.limit)
+	assert.NoError(t, err)
+	reaperAndMocks.assertExpectations(t)
+	assert.Equal(t, []task.ID{reapedTaskID}, reapedTaskIDs)
+}
+
+func TestTaskReaper_ReapTimedOut_WithoutProcessItem(t *testing.T) {
+	reaperAndMocks := newTaskReaperWithMocks()
+	reapedTaskID := task.ID{ProcessID: "1", TaskID: "1"}
+	completedTaskID := task.ID{ProcessID: "2", TaskID: "1"}
+	reaperAndMocks.mockTimedOutTasks(0, reaperAndMocks.limit, reapedTaskID, completedTaskID)
+	reaperAndMocks.mockEmptyShards(1, reaperAndMocks.limit-1)
+	for _, taskID := range []task.ID{reapedTaskID, completedTaskID} {
+		reaperAndMocks.mockReaping(taskID, &dynamodb.TransactionCanceledException{
+			CancellationReasons: []*dynamodb.CancellationReason{
+				{Code: aws.String("None")},
+				{Code: aws.String("ConditionalCheckFailed")},
+			},
+		})
+	}
+	reaperAndMocks.mockReapingWithoutProcessItem(reapedTaskID, nil)
+	reaperAndMocks.mockReapingWithoutProcessItem(completedTaskID,
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil))
+
+	reapedTaskIDs, err := reaperAndMocks.reaper.ReapTimedOut(context.Background(), reaperAndMocks.limit)
+	assert.NoError(t, err)
+	reaperAndMocks.assertExpectations(t)
+	assert.Equal(t, []task.ID{reapedTaskID}, reapedTaskIDs)
+}
+
+func TestTaskReaper_ReapTimedOut_LimitReached(t *testing.T) {
+	reaperAndMocks := newTaskReaperWithMocks()
+	reaperAndMocks.limit = 2
+	firstTaskID := task.ID{ProcessID: "1", TaskID: "1"}
+	secondTaskID := task.ID{ProcessID: "2", TaskID: "1"}
+	reaperAndMocks.mockTimedOutTasks(0, reaperAndMocks.limit, firstTaskID)
+	reaperAndMocks.mockTimedOutTasks(1, reaperAndMocks.limit-1, secondTaskID)
+	reaperAndMocks.mockReaping(firstTaskID, nil)
+	reaperAndMocks.mockReaping(secondTaskID, nil)
+
+	reapedTaskIDs, err := reaperAndMocks.reaper.ReapTimedOut(context.Background(), reaperAndMocks.limit)
+	assert.NoError(t, err)
+	reaperAndMocks.assertExpectations(t)
+	assert.Equal(t, []task.ID{firstTaskID, secondTaskID}, reapedTaskIDs)
+}
+
+func TestTaskReaper_ReapTimedOut_QueryError(t *testing.T) {
+	reaperAndMocks := newTaskReaperWithMocks()
+	reaperAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything, dynamo.BuildListTimedOutTasksQueryInput(
+		tasksTableName, 0, reaperAndMocks.currentTime, reaperAndMocks.limit)).
+		Return((*dynamodb.QueryOutput)(nil), errors.New("error"))
+
+	_, err := reaperAndMocks.reaper.ReapTimedOut(context.Background(), reaperAndMocks.limit)
+	assert.Error(t, err)
+	reaperAndMocks.assertExpectations(t)
+}
+
+func TestTaskReaper_ReapTimedOut_UpdateError(t *testing.T) {
+	reaperAndMocks := newTaskReaperWithMocks()
+	taskID := task.ID{ProcessID: "1", TaskID: "1"}
+	reaperAndMocks.mockTimedOutTasks(0, reaperAndMocks.limit, taskID)
+	reaperAndMocks.mockReaping(taskID, errors.New("error"))
+
+	_, err := reaperAndMocks.reaper.ReapTimedOut(context.Background(), reaperAndMocks.limit)
+	assert.Error(t, err)
+	reaperAndMocks.assertExpectations(t)
+}
+
+func TestBuildReapTimedOutTaskTransactWriteItemsInput_UpdatesProcessSummary(t *testing.T) {
+	transactWriteItemsInput := dynamo.BuildReapTimedOutTaskTransactWriteItemsInput(tasksTableName,
+		task.ID{ProcessID: "2", TaskID: "1"}, time.Now().UTC())
+
+	processItemUpdate := transactWriteItemsInput.TransactItems[1].Update
+	assert.Equal(t, "ADD #openTasksCount :openTasksCountIncrement, #timedOutTasksCount :timedOutTasksCountIncrement",
+		*processItemUpdate.UpdateExpression)
+	assert.Equal(t, aws.String("attribute_exists(#processID)"), processItemUpdate.ConditionExpression)
+	assert.Equal(t, aws.String("-1"), processItemUpdate.ExpressionAttributeValues[":openTasksCountIncrement"].N)
+	assert.Equal(t, aws.String("1"), processItemUpdate.ExpressionAttributeValues[":timedOutTasksCountIncrement"].N)
+}
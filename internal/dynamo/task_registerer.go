@@ -1,13 +1,13 @@
 package dynamo
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/artii15/termination-detector/pkg/task"
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 )
@@ -16,14 +16,18 @@ const (
 	decimalBase                        = 10
 	taskTTLValuePlaceholder            = ":ttl"
 	taskExpirationTimeValuePlaceholder = ":expirationTime"
+	taskCreationTimeValuePlaceholder   = ":creationTime"
+	maxTasksRegisteredInTransaction    = 99
+	processHasTasksQueryLimit          = 2
 )
 
 var (
 	registerTaskConditionExpr = fmt.Sprintf("attribute_not_exists(%s) and attribute_not_exists(%s)",
 		ProcessIDAttrAlias, taskIDAttrAlias)
-	registerTaskUpdateExpr = fmt.Sprintf(`SET %s = %s, %s = %s, %s = %s, %s = %s`,
+	registerTaskUpdateExpr = fmt.Sprintf(`SET %s = %s, %s = %s, %s = %s, %s = %s, %s = %s, %s = %s`,
 		taskExpirationTimeAttrAlias, taskExpirationTimeValuePlaceholder, taskStateAttrAlias, taskStateCreatedValuePlaceholder,
-		taskTTLAttrAlias, taskTTLValuePlaceholder, taskBadStateEnterTimeAttrAlias, taskBadStateEnterTimeValuePlaceholder)
+		taskTTLAttrAlias, taskTTLValuePlaceholder, taskBadStateEnterTimeAttrAlias, taskBadStateEnterTimeValuePlaceholder,
+		taskCreationTimeAttrAlias, taskCreationTimeValuePlaceholder, taskReapShardAttrAlias, taskReapShardValuePlaceholder)
 )
 
 type currentDateGetter interface {
@@ -47,36 +51,286 @@ func NewTaskRegisterer(dynamoAPI dynamodbiface.DynamoDBAPI, tasksTableName strin
 	}
 }
 
-func (registerer *TaskRegisterer) Register(registrationData task.RegistrationData) (task.RegistrationResult, error) {
-	if err := registerer.saveTask(registrationData); err != nil {
-		if awsErr, isAWSErr := err.(awserr.Error); isAWSErr && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
-			return task.RegistrationResultAlreadyRegistered, nil
+func (registerer *TaskRegisterer) Register(ctx context.Context,
+	registrationData task.RegistrationData) (task.RegistrationResult, error) {
+	registrationTime := registerer.currentDateGetter.GetCurrentDate()
+	summaryTracking := ProcessSummaryTracked
+	var canceledErr *dynamodb.TransactionCanceledException
+	for earliestExpirationTimeUpdateIndex := 0; earliestExpirationTimeUpdateIndex < len(earliestExpirationTimeUpdates); {
+		err := registerer.saveTasks(ctx, []task.RegistrationData{registrationData}, registrationTime,
+			earliestExpirationTimeUpdates[earliestExpirationTimeUpdateIndex], summaryTracking)
+		if err == nil {
+			return task.RegistrationResultCreated, nil
 		}
+		var isCanceledErr bool
+		if canceledErr, isCanceledErr = err.(*dynamodb.TransactionCanceledException); !isCanceledErr {
+			return "", err
+		}
+		registrationResult, err := readRegistrationCancellationResult(canceledErr, registrationTime)
+		if err != nil || registrationResult != "" {
+			return registrationResult, err
+		}
+		resolvedSummaryTracking, err := registerer.resolveSummaryTracking(ctx, registrationData.ID.ProcessID,
+			canceledErr.CancellationReasons[0], summaryTracking)
+		if err != nil {
+			return "", err
+		}
+		if resolvedSummaryTracking == summaryTracking {
+			earliestExpirationTimeUpdateIndex++
+		}
+		summaryTracking = resolvedSummaryTracking
+	}
+	return "", canceledErr
+}
+
+func (registerer *TaskRegisterer) BatchRegister(ctx context.Context,
+	request task.BatchRegistrationRequest) ([]task.BatchRegistrationResult, error) {
+	registrationTime := registerer.currentDateGetter.GetCurrentDate()
+	results := task.NewBatchRegistrationResults(request, task.RegistrationResultCreated)
+	if request.Transactional {
+		_, err := registerer.registerChunk(ctx, request.Tasks, results, registrationTime, true)
+		return results, err
+	}
+	for chunkStart := 0; chunkStart < len(request.Tasks); chunkStart += maxTasksRegisteredInTransaction {
+		chunkEnd := chunkStart + maxTasksRegisteredInTransaction
+		if chunkEnd > len(request.Tasks) {
+			chunkEnd = len(request.Tasks)
+		}
+		closedProcessResult, err := registerer.registerChunk(ctx, request.Tasks[chunkStart:chunkEnd],
+			results[chunkStart:chunkEnd], registrationTime, false)
+		if err != nil && chunkStart == 0 {
+			return nil, err
+		}
+		if err != nil {
+			task.ReplaceRegistrationResults(results[chunkStart:], task.RegistrationResultCreated,
+				task.RegistrationResultFailed)
+			break
+		}
+		if closedProcessResult != "" {
+			task.ReplaceRegistrationResults(results[chunkEnd:], task.RegistrationResultCreated, closedProcessResult)
+			break
+		}
+	}
+	return results, nil
+}
+
+func (registerer *TaskRegisterer) registerChunk(ctx context.Context, tasksRegistrationData []task.RegistrationData,
+	results []task.BatchRegistrationResult, registrationTime time.Time,
+	isTransactional bool) (task.RegistrationResult, error) {
+	earliestExpirationTimeUpdateIndex := 0
+	transactionConflictRetries := 0
+	summaryTracking := ProcessSummaryTracked
+	for {
+		pendingIndexes := make([]int, 0, len(tasksRegistrationData))
+		pendingTasks := make([]task.RegistrationData, 0, len(tasksRegistrationData))
+		for index, registrationData := range tasksRegistrationData {
+			if results[index].Result == task.RegistrationResultCreated {
+				pendingIndexes = append(pendingIndexes, index)
+				pendingTasks = append(pendingTasks, registrationData)
+			}
+		}
+		if len(pendingTasks) == 0 {
+			return "", nil
+		}
+		err := registerer.saveTasks(ctx, pendingTasks, registrationTime,
+			earliestExpirationTimeUpdates[earliestExpirationTimeUpdateIndex], summaryTracking)
+		if err == nil {
+			return "", nil
+		}
+		canceledErr, isCanceledErr := err.(*dynamodb.TransactionCanceledException)
+		if !isCanceledErr {
+			return "", err
+		}
+		reasons := canceledErr.CancellationReasons
+		isAnyTaskAlreadyRegistered := false
+		for position, index := range pendingIndexes {
+			if len(reasons) > position+1 && isConditionalCheckFailed(reasons[position+1]) {
+				results[index].Result = task.RegistrationResultAlreadyRegistered
+				isAnyTaskAlreadyRegistered = true
+			}
+		}
+		isEarliestExpirationTimeConflicting := false
+		isSummaryTrackingChanged := false
+		if len(reasons) > 0 && isConditionalCheckFailed(reasons[0]) {
+			closedProcessResult, err := readClosedProcessRegistrationResult(reasons[0], registrationTime)
+			if err != nil {
+				return "", err
+			}
+			if closedProcessResult != "" {
+				task.ReplaceRegistrationResults(results, task.RegistrationResultCreated, closedProcessResult)
+				return closedProcessResult, nil
+			}
+			resolvedSummaryTracking, err := registerer.resolveSummaryTracking(ctx,
+				tasksRegistrationData[0].ID.ProcessID, reasons[0], summaryTracking)
+			if err != nil {
+				return "", err
+			}
+			isSummaryTrackingChanged = resolvedSummaryTracking != summaryTracking
+			isEarliestExpirationTimeConflicting = !isSummaryTrackingChanged
+			summaryTracking = resolvedSummaryTracking
+		}
+		if !isAnyTaskAlreadyRegistered && !isEarliestExpirationTimeConflicting && !isSummaryTrackingChanged {
+			if !isTransactionConflicted(canceledErr) || transactionConflictRetries == maxTransactionConflictRetries {
+				return "", canceledErr
+			}
+			if err := waitForTransactionConflictRetry(ctx, transactionConflictRetries); err != nil {
+				return "", err
+			}
+			transactionConflictRetries++
+			continue
+		}
+		if isTransactional && isAnyTaskAlreadyRegistered {
+			task.ReplaceRegistrationResults(results, task.RegistrationResultCreated, task.RegistrationResultCanceled)
+			return "", nil
+		}
+		if isEarliestExpirationTimeConflicting {
+			earliestExpirationTimeUpdateIndex++
+			if earliestExpirationTimeUpdateIndex == len(earliestExpirationTimeUpdates) {
+				return "", canceledErr
+			}
+		}
+	}
+}
+
+func (registerer *TaskRegisterer) resolveSummaryTracking(ctx context.Context, processID string,
+	reason *dynamodb.CancellationReason, summaryTracking ProcessSummaryTracking) (ProcessSummaryTracking, error) {
+	item, err := readProcessItem(reason.Item)
+	if err != nil {
 		return "", err
 	}
-	return task.RegistrationResultCreated, nil
+	if item != nil && item.registrationsCount != nil {
+		return ProcessSummaryTracked, nil
+	}
+	if summaryTracking != ProcessSummaryTracked {
+		return summaryTracking, nil
+	}
+	hasTasks, err := checkIfProcessHasTasks(ctx, registerer.dynamoAPI, registerer.tasksTableName, processID)
+	if err != nil || !hasTasks {
+		return ProcessSummaryInitialized, err
+	}
+	return ProcessSummaryUntracked, nil
 }
 
-func (registerer *TaskRegisterer) saveTask(registrationData task.RegistrationData) error {
-	updateItemInput := BuildRegisterTaskUpdateItemInput(registerer.tasksTableName, TaskToRegister{
-		CreationTime:     registerer.currentDateGetter.GetCurrentDate(),
-		StoringDuration:  registerer.tasksStoringDuration,
-		RegistrationData: registrationData,
-	})
-	_, err := registerer.dynamoAPI.UpdateItem(updateItemInput)
+func (registerer *TaskRegisterer) saveTasks(ctx context.Context, tasksRegistrationData []task.RegistrationData,
+	registrationTime time.Time, earliestExpirationTimeUpdate EarliestExpirationTimeUpdate,
+	summaryTracking ProcessSummaryTracking) error {
+	tasksToRegister := make([]TaskToRegister, 0, len(tasksRegistrationData))
+	for _, registrationData := range tasksRegistrationData {
+		tasksToRegister = append(tasksToRegister, TaskToRegister{
+			CreationTime:     registrationTime,
+			StoringDuration:  registerer.tasksStoringDuration,
+			RegistrationData: registrationData,
+		})
+	}
+	transactWriteItemsInput := BuildRegisterTasksTransactWriteItemsInput(registerer.tasksTableName, tasksToRegister,
+		earliestExpirationTimeUpdate, summaryTracking)
+	_, err := registerer.dynamoAPI.TransactWriteItemsWithContext(ctx, transactWriteItemsInput)
 	return err
 }
 
+func readRegistrationCancellationResult(canceledErr *dynamodb.TransactionCanceledException,
+	registrationTime time.Time) (task.RegistrationResult, error) {
+	reasons := canceledErr.CancellationReasons
+	if len(reasons) > 1 && isConditionalCheckFailed(reasons[1]) {
+		return task.RegistrationResultAlreadyRegistered, nil
+	}
+	if len(reasons) > 0 && isConditionalCheckFailed(reasons[0]) {
+		return readClosedProcessRegistrationResult(reasons[0], registrationTime)
+	}
+	return "", canceledErr
+}
+
+func readClosedProcessRegistrationResult(reason *dynamodb.CancellationReason,
+	registrationTime time.Time) (task.RegistrationResult, error) {
+	isDeadlineExceeded, err := isProcessDeadlineExceeded(reason, registrationTime)
+	if err != nil {
+		return "", err
+	}
+	if isDeadlineExceeded {
+		return task.RegistrationResultProcessDeadlineExceeded, nil
+	}
+	isClosed, err := isProcessClosed(reason, registrationTime)
+	if err != nil || !isClosed {
+		return "", err
+	}
+	return task.RegistrationResultProcessSealed, nil
+}
+
 type TaskToRegister struct {
 	CreationTime     time.Time
 	StoringDuration  time.Duration
 	RegistrationData task.RegistrationData
 }
 
+func BuildRegisterTaskTransactWriteItemsInput(tableName string, taskToRegister TaskToRegister,
+	earliestExpirationTimeUpdate EarliestExpirationTimeUpdate,
+	summaryTracking ProcessSummaryTracking) *dynamodb.TransactWriteItemsInput {
+	return BuildRegisterTasksTransactWriteItemsInput(tableName, []TaskToRegister{taskToRegister},
+		earliestExpirationTimeUpdate, summaryTracking)
+}
+
+func BuildRegisterTasksTransactWriteItemsInput(tableName string, tasksToRegister []TaskToRegister,
+	earliestExpirationTimeUpdate EarliestExpirationTimeUpdate,
+	summaryTracking ProcessSummaryTracking) *dynamodb.TransactWriteItemsInput {
+	firstTask := tasksToRegister[0]
+	transactItems := []*dynamodb.TransactWriteItem{
+		newTransactUpdateReturningOldValues(BuildRegisterInProcessUpdateItemInput(tableName, TasksToRegisterInProcess{
+			ProcessID:                    firstTask.RegistrationData.ID.ProcessID,
+			TasksCount:                   len(tasksToRegister),
+			CreationTime:                 firstTask.CreationTime,
+			StoringDuration:              firstTask.StoringDuration,
+			CallbackURL:                  firstTask.RegistrationData.CallbackURL,
+			Deadline:                     firstTask.RegistrationData.ProcessDeadline,
+			Creator:                      firstTask.RegistrationData.Creator,
+			EarliestExpirationTime:       findEarliestExpirationTime(tasksToRegister),
+			EarliestExpirationTimeUpdate: earliestExpirationTimeUpdate,
+			SummaryTracking:              summaryTracking,
+		})),
+	}
+	for _, taskToRegister := range tasksToRegister {
+		transactItems = append(transactItems, newTransactUpdate(BuildRegisterTaskUpdateItemInput(tableName, taskToRegister)))
+	}
+	return &dynamodb.TransactWriteItemsInput{TransactItems: transactItems}
+}
+
+func checkIfProcessHasTasks(ctx context.Context, dynamoAPI dynamodbiface.DynamoDBAPI, tableName,
+	processID string) (bool, error) {
+	out, err := dynamoAPI.QueryWithContext(ctx, BuildCheckIfProcessHasTasksQueryInput(tableName, processID))
+	if err != nil || out == nil {
+		return false, err
+	}
+	for _, item := range out.Items {
+		if taskIDAttr, isTaskIDDefined := item[TaskIDAttrName]; isTaskIDDefined && taskIDAttr.S != nil &&
+			*taskIDAttr.S != ProcessItemTaskID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func BuildCheckIfProcessHasTasksQueryInput(tableName, processID string) *dynamodb.QueryInput {
+	queryInput := BuildCheckIfProcessExistsQueryInput(tableName, processID)
+	queryInput.ExpressionAttributeNames[taskIDAttrAlias] = aws.String(TaskIDAttrName)
+	queryInput.Limit = aws.Int64(processHasTasksQueryLimit)
+	queryInput.ProjectionExpression = aws.String(taskIDAttrAlias)
+	return queryInput
+}
+
+func findEarliestExpirationTime(tasksToRegister []TaskToRegister) time.Time {
+	earliestExpirationTime := tasksToRegister[0].RegistrationData.ExpirationTime
+	for _, taskToRegister := range tasksToRegister[1:] {
+		if taskToRegister.RegistrationData.ExpirationTime.Before(earliestExpirationTime) {
+			earliestExpirationTime = taskToRegister.RegistrationData.ExpirationTime
+		}
+	}
+	return earliestExpirationTime
+}
+
 func BuildRegisterTaskUpdateItemInput(tableName string, taskToRegister TaskToRegister) *dynamodb.UpdateItemInput {
 	ttl := taskToRegister.CreationTime.Add(taskToRegister.StoringDuration).UTC().Unix()
 	ttlString := strconv.FormatInt(ttl, decimalBase)
 	expirationTimeString := taskToRegister.RegistrationData.ExpirationTime.Format(time.RFC3339)
+	reapShard := formatTaskReapShard(TaskReapShard(taskToRegister.RegistrationData.ID))
 	return &dynamodb.UpdateItemInput{
 		ConditionExpression: &registerTaskConditionExpr,
 		ExpressionAttributeNames: map[string]*string{
@@ -86,8 +340,12 @@ func BuildRegisterTaskUpdateItemInput(tableName string, taskToRegister TaskToReg
 			taskExpirationTimeAttrAlias:    aws.String(taskExpirationTimeAttrName),
 			taskTTLAttrAlias:               aws.String(taskTTLAttributeName),
 			taskBadStateEnterTimeAttrAlias: aws.String(TaskBadStateEnterTimeAttrName),
+			taskCreationTimeAttrAlias:      aws.String(taskCreationTimeAttrName),
+			taskReapShardAttrAlias:         aws.String(taskReapShardAttrName),
 		},
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			taskCreationTimeValuePlaceholder:      {S: aws.String(taskToRegister.CreationTime.Format(time.RFC3339))},
+			taskReapShardValuePlaceholder:         {S: &reapShard},
 			taskStateCreatedValuePlaceholder:      {S: aws.String(string(task.StateCreated))},
 			taskTTLValuePlaceholder:               {N: &ttlString},
 			taskExpirationTimeValuePlaceholder:    {S: &expirationTimeString},
@@ -1,15 +1,18 @@
 package dynamo_test
 
 import (
+	"context"
 	"errors"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/artii15/termination-detector/internal/dynamo"
 	"github.com/artii15/termination-detector/pkg/task"
-	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 type taskRegistererWithMocks struct {
@@ -52,10 +55,11 @@ func TestTaskRegisterer_Register(t *testing.T) {
 		StoringDuration:  registererAndMocks.tasksStoringDuration,
 		RegistrationData: registrationData,
 	}
-	updateItemInput := dynamo.BuildRegisterTaskUpdateItemInput(tasksTableName, taskToRegister)
-	registererAndMocks.dynamoAPI.On("UpdateItem", updateItemInput).Return(&dynamodb.UpdateItemOutput{}, nil)
+	transactWriteItemsInput := dynamo.BuildRegisterTaskTransactWriteItemsInput(tasksTableName, taskToRegister,
+		dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryTracked)
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, transactWriteItemsInput).Return(&dynamodb.TransactWriteItemsOutput{}, nil)
 
-	registrationResult, err := registererAndMocks.registerer.Register(registrationData)
+	registrationResult, err := registererAndMocks.registerer.Register(context.Background(), registrationData)
 	assert.NoError(t, err)
 	assert.Equal(t, task.RegistrationResultCreated, registrationResult)
 	registererAndMocks.assertExpectations(t)
@@ -77,11 +81,18 @@ func TestTaskRegisterer_Register_TaskAlreadyExists(t *testing.T) {
 		StoringDuration:  registererAndMocks.tasksStoringDuration,
 		RegistrationData: registrationData,
 	}
-	updateItemInput := dynamo.BuildRegisterTaskUpdateItemInput(tasksTableName, taskToRegister)
-	errToReturn := awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil)
-	registererAndMocks.dynamoAPI.On("UpdateItem", updateItemInput).Return((*dynamodb.UpdateItemOutput)(nil), errToReturn)
+	transactWriteItemsInput := dynamo.BuildRegisterTaskTransactWriteItemsInput(tasksTableName, taskToRegister,
+		dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryTracked)
+	errToReturn := &dynamodb.TransactionCanceledException{
+		CancellationReasons: []*dynamodb.CancellationReason{
+			{Code: aws.String("None")},
+			{Code: aws.String("ConditionalCheckFailed")},
+		},
+	}
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, transactWriteItemsInput).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), errToReturn)
 
-	registrationResult, err := registererAndMocks.registerer.Register(registrationData)
+	registrationResult, err := registererAndMocks.registerer.Register(context.Background(), registrationData)
 	assert.NoError(t, err)
 	assert.Equal(t, task.RegistrationResultAlreadyRegistered, registrationResult)
 	registererAndMocks.assertExpectations(t)
@@ -103,11 +114,562 @@ func TestTaskRegisterer_Register_UnexpectedError(t *testing.T) {
 		StoringDuration:  registererAndMocks.tasksStoringDuration,
 		RegistrationData: registrationData,
 	}
-	updateItemInput := dynamo.BuildRegisterTaskUpdateItemInput(tasksTableName, taskToRegister)
+	transactWriteItemsInput := dynamo.BuildRegisterTaskTransactWriteItemsInput(tasksTableName, taskToRegister,
+		dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryTracked)
 	errToReturn := errors.New("error")
-	registererAndMocks.dynamoAPI.On("UpdateItem", updateItemInput).Return((*dynamodb.UpdateItemOutput)(nil), errToReturn)
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, transactWriteItemsInput).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), errToReturn)
 
-	_, err := registererAndMocks.registerer.Register(registrationData)
+	_, err := registererAndMocks.registerer.Register(context.Background(), registrationData)
 	assert.Error(t, err)
 	registererAndMocks.assertExpectations(t)
 }
+
+func TestTaskRegisterer_Register_ProcessSealed(t *testing.T) {
+	registererAndMocks := newTaskRegistererWithMocks()
+	currentDate := time.Now().UTC()
+	registrationData := task.RegistrationData{
+		ID: task.ID{
+			ProcessID: "2",
+			TaskID:    "1",
+		},
+		ExpirationTime: currentDate.Add(time.Hour),
+	}
+	registererAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	taskToRegister := dynamo.TaskToRegister{
+		CreationTime:     currentDate,
+		StoringDuration:  registererAndMocks.tasksStoringDuration,
+		RegistrationData: registrationData,
+	}
+	transactWriteItemsInput := dynamo.BuildRegisterTaskTransactWriteItemsInput(tasksTableName, taskToRegister,
+		dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryTracked)
+	errToReturn := &dynamodb.TransactionCanceledException{
+		CancellationReasons: []*dynamodb.CancellationReason{
+			{
+				Code: aws.String("ConditionalCheckFailed"),
+				Item: map[string]*dynamodb.AttributeValue{
+					dynamo.ProcessSealedTimeAttrName: {S: aws.String(currentDate.Format(time.RFC3339))},
+				},
+			},
+			{Code: aws.String("None")},
+		},
+	}
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, transactWriteItemsInput).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), errToReturn)
+
+	registrationResult, err := registererAndMocks.registerer.Register(context.Background(), registrationData)
+	assert.NoError(t, err)
+	assert.Equal(t, task.RegistrationResultProcessSealed, registrationResult)
+	registererAndMocks.assertExpectations(t)
+}
+
+func TestBuildRegisterInProcessUpdateItemInput_WithCallback(t *testing.T) {
+	tasksToRegister := dynamo.TasksToRegisterInProcess{
+		ProcessID:       "1",
+		TasksCount:      1,
+		CreationTime:    time.Now().UTC(),
+		StoringDuration: time.Hour,
+	}
+	updateItemInput := dynamo.BuildRegisterInProcessUpdateItemInput(tasksTableName, tasksToRegister)
+	assert.NotContains(t, *updateItemInput.UpdateExpression, "#callbackURL")
+
+	tasksToRegister.CallbackURL = aws.String("https://example.com/callback")
+	updateItemInput = dynamo.BuildRegisterInProcessUpdateItemInput(tasksTableName, tasksToRegister)
+	assert.Contains(t, *updateItemInput.UpdateExpression, "#callbackURL = if_not_exists(#callbackURL, :callbackURL)")
+	assert.Contains(t, updateItemInput.ExpressionAttributeNames, "#callbackURL")
+	assert.Equal(t, &dynamodb.AttributeValue{S: tasksToRegister.CallbackURL},
+		updateItemInput.ExpressionAttributeValues[":callbackURL"])
+}
+
+func TestTaskRegisterer_Register_ProcessDeadlineExceeded(t *testing.T) {
+	registererAndMocks := newTaskRegistererWithMocks()
+	currentDate := time.Now().UTC()
+	registrationData := task.RegistrationData{
+		ID: task.ID{
+			ProcessID: "2",
+			TaskID:    "1",
+		},
+		ExpirationTime: currentDate.Add(time.Hour),
+	}
+	registererAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	taskToRegister := dynamo.TaskToRegister{
+		CreationTime:     currentDate,
+		StoringDuration:  registererAndMocks.tasksStoringDuration,
+		RegistrationData: registrationData,
+	}
+	transactWriteItemsInput := dynamo.BuildRegisterTaskTransactWriteItemsInput(tasksTableName, taskToRegister,
+		dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryTracked)
+	errToReturn := &dynamodb.TransactionCanceledException{
+		CancellationReasons: []*dynamodb.CancellationReason{
+			{
+				Code: aws.String("ConditionalCheckFailed"),
+				Item: map[string]*dynamodb.AttributeValue{
+					dynamo.ProcessDeadlineAttrName: {S: aws.String(currentDate.Format(time.RFC3339))},
+				},
+			},
+			{Code: aws.String("None")},
+		},
+	}
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, transactWriteItemsInput).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), errToReturn)
+
+	registrationResult, err := registererAndMocks.registerer.Register(context.Background(), registrationData)
+	assert.NoError(t, err)
+	assert.Equal(t, task.RegistrationResultProcessDeadlineExceeded, registrationResult)
+	registererAndMocks.assertExpectations(t)
+}
+
+func TestBuildRegisterInProcessUpdateItemInput_WithDeadline(t *testing.T) {
+	deadline := time.Date(2020, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+	updateItemInput := dynamo.BuildRegisterInProcessUpdateItemInput(tasksTableName, dynamo.TasksToRegisterInProcess{
+		ProcessID:       "1",
+		TasksCount:      1,
+		CreationTime:    time.Now().UTC(),
+		StoringDuration: time.Hour,
+		Deadline:        deadline,
+	})
+	assert.Contains(t, *updateItemInput.UpdateExpression, "#deadline = if_not_exists(#deadline, :deadline)")
+	assert.Equal(t, &dynamodb.AttributeValue{S: aws.String("2020-01-02T02:04:05Z")},
+		updateItemInput.ExpressionAttributeValues[":deadline"])
+}
+
+func TestBuildRegisterInProcessUpdateItemInput_WithCreator(t *testing.T) {
+	creationTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	tasksToRegister := dynamo.TasksToRegisterInProcess{
+		ProcessID:       "1",
+		TasksCount:      1,
+		CreationTime:    creationTime,
+		StoringDuration: time.Hour,
+	}
+	updateItemInput := dynamo.BuildRegisterInProcessUpdateItemInput(tasksTableName, tasksToRegister)
+	assert.Contains(t, *updateItemInput.UpdateExpression,
+		"#processCreationTime = if_not_exists(#processCreationTime, :currentTime)")
+	assert.NotContains(t, *updateItemInput.UpdateExpression, "#creator")
+
+	tasksToRegister.Creator = aws.String("arn:aws:iam::123456789012:user/creator")
+	updateItemInput = dynamo.BuildRegisterInProcessUpdateItemInput(tasksTableName, tasksToRegister)
+	assert.Contains(t, *updateItemInput.UpdateExpression, "#creator = if_not_exists(#creator, :creator)")
+	assert.Equal(t, &dynamodb.AttributeValue{S: tasksToRegister.Creator}, updateItemInput.ExpressionAttributeValues[":creator"])
+	assert.Equal(t, &dynamodb.AttributeValue{S: aws.String("2020-01-02T03:04:05Z")},
+		updateItemInput.ExpressionAttributeValues[":currentTime"])
+}
+
+func newBatchRegistrationRequestWithTasks(registererAndMocks *taskRegistererWithMocks, currentDate time.Time,
+	transactional bool, taskIDs ...string) (task.BatchRegistrationRequest, []dynamo.TaskToRegister) {
+	request := task.BatchRegistrationRequest{ProcessID: "2", Transactional: transactional}
+	tasksToRegister := make([]dynamo.TaskToRegister, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		registrationData := task.RegistrationData{
+			ID:             task.ID{ProcessID: request.ProcessID, TaskID: taskID},
+			ExpirationTime: currentDate.Add(time.Hour),
+		}
+		request.Tasks = append(request.Tasks, registrationData)
+		tasksToRegister = append(tasksToRegister, dynamo.TaskToRegister{
+			CreationTime:     currentDate,
+			StoringDuration:  registererAndMocks.tasksStoringDuration,
+			RegistrationData: registrationData,
+		})
+	}
+	return request, tasksToRegister
+}
+
+func TestTaskRegisterer_BatchRegister(t *testing.T) {
+	registererAndMocks := newTaskRegistererWithMocks()
+	currentDate := time.Now().UTC()
+	request, tasksToRegister := newBatchRegistrationRequestWithTasks(registererAndMocks, currentDate, false, "1", "2", "3")
+	registererAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	errToReturn := &dynamodb.TransactionCanceledException{
+		CancellationReasons: []*dynamodb.CancellationReason{
+			{Code: aws.String("None")},
+			{Code: aws.String("None")},
+			{Code: aws.String("ConditionalCheckFailed")},
+			{Code: aws.String("None")},
+		},
+	}
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildRegisterTasksTransactWriteItemsInput(tasksTableName, tasksToRegister,
+			dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryTracked)).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), errToReturn).Once()
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildRegisterTasksTransactWriteItemsInput(tasksTableName, []dynamo.TaskToRegister{
+			tasksToRegister[0], tasksToRegister[2],
+		}, dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryTracked)).
+		Return(&dynamodb.TransactWriteItemsOutput{}, nil).Once()
+
+	results, err := registererAndMocks.registerer.BatchRegister(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, []task.BatchRegistrationResult{
+		{TaskID: "1", Result: task.RegistrationResultCreated},
+		{TaskID: "2", Result: task.RegistrationResultAlreadyRegistered},
+		{TaskID: "3", Result: task.RegistrationResultCreated},
+	}, results)
+	registererAndMocks.assertExpectations(t)
+}
+
+func TestTaskRegisterer_BatchRegister_Transactional(t *testing.T) {
+	registererAndMocks := newTaskRegistererWithMocks()
+	currentDate := time.Now().UTC()
+	request, tasksToRegister := newBatchRegistrationRequestWithTasks(registererAndMocks, currentDate, true, "1", "2")
+	registererAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	errToReturn := &dynamodb.TransactionCanceledException{
+		CancellationReasons: []*dynamodb.CancellationReason{
+			{Code: aws.String("None")},
+			{Code: aws.String("None")},
+			{Code: aws.String("ConditionalCheckFailed")},
+		},
+	}
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildRegisterTasksTransactWriteItemsInput(tasksTableName, tasksToRegister,
+			dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryTracked)).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), errToReturn).Once()
+
+	results, err := registererAndMocks.registerer.BatchRegister(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, []task.BatchRegistrationResult{
+		{TaskID: "1", Result: task.RegistrationResultCanceled},
+		{TaskID: "2", Result: task.RegistrationResultAlreadyRegistered},
+	}, results)
+	registererAndMocks.assertExpectations(t)
+}
+
+func TestTaskRegisterer_BatchRegister_ProcessSealed(t *testing.T) {
+	registererAndMocks := newTaskRegistererWithMocks()
+	currentDate := time.Now().UTC()
+	request, tasksToRegister := newBatchRegistrationRequestWithTasks(registererAndMocks, currentDate, false, "1", "2")
+	registererAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	errToReturn := &dynamodb.TransactionCanceledException{
+		CancellationReasons: []*dynamodb.CancellationReason{
+			{
+				Code: aws.String("ConditionalCheckFailed"),
+				Item: map[string]*dynamodb.AttributeValue{
+					dynamo.ProcessSealedTimeAttrName: {S: aws.String(currentDate.Format(time.RFC3339))},
+				},
+			},
+			{Code: aws.String("ConditionalCheckFailed")},
+			{Code: aws.String("None")},
+		},
+	}
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildRegisterTasksTransactWriteItemsInput(tasksTableName, tasksToRegister,
+			dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryTracked)).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), errToReturn).Once()
+
+	results, err := registererAndMocks.registerer.BatchRegister(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, []task.BatchRegistrationResult{
+		{TaskID: "1", Result: task.RegistrationResultAlreadyRegistered},
+		{TaskID: "2", Result: task.RegistrationResultProcessSealed},
+	}, results)
+	registererAndMocks.assertExpectations(t)
+}
+
+func TestTaskRegisterer_BatchRegister_LaterChunkFailed(t *testing.T) {
+	registererAndMocks := newTaskRegistererWithMocks()
+	currentDate := time.Now().UTC()
+	taskIDs := make([]string, 0, 100)
+	for taskIndex := 0; taskIndex < 100; taskIndex++ {
+		taskIDs = append(taskIDs, strconv.Itoa(taskIndex))
+	}
+	request, tasksToRegister := newBatchRegistrationRequestWithTasks(registererAndMocks, currentDate, false, taskIDs...)
+	registererAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	firstChunkInput := dynamo.BuildRegisterTasksTransactWriteItemsInput(tasksTableName, tasksToRegister[:99],
+		dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryTracked)
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, firstChunkInput).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), &dynamodb.TransactionCanceledException{
+			CancellationReasons: []*dynamodb.CancellationReason{{Code: aws.String("TransactionConflict")}},
+		}).Once()
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, firstChunkInput).
+		Return(&dynamodb.TransactWriteItemsOutput{}, nil).Once()
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildRegisterTasksTransactWriteItemsInput(tasksTableName, tasksToRegister[99:],
+			dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryTracked)).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), errors.New("throttled")).Once()
+
+	results, err := registererAndMocks.registerer.BatchRegister(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Len(t, results, 100)
+	assert.Equal(t, task.BatchRegistrationResult{TaskID: "0", Result: task.RegistrationResultCreated}, results[0])
+	assert.Equal(t, task.BatchRegistrationResult{TaskID: "99", Result: task.RegistrationResultFailed}, results[99])
+	registererAndMocks.assertExpectations(t)
+}
+
+func TestTaskRegisterer_BatchRegister_FirstChunkFailed(t *testing.T) {
+	registererAndMocks := newTaskRegistererWithMocks()
+	currentDate := time.Now().UTC()
+	request, tasksToRegister := newBatchRegistrationRequestWithTasks(registererAndMocks, currentDate, false, "1")
+	registererAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	errToReturn := &dynamodb.TransactionCanceledException{
+		CancellationReasons: []*dynamodb.CancellationReason{{Code: aws.String("TransactionConflict")}},
+	}
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildRegisterTasksTransactWriteItemsInput(tasksTableName, tasksToRegister,
+			dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryTracked)).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), errToReturn).Times(4)
+
+	_, err := registererAndMocks.registerer.BatchRegister(context.Background(), request)
+	assert.Equal(t, errToReturn, err)
+	registererAndMocks.assertExpectations(t)
+}
+
+func TestTaskRegisterer_BatchRegister_TransactionConflictRetryCanceled(t *testing.T) {
+	registererAndMocks := newTaskRegistererWithMocks()
+	currentDate := time.Now().UTC()
+	request, tasksToRegister := newBatchRegistrationRequestWithTasks(registererAndMocks, currentDate, false, "1")
+	registererAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	ctx, cancel := context.WithCancel(context.Background())
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", ctx,
+		dynamo.BuildRegisterTasksTransactWriteItemsInput(tasksTableName, tasksToRegister,
+			dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryTracked)).
+		Run(func(mock.Arguments) { cancel() }).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), &dynamodb.TransactionCanceledException{
+			CancellationReasons: []*dynamodb.CancellationReason{{Code: aws.String("TransactionConflict")}},
+		}).Once()
+
+	_, err := registererAndMocks.registerer.BatchRegister(ctx, request)
+	assert.Equal(t, context.Canceled, err)
+	registererAndMocks.assertExpectations(t)
+}
+
+func TestBuildRegisterTasksTransactWriteItemsInput(t *testing.T) {
+	currentDate := time.Now().UTC()
+	tasksToRegister := []dynamo.TaskToRegister{
+		{CreationTime: currentDate, RegistrationData: task.RegistrationData{ID: task.ID{ProcessID: "2", TaskID: "1"}}},
+		{CreationTime: currentDate, RegistrationData: task.RegistrationData{ID: task.ID{ProcessID: "2", TaskID: "2"}}},
+	}
+
+	transactWriteItemsInput := dynamo.BuildRegisterTasksTransactWriteItemsInput(tasksTableName, tasksToRegister,
+		dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryTracked)
+	assert.Len(t, transactWriteItemsInput.TransactItems, 3)
+	assert.Equal(t, &dynamodb.AttributeValue{N: aws.String("2")},
+		transactWriteItemsInput.TransactItems[0].Update.ExpressionAttributeValues[":registrationsCountIncrement"])
+}
+
+func TestBuildRegisterInProcessUpdateItemInput_MaintainsProcessSummary(t *testing.T) {
+	earliestExpirationTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+	updateItemInput := dynamo.BuildRegisterInProcessUpdateItemInput(tasksTableName, dynamo.TasksToRegisterInProcess{
+		ProcessID:              "1",
+		TasksCount:             2,
+		CreationTime:           time.Now().UTC(),
+		StoringDuration:        time.Hour,
+		FinishedTasksCount:     1,
+		EarliestExpirationTime: earliestExpirationTime,
+	})
+	assert.Contains(t, *updateItemInput.UpdateExpression,
+		"#earliestExpirationTime = if_not_exists(#earliestExpirationTime, :earliestExpirationTime)")
+	assert.Contains(t, *updateItemInput.ConditionExpression,
+		"(attribute_not_exists(#earliestExpirationTime) or #earliestExpirationTime <= :earliestExpirationTime)")
+	assert.Contains(t, *updateItemInput.ConditionExpression, "#openTasksCount > :zeroTasksCount")
+	assert.Contains(t, *updateItemInput.UpdateExpression, "ADD #registrationsCount :registrationsCountIncrement, "+
+		"#summaryRegistrationsCount :registrationsCountIncrement, "+
+		"#openTasksCount :openTasksCountIncrement, #finishedTasksCount :finishedTasksCountIncrement")
+	assert.NotContains(t, *updateItemInput.UpdateExpression, "#abortedTasksCount")
+	assert.Equal(t, aws.String("2020-01-02T02:04:05Z"), updateItemInput.ExpressionAttributeValues[":earliestExpirationTime"].S)
+	assert.Equal(t, aws.String("1"), updateItemInput.ExpressionAttributeValues[":openTasksCountIncrement"].N)
+	assert.Equal(t, aws.String("1"), updateItemInput.ExpressionAttributeValues[":finishedTasksCountIncrement"].N)
+}
+
+func TestBuildRegisterInProcessUpdateItemInput_EarliestExpirationTimeUpdates(t *testing.T) {
+	tasksToRegister := dynamo.TasksToRegisterInProcess{
+		ProcessID:                    "1",
+		TasksCount:                   1,
+		CreationTime:                 time.Now().UTC(),
+		StoringDuration:              time.Hour,
+		EarliestExpirationTime:       time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		EarliestExpirationTimeUpdate: dynamo.EarliestExpirationTimeLowered,
+	}
+	updateItemInput := dynamo.BuildRegisterInProcessUpdateItemInput(tasksTableName, tasksToRegister)
+	assert.Contains(t, *updateItemInput.UpdateExpression, "#earliestExpirationTime = :earliestExpirationTime")
+	assert.Contains(t, *updateItemInput.ConditionExpression, "#earliestExpirationTime > :earliestExpirationTime")
+	assert.Equal(t, aws.String("2020-01-02T03:04:05Z"), updateItemInput.ExpressionAttributeValues[":earliestExpirationTime"].S)
+
+	tasksToRegister.EarliestExpirationTimeUpdate = dynamo.EarliestExpirationTimeInvalidated
+	updateItemInput = dynamo.BuildRegisterInProcessUpdateItemInput(tasksTableName, tasksToRegister)
+	assert.Contains(t, *updateItemInput.UpdateExpression, "#earliestExpirationTime = :earliestExpirationTime")
+	assert.NotContains(t, *updateItemInput.ConditionExpression, ":earliestExpirationTime")
+	assert.Equal(t, aws.String("0"), updateItemInput.ExpressionAttributeValues[":earliestExpirationTime"].S)
+}
+
+func TestTaskRegisterer_Register_LowersEarliestExpirationTime(t *testing.T) {
+	registererAndMocks := newTaskRegistererWithMocks()
+	currentDate := time.Now().UTC()
+	registrationData := task.RegistrationData{
+		ID: task.ID{
+			ProcessID: "2",
+			TaskID:    "1",
+		},
+		ExpirationTime: currentDate.Add(time.Hour),
+	}
+	registererAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	taskToRegister := dynamo.TaskToRegister{
+		CreationTime:     currentDate,
+		StoringDuration:  registererAndMocks.tasksStoringDuration,
+		RegistrationData: registrationData,
+	}
+	errToReturn := &dynamodb.TransactionCanceledException{
+		CancellationReasons: []*dynamodb.CancellationReason{
+			{
+				Code: aws.String("ConditionalCheckFailed"),
+				Item: map[string]*dynamodb.AttributeValue{
+					dynamo.ProcessRegistrationsCountAttrName: {N: aws.String("1")},
+					dynamo.ProcessEarliestExpirationTimeAttrName: {S: aws.String(
+						currentDate.Add(2 * time.Hour).Format(time.RFC3339))},
+				},
+			},
+			{Code: aws.String("None")},
+		},
+	}
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildRegisterTaskTransactWriteItemsInput(tasksTableName, taskToRegister,
+			dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryTracked)).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), errToReturn).Once()
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildRegisterTaskTransactWriteItemsInput(tasksTableName, taskToRegister,
+			dynamo.EarliestExpirationTimeLowered, dynamo.ProcessSummaryTracked)).
+		Return(&dynamodb.TransactWriteItemsOutput{}, nil).Once()
+
+	registrationResult, err := registererAndMocks.registerer.Register(context.Background(), registrationData)
+	assert.NoError(t, err)
+	assert.Equal(t, task.RegistrationResultCreated, registrationResult)
+	registererAndMocks.assertExpectations(t)
+}
+
+func TestTaskRegisterer_Register_ProcessTerminatedBySummary(t *testing.T) {
+	registererAndMocks := newTaskRegistererWithMocks()
+	currentDate := time.Now().UTC()
+	registrationData := task.RegistrationData{
+		ID: task.ID{
+			ProcessID: "2",
+			TaskID:    "1",
+		},
+		ExpirationTime: currentDate.Add(time.Hour),
+	}
+	registererAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	taskToRegister := dynamo.TaskToRegister{
+		CreationTime:     currentDate,
+		StoringDuration:  registererAndMocks.tasksStoringDuration,
+		RegistrationData: registrationData,
+	}
+	errToReturn := &dynamodb.TransactionCanceledException{
+		CancellationReasons: []*dynamodb.CancellationReason{
+			{
+				Code: aws.String("ConditionalCheckFailed"),
+				Item: map[string]*dynamodb.AttributeValue{
+					dynamo.ProcessRegistrationsCountAttrName:        {N: aws.String("1")},
+					dynamo.ProcessSummaryRegistrationsCountAttrName: {N: aws.String("1")},
+					dynamo.ProcessOpenTasksCountAttrName:            {N: aws.String("0")},
+					dynamo.ProcessFinishedTasksCountAttrName:        {N: aws.String("1")},
+				},
+			},
+			{Code: aws.String("None")},
+		},
+	}
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildRegisterTaskTransactWriteItemsInput(tasksTableName, taskToRegister,
+			dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryTracked)).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), errToReturn).Once()
+
+	registrationResult, err := registererAndMocks.registerer.Register(context.Background(), registrationData)
+	assert.NoError(t, err)
+	assert.Equal(t, task.RegistrationResultProcessSealed, registrationResult)
+	registererAndMocks.assertExpectations(t)
+}
+
+func TestBuildRegisterInProcessUpdateItemInput_SummaryTracking(t *testing.T) {
+	tasksToRegister := dynamo.TasksToRegisterInProcess{
+		ProcessID:       "1",
+		TasksCount:      1,
+		CreationTime:    time.Now().UTC(),
+		StoringDuration: time.Hour,
+		SummaryTracking: dynamo.ProcessSummaryTracked,
+	}
+	updateItemInput := dynamo.BuildRegisterInProcessUpdateItemInput(tasksTableName, tasksToRegister)
+	assert.Contains(t, *updateItemInput.ConditionExpression, "and attribute_exists(#registrationsCount)")
+	assert.Contains(t, *updateItemInput.UpdateExpression, "#summaryRegistrationsCount :registrationsCountIncrement")
+
+	tasksToRegister.SummaryTracking = dynamo.ProcessSummaryInitialized
+	updateItemInput = dynamo.BuildRegisterInProcessUpdateItemInput(tasksTableName, tasksToRegister)
+	assert.Contains(t, *updateItemInput.ConditionExpression, "and attribute_not_exists(#registrationsCount)")
+	assert.Contains(t, *updateItemInput.UpdateExpression, "#summaryRegistrationsCount :registrationsCountIncrement")
+
+	tasksToRegister.SummaryTracking = dynamo.ProcessSummaryUntracked
+	updateItemInput = dynamo.BuildRegisterInProcessUpdateItemInput(tasksTableName, tasksToRegister)
+	assert.Contains(t, *updateItemInput.ConditionExpression, "and attribute_not_exists(#registrationsCount)")
+	assert.Contains(t, *updateItemInput.UpdateExpression, "ADD #registrationsCount :registrationsCountIncrement")
+	assert.NotContains(t, *updateItemInput.UpdateExpression, "#summaryRegistrationsCount")
+}
+
+func (registererAndMocks *taskRegistererWithMocks) mockProcessItemWithoutRegistrationsCount(
+	tasksToRegister []dynamo.TaskToRegister, partitionItems ...map[string]*dynamodb.AttributeValue) {
+	processID := tasksToRegister[0].RegistrationData.ID.ProcessID
+	reasons := []*dynamodb.CancellationReason{{Code: aws.String("ConditionalCheckFailed")}}
+	for range tasksToRegister {
+		reasons = append(reasons, &dynamodb.CancellationReason{Code: aws.String("None")})
+	}
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildRegisterTasksTransactWriteItemsInput(tasksTableName, tasksToRegister,
+			dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryTracked)).
+		Return((*dynamodb.TransactWriteItemsOutput)(nil), &dynamodb.TransactionCanceledException{
+			CancellationReasons: reasons,
+		}).Once()
+	registererAndMocks.dynamoAPI.On("QueryWithContext", mock.Anything,
+		dynamo.BuildCheckIfProcessHasTasksQueryInput(tasksTableName, processID)).
+		Return(&dynamodb.QueryOutput{Items: partitionItems}, nil).Once()
+}
+
+func TestTaskRegisterer_Register_NewProcessInitializesSummary(t *testing.T) {
+	registererAndMocks := newTaskRegistererWithMocks()
+	currentDate := time.Now().UTC()
+	request, tasksToRegister := newBatchRegistrationRequestWithTasks(registererAndMocks, currentDate, false, "1")
+	registererAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	registererAndMocks.mockProcessItemWithoutRegistrationsCount(tasksToRegister)
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildRegisterTasksTransactWriteItemsInput(tasksTableName, tasksToRegister,
+			dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryInitialized)).
+		Return(&dynamodb.TransactWriteItemsOutput{}, nil).Once()
+
+	registrationResult, err := registererAndMocks.registerer.Register(context.Background(), request.Tasks[0])
+	assert.NoError(t, err)
+	assert.Equal(t, task.RegistrationResultCreated, registrationResult)
+	registererAndMocks.assertExpectations(t)
+}
+
+func TestTaskRegisterer_Register_ProcessWithPreUpgradeTasks(t *testing.T) {
+	registererAndMocks := newTaskRegistererWithMocks()
+	currentDate := time.Now().UTC()
+	request, tasksToRegister := newBatchRegistrationRequestWithTasks(registererAndMocks, currentDate, false, "1")
+	registererAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	registererAndMocks.mockProcessItemWithoutRegistrationsCount(tasksToRegister,
+		map[string]*dynamodb.AttributeValue{dynamo.TaskIDAttrName: {S: aws.String(dynamo.ProcessItemTaskID)}},
+		map[string]*dynamodb.AttributeValue{dynamo.TaskIDAttrName: {S: aws.String("legacy")}})
+	untrackedInput := dynamo.BuildRegisterTasksTransactWriteItemsInput(tasksTableName, tasksToRegister,
+		dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryUntracked)
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything, untrackedInput).
+		Return(&dynamodb.TransactWriteItemsOutput{}, nil).Once()
+
+	registrationResult, err := registererAndMocks.registerer.Register(context.Background(), request.Tasks[0])
+	assert.NoError(t, err)
+	assert.Equal(t, task.RegistrationResultCreated, registrationResult)
+	registererAndMocks.assertExpectations(t)
+	assert.NotContains(t, *untrackedInput.TransactItems[0].Update.UpdateExpression, "#summaryRegistrationsCount")
+}
+
+func TestTaskRegisterer_BatchRegister_ProcessWithPreUpgradeTasks(t *testing.T) {
+	registererAndMocks := newTaskRegistererWithMocks()
+	currentDate := time.Now().UTC()
+	request, tasksToRegister := newBatchRegistrationRequestWithTasks(registererAndMocks, currentDate, false, "1", "2")
+	registererAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	registererAndMocks.mockProcessItemWithoutRegistrationsCount(tasksToRegister,
+		map[string]*dynamodb.AttributeValue{dynamo.TaskIDAttrName: {S: aws.String("legacy")}})
+	registererAndMocks.dynamoAPI.On("TransactWriteItemsWithContext", mock.Anything,
+		dynamo.BuildRegisterTasksTransactWriteItemsInput(tasksTableName, tasksToRegister,
+			dynamo.EarliestExpirationTimeKept, dynamo.ProcessSummaryUntracked)).
+		Return(&dynamodb.TransactWriteItemsOutput{}, nil).Once()
+
+	results, err := registererAndMocks.registerer.BatchRegister(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, []task.BatchRegistrationResult{
+		{TaskID: "1", Result: task.RegistrationResultCreated},
+		{TaskID: "2", Result: task.RegistrationResultCreated},
+	}, results)
+	registererAndMocks.assertExpectations(t)
+}
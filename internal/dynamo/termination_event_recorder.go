@@ -0,0 +1,117 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+const (
+	ProcessTerminationEventTimeAttrName      = "termination_event_time"
+	ProcessTerminationEventClaimTimeAttrName = "termination_event_claim_time"
+
+	processTerminationEventTimeAttrAlias      = "#terminationEventTime"
+	processTerminationEventClaimTimeAttrAlias = "#terminationEventClaimTime"
+
+	claimExpirationTimeValuePlaceholder = ":claimExpirationTime"
+)
+
+var (
+	claimTerminationEventConditionExpr = fmt.Sprintf("attribute_not_exists(%s) AND (attribute_not_exists(%s) OR %s <= %s)",
+		processTerminationEventTimeAttrAlias, processTerminationEventClaimTimeAttrAlias,
+		processTerminationEventClaimTimeAttrAlias, claimExpirationTimeValuePlaceholder)
+	claimTerminationEventUpdateExpr = fmt.Sprintf("SET %s = %s", processTerminationEventClaimTimeAttrAlias,
+		currentTimeValuePlaceholder)
+	confirmTerminationEventUpdateExpr = fmt.Sprintf("SET %s = %s REMOVE %s", processTerminationEventTimeAttrAlias,
+		currentTimeValuePlaceholder, processTerminationEventClaimTimeAttrAlias)
+	releaseTerminationEventUpdateExpr = fmt.Sprintf("REMOVE %s", processTerminationEventClaimTimeAttrAlias)
+)
+
+type TerminationEventRecorder struct {
+	dynamoAPI         dynamodbiface.DynamoDBAPI
+	tasksTableName    string
+	currentDateGetter currentDateGetter
+}
+
+func NewTerminationEventRecorder(dynamoAPI dynamodbiface.DynamoDBAPI, tasksTableName string,
+	currentDateGetter currentDateGetter) *TerminationEventRecorder {
+	return &TerminationEventRecorder{
+		dynamoAPI:         dynamoAPI,
+		tasksTableName:    tasksTableName,
+		currentDateGetter: currentDateGetter,
+	}
+}
+
+func (recorder *TerminationEventRecorder) ClaimTerminationEvent(ctx context.Context, processID string) (bool, error) {
+	_, err := recorder.dynamoAPI.UpdateItemWithContext(ctx, BuildClaimTerminationEventUpdateItemInput(
+		recorder.tasksTableName, processID, recorder.currentDateGetter.GetCurrentDate()))
+	if awsErr, isAWSErr := err.(awserr.Error); isAWSErr && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (recorder *TerminationEventRecorder) ConfirmTerminationEvent(ctx context.Context, processID string) error {
+	_, err := recorder.dynamoAPI.UpdateItemWithContext(ctx, BuildConfirmTerminationEventUpdateItemInput(
+		recorder.tasksTableName, processID, recorder.currentDateGetter.GetCurrentDate()))
+	return err
+}
+
+func (recorder *TerminationEventRecorder) ReleaseTerminationEvent(ctx context.Context, processID string) error {
+	_, err := recorder.dynamoAPI.UpdateItemWithContext(ctx,
+		BuildReleaseTerminationEventUpdateItemInput(recorder.tasksTableName, processID))
+	return err
+}
+
+func BuildClaimTerminationEventUpdateItemInput(tableName, processID string,
+	claimTime time.Time) *dynamodb.UpdateItemInput {
+	return &dynamodb.UpdateItemInput{
+		ConditionExpression: &claimTerminationEventConditionExpr,
+		ExpressionAttributeNames: map[string]*string{
+			processTerminationEventTimeAttrAlias:      aws.String(ProcessTerminationEventTimeAttrName),
+			processTerminationEventClaimTimeAttrAlias: aws.String(ProcessTerminationEventClaimTimeAttrName),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			currentTimeValuePlaceholder: {S: aws.String(claimTime.UTC().Format(time.RFC3339))},
+			claimExpirationTimeValuePlaceholder: {
+				S: aws.String(claimTime.Add(-process.TerminationEventClaimLease).UTC().Format(time.RFC3339)),
+			},
+		},
+		Key:              buildProcessItemKey(processID),
+		TableName:        &tableName,
+		UpdateExpression: &claimTerminationEventUpdateExpr,
+	}
+}
+
+func BuildConfirmTerminationEventUpdateItemInput(tableName, processID string,
+	publicationTime time.Time) *dynamodb.UpdateItemInput {
+	return &dynamodb.UpdateItemInput{
+		ExpressionAttributeNames: map[string]*string{
+			processTerminationEventTimeAttrAlias:      aws.String(ProcessTerminationEventTimeAttrName),
+			processTerminationEventClaimTimeAttrAlias: aws.String(ProcessTerminationEventClaimTimeAttrName),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			currentTimeValuePlaceholder: {S: aws.String(publicationTime.UTC().Format(time.RFC3339))},
+		},
+		Key:              buildProcessItemKey(processID),
+		TableName:        &tableName,
+		UpdateExpression: &confirmTerminationEventUpdateExpr,
+	}
+}
+
+func BuildReleaseTerminationEventUpdateItemInput(tableName, processID string) *dynamodb.UpdateItemInput {
+	return &dynamodb.UpdateItemInput{
+		ExpressionAttributeNames: map[string]*string{
+			processTerminationEventClaimTimeAttrAlias: aws.String(ProcessTerminationEventClaimTimeAttrName),
+		},
+		Key:              buildProcessItemKey(processID),
+		TableName:        &tableName,
+		UpdateExpression: &releaseTerminationEventUpdateExpr,
+	}
+}
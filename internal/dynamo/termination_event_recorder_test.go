@@ -0,0 +1,84 @@
+package dynamo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/dynamo"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type terminationEventRecorderWithMocks struct {
+	recorder          *dynamo.TerminationEventRecorder
+	dynamoAPI         *dynamoAPIMock
+	currentDateGetter *currentDateGetterMock
+	currentDate       time.Time
+}
+
+func (recorderAndMocks *terminationEventRecorderWithMocks) assertExpectations(t *testing.T) {
+	recorderAndMocks.dynamoAPI.AssertExpectations(t)
+	recorderAndMocks.currentDateGetter.AssertExpectations(t)
+}
+
+func newTerminationEventRecorderWithMocks() *terminationEventRecorderWithMocks {
+	dynamoAPI := new(dynamoAPIMock)
+	currentDateGetter := new(currentDateGetterMock)
+	currentDate := time.Now().UTC()
+	currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	return &terminationEventRecorderWithMocks{
+		recorder:          dynamo.NewTerminationEventRecorder(dynamoAPI, tasksTableName, currentDateGetter),
+		dynamoAPI:         dynamoAPI,
+		currentDateGetter: currentDateGetter,
+		currentDate:       currentDate,
+	}
+}
+
+func TestTerminationEventRecorder_ClaimTerminationEvent(t *testing.T) {
+	testCases := []struct {
+		name              string
+		updateErr         error
+		expectedIsClaimed bool
+		expectedErr       bool
+	}{
+		{name: "claimed", expectedIsClaimed: true},
+		{name: "already claimed", updateErr: awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil)},
+		{name: "error", updateErr: errors.New("error"), expectedErr: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			recorderAndMocks := newTerminationEventRecorderWithMocks()
+			recorderAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything,
+				dynamo.BuildClaimTerminationEventUpdateItemInput(tasksTableName, "1", recorderAndMocks.currentDate)).
+				Return(&dynamodb.UpdateItemOutput{}, testCase.updateErr)
+
+			isClaimed, err := recorderAndMocks.recorder.ClaimTerminationEvent(context.Background(), "1")
+			assert.Equal(t, testCase.expectedErr, err != nil)
+			assert.Equal(t, testCase.expectedIsClaimed, isClaimed)
+			recorderAndMocks.assertExpectations(t)
+		})
+	}
+}
+
+func TestTerminationEventRecorder_ConfirmTerminationEvent(t *testing.T) {
+	recorderAndMocks := newTerminationEventRecorderWithMocks()
+	recorderAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything,
+		dynamo.BuildConfirmTerminationEventUpdateItemInput(tasksTableName, "1", recorderAndMocks.currentDate)).
+		Return(&dynamodb.UpdateItemOutput{}, nil)
+
+	assert.NoError(t, recorderAndMocks.recorder.ConfirmTerminationEvent(context.Background(), "1"))
+	recorderAndMocks.assertExpectations(t)
+}
+
+func TestTerminationEventRecorder_ReleaseTerminationEvent(t *testing.T) {
+	recorderAndMocks := newTerminationEventRecorderWithMocks()
+	recorderAndMocks.dynamoAPI.On("UpdateItemWithContext", mock.Anything,
+		dynamo.BuildReleaseTerminationEventUpdateItemInput(tasksTableName, "1")).Return(&dynamodb.UpdateItemOutput{}, nil)
+
+	assert.NoError(t, recorderAndMocks.recorder.ReleaseTerminationEvent(context.Background(), "1"))
+	recorderAndMocks.dynamoAPI.AssertExpectations(t)
+}
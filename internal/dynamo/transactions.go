@@ -0,0 +1,93 @@
+package dynamo
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+const (
+	cancellationReasonConditionalCheckFailed = "ConditionalCheckFailed"
+	cancellationReasonTransactionConflict    = "TransactionConflict"
+	maxTransactionConflictRetries            = 3
+	transactionConflictBaseBackoff           = 25 * time.Millisecond
+)
+
+func newTransactUpdate(updateItemInput *dynamodb.UpdateItemInput) *dynamodb.TransactWriteItem {
+	return &dynamodb.TransactWriteItem{
+		Update: &dynamodb.Update{
+			ConditionExpression:       updateItemInput.ConditionExpression,
+			ExpressionAttributeNames:  updateItemInput.ExpressionAttributeNames,
+			ExpressionAttributeValues: updateItemInput.ExpressionAttributeValues,
+			Key:                       updateItemInput.Key,
+			TableName:                 updateItemInput.TableName,
+			UpdateExpression:          updateItemInput.UpdateExpression,
+		},
+	}
+}
+
+func newTransactUpdateReturningOldValues(updateItemInput *dynamodb.UpdateItemInput) *dynamodb.TransactWriteItem {
+	transactUpdate := newTransactUpdate(updateItemInput)
+	transactUpdate.Update.ReturnValuesOnConditionCheckFailure = aws.String(
+		dynamodb.ReturnValuesOnConditionCheckFailureAllOld)
+	return transactUpdate
+}
+
+func isConditionalCheckFailed(reason *dynamodb.CancellationReason) bool {
+	return reason != nil && reason.Code != nil && *reason.Code == cancellationReasonConditionalCheckFailed
+}
+
+func isTransactionConflicted(canceledErr *dynamodb.TransactionCanceledException) bool {
+	for _, reason := range canceledErr.CancellationReasons {
+		if reason != nil && reason.Code != nil && *reason.Code == cancellationReasonTransactionConflict {
+			return true
+		}
+	}
+	return false
+}
+
+func transactWriteItemsRetryingConflicts(ctx context.Context, dynamoAPI dynamodbiface.DynamoDBAPI,
+	transactWriteItemsInput *dynamodb.TransactWriteItemsInput) error {
+	for retries := 0; ; retries++ {
+		_, err := dynamoAPI.TransactWriteItemsWithContext(ctx, transactWriteItemsInput)
+		canceledErr, isCanceledErr := err.(*dynamodb.TransactionCanceledException)
+		if !isCanceledErr || !isTransactionConflicted(canceledErr) || retries == maxTransactionConflictRetries {
+			return err
+		}
+		if err := waitForTransactionConflictRetry(ctx, retries); err != nil {
+			return err
+		}
+	}
+}
+
+func waitForTransactionConflictRetry(ctx context.Context, retries int) error {
+	backoff := transactionConflictBaseBackoff << uint(retries)
+	timer := time.NewTimer(backoff/2 + time.Duration(rand.Int63n(int64(backoff/2))))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func isProcessDeadlineExceeded(reason *dynamodb.CancellationReason, currentTime time.Time) (bool, error) {
+	item, err := readProcessItem(reason.Item)
+	if err != nil {
+		return false, err
+	}
+	return item.isDeadlineExceeded(currentTime), nil
+}
+
+func isProcessClosed(reason *dynamodb.CancellationReason, currentTime time.Time) (bool, error) {
+	item, err := readProcessItem(reason.Item)
+	if err != nil {
+		return false, err
+	}
+	return item.isClosed(currentTime), nil
+}
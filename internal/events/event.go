@@ -0,0 +1,38 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/pkg/errors"
+)
+
+const ProcessTerminatedEventType = "ProcessTerminated"
+
+type ProcessTerminated struct {
+	Process process.Process
+	Time    time.Time
+}
+
+type processTerminatedPayload struct {
+	Type    string               `json:"type"`
+	Time    time.Time            `json:"time"`
+	Process internalHTTP.Process `json:"process"`
+}
+
+func (event ProcessTerminated) JSON() string {
+	proc := event.Process
+	proc.Callback = nil
+	payload := processTerminatedPayload{
+		Type:    ProcessTerminatedEventType,
+		Time:    event.Time.UTC(),
+		Process: internalHTTP.ConvertInternalToHTTPProcess(proc),
+	}
+	marshalledPayload, err := json.Marshal(payload)
+	if err != nil {
+		panic(errors.Wrapf(err, "failed to marshal process terminated event: %+v", event))
+	}
+	return string(marshalledPayload)
+}
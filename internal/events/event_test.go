@@ -0,0 +1,32 @@
+package events_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/events"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func newProcessTerminatedEvent() events.ProcessTerminated {
+	return events.ProcessTerminated{
+		Process: process.Process{
+			ID:           "1",
+			State:        process.StateError,
+			StateMessage: aws.String("failed"),
+			Sealed:       true,
+			Callback:     &process.Callback{URL: "https://example.com", State: process.CallbackStatePending},
+		},
+		Time: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestProcessTerminated_JSON(t *testing.T) {
+	assert.JSONEq(t, `{
+		"type": "ProcessTerminated",
+		"time": "2020-01-02T03:04:05Z",
+		"process": {"id": "1", "state": "ERROR", "stateMessage": "failed", "sealed": true}
+	}`, newProcessTerminatedEvent().JSON())
+}
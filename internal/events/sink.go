@@ -0,0 +1,142 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+)
+
+const (
+	EventTypeAttributeName = "eventType"
+	EventSource            = "termination-detector"
+	stringDataType         = "String"
+)
+
+type Sink interface {
+	Publish(ctx context.Context, event ProcessTerminated) error
+}
+
+type MemorySink struct {
+	mutex  sync.Mutex
+	events []ProcessTerminated
+}
+
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (sink *MemorySink) Publish(_ context.Context, event ProcessTerminated) error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	sink.events = append(sink.events, event)
+	return nil
+}
+
+func (sink *MemorySink) Events() []ProcessTerminated {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	return append([]ProcessTerminated(nil), sink.events...)
+}
+
+type SNSSink struct {
+	snsAPI   snsiface.SNSAPI
+	topicARN string
+}
+
+func NewSNSSink(snsAPI snsiface.SNSAPI, topicARN string) *SNSSink {
+	return &SNSSink{
+		snsAPI:   snsAPI,
+		topicARN: topicARN,
+	}
+}
+
+func (sink *SNSSink) Publish(ctx context.Context, event ProcessTerminated) error {
+	_, err := sink.snsAPI.PublishWithContext(ctx, BuildSNSPublishInput(sink.topicARN, event))
+	return err
+}
+
+func BuildSNSPublishInput(topicARN string, event ProcessTerminated) *sns.PublishInput {
+	return &sns.PublishInput{
+		Message: aws.String(event.JSON()),
+		MessageAttributes: map[string]*sns.MessageAttributeValue{
+			EventTypeAttributeName: {
+				DataType:    aws.String(stringDataType),
+				StringValue: aws.String(ProcessTerminatedEventType),
+			},
+		},
+		TopicArn: &topicARN,
+	}
+}
+
+type SQSSink struct {
+	sqsAPI   sqsiface.SQSAPI
+	queueURL string
+}
+
+func NewSQSSink(sqsAPI sqsiface.SQSAPI, queueURL string) *SQSSink {
+	return &SQSSink{
+		sqsAPI:   sqsAPI,
+		queueURL: queueURL,
+	}
+}
+
+func (sink *SQSSink) Publish(ctx context.Context, event ProcessTerminated) error {
+	_, err := sink.sqsAPI.SendMessageWithContext(ctx, BuildSQSSendMessageInput(sink.queueURL, event))
+	return err
+}
+
+func BuildSQSSendMessageInput(queueURL string, event ProcessTerminated) *sqs.SendMessageInput {
+	return &sqs.SendMessageInput{
+		MessageAttributes: map[string]*sqs.MessageAttributeValue{
+			EventTypeAttributeName: {
+				DataType:    aws.String(stringDataType),
+				StringValue: aws.String(ProcessTerminatedEventType),
+			},
+		},
+		MessageBody: aws.String(event.JSON()),
+		QueueUrl:    &queueURL,
+	}
+}
+
+type EventBridgeSink struct {
+	eventBridgeAPI eventbridgeiface.EventBridgeAPI
+	eventBusName   string
+}
+
+func NewEventBridgeSink(eventBridgeAPI eventbridgeiface.EventBridgeAPI, eventBusName string) *EventBridgeSink {
+	return &EventBridgeSink{
+		eventBridgeAPI: eventBridgeAPI,
+		eventBusName:   eventBusName,
+	}
+}
+
+func (sink *EventBridgeSink) Publish(ctx context.Context, event ProcessTerminated) error {
+	out, err := sink.eventBridgeAPI.PutEventsWithContext(ctx, BuildEventBridgePutEventsInput(sink.eventBusName, event))
+	if err != nil {
+		return err
+	}
+	if out.FailedEntryCount != nil && *out.FailedEntryCount > 0 {
+		return fmt.Errorf("failed to put process terminated event: %+v", out.Entries)
+	}
+	return nil
+}
+
+func BuildEventBridgePutEventsInput(eventBusName string, event ProcessTerminated) *eventbridge.PutEventsInput {
+	return &eventbridge.PutEventsInput{
+		Entries: []*eventbridge.PutEventsRequestEntry{{
+			Detail:       aws.String(event.JSON()),
+			DetailType:   aws.String(ProcessTerminatedEventType),
+			EventBusName: &eventBusName,
+			Source:       aws.String(EventSource),
+			Time:         aws.Time(event.Time),
+		}},
+	}
+}
@@ -0,0 +1,116 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/artii15/termination-detector/internal/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type snsAPIMock struct {
+	mock.Mock
+	snsiface.SNSAPI
+}
+
+func (api *snsAPIMock) PublishWithContext(ctx aws.Context, input *sns.PublishInput, _ ...request.Option) (
+	*sns.PublishOutput, error) {
+	args := api.Called(ctx, input)
+	return args.Get(0).(*sns.PublishOutput), args.Error(1)
+}
+
+type sqsAPIMock struct {
+	mock.Mock
+	sqsiface.SQSAPI
+}
+
+func (api *sqsAPIMock) SendMessageWithContext(ctx aws.Context, input *sqs.SendMessageInput, _ ...request.Option) (
+	*sqs.SendMessageOutput, error) {
+	args := api.Called(ctx, input)
+	return args.Get(0).(*sqs.SendMessageOutput), args.Error(1)
+}
+
+type eventBridgeAPIMock struct {
+	mock.Mock
+	eventbridgeiface.EventBridgeAPI
+}
+
+func (api *eventBridgeAPIMock) PutEventsWithContext(ctx aws.Context, input *eventbridge.PutEventsInput,
+	_ ...request.Option) (*eventbridge.PutEventsOutput, error) {
+	args := api.Called(ctx, input)
+	return args.Get(0).(*eventbridge.PutEventsOutput), args.Error(1)
+}
+
+func TestMemorySink_Publish(t *testing.T) {
+	sink := events.NewMemorySink()
+	event := newProcessTerminatedEvent()
+
+	assert.NoError(t, sink.Publish(context.Background(), event))
+	assert.Equal(t, []events.ProcessTerminated{event}, sink.Events())
+}
+
+func TestSNSSink_Publish(t *testing.T) {
+	snsAPI := new(snsAPIMock)
+	topicARN := "arn:aws:sns:eu-west-1:123456789012:process-events"
+	event := newProcessTerminatedEvent()
+	snsAPI.On("PublishWithContext", mock.Anything, events.BuildSNSPublishInput(topicARN, event)).
+		Return(&sns.PublishOutput{}, nil)
+
+	assert.NoError(t, events.NewSNSSink(snsAPI, topicARN).Publish(context.Background(), event))
+	snsAPI.AssertExpectations(t)
+}
+
+func TestSNSSink_Publish_Error(t *testing.T) {
+	snsAPI := new(snsAPIMock)
+	topicARN := "arn:aws:sns:eu-west-1:123456789012:process-events"
+	event := newProcessTerminatedEvent()
+	snsAPI.On("PublishWithContext", mock.Anything, events.BuildSNSPublishInput(topicARN, event)).
+		Return((*sns.PublishOutput)(nil), errors.New("error"))
+
+	assert.Error(t, events.NewSNSSink(snsAPI, topicARN).Publish(context.Background(), event))
+	snsAPI.AssertExpectations(t)
+}
+
+func TestSQSSink_Publish(t *testing.T) {
+	sqsAPI := new(sqsAPIMock)
+	queueURL := "https://sqs.eu-west-1.amazonaws.com/123456789012/process-events"
+	event := newProcessTerminatedEvent()
+	sqsAPI.On("SendMessageWithContext", mock.Anything, events.BuildSQSSendMessageInput(queueURL, event)).
+		Return(&sqs.SendMessageOutput{}, nil)
+
+	assert.NoError(t, events.NewSQSSink(sqsAPI, queueURL).Publish(context.Background(), event))
+	sqsAPI.AssertExpectations(t)
+}
+
+func TestEventBridgeSink_Publish(t *testing.T) {
+	eventBridgeAPI := new(eventBridgeAPIMock)
+	event := newProcessTerminatedEvent()
+	eventBridgeAPI.On("PutEventsWithContext", mock.Anything, events.BuildEventBridgePutEventsInput("default", event)).
+		Return(&eventbridge.PutEventsOutput{FailedEntryCount: aws.Int64(0)}, nil)
+
+	assert.NoError(t, events.NewEventBridgeSink(eventBridgeAPI, "default").Publish(context.Background(), event))
+	eventBridgeAPI.AssertExpectations(t)
+}
+
+func TestEventBridgeSink_Publish_FailedEntry(t *testing.T) {
+	eventBridgeAPI := new(eventBridgeAPIMock)
+	event := newProcessTerminatedEvent()
+	eventBridgeAPI.On("PutEventsWithContext", mock.Anything, events.BuildEventBridgePutEventsInput("default", event)).
+		Return(&eventbridge.PutEventsOutput{
+			FailedEntryCount: aws.Int64(1),
+			Entries:          []*eventbridge.PutEventsResultEntry{{ErrorCode: aws.String("InternalFailure")}},
+		}, nil)
+
+	assert.Error(t, events.NewEventBridgeSink(eventBridgeAPI, "default").Publish(context.Background(), event))
+	eventBridgeAPI.AssertExpectations(t)
+}
@@ -0,0 +1,63 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/artii15/termination-detector/pkg/env"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sqs"
+)
+
+type SinkType string
+
+const (
+	SinkTypeSNS         SinkType = "sns"
+	SinkTypeSQS         SinkType = "sqs"
+	SinkTypeEventBridge SinkType = "eventbridge"
+	SinkTypeMemory      SinkType = "memory"
+
+	DefaultSinkType = SinkTypeSNS
+
+	SinkTypeEnvVar      = "EVENTS_SINK"
+	snsTopicARNEnvVar   = "EVENTS_SNS_TOPIC_ARN"
+	sqsQueueURLEnvVar   = "EVENTS_SQS_QUEUE_URL"
+	eventBusNameEnvVar  = "EVENTS_EVENT_BUS_NAME"
+	defaultEventBusName = "default"
+)
+
+func ReadSinkType() SinkType {
+	return SinkType(env.ReadOrDefault(SinkTypeEnvVar, string(DefaultSinkType)))
+}
+
+func BuildSink(sinkType SinkType) (Sink, error) {
+	if sinkType == SinkTypeMemory {
+		return NewMemorySink(), nil
+	}
+	awsSess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch sinkType {
+	case SinkTypeSNS:
+		topicARN, err := env.Read(snsTopicARNEnvVar)
+		if err != nil {
+			return nil, err
+		}
+		return NewSNSSink(sns.New(awsSess), topicARN), nil
+	case SinkTypeSQS:
+		queueURL, err := env.Read(sqsQueueURLEnvVar)
+		if err != nil {
+			return nil, err
+		}
+		return NewSQSSink(sqs.New(awsSess), queueURL), nil
+	case SinkTypeEventBridge:
+		return NewEventBridgeSink(eventbridge.New(awsSess),
+			env.ReadOrDefault(eventBusNameEnvVar, defaultEventBusName)), nil
+	default:
+		return nil, fmt.Errorf("unknown events sink: %s", sinkType)
+	}
+}
@@ -0,0 +1,47 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/pkg/errors"
+)
+
+type TerminationPublisher struct {
+	processGetter            process.Getter
+	terminationEventRecorder process.TerminationEventRecorder
+	sink                     Sink
+}
+
+func NewTerminationPublisher(processGetter process.Getter, terminationEventRecorder process.TerminationEventRecorder,
+	sink Sink) *TerminationPublisher {
+	return &TerminationPublisher{
+		processGetter:            processGetter,
+		terminationEventRecorder: terminationEventRecorder,
+		sink:                     sink,
+	}
+}
+
+func (publisher *TerminationPublisher) PublishIfTerminated(ctx context.Context, processID string,
+	terminationTime time.Time) error {
+	proc, err := publisher.processGetter.Get(ctx, processID)
+	if err != nil || proc == nil || !proc.IsTerminated() {
+		return err
+	}
+	isClaimed, err := publisher.terminationEventRecorder.ClaimTerminationEvent(ctx, processID)
+	if err != nil || !isClaimed {
+		return err
+	}
+	publishingErr := publisher.sink.Publish(ctx, ProcessTerminated{
+		Process: *proc,
+		Time:    terminationTime,
+	})
+	if publishingErr == nil {
+		return publisher.terminationEventRecorder.ConfirmTerminationEvent(ctx, processID)
+	}
+	if err := publisher.terminationEventRecorder.ReleaseTerminationEvent(ctx, processID); err != nil {
+		return errors.Wrapf(publishingErr, "failed to release termination event: %s", err.Error())
+	}
+	return publishingErr
+}
@@ -0,0 +1,134 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/events"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type processGetterMock struct {
+	mock.Mock
+}
+
+func (getter *processGetterMock) Get(ctx context.Context, processID string) (*process.Process, error) {
+	args := getter.Called(ctx, processID)
+	return args.Get(0).(*process.Process), args.Error(1)
+}
+
+type terminationEventRecorderMock struct {
+	mock.Mock
+}
+
+func (recorder *terminationEventRecorderMock) ClaimTerminationEvent(ctx context.Context, processID string) (bool, error) {
+	args := recorder.Called(ctx, processID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (recorder *terminationEventRecorderMock) ConfirmTerminationEvent(ctx context.Context, processID string) error {
+	return recorder.Called(ctx, processID).Error(0)
+}
+
+func (recorder *terminationEventRecorderMock) ReleaseTerminationEvent(ctx context.Context, processID string) error {
+	return recorder.Called(ctx, processID).Error(0)
+}
+
+type sinkMock struct {
+	mock.Mock
+}
+
+func (sink *sinkMock) Publish(ctx context.Context, event events.ProcessTerminated) error {
+	return sink.Called(ctx, event).Error(0)
+}
+
+type terminationPublisherWithMocks struct {
+	publisher                *events.TerminationPublisher
+	processGetter            *processGetterMock
+	terminationEventRecorder *terminationEventRecorderMock
+	sink                     *sinkMock
+}
+
+func (publisherAndMocks *terminationPublisherWithMocks) assertExpectations(t *testing.T) {
+	publisherAndMocks.processGetter.AssertExpectations(t)
+	publisherAndMocks.terminationEventRecorder.AssertExpectations(t)
+	publisherAndMocks.sink.AssertExpectations(t)
+}
+
+func newTerminationPublisherWithMocks() *terminationPublisherWithMocks {
+	processGetter := new(processGetterMock)
+	terminationEventRecorder := new(terminationEventRecorderMock)
+	sink := new(sinkMock)
+	return &terminationPublisherWithMocks{
+		publisher:                events.NewTerminationPublisher(processGetter, terminationEventRecorder, sink),
+		processGetter:            processGetter,
+		terminationEventRecorder: terminationEventRecorder,
+		sink:                     sink,
+	}
+}
+
+func TestTerminationPublisher_PublishIfTerminated(t *testing.T) {
+	publisherAndMocks := newTerminationPublisherWithMocks()
+	proc := &process.Process{ID: "1", State: process.StateCompleted}
+	terminationTime := time.Unix(1000, 0)
+	publisherAndMocks.processGetter.On("Get", mock.Anything, proc.ID).Return(proc, nil)
+	publisherAndMocks.terminationEventRecorder.On("ClaimTerminationEvent", mock.Anything, proc.ID).Return(true, nil)
+	publisherAndMocks.sink.On("Publish", mock.Anything, events.ProcessTerminated{Process: *proc, Time: terminationTime}).
+		Return(nil)
+	publisherAndMocks.terminationEventRecorder.On("ConfirmTerminationEvent", mock.Anything, proc.ID).Return(nil)
+
+	assert.NoError(t, publisherAndMocks.publisher.PublishIfTerminated(context.Background(), proc.ID, terminationTime))
+	publisherAndMocks.assertExpectations(t)
+}
+
+func TestTerminationPublisher_PublishIfTerminated_ConfirmationError(t *testing.T) {
+	publisherAndMocks := newTerminationPublisherWithMocks()
+	proc := &process.Process{ID: "1", State: process.StateCompleted}
+	terminationTime := time.Unix(1000, 0)
+	publisherAndMocks.processGetter.On("Get", mock.Anything, proc.ID).Return(proc, nil)
+	publisherAndMocks.terminationEventRecorder.On("ClaimTerminationEvent", mock.Anything, proc.ID).Return(true, nil)
+	publisherAndMocks.sink.On("Publish", mock.Anything, events.ProcessTerminated{Process: *proc, Time: terminationTime}).
+		Return(nil)
+	publisherAndMocks.terminationEventRecorder.On("ConfirmTerminationEvent", mock.Anything, proc.ID).
+		Return(errors.New("error"))
+
+	assert.Error(t, publisherAndMocks.publisher.PublishIfTerminated(context.Background(), proc.ID, terminationTime))
+	publisherAndMocks.assertExpectations(t)
+}
+
+func TestTerminationPublisher_PublishIfTerminated_NotTerminated(t *testing.T) {
+	publisherAndMocks := newTerminationPublisherWithMocks()
+	proc := &process.Process{ID: "1", State: process.StateCreated}
+	publisherAndMocks.processGetter.On("Get", mock.Anything, proc.ID).Return(proc, nil)
+
+	assert.NoError(t, publisherAndMocks.publisher.PublishIfTerminated(context.Background(), proc.ID, time.Now()))
+	publisherAndMocks.assertExpectations(t)
+}
+
+func TestTerminationPublisher_PublishIfTerminated_AlreadyClaimed(t *testing.T) {
+	publisherAndMocks := newTerminationPublisherWithMocks()
+	proc := &process.Process{ID: "1", State: process.StateError}
+	publisherAndMocks.processGetter.On("Get", mock.Anything, proc.ID).Return(proc, nil)
+	publisherAndMocks.terminationEventRecorder.On("ClaimTerminationEvent", mock.Anything, proc.ID).Return(false, nil)
+
+	assert.NoError(t, publisherAndMocks.publisher.PublishIfTerminated(context.Background(), proc.ID, time.Now()))
+	publisherAndMocks.assertExpectations(t)
+}
+
+func TestTerminationPublisher_PublishIfTerminated_PublishingError(t *testing.T) {
+	publisherAndMocks := newTerminationPublisherWithMocks()
+	proc := &process.Process{ID: "1", State: process.StateCompleted}
+	terminationTime := time.Unix(1000, 0)
+	publisherAndMocks.processGetter.On("Get", mock.Anything, proc.ID).Return(proc, nil)
+	publisherAndMocks.terminationEventRecorder.On("ClaimTerminationEvent", mock.Anything, proc.ID).Return(true, nil)
+	publisherAndMocks.sink.On("Publish", mock.Anything, events.ProcessTerminated{Process: *proc, Time: terminationTime}).
+		Return(errors.New("error"))
+	publisherAndMocks.terminationEventRecorder.On("ReleaseTerminationEvent", mock.Anything, proc.ID).Return(nil)
+
+	assert.Error(t, publisherAndMocks.publisher.PublishIfTerminated(context.Background(), proc.ID, terminationTime))
+	publisherAndMocks.assertExpectations(t)
+}
@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+func (store *Store) ListPendingCallbacks(_ context.Context, afterProcessID string, limit int) ([]string, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	var processIDs []string
+	for processID, storedProcess := range store.processes {
+		if processID > afterProcessID && storedProcess.callback.IsPending() {
+			processIDs = append(processIDs, processID)
+		}
+	}
+	sort.Strings(processIDs)
+	if len(processIDs) > limit {
+		processIDs = processIDs[:limit]
+	}
+	return processIDs, nil
+}
+
+func (store *Store) RecordCallbackDelivery(_ context.Context, delivery process.CallbackDelivery) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	storedProcess, processExists := store.processes[delivery.ProcessID]
+	if !processExists || storedProcess.callback == nil {
+		return nil
+	}
+	storedProcess.callback.State = delivery.State
+	storedProcess.callback.Attempts = delivery.Attempts
+	storedProcess.callback.LastError = copyMessage(delivery.LastError)
+	storedProcess.callback.DeliveryTime = truncateToStoredPrecision(delivery.DeliveryTime)
+	return nil
+}
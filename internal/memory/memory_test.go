@@ -0,0 +1,51 @@
+package memory_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/memory"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/stretchr/testify/mock"
+)
+
+type currentDateGetterMock struct {
+	mock.Mock
+}
+
+func (getter *currentDateGetterMock) GetCurrentDate() time.Time {
+	return getter.Called().Get(0).(time.Time)
+}
+
+type storeWithMocks struct {
+	store             *memory.Store
+	currentDateGetter *currentDateGetterMock
+	currentDate       time.Time
+}
+
+func newStoreWithMocks() *storeWithMocks {
+	currentDateGetter := new(currentDateGetterMock)
+	currentDate := time.Now().UTC()
+	currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	return &storeWithMocks{
+		store:             memory.NewStore(currentDateGetter),
+		currentDateGetter: currentDateGetter,
+		currentDate:       currentDate,
+	}
+}
+
+func (storeAndMocks *storeWithMocks) mustRegister(taskID task.ID, expirationTime time.Time) {
+	registrationResult, err := storeAndMocks.store.Register(context.Background(), task.RegistrationData{
+		ID:             taskID,
+		ExpirationTime: expirationTime,
+	})
+	if err != nil || registrationResult != task.RegistrationResultCreated {
+		panic("failed to register test task")
+	}
+}
+
+func (storeAndMocks *storeWithMocks) moveCurrentDate(currentDate time.Time) {
+	storeAndMocks.currentDateGetter.ExpectedCalls = nil
+	storeAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	storeAndMocks.currentDate = currentDate
+}
@@ -0,0 +1,136 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+func (store *Store) Get(_ context.Context, processID string) (*process.Process, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	storedProcess, processExists := store.processes[processID]
+	if !processExists {
+		return nil, nil
+	}
+
+	foundProcess, err := store.observeProcess(processID, storedProcess)
+	if err != nil {
+		return nil, err
+	}
+	return &foundProcess, nil
+}
+
+func (store *Store) observeProcess(processID string, storedProcess *storedProcess) (process.Process, error) {
+	foundProcess, err := store.evaluateProcess(processID, storedProcess)
+	if err != nil {
+		return process.Process{}, err
+	}
+	if foundProcess.IsTerminated() {
+		storedProcess.isSealed = true
+	}
+	foundProcess.Sealed = storedProcess.isSealed
+	foundProcess.Callback = copyCallback(storedProcess.callback)
+	foundProcess.Deadline = storedProcess.deadline
+	foundProcess.Labels = copyLabels(storedProcess.metadata.labels)
+	foundProcess.Description = copyMessage(storedProcess.metadata.description)
+	foundProcess.CreationTime = storedProcess.metadata.creationTime
+	foundProcess.Creator = copyMessage(storedProcess.metadata.creator)
+	foundProcess.Progress = newProcessProgress(storedProcess.tasks, store.currentDateGetter.GetCurrentDate())
+	return foundProcess, nil
+}
+
+func newProcessProgress(processTasks map[string]*storedTask, currentTime time.Time) *process.Progress {
+	progress := &process.Progress{TotalTasksCount: len(processTasks)}
+	for _, storedTask := range processTasks {
+		switch storedTask.state {
+		case task.StateCreated:
+			progress.CreatedTasksCount++
+			if currentTime.Before(storedTask.expirationTime) && (progress.EarliestPendingExpirationTime.IsZero() ||
+				storedTask.expirationTime.Before(progress.EarliestPendingExpirationTime)) {
+				progress.EarliestPendingExpirationTime = storedTask.expirationTime
+			}
+		case task.StateFinished:
+			progress.FinishedTasksCount++
+		case task.StateAborted:
+			progress.AbortedTasksCount++
+		case task.StateTimedOut:
+			progress.TimedOutTasksCount++
+		}
+	}
+	return progress
+}
+
+func (store *Store) evaluateProcess(processID string, processToEvaluate *storedProcess) (process.Process, error) {
+	firstBadTaskID, firstBadTask := findFirstTaskInBadState(processToEvaluate.tasks)
+	if firstBadTask == nil {
+		return process.Process{ID: processID, State: process.StateCompleted}, nil
+	}
+	currentTime := truncateToStoredPrecision(store.currentDateGetter.GetCurrentDate())
+	if process.IsDeadlineExceededBefore(processToEvaluate.deadline, firstBadTask.badStateEnterTime, currentTime) {
+		return newDeadlineExceededProcess(processID), nil
+	}
+
+	switch firstBadTask.state {
+	case task.StateAborted:
+		return process.Process{
+			ID:           processID,
+			State:        process.StateError,
+			StateMessage: copyMessage(firstBadTask.stateMessage),
+		}, nil
+	case task.StateCreated:
+		return store.reportFailureIfTaskTimedOut(processID, firstBadTask), nil
+	case task.StateTimedOut:
+		return newTimedOutProcess(processID), nil
+	default:
+		return process.Process{}, fmt.Errorf("unexpected state of task %s: %s", firstBadTaskID, firstBadTask.state)
+	}
+}
+
+func (store *Store) reportFailureIfTaskTimedOut(processID string, storedTask *storedTask) process.Process {
+	if store.currentDateGetter.GetCurrentDate().Before(storedTask.badStateEnterTime) {
+		return process.Process{
+			ID:    processID,
+			State: process.StateCreated,
+		}
+	}
+	return newTimedOutProcess(processID)
+}
+
+func newTimedOutProcess(processID string) process.Process {
+	timedOutErrorMessage := process.TimedOutErrorMessage
+	return process.Process{
+		ID:           processID,
+		State:        process.StateError,
+		StateMessage: &timedOutErrorMessage,
+	}
+}
+
+func newDeadlineExceededProcess(processID string) process.Process {
+	deadlineExceededErrorMessage := process.DeadlineExceededErrorMessage
+	return process.Process{
+		ID:           processID,
+		State:        process.StateError,
+		StateMessage: &deadlineExceededErrorMessage,
+	}
+}
+
+func findFirstTaskInBadState(processTasks map[string]*storedTask) (string, *storedTask) {
+	var firstBadTaskID string
+	var firstBadTask *storedTask
+	for taskID, storedTask := range processTasks {
+		if !storedTask.isInBadState() {
+			continue
+		}
+		if firstBadTask == nil || storedTask.badStateEnterTime.Before(firstBadTask.badStateEnterTime) ||
+			(storedTask.badStateEnterTime.Equal(firstBadTask.badStateEnterTime) && taskID < firstBadTaskID) {
+			firstBadTaskID = taskID
+			firstBadTask = storedTask
+		}
+	}
+	return firstBadTaskID, firstBadTask
+}
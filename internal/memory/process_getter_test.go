@@ -0,0 +1,173 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_Get_ProcessNotExists(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+
+	proc, err := storeAndMocks.store.Get(context.Background(), "1")
+	assert.NoError(t, err)
+	assert.Nil(t, proc)
+}
+
+func TestStore_Get_CompletedProcess(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+	taskID := task.ID{ProcessID: "1", TaskID: "1"}
+	storeAndMocks.mustRegister(taskID, storeAndMocks.currentDate.Add(time.Hour))
+	_, err := storeAndMocks.store.Complete(context.Background(), task.CompleteRequest{ID: taskID, State: task.StateFinished})
+	assert.NoError(t, err)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), taskID.ProcessID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           taskID.ProcessID,
+		State:        process.StateCompleted,
+		Sealed:       true,
+		CreationTime: storeAndMocks.currentDate.Truncate(time.Second),
+		Progress: &process.Progress{
+			TotalTasksCount:    1,
+			FinishedTasksCount: 1,
+		},
+	}, proc)
+}
+
+func TestStore_Get_AbortedProcess(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+	processID := "1"
+	abortedTaskID := task.ID{ProcessID: processID, TaskID: "1"}
+	storeAndMocks.mustRegister(abortedTaskID, storeAndMocks.currentDate.Add(time.Hour))
+	storeAndMocks.mustRegister(task.ID{ProcessID: processID, TaskID: "2"}, storeAndMocks.currentDate.Add(time.Hour))
+	failureReason := "failure"
+	_, err := storeAndMocks.store.Complete(context.Background(), task.CompleteRequest{
+		ID:      abortedTaskID,
+		State:   task.StateAborted,
+		Message: &failureReason,
+	})
+	assert.NoError(t, err)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), processID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           processID,
+		State:        process.StateError,
+		StateMessage: &failureReason,
+		Sealed:       true,
+		CreationTime: storeAndMocks.currentDate.Truncate(time.Second),
+		Progress: &process.Progress{
+			TotalTasksCount:               2,
+			CreatedTasksCount:             1,
+			AbortedTasksCount:             1,
+			EarliestPendingExpirationTime: storeAndMocks.currentDate.Add(time.Hour).Truncate(time.Second),
+		},
+	}, proc)
+}
+
+func TestStore_Get_ProcessTimedOut(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+	processID := "1"
+	storeAndMocks.mustRegister(task.ID{ProcessID: processID, TaskID: "1"}, storeAndMocks.currentDate.Add(-time.Hour))
+	storeAndMocks.mustRegister(task.ID{ProcessID: processID, TaskID: "2"}, storeAndMocks.currentDate.Add(time.Hour))
+
+	proc, err := storeAndMocks.store.Get(context.Background(), processID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           processID,
+		State:        process.StateError,
+		StateMessage: aws.String(process.TimedOutErrorMessage),
+		Sealed:       true,
+		CreationTime: storeAndMocks.currentDate.Truncate(time.Second),
+		Progress: &process.Progress{
+			TotalTasksCount:               2,
+			CreatedTasksCount:             2,
+			EarliestPendingExpirationTime: storeAndMocks.currentDate.Add(time.Hour).Truncate(time.Second),
+		},
+	}, proc)
+}
+
+func TestStore_Get_ProcessIsWaiting(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+	processID := "1"
+	finishedTaskID := task.ID{ProcessID: processID, TaskID: "1"}
+	storeAndMocks.mustRegister(finishedTaskID, storeAndMocks.currentDate.Add(time.Hour))
+	storeAndMocks.mustRegister(task.ID{ProcessID: processID, TaskID: "2"}, storeAndMocks.currentDate.Add(time.Hour))
+	_, err := storeAndMocks.store.Complete(context.Background(), task.CompleteRequest{ID: finishedTaskID, State: task.StateFinished})
+	assert.NoError(t, err)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), processID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           processID,
+		State:        process.StateCreated,
+		CreationTime: storeAndMocks.currentDate.Truncate(time.Second),
+		Progress: &process.Progress{
+			TotalTasksCount:               2,
+			CreatedTasksCount:             1,
+			FinishedTasksCount:            1,
+			EarliestPendingExpirationTime: storeAndMocks.currentDate.Add(time.Hour).Truncate(time.Second),
+		},
+	}, proc)
+}
+
+func TestStore_Get_ProcessDeadlineExceeded(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+	processID := "1"
+	processDeadline := storeAndMocks.currentDate.Add(-time.Minute).Truncate(time.Second)
+	_, err := storeAndMocks.store.Register(context.Background(), task.RegistrationData{
+		ID:              task.ID{ProcessID: processID, TaskID: "1"},
+		ExpirationTime:  storeAndMocks.currentDate.Add(time.Hour),
+		ProcessDeadline: processDeadline,
+	})
+	assert.NoError(t, err)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), processID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           processID,
+		State:        process.StateError,
+		StateMessage: aws.String(process.DeadlineExceededErrorMessage),
+		Sealed:       true,
+		Deadline:     processDeadline,
+		CreationTime: storeAndMocks.currentDate.Truncate(time.Second),
+		Progress: &process.Progress{
+			TotalTasksCount:               1,
+			CreatedTasksCount:             1,
+			EarliestPendingExpirationTime: storeAndMocks.currentDate.Add(time.Hour).Truncate(time.Second),
+		},
+	}, proc)
+}
+
+func TestStore_Get_ProcessTimedOutBeforeDeadline(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+	processID := "1"
+	processDeadline := storeAndMocks.currentDate.Add(-time.Minute).Truncate(time.Second)
+	_, err := storeAndMocks.store.Register(context.Background(), task.RegistrationData{
+		ID:              task.ID{ProcessID: processID, TaskID: "1"},
+		ExpirationTime:  storeAndMocks.currentDate.Add(-time.Hour),
+		ProcessDeadline: processDeadline,
+	})
+	assert.NoError(t, err)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), processID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           processID,
+		State:        process.StateError,
+		StateMessage: aws.String(process.TimedOutErrorMessage),
+		Sealed:       true,
+		Deadline:     processDeadline,
+		CreationTime: storeAndMocks.currentDate.Truncate(time.Second),
+		Progress: &process.Progress{
+			TotalTasksCount:   1,
+			CreatedTasksCount: 1,
+		},
+	}, proc)
+}
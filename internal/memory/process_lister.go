@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+func (store *Store) ListProcesses(_ context.Context, request process.ListRequest) (process.List, error) {
+	var lastPosition *process.ListPosition
+	if request.Cursor != "" {
+		decodedPosition, err := process.DecodeListCursor(request.Cursor)
+		if err != nil {
+			return process.List{}, err
+		}
+		lastPosition = &decodedPosition
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	processesList := process.List{Processes: []process.Process{}}
+	for _, position := range store.sortedProcessesPositions() {
+		if lastPosition != nil && !position.IsAfter(*lastPosition) {
+			continue
+		}
+		if !request.CreatedAfter.IsZero() && !position.CreationTime.After(request.CreatedAfter) {
+			continue
+		}
+		listedProcess, err := store.observeProcess(position.ProcessID, store.processes[position.ProcessID])
+		if err != nil {
+			return process.List{}, err
+		}
+		if !request.Matches(listedProcess) {
+			continue
+		}
+		if len(processesList.Processes) == request.Limit {
+			processesList.NextCursor = process.EncodeListCursor(newListPosition(
+				processesList.Processes[len(processesList.Processes)-1]))
+			break
+		}
+		processesList.Processes = append(processesList.Processes, listedProcess)
+	}
+	return processesList, nil
+}
+
+func (store *Store) sortedProcessesPositions() []process.ListPosition {
+	positions := make([]process.ListPosition, 0, len(store.processes))
+	for processID, storedProcess := range store.processes {
+		positions = append(positions, process.ListPosition{
+			CreationTime: storedProcess.metadata.creationTime,
+			ProcessID:    processID,
+		})
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		return positions[j].IsAfter(positions[i])
+	})
+	return positions
+}
+
+func newListPosition(proc process.Process) process.ListPosition {
+	return process.ListPosition{CreationTime: proc.CreationTime, ProcessID: proc.ID}
+}
@@ -0,0 +1,19 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+func (store *Store) Seal(_ context.Context, processID string) (process.SealingResult, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	processToSeal, processExists := store.processes[processID]
+	if !processExists {
+		return process.SealingResultNotFound, nil
+	}
+	processToSeal.isSealed = true
+	return process.SealingResultSealed, nil
+}
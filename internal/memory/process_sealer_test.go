@@ -0,0 +1,43 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_Seal(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+	processID := "1"
+	storeAndMocks.mustRegister(task.ID{ProcessID: processID, TaskID: "1"}, storeAndMocks.currentDate.Add(time.Hour))
+
+	sealingResult, err := storeAndMocks.store.Seal(context.Background(), processID)
+	assert.NoError(t, err)
+	assert.Equal(t, process.SealingResultSealed, sealingResult)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), processID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           processID,
+		State:        process.StateCreated,
+		Sealed:       true,
+		CreationTime: storeAndMocks.currentDate.Truncate(time.Second),
+		Progress: &process.Progress{
+			TotalTasksCount:               1,
+			CreatedTasksCount:             1,
+			EarliestPendingExpirationTime: storeAndMocks.currentDate.Add(time.Hour).Truncate(time.Second),
+		},
+	}, proc)
+}
+
+func TestStore_Seal_ProcessNotExists(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+
+	sealingResult, err := storeAndMocks.store.Seal(context.Background(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, process.SealingResultNotFound, sealingResult)
+}
@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+func (store *Store) Update(_ context.Context, request process.UpdateRequest) (process.UpdatingResult, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	processToUpdate, processExists := store.processes[request.ProcessID]
+	if !processExists {
+		return process.UpdatingResultNotFound, nil
+	}
+	if request.CallbackURL != nil {
+		processToUpdate.callback = newPendingCallback(*request.CallbackURL)
+	}
+	if request.Deadline != nil {
+		processToUpdate.deadline = truncateToStoredPrecision(*request.Deadline)
+	}
+	if request.Labels != nil {
+		processToUpdate.metadata.labels = copyLabels(request.Labels)
+	}
+	if request.Description != nil {
+		processToUpdate.metadata.description = copyMessage(request.Description)
+	}
+	return process.UpdatingResultUpdated, nil
+}
+
+func newPendingCallback(url string) *process.Callback {
+	return &process.Callback{
+		URL:   url,
+		State: process.CallbackStatePending,
+	}
+}
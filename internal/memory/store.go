@@ -0,0 +1,136 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+type currentDateGetter interface {
+	GetCurrentDate() time.Time
+}
+
+type storedTask struct {
+	state             task.State
+	stateMessage      *string
+	expirationTime    time.Time
+	creationTime      time.Time
+	badStateEnterTime time.Time
+}
+
+func (storedTask *storedTask) isInBadState() bool {
+	return !storedTask.badStateEnterTime.IsZero()
+}
+
+type storedProcess struct {
+	tasks                       map[string]*storedTask
+	isSealed                    bool
+	callback                    *process.Callback
+	deadline                    time.Time
+	metadata                    processMetadata
+	terminationEventClaimTime   time.Time
+	isTerminationEventPublished bool
+}
+
+func (storedProcess *storedProcess) areTasksTerminated() bool {
+	hasCreatedTasks := false
+	for _, storedTask := range storedProcess.tasks {
+		switch storedTask.state {
+		case task.StateAborted, task.StateTimedOut:
+			return true
+		case task.StateCreated:
+			hasCreatedTasks = true
+		}
+	}
+	return len(storedProcess.tasks) > 0 && !hasCreatedTasks
+}
+
+type processMetadata struct {
+	labels       map[string]string
+	description  *string
+	creationTime time.Time
+	creator      *string
+}
+
+type Store struct {
+	mutex             sync.RWMutex
+	processes         map[string]*storedProcess
+	currentDateGetter currentDateGetter
+}
+
+func NewStore(currentDateGetter currentDateGetter) *Store {
+	return &Store{
+		processes:         make(map[string]*storedProcess),
+		currentDateGetter: currentDateGetter,
+	}
+}
+
+func (store *Store) findTask(taskID task.ID) (*storedTask, bool) {
+	foundProcess, processExists := store.processes[taskID.ProcessID]
+	if !processExists {
+		return nil, false
+	}
+	foundTask, taskExists := foundProcess.tasks[taskID.TaskID]
+	return foundTask, taskExists
+}
+
+func (store *Store) isSealed(processID string) bool {
+	foundProcess, processExists := store.processes[processID]
+	if !processExists {
+		return false
+	}
+	if foundProcess.areTasksTerminated() {
+		foundProcess.isSealed = true
+	}
+	return foundProcess.isSealed
+}
+
+func (store *Store) isDeadlineExceeded(processID string, currentTime time.Time) bool {
+	foundProcess, processExists := store.processes[processID]
+	return processExists && process.IsDeadlineExceeded(foundProcess.deadline, truncateToStoredPrecision(currentTime))
+}
+
+func (storedTask *storedTask) toTask(taskID task.ID, currentTime time.Time) task.Task {
+	return task.Task{
+		ID:             taskID,
+		State:          storedTask.state,
+		StateMessage:   copyMessage(storedTask.stateMessage),
+		ExpirationTime: storedTask.expirationTime,
+		CreationTime:   storedTask.creationTime,
+		TimedOut:       task.IsTimedOut(storedTask.state, storedTask.expirationTime, currentTime),
+	}
+}
+
+func truncateToStoredPrecision(date time.Time) time.Time {
+	return date.Truncate(time.Second)
+}
+
+func copyMessage(message *string) *string {
+	if message == nil {
+		return nil
+	}
+	messageCopy := *message
+	return &messageCopy
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	if labels == nil {
+		return nil
+	}
+	labelsCopy := make(map[string]string, len(labels))
+	for labelName, labelValue := range labels {
+		labelsCopy[labelName] = labelValue
+	}
+	return labelsCopy
+}
+
+func copyCallback(callback *process.Callback) *process.Callback {
+	if callback == nil {
+		return nil
+	}
+	callbackCopy := *callback
+	callbackCopy.LastError = copyMessage(callback.LastError)
+	return &callbackCopy
+}
@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+func (store *Store) Complete(_ context.Context, request task.CompleteRequest) (task.CompletingResult, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.complete(request, store.currentDateGetter.GetCurrentDate()), nil
+}
+
+func (store *Store) CompleteWithChildren(_ context.Context, request task.CompleteWithChildrenRequest) (task.CompletingResult, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	completionTime := store.currentDateGetter.GetCurrentDate()
+	if taskToComplete, taskExists := store.findTask(request.ID); !taskExists || !canBeCompleted(taskToComplete, completionTime) {
+		return store.readCompletingConflictResult(request.CompleteRequest, completionTime), nil
+	}
+	if store.isDeadlineExceeded(request.ProcessID, completionTime) {
+		return task.CompletingResultProcessDeadlineExceeded, nil
+	}
+	if len(request.Children) > 0 && store.isSealed(request.ProcessID) {
+		return task.CompletingResultProcessSealed, nil
+	}
+	if !store.canBeRegistered(request.Children) {
+		return task.CompletingResultChildConflict, nil
+	}
+
+	for _, child := range request.Children {
+		store.register(child)
+	}
+	return store.complete(request.CompleteRequest, completionTime), nil
+}
+
+func (store *Store) BatchComplete(_ context.Context,
+	request task.BatchCompleteRequest) ([]task.BatchCompletingResult, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	completionTime := store.currentDateGetter.GetCurrentDate()
+	results := task.NewBatchCompletingResults(request, task.CompletingResultCompleted)
+	for index, completeRequest := range request.Tasks {
+		taskToComplete, taskExists := store.findTask(completeRequest.ID)
+		if !taskExists || !canBeCompleted(taskToComplete, completionTime) {
+			results[index].Result = store.readCompletingConflictResult(completeRequest, completionTime)
+		} else if store.isDeadlineExceeded(completeRequest.ProcessID, completionTime) {
+			results[index].Result = task.CompletingResultProcessDeadlineExceeded
+		}
+	}
+	if request.Transactional && !task.AreCompletingSucceeded(results) {
+		task.ReplaceCompletingResults(results, task.CompletingResultCompleted, task.CompletingResultCanceled)
+		return results, nil
+	}
+	for index, completeRequest := range request.Tasks {
+		if results[index].Result == task.CompletingResultCompleted {
+			store.complete(completeRequest, completionTime)
+		}
+	}
+	return results, nil
+}
+
+func (store *Store) complete(request task.CompleteRequest, completionTime time.Time) task.CompletingResult {
+	taskToComplete, taskExists := store.findTask(request.ID)
+	if !taskExists || !canBeCompleted(taskToComplete, completionTime) {
+		return store.readCompletingConflictResult(request, completionTime)
+	}
+	if store.isDeadlineExceeded(request.ProcessID, completionTime) {
+		return task.CompletingResultProcessDeadlineExceeded
+	}
+
+	taskToComplete.state = request.State
+	taskToComplete.stateMessage = copyMessage(request.Message)
+	taskToComplete.badStateEnterTime = time.Time{}
+	if request.State == task.StateAborted {
+		taskToComplete.badStateEnterTime = truncateToStoredPrecision(completionTime)
+	}
+	return task.CompletingResultCompleted
+}
+
+func (store *Store) readCompletingConflictResult(request task.CompleteRequest,
+	completionTime time.Time) task.CompletingResult {
+	existingTask, taskExists := store.findTask(request.ID)
+	if !taskExists {
+		return task.ReadCompletingConflictResult(nil, request)
+	}
+	existingTaskDetails := existingTask.toTask(request.ID, completionTime)
+	return task.ReadCompletingConflictResult(&existingTaskDetails, request)
+}
+
+func canBeCompleted(storedTask *storedTask, completionTime time.Time) bool {
+	return storedTask.state == task.StateCreated &&
+		storedTask.expirationTime.After(truncateToStoredPrecision(completionTime))
+}
@@ -0,0 +1,265 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_Complete(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+	taskID := task.ID{ProcessID: "2", TaskID: "1"}
+	storeAndMocks.mustRegister(taskID, storeAndMocks.currentDate.Add(time.Hour))
+
+	completingResult, err := storeAndMocks.store.Complete(context.Background(), task.CompleteRequest{
+		ID:    taskID,
+		State: task.StateFinished,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.CompletingResultCompleted, completingResult)
+}
+
+func TestStore_Complete_TaskAlreadyCompleted(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+	taskID := task.ID{ProcessID: "2", TaskID: "1"}
+	storeAndMocks.mustRegister(taskID, storeAndMocks.currentDate.Add(time.Hour))
+	completeRequest := task.CompleteRequest{
+		ID:      taskID,
+		State:   task.StateAborted,
+		Message: aws.String("failed to execute task"),
+	}
+	_, err := storeAndMocks.store.Complete(context.Background(), completeRequest)
+	assert.NoError(t, err)
+
+	completingResult, err := storeAndMocks.store.Complete(context.Background(), completeRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, task.CompletingResultAlreadyCompletedSame, completingResult)
+}
+
+func TestStore_Complete_TaskAlreadyCompletedDifferently(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+	taskID := task.ID{ProcessID: "2", TaskID: "1"}
+	storeAndMocks.mustRegister(taskID, storeAndMocks.currentDate.Add(time.Hour))
+	completeRequest := task.CompleteRequest{
+		ID:      taskID,
+		State:   task.StateAborted,
+		Message: aws.String("failed to execute task"),
+	}
+	_, err := storeAndMocks.store.Complete(context.Background(), completeRequest)
+	assert.NoError(t, err)
+
+	completeRequest.State = task.StateFinished
+	completingResult, err := storeAndMocks.store.Complete(context.Background(), completeRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, task.CompletingResultAlreadyCompletedDifferent, completingResult)
+}
+
+func TestStore_Complete_TaskNotRegistered(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+
+	completingResult, err := storeAndMocks.store.Complete(context.Background(), task.CompleteRequest{
+		ID:    task.ID{ProcessID: "2", TaskID: "1"},
+		State: task.StateFinished,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.CompletingResultNotFound, completingResult)
+}
+
+func TestStore_Complete_TaskExpired(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+	taskID := task.ID{ProcessID: "2", TaskID: "1"}
+	storeAndMocks.mustRegister(taskID, storeAndMocks.currentDate)
+
+	completingResult, err := storeAndMocks.store.Complete(context.Background(), task.CompleteRequest{
+		ID:    taskID,
+		State: task.StateFinished,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.CompletingResultExpired, completingResult)
+}
+
+func TestStore_Complete_ProcessDeadlineExceeded(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+	taskID := task.ID{ProcessID: "2", TaskID: "1"}
+	storeAndMocks.mustRegister(taskID, storeAndMocks.currentDate.Add(time.Hour))
+	processDeadline := storeAndMocks.currentDate
+	_, err := storeAndMocks.store.Update(context.Background(), process.UpdateRequest{
+		ProcessID: taskID.ProcessID,
+		Deadline:  &processDeadline,
+	})
+	assert.NoError(t, err)
+
+	completingResult, err := storeAndMocks.store.Complete(context.Background(), task.CompleteRequest{
+		ID:    taskID,
+		State: task.StateFinished,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.CompletingResultProcessDeadlineExceeded, completingResult)
+}
+
+func TestStore_CompleteWithChildren(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+	parentID := task.ID{ProcessID: "2", TaskID: "1"}
+	childID := task.ID{ProcessID: "2", TaskID: "3"}
+	storeAndMocks.mustRegister(parentID, storeAndMocks.currentDate.Add(time.Hour))
+
+	completingResult, err := storeAndMocks.store.CompleteWithChildren(context.Background(), task.CompleteWithChildrenRequest{
+		CompleteRequest: task.CompleteRequest{ID: parentID, State: task.StateFinished},
+		Children: []task.RegistrationData{
+			{ID: childID, ExpirationTime: storeAndMocks.currentDate.Add(time.Hour)},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.CompletingResultCompleted, completingResult)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), parentID.ProcessID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           parentID.ProcessID,
+		State:        process.StateCreated,
+		CreationTime: storeAndMocks.currentDate.Truncate(time.Second),
+		Progress: &process.Progress{
+			TotalTasksCount:               2,
+			CreatedTasksCount:             1,
+			FinishedTasksCount:            1,
+			EarliestPendingExpirationTime: storeAndMocks.currentDate.Add(time.Hour).Truncate(time.Second),
+		},
+	}, proc)
+}
+
+func TestStore_CompleteWithChildren_ParentConflict(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+	parentID := task.ID{ProcessID: "2", TaskID: "1"}
+	childID := task.ID{ProcessID: "2", TaskID: "3"}
+
+	completingResult, err := storeAndMocks.store.CompleteWithChildren(context.Background(), task.CompleteWithChildrenRequest{
+		CompleteRequest: task.CompleteRequest{ID: parentID, State: task.StateFinished},
+		Children: []task.RegistrationData{
+			{ID: childID, ExpirationTime: storeAndMocks.currentDate.Add(time.Hour)},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.CompletingResultNotFound, completingResult)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), parentID.ProcessID)
+	assert.NoError(t, err)
+	assert.Nil(t, proc)
+}
+
+func TestStore_CompleteWithChildren_ProcessSealed(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+	parentID := task.ID{ProcessID: "2", TaskID: "1"}
+	storeAndMocks.mustRegister(parentID, storeAndMocks.currentDate.Add(time.Hour))
+	_, err := storeAndMocks.store.Seal(context.Background(), parentID.ProcessID)
+	assert.NoError(t, err)
+
+	completingResult, err := storeAndMocks.store.CompleteWithChildren(context.Background(), task.CompleteWithChildrenRequest{
+		CompleteRequest: task.CompleteRequest{ID: parentID, State: task.StateFinished},
+		Children: []task.RegistrationData{
+			{ID: task.ID{ProcessID: "2", TaskID: "3"}, ExpirationTime: storeAndMocks.currentDate.Add(time.Hour)},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.CompletingResultProcessSealed, completingResult)
+}
+
+func TestStore_CompleteWithChildren_ChildConflict(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+	parentID := task.ID{ProcessID: "2", TaskID: "1"}
+	existingChildID := task.ID{ProcessID: "2", TaskID: "3"}
+	storeAndMocks.mustRegister(parentID, storeAndMocks.currentDate.Add(time.Hour))
+	storeAndMocks.mustRegister(existingChildID, storeAndMocks.currentDate.Add(time.Hour))
+
+	completingResult, err := storeAndMocks.store.CompleteWithChildren(context.Background(), task.CompleteWithChildrenRequest{
+		CompleteRequest: task.CompleteRequest{ID: parentID, State: task.StateFinished},
+		Children: []task.RegistrationData{
+			{ID: task.ID{ProcessID: "2", TaskID: "4"}, ExpirationTime: storeAndMocks.currentDate.Add(time.Hour)},
+			{ID: existingChildID, ExpirationTime: storeAndMocks.currentDate.Add(time.Hour)},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.CompletingResultChildConflict, completingResult)
+
+	completingResult, err = storeAndMocks.store.Complete(context.Background(), task.CompleteRequest{ID: parentID, State: task.StateFinished})
+	assert.NoError(t, err)
+	assert.Equal(t, task.CompletingResultCompleted, completingResult)
+	completingResult, err = storeAndMocks.store.Complete(context.Background(), task.CompleteRequest{
+		ID:    task.ID{ProcessID: "2", TaskID: "4"},
+		State: task.StateFinished,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.CompletingResultNotFound, completingResult)
+}
+
+func newBatchCompleteRequest(processID string, transactional bool, taskIDs ...string) task.BatchCompleteRequest {
+	request := task.BatchCompleteRequest{ProcessID: processID, Transactional: transactional}
+	for _, taskID := range taskIDs {
+		request.Tasks = append(request.Tasks, task.CompleteRequest{
+			ID:    task.ID{ProcessID: processID, TaskID: taskID},
+			State: task.StateFinished,
+		})
+	}
+	return request
+}
+
+func TestStore_BatchComplete(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+	completedTaskID := task.ID{ProcessID: "2", TaskID: "1"}
+	storeAndMocks.mustRegister(completedTaskID, storeAndMocks.currentDate.Add(time.Hour))
+	storeAndMocks.mustRegister(task.ID{ProcessID: "2", TaskID: "2"}, storeAndMocks.currentDate.Add(time.Hour))
+	_, err := storeAndMocks.store.Complete(context.Background(), task.CompleteRequest{
+		ID:    completedTaskID,
+		State: task.StateFinished,
+	})
+	assert.NoError(t, err)
+
+	results, err := storeAndMocks.store.BatchComplete(context.Background(),
+		newBatchCompleteRequest(completedTaskID.ProcessID, false, "1", "2", "3"))
+	assert.NoError(t, err)
+	assert.Equal(t, []task.BatchCompletingResult{
+		{TaskID: "1", Result: task.CompletingResultAlreadyCompletedSame},
+		{TaskID: "2", Result: task.CompletingResultCompleted},
+		{TaskID: "3", Result: task.CompletingResultNotFound},
+	}, results)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), completedTaskID.ProcessID)
+	assert.NoError(t, err)
+	assert.Equal(t, process.StateCompleted, proc.State)
+}
+
+func TestStore_BatchComplete_Transactional(t *testing.T) {
+	storeAndMocks := newStoreWithMocks()
+	completedTaskID := task.ID{ProcessID: "2", TaskID: "1"}
+	storeAndMocks.mustRegister(completedTaskID, storeAndMocks.currentDate.Add(time.Hour))
+	storeAndMocks.mustRegister(task.ID{ProcessID: "2", TaskID: "2"}, storeAndMocks.currentDate.Add(time.Hour))
+	_, err := storeAndMocks.store.Complete(context.Background(), task.CompleteRequest{
+		ID:    completedTaskID,
+		State: task.StateFinished,
+	})
+	assert.NoError(t, err)
+
+	results, err := storeAndMocks.store.BatchComplete(context.Background(),
+		newBatchCompleteRequest(completedTaskID.ProcessID, true, "1", "2", "3"))
+	assert.NoError(t, err)
+	assert.Equal(t, []task.BatchCompletingResult{
+		{TaskID: "1", Result: task.CompletingResultAlreadyCompletedSame},
+		{TaskID: "2", Result: task.CompletingResultCanceled},
+		{TaskID: "3", Result: task.CompletingResultNotFound},
+	}, results)
+	canceledTask, err := storeAndMocks.store.GetTask(context.Background(), task.ID{ProcessID: "2", TaskID: "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, task.StateCreated, canceledTask.State)
+
+	results, err = storeAndMocks.store.BatchComplete(context.Background(),
+		newBatchCompleteRequest(completedTaskID.ProcessID, true, "1", "2"))
+	assert.NoError(t, err)
+	assert.Equal(t, []task.BatchCompletingResult{
+		{TaskID: "1", Result: task.CompletingResultAlreadyCompletedSame},
+		{TaskID: "2", Result: task.CompletingResultCompleted},
+	}, results)
+}
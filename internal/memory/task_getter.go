@@ -0,0 +1,19 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+func (store *Store) GetTask(_ context.Context, id task.ID) (*task.Task, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	foundTask, taskExists := store.findTask(id)
+	if !taskExists {
+		return nil, nil
+	}
+	convertedTask := foundTask.toTask(id, store.currentDateGetter.GetCurrentDate())
+	return &convertedTask, nil
+}
@@ -0,0 +1,21 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+func (store *Store) Heartbeat(_ context.Context, request task.HeartbeatRequest) (task.HeartbeatResult, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	taskToExtend, taskExists := store.findTask(request.ID)
+	if !taskExists || !canBeCompleted(taskToExtend, store.currentDateGetter.GetCurrentDate()) {
+		return task.HeartbeatResultConflict, nil
+	}
+	expirationTime := truncateToStoredPrecision(request.ExpirationTime)
+	taskToExtend.expirationTime = expirationTime
+	taskToExtend.badStateEnterTime = expirationTime
+	return task.HeartbeatResultExtended, nil
+}
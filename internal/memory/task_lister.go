@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+func (store *Store) List(_ context.Context, request task.ListRequest) (task.List, error) {
+	lastTaskID := ""
+	if request.Cursor != "" {
+		decodedTaskID, err := task.DecodeListCursor(request.Cursor)
+		if err != nil {
+			return task.List{}, err
+		}
+		lastTaskID = decodedTaskID
+	}
+
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	currentTime := store.currentDateGetter.GetCurrentDate()
+	tasksList := task.List{Tasks: []task.Task{}}
+	foundProcess, processExists := store.processes[request.ProcessID]
+	if !processExists {
+		return tasksList, nil
+	}
+	for _, taskID := range sortedTaskIDs(foundProcess.tasks) {
+		storedTask := foundProcess.tasks[taskID]
+		if taskID <= lastTaskID || (request.State != nil && storedTask.state != *request.State) {
+			continue
+		}
+		if len(tasksList.Tasks) == request.Limit {
+			tasksList.NextCursor = task.EncodeListCursor(tasksList.Tasks[len(tasksList.Tasks)-1].TaskID)
+			break
+		}
+		tasksList.Tasks = append(tasksList.Tasks,
+			storedTask.toTask(task.ID{ProcessID: request.ProcessID, TaskID: taskID}, currentTime))
+	}
+	return tasksList, nil
+}
+
+func sortedTaskIDs(tasks map[string]*storedTask) []string {
+	taskIDs := make([]string, 0, len(tasks))
+	for taskID := range tasks {
+		taskIDs = append(taskIDs, taskID)
+	}
+	sort.Strings(taskIDs)
+	return taskIDs
+}
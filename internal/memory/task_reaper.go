@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+type timedOutTask struct {
+	id         task.ID
+	storedTask *storedTask
+}
+
+func (store *Store) ReapTimedOut(_ context.Context, limit int) ([]task.ID, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	timedOutTasks := store.findTimedOutTasks()
+	if len(timedOutTasks) > limit {
+		timedOutTasks = timedOutTasks[:limit]
+	}
+	timedOutTaskIDs := make([]task.ID, 0, len(timedOutTasks))
+	for _, timedOutTask := range timedOutTasks {
+		timedOutErrorMessage := process.TimedOutErrorMessage
+		timedOutTask.storedTask.state = task.StateTimedOut
+		timedOutTask.storedTask.stateMessage = &timedOutErrorMessage
+		timedOutTaskIDs = append(timedOutTaskIDs, timedOutTask.id)
+	}
+	return timedOutTaskIDs, nil
+}
+
+func (store *Store) findTimedOutTasks() []timedOutTask {
+	currentTime := store.currentDateGetter.GetCurrentDate()
+	var timedOutTasks []timedOutTask
+	for processID, storedProcess := range store.processes {
+		for taskID, storedTask := range storedProcess.tasks {
+			if storedTask.state == task.StateCreated && !currentTime.Before(storedTask.expirationTime) {
+				timedOutTasks = append(timedOutTasks, timedOutTask{
+					id:         task.ID{ProcessID: processID, TaskID: taskID},
+					storedTask: storedTask,
+				})
+			}
+		}
+	}
+	sort.Slice(timedOutTasks, func(first, second int) bool {
+		return timedOutTasks[first].isBefore(timedOutTasks[second])
+	})
+	return timedOutTasks
+}
+
+func (first timedOutTask) isBefore(second timedOutTask) bool {
+	if !first.storedTask.expirationTime.Equal(second.storedTask.expirationTime) {
+		return first.storedTask.expirationTime.Before(second.storedTask.expirationTime)
+	}
+	if first.id.ProcessID != second.id.ProcessID {
+		return first.id.ProcessID < second.id.ProcessID
+	}
+	return first.id.TaskID < second.id.TaskID
+}
@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+func (store *Store) Register(_ context.Context, registrationData task.RegistrationData) (task.RegistrationResult, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if _, taskExists := store.findTask(registrationData.ID); taskExists {
+		return task.RegistrationResultAlreadyRegistered, nil
+	}
+	if closedProcessResult, isProcessClosed := store.readClosedProcessRegistrationResult(
+		registrationData.ID.ProcessID); isProcessClosed {
+		return closedProcessResult, nil
+	}
+	store.registerConfigured(registrationData)
+	return task.RegistrationResultCreated, nil
+}
+
+func (store *Store) BatchRegister(_ context.Context,
+	request task.BatchRegistrationRequest) ([]task.BatchRegistrationResult, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	results := task.NewBatchRegistrationResults(request, task.RegistrationResultCreated)
+	isAnyTaskAlreadyRegistered := false
+	for index, registrationData := range request.Tasks {
+		if _, taskExists := store.findTask(registrationData.ID); taskExists {
+			results[index].Result = task.RegistrationResultAlreadyRegistered
+			isAnyTaskAlreadyRegistered = true
+		}
+	}
+	if closedProcessResult, isProcessClosed := store.readClosedProcessRegistrationResult(
+		request.ProcessID); isProcessClosed {
+		task.ReplaceRegistrationResults(results, task.RegistrationResultCreated, closedProcessResult)
+		return results, nil
+	}
+	if request.Transactional && isAnyTaskAlreadyRegistered {
+		task.ReplaceRegistrationResults(results, task.RegistrationResultCreated, task.RegistrationResultCanceled)
+		return results, nil
+	}
+	for index, registrationData := range request.Tasks {
+		if results[index].Result == task.RegistrationResultCreated {
+			store.registerConfigured(registrationData)
+		}
+	}
+	return results, nil
+}
+
+func (store *Store) readClosedProcessRegistrationResult(processID string) (task.RegistrationResult, bool) {
+	if store.isDeadlineExceeded(processID, store.currentDateGetter.GetCurrentDate()) {
+		return task.RegistrationResultProcessDeadlineExceeded, true
+	}
+	if store.isSealed(processID) {
+		return task.RegistrationResultProcessSealed, true
+	}
+	return "", false
+}
+
+func (store *Store) registerConfigured(registrationData task.RegistrationData) {
+	store.register(registrationData)
+	store.configureInitialCallback(registrationData)
+	store.configureInitialDeadline(registrationData)
+}
+
+func (store *Store) canBeRegistered(tasksRegistrationData []task.RegistrationData) bool {
+	for _, registrationData := range tasksRegistrationData {
+		if _, taskExists := store.findTask(registrationData.ID); taskExists {
+			return false
+		}
+	}
+	return true
+}
+
+func (store *Store) register(registrationData task.RegistrationData) {
+	processToRegisterIn, processExists := store.processes[registrationData.ID.ProcessID]
+	if !processExists {
+		processToRegisterIn = &storedProcess{
+			tasks: make(map[string]*storedTask),
+			metadata: processMetadata{
+				creationTime: truncateToStoredPrecision(store.currentDateGetter.GetCurrentDate()),
+				creator:      copyMessage(registrationData.Creator),
+			},
+		}
+		store.processes[registrationData.ID.ProcessID] = processToRegisterIn
+	}
+	expirationTime := truncateToStoredPrecision(registrationData.ExpirationTime)
+	processToRegisterIn.tasks[registrationData.ID.TaskID] = &storedTask{
+		state:             task.StateCreated,
+		expirationTime:    expirationTime,
+		creationTime:      truncateToStoredPrecision(store.currentDateGetter.GetCurrentDate()),
+		badStateEnterTime: expirationTime,
+	}
+}
+
+func (store *Store) configureInitialCallback(registrationData task.RegistrationData) {
+	registeredProcess := store.processes[registrationData.ID.ProcessID]
+	if registrationData.CallbackURL == nil || registeredProcess.callback != nil {
+		return
+	}
+	registeredProcess.callback = newPendingCallback(*registrationData.CallbackURL)
+}
+
+func (store *Store) configureInitialDeadline(registrationData task.RegistrationData) {
+	registeredProcess := store.processes[registrationData.ID.ProcessID]
+	if registrationData.ProcessDeadline.IsZero() || !registeredProcess.deadline.IsZero() {
+		return
+	}
+	registeredProcess.deadline = truncateToStoredPrecision(registrationData.ProcessDeadline)
+}
@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+func (store *Store) ClaimTerminationEvent(_ context.Context, processID string) (bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	terminatedProcess, processExists := store.processes[processID]
+	if !processExists || terminatedProcess.isTerminationEventPublished {
+		return false, nil
+	}
+	currentDate := store.currentDateGetter.GetCurrentDate()
+	if !terminatedProcess.terminationEventClaimTime.IsZero() &&
+		currentDate.Before(terminatedProcess.terminationEventClaimTime.Add(process.TerminationEventClaimLease)) {
+		return false, nil
+	}
+	terminatedProcess.terminationEventClaimTime = currentDate
+	return true, nil
+}
+
+func (store *Store) ConfirmTerminationEvent(_ context.Context, processID string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if terminatedProcess, processExists := store.processes[processID]; processExists {
+		terminatedProcess.isTerminationEventPublished = true
+		terminatedProcess.terminationEventClaimTime = time.Time{}
+	}
+	return nil
+}
+
+func (store *Store) ReleaseTerminationEvent(_ context.Context, processID string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if terminatedProcess, processExists := store.processes[processID]; processExists {
+		terminatedProcess.terminationEventClaimTime = time.Time{}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package reaper
+
+import (
+	"context"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/events"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultBatchSize  = 100
+	DefaultMaxBatches = 50
+)
+
+type Config struct {
+	BatchSize  int
+	MaxBatches int
+}
+
+var DefaultConfig = Config{
+	BatchSize:  DefaultBatchSize,
+	MaxBatches: DefaultMaxBatches,
+}
+
+type currentDateGetter interface {
+	GetCurrentDate() time.Time
+}
+
+type Reaper struct {
+	taskReaper           task.Reaper
+	terminationPublisher *events.TerminationPublisher
+	currentDateGetter    currentDateGetter
+	config               Config
+}
+
+func New(taskReaper task.Reaper, processGetter process.Getter, terminationEventRecorder process.TerminationEventRecorder,
+	sink events.Sink, currentDateGetter currentDateGetter, config Config) *Reaper {
+	return &Reaper{
+		taskReaper:           taskReaper,
+		terminationPublisher: events.NewTerminationPublisher(processGetter, terminationEventRecorder, sink),
+		currentDateGetter:    currentDateGetter,
+		config:               config,
+	}
+}
+
+func (reaper *Reaper) Reap(ctx context.Context) error {
+	for batch := 0; batch < reaper.config.MaxBatches; batch++ {
+		reapedTaskIDs, err := reaper.taskReaper.ReapTimedOut(ctx, reaper.config.BatchSize)
+		reaper.publishTerminatedProcesses(ctx, reapedTaskIDs)
+		if err != nil || len(reapedTaskIDs) < reaper.config.BatchSize {
+			return err
+		}
+	}
+	return nil
+}
+
+func (reaper *Reaper) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := reaper.Reap(ctx); err != nil && ctx.Err() == nil {
+			logrus.WithError(err).Error("failed to reap timed out tasks")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (reaper *Reaper) publishTerminatedProcesses(ctx context.Context, reapedTaskIDs []task.ID) {
+	publishedProcessIDs := make(map[string]bool)
+	for _, reapedTaskID := range reapedTaskIDs {
+		if publishedProcessIDs[reapedTaskID.ProcessID] {
+			continue
+		}
+		publishedProcessIDs[reapedTaskID.ProcessID] = true
+		err := reaper.terminationPublisher.PublishIfTerminated(ctx, reapedTaskID.ProcessID,
+			reaper.currentDateGetter.GetCurrentDate())
+		if err != nil {
+			logrus.WithError(err).WithField("process_id", reapedTaskID.ProcessID).
+				Error("failed to publish process terminated event")
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package reaper_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/events"
+	"github.com/artii15/termination-detector/internal/reaper"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type taskReaperMock struct {
+	mock.Mock
+}
+
+func (taskReaper *taskReaperMock) ReapTimedOut(ctx context.Context, limit int) ([]task.ID, error) {
+	args := taskReaper.Called(ctx, limit)
+	return args.Get(0).([]task.ID), args.Error(1)
+}
+
+type processGetterMock struct {
+	mock.Mock
+}
+
+func (getter *processGetterMock) Get(ctx context.Context, processID string) (*process.Process, error) {
+	args := getter.Called(ctx, processID)
+	return args.Get(0).(*process.Process), args.Error(1)
+}
+
+type terminationEventRecorderMock struct {
+	mock.Mock
+}
+
+func (recorder *terminationEventRecorderMock) ClaimTerminationEvent(ctx context.Context, processID string) (bool, error) {
+	args := recorder.Called(ctx, processID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (recorder *terminationEventRecorderMock) ConfirmTerminationEvent(ctx context.Context, processID string) error {
+	return recorder.Called(ctx, processID).Error(0)
+}
+
+func (recorder *terminationEventRecorderMock) ReleaseTerminationEvent(ctx context.Context, processID string) error {
+	return recorder.Called(ctx, processID).Error(0)
+}
+
+type currentDateGetterMock struct {
+	mock.Mock
+}
+
+func (getter *currentDateGetterMock) GetCurrentDate() time.Time {
+	return getter.Called().Get(0).(time.Time)
+}
+
+type reaperWithMocks struct {
+	reaper                   *reaper.Reaper
+	taskReaper               *taskReaperMock
+	processGetter            *processGetterMock
+	terminationEventRecorder *terminationEventRecorderMock
+	sink                     *events.MemorySink
+	currentDateGetter        *currentDateGetterMock
+	currentDate              time.Time
+	config                   reaper.Config
+}
+
+func (reaperAndMocks *reaperWithMocks) assertExpectations(t *testing.T) {
+	reaperAndMocks.taskReaper.AssertExpectations(t)
+	reaperAndMocks.processGetter.AssertExpectations(t)
+	reaperAndMocks.terminationEventRecorder.AssertExpectations(t)
+}
+
+func newReaperWithMocks() *reaperWithMocks {
+	taskReaper := new(taskReaperMock)
+	processGetter := new(processGetterMock)
+	terminationEventRecorder := new(terminationEventRecorderMock)
+	sink := events.NewMemorySink()
+	currentDateGetter := new(currentDateGetterMock)
+	currentDate := time.Now().UTC()
+	currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	config := reaper.Config{BatchSize: 2, MaxBatches: 3}
+	return &reaperWithMocks{
+		reaper: reaper.New(taskReaper, processGetter, terminationEventRecorder, sink, currentDateGetter,
+			config),
+		taskReaper:               taskReaper,
+		processGetter:            processGetter,
+		terminationEventRecorder: terminationEventRecorder,
+		sink:                     sink,
+		currentDateGetter:        currentDateGetter,
+		currentDate:              currentDate,
+		config:                   config,
+	}
+}
+
+func newTimedOutProcess(processID string) *process.Process {
+	return &process.Process{
+		ID:           processID,
+		State:        process.StateError,
+		StateMessage: aws.String(process.TimedOutErrorMessage),
+		Sealed:       true,
+	}
+}
+
+func TestReaper_Reap(t *testing.T) {
+	reaperAndMocks := newReaperWithMocks()
+	timedOutProcess := newTimedOutProcess("1")
+	runningProcess := &process.Process{ID: "2", State: process.StateCreated}
+	reaperAndMocks.taskReaper.On("ReapTimedOut", mock.Anything, reaperAndMocks.config.BatchSize).Return([]task.ID{
+		{ProcessID: timedOutProcess.ID, TaskID: "1"},
+		{ProcessID: timedOutProcess.ID, TaskID: "2"},
+	}, nil).Once()
+	reaperAndMocks.taskReaper.On("ReapTimedOut", mock.Anything, reaperAndMocks.config.BatchSize).Return([]task.ID{
+		{ProcessID: runningProcess.ID, TaskID: "1"},
+	}, nil).Once()
+	reaperAndMocks.processGetter.On("Get", mock.Anything, timedOutProcess.ID).Return(timedOutProcess, nil).Once()
+	reaperAndMocks.terminationEventRecorder.On("ClaimTerminationEvent", mock.Anything, timedOutProcess.ID).
+		Return(true, nil).Once()
+	reaperAndMocks.terminationEventRecorder.On("ConfirmTerminationEvent", mock.Anything, timedOutProcess.ID).
+		Return(nil).Once()
+	reaperAndMocks.processGetter.On("Get", mock.Anything, runningProcess.ID).Return(runningProcess, nil).Once()
+
+	assert.NoError(t, reaperAndMocks.reaper.Reap(context.Background()))
+	reaperAndMocks.assertExpectations(t)
+	assert.Equal(t, []events.ProcessTerminated{{
+		Process: *timedOutProcess,
+		Time:    reaperAndMocks.currentDate,
+	}}, reaperAndMocks.sink.Events())
+}
+
+func TestReaper_Reap_MaxBatches(t *testing.T) {
+	reaperAndMocks := newReaperWithMocks()
+	reaperAndMocks.taskReaper.On("ReapTimedOut", mock.Anything, reaperAndMocks.config.BatchSize).Return([]task.ID{
+		{ProcessID: "1", TaskID: "1"},
+		{ProcessID: "2", TaskID: "1"},
+	}, nil).Times(reaperAndMocks.config.MaxBatches)
+	reaperAndMocks.processGetter.On("Get", mock.Anything, mock.Anything).Return((*process.Process)(nil), nil)
+
+	assert.NoError(t, reaperAndMocks.reaper.Reap(context.Background()))
+	reaperAndMocks.assertExpectations(t)
+	assert.Empty(t, reaperAndMocks.sink.Events())
+}
+
+func TestReaper_Reap_ReapingError(t *testing.T) {
+	reaperAndMocks := newReaperWithMocks()
+	timedOutProcess := newTimedOutProcess("1")
+	reaperAndMocks.taskReaper.On("ReapTimedOut", mock.Anything, reaperAndMocks.config.BatchSize).Return([]task.ID{
+		{ProcessID: timedOutProcess.ID, TaskID: "1"},
+	}, errors.New("error")).Once()
+	reaperAndMocks.processGetter.On("Get", mock.Anything, timedOutProcess.ID).Return(timedOutProcess, nil).Once()
+	reaperAndMocks.terminationEventRecorder.On("ClaimTerminationEvent", mock.Anything, timedOutProcess.ID).
+		Return(true, nil).Once()
+	reaperAndMocks.terminationEventRecorder.On("ConfirmTerminationEvent", mock.Anything, timedOutProcess.ID).
+		Return(nil).Once()
+
+	assert.Error(t, reaperAndMocks.reaper.Reap(context.Background()))
+	reaperAndMocks.assertExpectations(t)
+	assert.Len(t, reaperAndMocks.sink.Events(), 1)
+}
+
+func TestReaper_Reap_GetterError(t *testing.T) {
+	reaperAndMocks := newReaperWithMocks()
+	timedOutProcess := newTimedOutProcess("2")
+	reaperAndMocks.taskReaper.On("ReapTimedOut", mock.Anything, reaperAndMocks.config.BatchSize).Return([]task.ID{
+		{ProcessID: "1", TaskID: "1"},
+		{ProcessID: timedOutProcess.ID, TaskID: "1"},
+	}, nil).Once()
+	reaperAndMocks.taskReaper.On("ReapTimedOut", mock.Anything, reaperAndMocks.config.BatchSize).
+		Return([]task.ID{}, nil).Once()
+	reaperAndMocks.processGetter.On("Get", mock.Anything, "1").Return((*process.Process)(nil), errors.New("error"))
+	reaperAndMocks.processGetter.On("Get", mock.Anything, timedOutProcess.ID).Return(timedOutProcess, nil)
+	reaperAndMocks.terminationEventRecorder.On("ClaimTerminationEvent", mock.Anything, timedOutProcess.ID).
+		Return(true, nil).Once()
+	reaperAndMocks.terminationEventRecorder.On("ConfirmTerminationEvent", mock.Anything, timedOutProcess.ID).
+		Return(nil).Once()
+
+	assert.NoError(t, reaperAndMocks.reaper.Reap(context.Background()))
+	reaperAndMocks.assertExpectations(t)
+	assert.Len(t, reaperAndMocks.sink.Events(), 1)
+}
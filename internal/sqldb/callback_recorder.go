@@ -0,0 +1,45 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+const (
+	listPendingCallbacksQuery = `SELECT process_id FROM processes WHERE callback_state = ? AND process_id > ?
+		ORDER BY process_id
+		LIMIT ?`
+	recordCallbackDeliveryStatement = `UPDATE processes SET callback_state = ?, callback_attempts = ?,
+	callback_last_error = ?, callback_delivery_time = ? WHERE process_id = ? AND callback_url IS NOT NULL`
+)
+
+func (store *Store) ListPendingCallbacks(ctx context.Context, afterProcessID string, limit int) ([]string, error) {
+	rows, err := store.db.QueryContext(ctx, store.dialect.rebind(listPendingCallbacksQuery),
+		string(process.CallbackStatePending), afterProcessID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var processIDs []string
+	for rows.Next() {
+		var processID string
+		if err := rows.Scan(&processID); err != nil {
+			return nil, err
+		}
+		processIDs = append(processIDs, processID)
+	}
+	return processIDs, rows.Err()
+}
+
+func (store *Store) RecordCallbackDelivery(ctx context.Context, delivery process.CallbackDelivery) error {
+	var deliveryTime sql.NullInt64
+	if !delivery.DeliveryTime.IsZero() {
+		deliveryTime = sql.NullInt64{Int64: toStoredTime(delivery.DeliveryTime), Valid: true}
+	}
+	_, err := store.db.ExecContext(ctx, store.dialect.rebind(recordCallbackDeliveryStatement), string(delivery.State),
+		delivery.Attempts, delivery.LastError, deliveryTime, delivery.ProcessID)
+	return err
+}
@@ -0,0 +1,76 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (storeAndMocks *storeWithMocks) mustRegisterWithCallback(t *testing.T, taskID task.ID, callbackURL string) {
+	registrationResult, err := storeAndMocks.store.Register(context.Background(), task.RegistrationData{
+		ID:             taskID,
+		ExpirationTime: storeAndMocks.currentDate.Add(time.Hour),
+		CallbackURL:    &callbackURL,
+	})
+	require.NoError(t, err)
+	require.Equal(t, task.RegistrationResultCreated, registrationResult)
+}
+
+func TestStore_ListPendingCallbacks(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	storeAndMocks.mustRegisterWithCallback(t, task.ID{ProcessID: "3", TaskID: "1"}, "https://example.com/3")
+	storeAndMocks.mustRegisterWithCallback(t, task.ID{ProcessID: "1", TaskID: "1"}, "https://example.com/1")
+	storeAndMocks.mustRegisterWithCallback(t, task.ID{ProcessID: "2", TaskID: "1"}, "https://example.com/2")
+	storeAndMocks.mustRegister(t, task.ID{ProcessID: "4", TaskID: "1"}, storeAndMocks.currentDate.Add(time.Hour))
+
+	processIDs, err := storeAndMocks.store.ListPendingCallbacks(context.Background(), "", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, processIDs)
+}
+
+func TestStore_ListPendingCallbacks_AfterProcessID(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	storeAndMocks.mustRegisterWithCallback(t, task.ID{ProcessID: "1", TaskID: "1"}, "https://example.com/1")
+	storeAndMocks.mustRegisterWithCallback(t, task.ID{ProcessID: "2", TaskID: "1"}, "https://example.com/2")
+	storeAndMocks.mustRegisterWithCallback(t, task.ID{ProcessID: "3", TaskID: "1"}, "https://example.com/3")
+
+	processIDs, err := storeAndMocks.store.ListPendingCallbacks(context.Background(), "1", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2", "3"}, processIDs)
+}
+
+func TestStore_RecordCallbackDelivery(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	processID := "1"
+	storeAndMocks.mustRegisterWithCallback(t, task.ID{ProcessID: processID, TaskID: "1"}, "https://example.com/callback")
+	deliveryTime := storeAndMocks.currentDate.Truncate(time.Second)
+
+	err := storeAndMocks.store.RecordCallbackDelivery(context.Background(), process.CallbackDelivery{
+		ProcessID:    processID,
+		State:        process.CallbackStateDelivered,
+		Attempts:     2,
+		LastError:    aws.String("unexpected status code: 503"),
+		DeliveryTime: deliveryTime,
+	})
+	assert.NoError(t, err)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), processID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Callback{
+		URL:          "https://example.com/callback",
+		State:        process.CallbackStateDelivered,
+		Attempts:     2,
+		LastError:    aws.String("unexpected status code: 503"),
+		DeliveryTime: deliveryTime,
+	}, proc.Callback)
+
+	processIDs, err := storeAndMocks.store.ListPendingCallbacks(context.Background(), "", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, processIDs)
+}
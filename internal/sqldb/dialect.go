@@ -0,0 +1,33 @@
+package sqldb
+
+import (
+	"strconv"
+	"strings"
+)
+
+const bindVarPlaceholder = '?'
+
+type Dialect string
+
+const (
+	DialectPostgres Dialect = "postgres"
+	DialectSQLite   Dialect = "sqlite3"
+)
+
+func (dialect Dialect) rebind(query string) string {
+	if dialect != DialectPostgres {
+		return query
+	}
+	var rebound strings.Builder
+	bindVarNumber := 0
+	for _, character := range query {
+		if character != bindVarPlaceholder {
+			rebound.WriteRune(character)
+			continue
+		}
+		bindVarNumber++
+		rebound.WriteString("$")
+		rebound.WriteString(strconv.Itoa(bindVarNumber))
+	}
+	return rebound.String()
+}
@@ -0,0 +1,119 @@
+package sqldb
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	createMigrationsTableStatement = `CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`
+	selectSchemaVersionQuery       = `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`
+	insertSchemaVersionStatement   = `INSERT INTO schema_migrations (version) VALUES (?)`
+	lockMigrationsStatement        = `SELECT pg_advisory_xact_lock(?)`
+
+	migrationsLockKey int64 = 7305123145243470413
+)
+
+var migrations = []string{
+	`CREATE TABLE tasks (
+		process_id           TEXT   NOT NULL,
+		task_id              TEXT   NOT NULL,
+		state                TEXT   NOT NULL,
+		state_message        TEXT,
+		expiration_time      BIGINT NOT NULL,
+		bad_state_enter_time BIGINT,
+		PRIMARY KEY (process_id, task_id)
+	)`,
+	`CREATE INDEX tasks_bad_state_enter_time_idx ON tasks (process_id, bad_state_enter_time)`,
+	`CREATE TABLE processes (
+		process_id          TEXT   NOT NULL PRIMARY KEY,
+		registrations_count BIGINT NOT NULL,
+		sealed_time         BIGINT
+	)`,
+	`INSERT INTO processes (process_id, registrations_count)
+		SELECT process_id, COUNT(*) FROM tasks GROUP BY process_id`,
+	`ALTER TABLE tasks ADD COLUMN creation_time BIGINT`,
+	`ALTER TABLE processes ADD COLUMN callback_url TEXT`,
+	`ALTER TABLE processes ADD COLUMN callback_state TEXT`,
+	`ALTER TABLE processes ADD COLUMN callback_attempts INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE processes ADD COLUMN callback_last_error TEXT`,
+	`ALTER TABLE processes ADD COLUMN callback_delivery_time BIGINT`,
+	`CREATE INDEX processes_callback_state_idx ON processes (callback_state, process_id)`,
+	`CREATE INDEX tasks_state_expiration_time_idx ON tasks (state, expiration_time)`,
+	`ALTER TABLE processes ADD COLUMN deadline BIGINT`,
+	`ALTER TABLE processes ADD COLUMN labels TEXT`,
+	`ALTER TABLE processes ADD COLUMN description TEXT`,
+	`ALTER TABLE processes ADD COLUMN creation_time BIGINT`,
+	`ALTER TABLE processes ADD COLUMN creator TEXT`,
+	`CREATE INDEX processes_creation_time_idx ON processes ((COALESCE(creation_time, 0)), process_id)`,
+	`ALTER TABLE processes ADD COLUMN termination_event_time BIGINT`,
+	`ALTER TABLE processes ADD COLUMN created_tasks_count BIGINT NOT NULL DEFAULT 0`,
+	`ALTER TABLE processes ADD COLUMN finished_tasks_count BIGINT NOT NULL DEFAULT 0`,
+	`ALTER TABLE processes ADD COLUMN aborted_tasks_count BIGINT NOT NULL DEFAULT 0`,
+	`ALTER TABLE processes ADD COLUMN timed_out_tasks_count BIGINT NOT NULL DEFAULT 0`,
+	`UPDATE processes SET
+		created_tasks_count = (SELECT COUNT(*) FROM tasks
+			WHERE tasks.process_id = processes.process_id AND tasks.state = 'CREATED'),
+		finished_tasks_count = (SELECT COUNT(*) FROM tasks
+			WHERE tasks.process_id = processes.process_id AND tasks.state = 'FINISHED'),
+		aborted_tasks_count = (SELECT COUNT(*) FROM tasks
+			WHERE tasks.process_id = processes.process_id AND tasks.state = 'ABORTED'),
+		timed_out_tasks_count = (SELECT COUNT(*) FROM tasks
+			WHERE tasks.process_id = processes.process_id AND tasks.state = 'TIMED_OUT')`,
+	`CREATE INDEX tasks_process_state_expiration_time_idx ON tasks (process_id, state, expiration_time)`,
+	`ALTER TABLE processes ADD COLUMN termination_event_claim_time BIGINT`,
+}
+
+func Migrate(db *sql.DB, dialect Dialect) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := migrateInTransaction(tx, dialect); err != nil {
+		return rollback(tx, err)
+	}
+	return tx.Commit()
+}
+
+func migrateInTransaction(tx *sql.Tx, dialect Dialect) error {
+	if err := lockMigrations(tx, dialect); err != nil {
+		return errors.Wrap(err, "failed to lock schema migrations")
+	}
+	if _, err := tx.Exec(createMigrationsTableStatement); err != nil {
+		return errors.Wrap(err, "failed to create schema migrations table")
+	}
+	var schemaVersion int
+	if err := tx.QueryRow(selectSchemaVersionQuery).Scan(&schemaVersion); err != nil {
+		return errors.Wrap(err, "failed to read schema version")
+	}
+	for migrationIndex := schemaVersion; migrationIndex < len(migrations); migrationIndex++ {
+		if err := applyMigration(tx, dialect, migrationIndex+1, migrations[migrationIndex]); err != nil {
+			return errors.Wrapf(err, "failed to apply migration %d", migrationIndex+1)
+		}
+	}
+	return nil
+}
+
+func lockMigrations(tx *sql.Tx, dialect Dialect) error {
+	if dialect != DialectPostgres {
+		return nil
+	}
+	_, err := tx.Exec(dialect.rebind(lockMigrationsStatement), migrationsLockKey)
+	return err
+}
+
+func applyMigration(tx *sql.Tx, dialect Dialect, version int, migration string) error {
+	if _, err := tx.Exec(migration); err != nil {
+		return err
+	}
+	_, err := tx.Exec(dialect.rebind(insertSchemaVersionStatement), version)
+	return err
+}
+
+func rollback(tx *sql.Tx, cause error) error {
+	if err := tx.Rollback(); err != nil {
+		return errors.Wrapf(cause, "rollback failed: %s", err.Error())
+	}
+	return cause
+}
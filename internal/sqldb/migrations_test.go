@@ -0,0 +1,18 @@
+package sqldb_test
+
+import (
+	"testing"
+
+	"github.com/artii15/termination-detector/internal/sqldb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate_IsIdempotent(t *testing.T) {
+	db, err := sqldb.Open(sqldb.DialectSQLite, ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	assert.NoError(t, sqldb.Migrate(db, sqldb.DialectSQLite))
+	assert.NoError(t, sqldb.Migrate(db, sqldb.DialectSQLite))
+}
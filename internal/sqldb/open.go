@@ -0,0 +1,28 @@
+package sqldb
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+)
+
+func Open(dialect Dialect, dataSourceName string) (*sql.DB, error) {
+	db, err := sql.Open(string(dialect), dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	if dialect == DialectSQLite {
+		db.SetMaxOpenConns(1)
+	}
+	if err := db.Ping(); err != nil {
+		return nil, closeAfterFailure(db, err)
+	}
+	return db, nil
+}
+
+func closeAfterFailure(db *sql.DB, cause error) error {
+	if err := db.Close(); err != nil {
+		return errors.Wrapf(cause, "failed to close database: %s", err.Error())
+	}
+	return cause
+}
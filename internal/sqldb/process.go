@@ -0,0 +1,195 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+const (
+	createProcessStatement = `INSERT INTO processes (process_id, registrations_count, creation_time, creator)
+	VALUES (?, 0, ?, ?) ON CONFLICT (process_id) DO NOTHING`
+	registerInProcessStatement = `UPDATE processes SET registrations_count = registrations_count + ?,
+	created_tasks_count = created_tasks_count + ?
+	WHERE process_id = ? AND sealed_time IS NULL AND (deadline IS NULL OR deadline > ?)
+	AND (registrations_count = 0
+		OR (created_tasks_count > 0 AND aborted_tasks_count = 0 AND timed_out_tasks_count = 0))`
+	configureInitialCallbackStatement = `UPDATE processes SET callback_url = ?, callback_state = ?
+	WHERE process_id = ? AND callback_url IS NULL`
+	configureInitialDeadlineStatement = `UPDATE processes SET deadline = ? WHERE process_id = ? AND deadline IS NULL`
+	getProcessRowQuery                = `SELECT registrations_count, sealed_time, callback_url, callback_state, callback_attempts,
+	callback_last_error, callback_delivery_time, deadline, labels, description, creation_time, creator,
+	created_tasks_count, finished_tasks_count, aborted_tasks_count, timed_out_tasks_count
+	FROM processes WHERE process_id = ?`
+	completeInProcessStatement = `UPDATE processes SET created_tasks_count = created_tasks_count - 1,
+	finished_tasks_count = finished_tasks_count + ?, aborted_tasks_count = aborted_tasks_count + ?
+	WHERE process_id = ?`
+	timeOutInProcessStatement = `UPDATE processes SET created_tasks_count = created_tasks_count - 1,
+	timed_out_tasks_count = timed_out_tasks_count + 1
+	WHERE process_id = ?`
+)
+
+type processRow struct {
+	registrationsCount   int64
+	sealedTime           sql.NullInt64
+	callbackURL          sql.NullString
+	callbackState        sql.NullString
+	callbackAttempts     int
+	callbackLastError    sql.NullString
+	callbackDeliveryTime sql.NullInt64
+	deadline             sql.NullInt64
+	labels               sql.NullString
+	description          sql.NullString
+	creationTime         sql.NullInt64
+	creator              sql.NullString
+	createdTasksCount    int64
+	finishedTasksCount   int64
+	abortedTasksCount    int64
+	timedOutTasksCount   int64
+}
+
+func (row processRow) isSealed() bool {
+	return row.sealedTime.Valid
+}
+
+func (row processRow) processDeadline() time.Time {
+	if !row.deadline.Valid {
+		return time.Time{}
+	}
+	return fromStoredTime(row.deadline.Int64)
+}
+
+func (row processRow) fillMetadata(proc *process.Process) error {
+	labels, err := decodeLabels(row.labels)
+	if err != nil {
+		return err
+	}
+	proc.Labels = labels
+	proc.Description = readNullString(row.description)
+	proc.Creator = readNullString(row.creator)
+	if row.creationTime.Valid {
+		proc.CreationTime = fromStoredTime(row.creationTime.Int64)
+	}
+	return nil
+}
+
+func (row processRow) progress() *process.Progress {
+	return &process.Progress{
+		TotalTasksCount:    int(row.registrationsCount),
+		CreatedTasksCount:  int(row.createdTasksCount),
+		FinishedTasksCount: int(row.finishedTasksCount),
+		AbortedTasksCount:  int(row.abortedTasksCount),
+		TimedOutTasksCount: int(row.timedOutTasksCount),
+	}
+}
+
+func (row processRow) callback() *process.Callback {
+	if !row.callbackURL.Valid {
+		return nil
+	}
+	callback := &process.Callback{
+		URL:       row.callbackURL.String,
+		State:     process.CallbackState(row.callbackState.String),
+		Attempts:  row.callbackAttempts,
+		LastError: readNullString(row.callbackLastError),
+	}
+	if row.callbackDeliveryTime.Valid {
+		callback.DeliveryTime = fromStoredTime(row.callbackDeliveryTime.Int64)
+	}
+	return callback
+}
+
+func (store *Store) registerInProcess(ctx context.Context, executor executor, processID string, tasksCount int,
+	creator *string) (bool, error) {
+	currentTime := toStoredTime(store.currentDateGetter.GetCurrentDate())
+	if _, err := executor.ExecContext(ctx, store.dialect.rebind(createProcessStatement), processID, currentTime,
+		creator); err != nil {
+		return false, err
+	}
+	return execAffectingRows(ctx, executor, store.dialect.rebind(registerInProcessStatement), tasksCount, tasksCount,
+		processID, currentTime)
+}
+
+func (store *Store) completeInProcess(ctx context.Context, executor executor, processID string,
+	state task.State) error {
+	finishedTasksCount, abortedTasksCount := 0, 0
+	if state == task.StateAborted {
+		abortedTasksCount = 1
+	} else {
+		finishedTasksCount = 1
+	}
+	_, err := executor.ExecContext(ctx, store.dialect.rebind(completeInProcessStatement), finishedTasksCount,
+		abortedTasksCount, processID)
+	return err
+}
+
+func (store *Store) timeOutInProcess(ctx context.Context, executor executor, processID string) error {
+	_, err := executor.ExecContext(ctx, store.dialect.rebind(timeOutInProcessStatement), processID)
+	return err
+}
+
+func (store *Store) isDeadlineExceeded(ctx context.Context, processID string) (bool, error) {
+	foundProcessRow, err := store.getProcessRow(ctx, processID)
+	if err != nil || foundProcessRow == nil {
+		return false, err
+	}
+	return process.IsDeadlineExceeded(foundProcessRow.processDeadline(), store.currentDateGetter.GetCurrentDate()), nil
+}
+
+func (store *Store) getProcessRow(ctx context.Context, processID string) (*processRow, error) {
+	var row processRow
+	err := store.db.QueryRowContext(ctx, store.dialect.rebind(getProcessRowQuery), processID).Scan(&row.registrationsCount,
+		&row.sealedTime, &row.callbackURL, &row.callbackState, &row.callbackAttempts, &row.callbackLastError,
+		&row.callbackDeliveryTime, &row.deadline, &row.labels, &row.description, &row.creationTime, &row.creator,
+		&row.createdTasksCount, &row.finishedTasksCount, &row.abortedTasksCount, &row.timedOutTasksCount)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+func (store *Store) configureInitialCallback(ctx context.Context, executor executor, processID string,
+	callbackURL *string) error {
+	if callbackURL == nil {
+		return nil
+	}
+	_, err := executor.ExecContext(ctx, store.dialect.rebind(configureInitialCallbackStatement), *callbackURL,
+		string(process.CallbackStatePending), processID)
+	return err
+}
+
+func (store *Store) configureInitialDeadline(ctx context.Context, executor executor, processID string,
+	deadline time.Time) error {
+	if deadline.IsZero() {
+		return nil
+	}
+	_, err := executor.ExecContext(ctx, store.dialect.rebind(configureInitialDeadlineStatement), toStoredTime(deadline),
+		processID)
+	return err
+}
+
+func encodeLabels(labels map[string]string) (string, error) {
+	encodedLabels, err := json.Marshal(labels)
+	if err != nil {
+		return "", err
+	}
+	return string(encodedLabels), nil
+}
+
+func decodeLabels(encodedLabels sql.NullString) (map[string]string, error) {
+	if !encodedLabels.Valid {
+		return nil, nil
+	}
+	var labels map[string]string
+	if err := json.Unmarshal([]byte(encodedLabels.String), &labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
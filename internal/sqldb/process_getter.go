@@ -0,0 +1,156 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+const (
+	maxSealingAttempts           = 3
+	sealObservedProcessStatement = `UPDATE processes SET sealed_time = ?
+		WHERE process_id = ? AND sealed_time IS NULL AND registrations_count = ?`
+	getFirstBadTaskQuery = `SELECT task_id, state, state_message, bad_state_enter_time FROM tasks
+		WHERE process_id = ? AND bad_state_enter_time IS NOT NULL
+		ORDER BY bad_state_enter_time, task_id
+		LIMIT 1`
+	getEarliestPendingExpirationTimeQuery = `SELECT MIN(expiration_time) FROM tasks
+		WHERE process_id = ? AND state = ? AND expiration_time > ?`
+)
+
+type badTask struct {
+	taskID            string
+	state             task.State
+	stateMessage      sql.NullString
+	badStateEnterTime int64
+}
+
+func (store *Store) Get(ctx context.Context, processID string) (*process.Process, error) {
+	for attempt := 0; attempt < maxSealingAttempts; attempt++ {
+		foundProcess, isObservationValid, err := store.observeProcess(ctx, processID)
+		if err != nil || isObservationValid {
+			return foundProcess, err
+		}
+	}
+	return nil, fmt.Errorf("process %s kept changing while sealing it", processID)
+}
+
+func (store *Store) observeProcess(ctx context.Context, processID string) (*process.Process, bool, error) {
+	foundProcessRow, err := store.getProcessRow(ctx, processID)
+	if err != nil || foundProcessRow == nil {
+		return nil, err == nil, err
+	}
+
+	foundProcess, err := store.getProcess(ctx, processID, foundProcessRow.processDeadline())
+	if err != nil {
+		return nil, false, err
+	}
+	foundProcess.Sealed = foundProcessRow.isSealed()
+	foundProcess.Callback = foundProcessRow.callback()
+	foundProcess.Deadline = foundProcessRow.processDeadline()
+	if err := foundProcessRow.fillMetadata(&foundProcess); err != nil {
+		return nil, false, err
+	}
+	if foundProcess.Progress, err = store.getProcessProgress(ctx, processID, *foundProcessRow); err != nil {
+		return nil, false, err
+	}
+	if foundProcess.Sealed || !foundProcess.IsTerminated() {
+		return &foundProcess, true, nil
+	}
+
+	isSealed, err := execAffectingRows(ctx, store.db, store.dialect.rebind(sealObservedProcessStatement),
+		toStoredTime(store.currentDateGetter.GetCurrentDate()), processID, foundProcessRow.registrationsCount)
+	foundProcess.Sealed = isSealed
+	return &foundProcess, isSealed, err
+}
+
+func (store *Store) getProcess(ctx context.Context, processID string, deadline time.Time) (process.Process, error) {
+	var firstBadTask badTask
+	err := store.db.QueryRowContext(ctx, store.dialect.rebind(getFirstBadTaskQuery), processID).Scan(&firstBadTask.taskID,
+		&firstBadTask.state, &firstBadTask.stateMessage, &firstBadTask.badStateEnterTime)
+	if err == sql.ErrNoRows {
+		return process.Process{ID: processID, State: process.StateCompleted}, nil
+	}
+	if err != nil {
+		return process.Process{}, err
+	}
+	if process.IsDeadlineExceededBefore(deadline, fromStoredTime(firstBadTask.badStateEnterTime),
+		store.currentDateGetter.GetCurrentDate()) {
+		return newDeadlineExceededProcess(processID), nil
+	}
+	return store.readNotCompletedProcess(processID, firstBadTask)
+}
+
+func (store *Store) getProcessProgress(ctx context.Context, processID string,
+	foundProcessRow processRow) (*process.Progress, error) {
+	progress := foundProcessRow.progress()
+	if progress.CreatedTasksCount == 0 {
+		return progress, nil
+	}
+	var earliestPendingExpirationTime sql.NullInt64
+	err := store.db.QueryRowContext(ctx, store.dialect.rebind(getEarliestPendingExpirationTimeQuery), processID,
+		task.StateCreated, toStoredTime(store.currentDateGetter.GetCurrentDate())).Scan(&earliestPendingExpirationTime)
+	if err != nil {
+		return nil, err
+	}
+	if earliestPendingExpirationTime.Valid {
+		progress.EarliestPendingExpirationTime = fromStoredTime(earliestPendingExpirationTime.Int64)
+	}
+	return progress, nil
+}
+
+func (store *Store) readNotCompletedProcess(processID string, firstBadTask badTask) (process.Process, error) {
+	switch firstBadTask.state {
+	case task.StateAborted:
+		return process.Process{
+			ID:           processID,
+			State:        process.StateError,
+			StateMessage: readNullString(firstBadTask.stateMessage),
+		}, nil
+	case task.StateCreated:
+		return store.reportFailureIfTaskTimedOut(processID, firstBadTask), nil
+	case task.StateTimedOut:
+		return newTimedOutProcess(processID), nil
+	default:
+		return process.Process{}, fmt.Errorf("unexpected state of task %s: %s", firstBadTask.taskID, firstBadTask.state)
+	}
+}
+
+func (store *Store) reportFailureIfTaskTimedOut(processID string, firstBadTask badTask) process.Process {
+	if store.currentDateGetter.GetCurrentDate().Before(fromStoredTime(firstBadTask.badStateEnterTime)) {
+		return process.Process{
+			ID:    processID,
+			State: process.StateCreated,
+		}
+	}
+	return newTimedOutProcess(processID)
+}
+
+func newTimedOutProcess(processID string) process.Process {
+	timedOutErrorMessage := process.TimedOutErrorMessage
+	return process.Process{
+		ID:           processID,
+		State:        process.StateError,
+		StateMessage: &timedOutErrorMessage,
+	}
+}
+
+func newDeadlineExceededProcess(processID string) process.Process {
+	deadlineExceededErrorMessage := process.DeadlineExceededErrorMessage
+	return process.Process{
+		ID:           processID,
+		State:        process.StateError,
+		StateMessage: &deadlineExceededErrorMessage,
+	}
+}
+
+func readNullString(nullString sql.NullString) *string {
+	if !nullString.Valid {
+		return nil
+	}
+	return &nullString.String
+}
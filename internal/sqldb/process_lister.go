@@ -0,0 +1,100 @@
+package sqldb
+
+import (
+	"context"
+	"strings"
+
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+const (
+	listProcessesQuery           = `SELECT process_id, COALESCE(creation_time, 0) FROM processes`
+	listProcessesCursorCondition = `(COALESCE(creation_time, 0) > ?
+	OR (COALESCE(creation_time, 0) = ? AND process_id > ?))`
+	listProcessesCreatedAfterCondition = `creation_time > ?`
+	listProcessesPageSuffix            = ` ORDER BY COALESCE(creation_time, 0), process_id LIMIT ?`
+)
+
+func (store *Store) ListProcesses(ctx context.Context, request process.ListRequest) (process.List, error) {
+	var lastPosition *process.ListPosition
+	if request.Cursor != "" {
+		decodedPosition, err := process.DecodeListCursor(request.Cursor)
+		if err != nil {
+			return process.List{}, err
+		}
+		lastPosition = &decodedPosition
+	}
+
+	processesList := process.List{Processes: []process.Process{}}
+	scannedCount := 0
+	for {
+		positions, err := store.listProcessesPositions(ctx, request, lastPosition, request.Limit+1)
+		if err != nil {
+			return process.List{}, err
+		}
+		for positionIndex := range positions {
+			if scannedCount == process.MaxScannedProcessesPerPage {
+				processesList.NextCursor = process.EncodeListCursor(*lastPosition)
+				return processesList, nil
+			}
+			scannedCount++
+			position := positions[positionIndex]
+			listedProcess, err := store.Get(ctx, position.ProcessID)
+			if err != nil {
+				return process.List{}, err
+			}
+			if listedProcess != nil && request.Matches(*listedProcess) {
+				if len(processesList.Processes) == request.Limit {
+					processesList.NextCursor = process.EncodeListCursor(*lastPosition)
+					return processesList, nil
+				}
+				processesList.Processes = append(processesList.Processes, *listedProcess)
+			}
+			lastPosition = &position
+		}
+		if len(positions) <= request.Limit {
+			return processesList, nil
+		}
+	}
+}
+
+func (store *Store) listProcessesPositions(ctx context.Context, request process.ListRequest,
+	lastPosition *process.ListPosition, limit int) ([]process.ListPosition, error) {
+	var conditions []string
+	var args []interface{}
+	if lastPosition != nil {
+		lastCreationTime := toStoredTime(lastPosition.CreationTime)
+		conditions = append(conditions, listProcessesCursorCondition)
+		args = append(args, lastCreationTime, lastCreationTime, lastPosition.ProcessID)
+	}
+	if !request.CreatedAfter.IsZero() {
+		conditions = append(conditions, listProcessesCreatedAfterCondition)
+		args = append(args, toStoredTime(request.CreatedAfter))
+	}
+	query := listProcessesQuery
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += listProcessesPageSuffix
+	args = append(args, limit)
+
+	rows, err := store.db.QueryContext(ctx, store.dialect.rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var positions []process.ListPosition
+	for rows.Next() {
+		var processID string
+		var creationTime int64
+		if err := rows.Scan(&processID, &creationTime); err != nil {
+			return nil, err
+		}
+		positions = append(positions, process.ListPosition{
+			CreationTime: fromStoredTime(creationTime),
+			ProcessID:    processID,
+		})
+	}
+	return positions, rows.Err()
+}
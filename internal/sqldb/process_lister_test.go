@@ -0,0 +1,122 @@
+package sqldb_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_ListProcesses(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	for _, processID := range []string{"2", "1", "3"} {
+		storeAndMocks.mustRegister(t, task.ID{ProcessID: processID, TaskID: "1"}, storeAndMocks.currentDate.Add(time.Hour))
+	}
+	creationTime := storeAndMocks.currentDate.Truncate(time.Second)
+	progress := &process.Progress{
+		TotalTasksCount:               1,
+		CreatedTasksCount:             1,
+		EarliestPendingExpirationTime: storeAndMocks.currentDate.Add(time.Hour).Truncate(time.Second),
+	}
+
+	processesList, err := storeAndMocks.store.ListProcesses(context.Background(), process.ListRequest{Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []process.Process{
+		{ID: "1", State: process.StateCreated, CreationTime: creationTime, Progress: progress},
+		{ID: "2", State: process.StateCreated, CreationTime: creationTime, Progress: progress},
+	}, processesList.Processes)
+	assert.NotEmpty(t, processesList.NextCursor)
+
+	processesList, err = storeAndMocks.store.ListProcesses(context.Background(), process.ListRequest{
+		Cursor: processesList.NextCursor,
+		Limit:  2,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []process.Process{
+		{ID: "3", State: process.StateCreated, CreationTime: creationTime, Progress: progress},
+	}, processesList.Processes)
+	assert.Empty(t, processesList.NextCursor)
+}
+
+func TestStore_ListProcesses_Filtered(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	storeAndMocks.mustRegister(t, task.ID{ProcessID: "1", TaskID: "1"}, storeAndMocks.currentDate.Add(-time.Hour))
+	storeAndMocks.mustRegister(t, task.ID{ProcessID: "2", TaskID: "1"}, storeAndMocks.currentDate.Add(-time.Hour))
+	storeAndMocks.mustRegister(t, task.ID{ProcessID: "3", TaskID: "1"}, storeAndMocks.currentDate.Add(time.Hour))
+	for _, processID := range []string{"2", "3"} {
+		_, err := storeAndMocks.store.Update(context.Background(), process.UpdateRequest{
+			ProcessID: processID,
+			Labels:    map[string]string{"team": "ingest"},
+		})
+		assert.NoError(t, err)
+	}
+	errorState := process.StateError
+
+	processesList, err := storeAndMocks.store.ListProcesses(context.Background(), process.ListRequest{
+		State: &errorState,
+		Label: &process.Label{Name: "team", Value: "ingest"},
+		Limit: 10,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []process.Process{{
+		ID:           "2",
+		State:        process.StateError,
+		StateMessage: aws.String(process.TimedOutErrorMessage),
+		Sealed:       true,
+		Labels:       map[string]string{"team": "ingest"},
+		CreationTime: storeAndMocks.currentDate.Truncate(time.Second),
+		Progress:     &process.Progress{TotalTasksCount: 1, CreatedTasksCount: 1},
+	}}, processesList.Processes)
+	assert.Empty(t, processesList.NextCursor)
+}
+
+func TestStore_ListProcesses_CreatedAfter(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	storeAndMocks.mustRegister(t, task.ID{ProcessID: "1", TaskID: "1"}, storeAndMocks.currentDate.Add(time.Hour))
+
+	processesList, err := storeAndMocks.store.ListProcesses(context.Background(), process.ListRequest{
+		CreatedAfter: storeAndMocks.currentDate.Add(-time.Minute),
+		Limit:        10,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, processesList.Processes, 1)
+
+	processesList, err = storeAndMocks.store.ListProcesses(context.Background(), process.ListRequest{
+		CreatedAfter: storeAndMocks.currentDate,
+		Limit:        10,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, processesList.Processes)
+}
+
+func TestStore_ListProcesses_ScannedProcessesLimitReached(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	for processIndex := 0; processIndex <= process.MaxScannedProcessesPerPage; processIndex++ {
+		storeAndMocks.mustRegister(t, task.ID{ProcessID: fmt.Sprintf("%04d", processIndex), TaskID: "1"},
+			storeAndMocks.currentDate.Add(time.Hour))
+	}
+	errorState := process.StateError
+
+	processesList, err := storeAndMocks.store.ListProcesses(context.Background(), process.ListRequest{
+		State: &errorState,
+		Limit: 10,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, processesList.Processes)
+	assert.Equal(t, process.EncodeListCursor(process.ListPosition{
+		CreationTime: storeAndMocks.currentDate.Truncate(time.Second),
+		ProcessID:    fmt.Sprintf("%04d", process.MaxScannedProcessesPerPage-1),
+	}), processesList.NextCursor)
+}
+
+func TestStore_ListProcesses_InvalidCursor(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+
+	_, err := storeAndMocks.store.ListProcesses(context.Background(), process.ListRequest{Cursor: "!", Limit: 10})
+	assert.Equal(t, process.ErrInvalidCursor, err)
+}
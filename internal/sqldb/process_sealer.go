@@ -0,0 +1,21 @@
+package sqldb
+
+import (
+	"context"
+
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+const sealProcessStatement = `UPDATE processes SET sealed_time = COALESCE(sealed_time, ?) WHERE process_id = ?`
+
+func (store *Store) Seal(ctx context.Context, processID string) (process.SealingResult, error) {
+	isSealed, err := execAffectingRows(ctx, store.db, store.dialect.rebind(sealProcessStatement),
+		toStoredTime(store.currentDateGetter.GetCurrentDate()), processID)
+	if err != nil {
+		return "", err
+	}
+	if !isSealed {
+		return process.SealingResultNotFound, nil
+	}
+	return process.SealingResultSealed, nil
+}
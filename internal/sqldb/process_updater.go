@@ -0,0 +1,84 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+const (
+	updateProcessCallbackStatement = `UPDATE processes SET callback_url = ?, callback_state = ?, callback_attempts = 0,
+	callback_last_error = NULL, callback_delivery_time = NULL WHERE process_id = ?`
+	updateProcessDeadlineStatement    = `UPDATE processes SET deadline = ? WHERE process_id = ?`
+	updateProcessLabelsStatement      = `UPDATE processes SET labels = ? WHERE process_id = ?`
+	updateProcessDescriptionStatement = `UPDATE processes SET description = ? WHERE process_id = ?`
+)
+
+func (store *Store) Update(ctx context.Context, request process.UpdateRequest) (process.UpdatingResult, error) {
+	if request.CallbackURL == nil && request.Deadline == nil && request.Labels == nil && request.Description == nil {
+		return store.checkIfProcessUpdatable(ctx, request.ProcessID)
+	}
+	var updatingResult process.UpdatingResult
+	err := store.inTransaction(ctx, func(tx *sql.Tx) (bool, error) {
+		isUpdated, err := store.update(ctx, tx, request)
+		if err != nil || !isUpdated {
+			updatingResult = process.UpdatingResultNotFound
+			return false, err
+		}
+		updatingResult = process.UpdatingResultUpdated
+		return true, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return updatingResult, nil
+}
+
+func (store *Store) update(ctx context.Context, executor executor, request process.UpdateRequest) (bool, error) {
+	if request.CallbackURL != nil {
+		isUpdated, err := execAffectingRows(ctx, executor, store.dialect.rebind(updateProcessCallbackStatement),
+			*request.CallbackURL, string(process.CallbackStatePending), request.ProcessID)
+		if err != nil || !isUpdated {
+			return false, err
+		}
+	}
+	if request.Deadline != nil {
+		isUpdated, err := execAffectingRows(ctx, executor, store.dialect.rebind(updateProcessDeadlineStatement),
+			toStoredTime(*request.Deadline), request.ProcessID)
+		if err != nil || !isUpdated {
+			return false, err
+		}
+	}
+	return store.updateMetadata(ctx, executor, request)
+}
+
+func (store *Store) updateMetadata(ctx context.Context, executor executor, request process.UpdateRequest) (bool, error) {
+	if request.Labels != nil {
+		encodedLabels, err := encodeLabels(request.Labels)
+		if err != nil {
+			return false, err
+		}
+		isUpdated, err := execAffectingRows(ctx, executor, store.dialect.rebind(updateProcessLabelsStatement),
+			encodedLabels, request.ProcessID)
+		if err != nil || !isUpdated {
+			return false, err
+		}
+	}
+	if request.Description != nil {
+		return execAffectingRows(ctx, executor, store.dialect.rebind(updateProcessDescriptionStatement),
+			*request.Description, request.ProcessID)
+	}
+	return true, nil
+}
+
+func (store *Store) checkIfProcessUpdatable(ctx context.Context, processID string) (process.UpdatingResult, error) {
+	foundProcessRow, err := store.getProcessRow(ctx, processID)
+	if err != nil {
+		return "", err
+	}
+	if foundProcessRow == nil {
+		return process.UpdatingResultNotFound, nil
+	}
+	return process.UpdatingResultUpdated, nil
+}
@@ -0,0 +1,127 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_Update(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	processID := "1"
+	storeAndMocks.mustRegister(t, task.ID{ProcessID: processID, TaskID: "1"}, storeAndMocks.currentDate.Add(time.Hour))
+
+	updatingResult, err := storeAndMocks.store.Update(context.Background(), process.UpdateRequest{
+		ProcessID:   processID,
+		CallbackURL: aws.String("https://example.com/callback"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, process.UpdatingResultUpdated, updatingResult)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), processID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:    processID,
+		State: process.StateCreated,
+		Callback: &process.Callback{
+			URL:   "https://example.com/callback",
+			State: process.CallbackStatePending,
+		},
+		CreationTime: storeAndMocks.currentDate.Truncate(time.Second),
+		Progress: &process.Progress{
+			TotalTasksCount:               1,
+			CreatedTasksCount:             1,
+			EarliestPendingExpirationTime: storeAndMocks.currentDate.Add(time.Hour).Truncate(time.Second),
+		},
+	}, proc)
+}
+
+func TestStore_Update_Metadata(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	processID := "1"
+	storeAndMocks.mustRegister(t, task.ID{ProcessID: processID, TaskID: "1"}, storeAndMocks.currentDate.Add(time.Hour))
+	labels := map[string]string{"team": "payments"}
+
+	updatingResult, err := storeAndMocks.store.Update(context.Background(), process.UpdateRequest{
+		ProcessID:   processID,
+		Labels:      labels,
+		Description: aws.String("nightly settlement"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, process.UpdatingResultUpdated, updatingResult)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), processID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           processID,
+		State:        process.StateCreated,
+		Labels:       labels,
+		Description:  aws.String("nightly settlement"),
+		CreationTime: storeAndMocks.currentDate.Truncate(time.Second),
+		Progress: &process.Progress{
+			TotalTasksCount:               1,
+			CreatedTasksCount:             1,
+			EarliestPendingExpirationTime: storeAndMocks.currentDate.Add(time.Hour).Truncate(time.Second),
+		},
+	}, proc)
+}
+
+func TestStore_Update_ProcessNotExists(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+
+	for _, callbackURL := range []*string{nil, aws.String("https://example.com/callback")} {
+		updatingResult, err := storeAndMocks.store.Update(context.Background(), process.UpdateRequest{
+			ProcessID:   "1",
+			CallbackURL: callbackURL,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, process.UpdatingResultNotFound, updatingResult)
+	}
+}
+
+func TestStore_Register_WithCallback(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	processID := "1"
+	storeAndMocks.mustRegisterWithCallback(t, task.ID{ProcessID: processID, TaskID: "1"}, "https://example.com/first")
+	storeAndMocks.mustRegisterWithCallback(t, task.ID{ProcessID: processID, TaskID: "2"}, "https://example.com/second")
+
+	proc, err := storeAndMocks.store.Get(context.Background(), processID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Callback{
+		URL:   "https://example.com/first",
+		State: process.CallbackStatePending,
+	}, proc.Callback)
+}
+
+func TestStore_Update_Deadline(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	processID := "1"
+	storeAndMocks.mustRegister(t, task.ID{ProcessID: processID, TaskID: "1"}, storeAndMocks.currentDate.Add(time.Hour))
+	processDeadline := storeAndMocks.currentDate.Add(time.Minute)
+
+	updatingResult, err := storeAndMocks.store.Update(context.Background(), process.UpdateRequest{
+		ProcessID: processID,
+		Deadline:  &processDeadline,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, process.UpdatingResultUpdated, updatingResult)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), processID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           processID,
+		State:        process.StateCreated,
+		Deadline:     processDeadline.Truncate(time.Second),
+		CreationTime: storeAndMocks.currentDate.Truncate(time.Second),
+		Progress: &process.Progress{
+			TotalTasksCount:               1,
+			CreatedTasksCount:             1,
+			EarliestPendingExpirationTime: storeAndMocks.currentDate.Add(time.Hour).Truncate(time.Second),
+		},
+	}, proc)
+}
@@ -0,0 +1,61 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/sqldb"
+	"github.com/artii15/termination-detector/pkg/task"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type currentDateGetterMock struct {
+	mock.Mock
+}
+
+func (getter *currentDateGetterMock) GetCurrentDate() time.Time {
+	return getter.Called().Get(0).(time.Time)
+}
+
+type storeWithMocks struct {
+	store             *sqldb.Store
+	currentDateGetter *currentDateGetterMock
+	currentDate       time.Time
+}
+
+func newStoreWithMocks(t *testing.T) *storeWithMocks {
+	db, err := sqldb.Open(sqldb.DialectSQLite, ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, db.Close())
+	})
+	require.NoError(t, sqldb.Migrate(db, sqldb.DialectSQLite))
+
+	currentDateGetter := new(currentDateGetterMock)
+	currentDate := time.Now().UTC()
+	currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	return &storeWithMocks{
+		store:             sqldb.NewStore(db, sqldb.DialectSQLite, currentDateGetter),
+		currentDateGetter: currentDateGetter,
+		currentDate:       currentDate,
+	}
+}
+
+func (storeAndMocks *storeWithMocks) mustRegister(t *testing.T, taskID task.ID, expirationTime time.Time) {
+	registrationResult, err := storeAndMocks.store.Register(context.Background(), task.RegistrationData{
+		ID:             taskID,
+		ExpirationTime: expirationTime,
+	})
+	require.NoError(t, err)
+	require.Equal(t, task.RegistrationResultCreated, registrationResult)
+}
+
+func (storeAndMocks *storeWithMocks) moveCurrentDate(currentDate time.Time) {
+	storeAndMocks.currentDateGetter.ExpectedCalls = nil
+	storeAndMocks.currentDateGetter.On("GetCurrentDate").Return(currentDate)
+	storeAndMocks.currentDate = currentDate
+}
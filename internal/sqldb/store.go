@@ -0,0 +1,64 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type currentDateGetter interface {
+	GetCurrentDate() time.Time
+}
+
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+type Store struct {
+	db                *sql.DB
+	dialect           Dialect
+	currentDateGetter currentDateGetter
+}
+
+func NewStore(db *sql.DB, dialect Dialect, currentDateGetter currentDateGetter) *Store {
+	return &Store{
+		db:                db,
+		dialect:           dialect,
+		currentDateGetter: currentDateGetter,
+	}
+}
+
+func (store *Store) inTransaction(ctx context.Context, operation func(tx *sql.Tx) (bool, error)) error {
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	shouldCommit, err := operation(tx)
+	if err != nil {
+		return rollback(tx, err)
+	}
+	if !shouldCommit {
+		return tx.Rollback()
+	}
+	return tx.Commit()
+}
+
+func execAffectingRows(ctx context.Context, executor executor, statement string, args ...interface{}) (bool, error) {
+	result, err := executor.ExecContext(ctx, statement, args...)
+	if err != nil {
+		return false, err
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affectedRows > 0, nil
+}
+
+func toStoredTime(date time.Time) int64 {
+	return date.UTC().Unix()
+}
+
+func fromStoredTime(storedTime int64) time.Time {
+	return time.Unix(storedTime, 0).UTC()
+}
@@ -0,0 +1,44 @@
+package sqldb
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+const taskColumns = `task_id, state, state_message, expiration_time, creation_time`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+type taskRow struct {
+	taskID         string
+	state          task.State
+	stateMessage   sql.NullString
+	expirationTime int64
+	creationTime   sql.NullInt64
+}
+
+func scanTaskRow(scanner rowScanner) (taskRow, error) {
+	var row taskRow
+	err := scanner.Scan(&row.taskID, &row.state, &row.stateMessage, &row.expirationTime, &row.creationTime)
+	return row, err
+}
+
+func (row taskRow) toTask(processID string, currentTime time.Time) task.Task {
+	expirationTime := fromStoredTime(row.expirationTime)
+	var creationTime time.Time
+	if row.creationTime.Valid {
+		creationTime = fromStoredTime(row.creationTime.Int64)
+	}
+	return task.Task{
+		ID:             task.ID{ProcessID: processID, TaskID: row.taskID},
+		State:          row.state,
+		StateMessage:   readNullString(row.stateMessage),
+		ExpirationTime: expirationTime,
+		CreationTime:   creationTime,
+		TimedOut:       task.IsTimedOut(row.state, expirationTime, currentTime),
+	}
+}
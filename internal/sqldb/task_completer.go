@@ -0,0 +1,158 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+const completeTaskStatement = `UPDATE tasks SET state = ?, state_message = ?, bad_state_enter_time = ?
+	WHERE process_id = ? AND task_id = ? AND state = ? AND expiration_time > ?
+	AND NOT EXISTS (SELECT 1 FROM processes
+		WHERE processes.process_id = tasks.process_id AND processes.deadline <= ?)`
+
+func (store *Store) Complete(ctx context.Context, request task.CompleteRequest) (task.CompletingResult, error) {
+	isCompleted := false
+	err := store.inTransaction(ctx, func(tx *sql.Tx) (bool, error) {
+		var err error
+		isCompleted, err = store.complete(ctx, tx, request, store.currentDateGetter.GetCurrentDate())
+		return isCompleted, err
+	})
+	if err != nil {
+		return "", err
+	}
+	if !isCompleted {
+		return store.readCompletingConflictResult(ctx, request)
+	}
+	return task.CompletingResultCompleted, nil
+}
+
+func (store *Store) CompleteWithChildren(ctx context.Context, request task.CompleteWithChildrenRequest) (task.CompletingResult, error) {
+	var completingResult task.CompletingResult
+	err := store.inTransaction(ctx, func(tx *sql.Tx) (bool, error) {
+		isCompleted, err := store.completeTask(ctx, tx, request.CompleteRequest, store.currentDateGetter.GetCurrentDate())
+		if err != nil || !isCompleted {
+			completingResult = task.CompletingResultConflict
+			return false, err
+		}
+		if len(request.Children) > 0 {
+			isRegisteredInProcess, err := store.registerInProcess(ctx, tx, request.ProcessID, len(request.Children), nil)
+			if err != nil || !isRegisteredInProcess {
+				completingResult = task.CompletingResultProcessSealed
+				return false, err
+			}
+		}
+		if err := store.completeInProcess(ctx, tx, request.ProcessID, request.State); err != nil {
+			return false, err
+		}
+		for _, child := range request.Children {
+			isRegistered, err := store.register(ctx, tx, child)
+			if err != nil || !isRegistered {
+				completingResult = task.CompletingResultChildConflict
+				return false, err
+			}
+		}
+		completingResult = task.CompletingResultCompleted
+		return true, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if completingResult == task.CompletingResultConflict {
+		return store.readCompletingConflictResult(ctx, request.CompleteRequest)
+	}
+	return completingResult, nil
+}
+
+func (store *Store) BatchComplete(ctx context.Context,
+	request task.BatchCompleteRequest) ([]task.BatchCompletingResult, error) {
+	completionTime := store.currentDateGetter.GetCurrentDate()
+	results := task.NewBatchCompletingResults(request, task.CompletingResultCompleted)
+	for {
+		isAnyTaskConflicting := false
+		err := store.inTransaction(ctx, func(tx *sql.Tx) (bool, error) {
+			for index, completeRequest := range request.Tasks {
+				if results[index].Result != task.CompletingResultCompleted {
+					continue
+				}
+				isCompleted, err := store.complete(ctx, tx, completeRequest, completionTime)
+				if err != nil {
+					return false, err
+				}
+				if !isCompleted {
+					results[index].Result = task.CompletingResultConflict
+					isAnyTaskConflicting = true
+				}
+			}
+			return !request.Transactional || !isAnyTaskConflicting, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := store.resolveCompletingConflicts(ctx, request, results); err != nil {
+			return nil, err
+		}
+		if !request.Transactional || !isAnyTaskConflicting {
+			return results, nil
+		}
+		if !task.AreCompletingSucceeded(results) {
+			task.ReplaceCompletingResults(results, task.CompletingResultCompleted, task.CompletingResultCanceled)
+			return results, nil
+		}
+	}
+}
+
+func (store *Store) resolveCompletingConflicts(ctx context.Context, request task.BatchCompleteRequest,
+	results []task.BatchCompletingResult) error {
+	for index, completeRequest := range request.Tasks {
+		if results[index].Result != task.CompletingResultConflict {
+			continue
+		}
+		completingResult, err := store.readCompletingConflictResult(ctx, completeRequest)
+		if err != nil {
+			return err
+		}
+		results[index].Result = completingResult
+	}
+	return nil
+}
+
+func (store *Store) readCompletingConflictResult(ctx context.Context,
+	request task.CompleteRequest) (task.CompletingResult, error) {
+	existingTask, err := store.GetTask(ctx, request.ID)
+	if err != nil {
+		return "", err
+	}
+	if existingTask != nil && existingTask.State == task.StateCreated && !existingTask.TimedOut {
+		isDeadlineExceeded, err := store.isDeadlineExceeded(ctx, request.ProcessID)
+		if err != nil {
+			return "", err
+		}
+		if isDeadlineExceeded {
+			return task.CompletingResultProcessDeadlineExceeded, nil
+		}
+	}
+	return task.ReadCompletingConflictResult(existingTask, request), nil
+}
+
+func (store *Store) complete(ctx context.Context, executor executor, request task.CompleteRequest, completionTime time.Time) (bool, error) {
+	isCompleted, err := store.completeTask(ctx, executor, request, completionTime)
+	if err != nil || !isCompleted {
+		return false, err
+	}
+	return true, store.completeInProcess(ctx, executor, request.ProcessID, request.State)
+}
+
+func (store *Store) completeTask(ctx context.Context, executor executor, request task.CompleteRequest,
+	completionTime time.Time) (bool, error) {
+	storedCompletionTime := toStoredTime(completionTime)
+	var badStateEnterTime sql.NullInt64
+	if request.State == task.StateAborted {
+		badStateEnterTime = sql.NullInt64{Int64: storedCompletionTime, Valid: true}
+	}
+	return execAffectingRows(ctx, executor, store.dialect.rebind(completeTaskStatement), string(request.State),
+		request.Message, badStateEnterTime, request.ProcessID, request.TaskID, string(task.StateCreated),
+		storedCompletionTime, storedCompletionTime)
+}
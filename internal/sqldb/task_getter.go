@@ -0,0 +1,22 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+const getTaskQuery = `SELECT ` + taskColumns + ` FROM tasks WHERE process_id = ? AND task_id = ?`
+
+func (store *Store) GetTask(ctx context.Context, id task.ID) (*task.Task, error) {
+	row, err := scanTaskRow(store.db.QueryRowContext(ctx, store.dialect.rebind(getTaskQuery), id.ProcessID, id.TaskID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	foundTask := row.toTask(id.ProcessID, store.currentDateGetter.GetCurrentDate())
+	return &foundTask, nil
+}
@@ -0,0 +1,59 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_GetTask(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	taskID := task.ID{ProcessID: "1", TaskID: "2"}
+	expirationTime := storeAndMocks.currentDate.Add(time.Hour).Truncate(time.Second)
+	storeAndMocks.mustRegister(t, taskID, expirationTime)
+	_, err := storeAndMocks.store.Complete(context.Background(), task.CompleteRequest{
+		ID:      taskID,
+		State:   task.StateAborted,
+		Message: aws.String("failure"),
+	})
+	assert.NoError(t, err)
+
+	foundTask, err := storeAndMocks.store.GetTask(context.Background(), taskID)
+	assert.NoError(t, err)
+	assert.Equal(t, &task.Task{
+		ID:             taskID,
+		State:          task.StateAborted,
+		StateMessage:   aws.String("failure"),
+		ExpirationTime: expirationTime,
+		CreationTime:   storeAndMocks.currentDate.Truncate(time.Second),
+	}, foundTask)
+}
+
+func TestStore_GetTask_TimedOut(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	taskID := task.ID{ProcessID: "1", TaskID: "2"}
+	expirationTime := storeAndMocks.currentDate.Add(-time.Hour).Truncate(time.Second)
+	storeAndMocks.mustRegister(t, taskID, expirationTime)
+
+	foundTask, err := storeAndMocks.store.GetTask(context.Background(), taskID)
+	assert.NoError(t, err)
+	assert.Equal(t, &task.Task{
+		ID:             taskID,
+		State:          task.StateCreated,
+		ExpirationTime: expirationTime,
+		CreationTime:   storeAndMocks.currentDate.Truncate(time.Second),
+		TimedOut:       true,
+	}, foundTask)
+}
+
+func TestStore_GetTask_NotFound(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+
+	foundTask, err := storeAndMocks.store.GetTask(context.Background(), task.ID{ProcessID: "1", TaskID: "2"})
+	assert.NoError(t, err)
+	assert.Nil(t, foundTask)
+}
@@ -0,0 +1,24 @@
+package sqldb
+
+import (
+	"context"
+
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+const heartbeatTaskStatement = `UPDATE tasks SET expiration_time = ?, bad_state_enter_time = ?
+	WHERE process_id = ? AND task_id = ? AND state = ? AND expiration_time > ?`
+
+func (store *Store) Heartbeat(ctx context.Context, request task.HeartbeatRequest) (task.HeartbeatResult, error) {
+	expirationTime := toStoredTime(request.ExpirationTime)
+	isExtended, err := execAffectingRows(ctx, store.db, store.dialect.rebind(heartbeatTaskStatement), expirationTime,
+		expirationTime, request.ProcessID, request.TaskID, string(task.StateCreated),
+		toStoredTime(store.currentDateGetter.GetCurrentDate()))
+	if err != nil {
+		return "", err
+	}
+	if !isExtended {
+		return task.HeartbeatResultConflict, nil
+	}
+	return task.HeartbeatResultExtended, nil
+}
@@ -0,0 +1,78 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_Heartbeat(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	taskID := task.ID{ProcessID: "2", TaskID: "1"}
+	storeAndMocks.mustRegister(t, taskID, storeAndMocks.currentDate.Add(time.Hour))
+
+	heartbeatResult, err := storeAndMocks.store.Heartbeat(context.Background(), task.HeartbeatRequest{
+		ID:             taskID,
+		ExpirationTime: storeAndMocks.currentDate.Add(-time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.HeartbeatResultExtended, heartbeatResult)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), taskID.ProcessID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           taskID.ProcessID,
+		State:        process.StateError,
+		StateMessage: aws.String(process.TimedOutErrorMessage),
+		Sealed:       true,
+		CreationTime: storeAndMocks.currentDate.Truncate(time.Second),
+		Progress: &process.Progress{
+			TotalTasksCount:   1,
+			CreatedTasksCount: 1,
+		},
+	}, proc)
+}
+
+func TestStore_Heartbeat_TaskCompleted(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	taskID := task.ID{ProcessID: "2", TaskID: "1"}
+	storeAndMocks.mustRegister(t, taskID, storeAndMocks.currentDate.Add(time.Hour))
+	_, err := storeAndMocks.store.Complete(context.Background(), task.CompleteRequest{ID: taskID, State: task.StateFinished})
+	assert.NoError(t, err)
+
+	heartbeatResult, err := storeAndMocks.store.Heartbeat(context.Background(), task.HeartbeatRequest{
+		ID:             taskID,
+		ExpirationTime: storeAndMocks.currentDate.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.HeartbeatResultConflict, heartbeatResult)
+}
+
+func TestStore_Heartbeat_TaskExpired(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	taskID := task.ID{ProcessID: "2", TaskID: "1"}
+	storeAndMocks.mustRegister(t, taskID, storeAndMocks.currentDate)
+
+	heartbeatResult, err := storeAndMocks.store.Heartbeat(context.Background(), task.HeartbeatRequest{
+		ID:             taskID,
+		ExpirationTime: storeAndMocks.currentDate.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.HeartbeatResultConflict, heartbeatResult)
+}
+
+func TestStore_Heartbeat_TaskNotRegistered(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+
+	heartbeatResult, err := storeAndMocks.store.Heartbeat(context.Background(), task.HeartbeatRequest{
+		ID:             task.ID{ProcessID: "2", TaskID: "1"},
+		ExpirationTime: storeAndMocks.currentDate.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.HeartbeatResultConflict, heartbeatResult)
+}
@@ -0,0 +1,54 @@
+package sqldb
+
+import (
+	"context"
+
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+const (
+	listTasksQuery          = `SELECT ` + taskColumns + ` FROM tasks WHERE process_id = ? AND task_id > ?`
+	listTasksStateCondition = ` AND state = ?`
+	listTasksPageSuffix     = ` ORDER BY task_id LIMIT ?`
+)
+
+func (store *Store) List(ctx context.Context, request task.ListRequest) (task.List, error) {
+	lastTaskID := ""
+	if request.Cursor != "" {
+		decodedTaskID, err := task.DecodeListCursor(request.Cursor)
+		if err != nil {
+			return task.List{}, err
+		}
+		lastTaskID = decodedTaskID
+	}
+
+	query := listTasksQuery
+	args := []interface{}{request.ProcessID, lastTaskID}
+	if request.State != nil {
+		query += listTasksStateCondition
+		args = append(args, string(*request.State))
+	}
+	query += listTasksPageSuffix
+	args = append(args, request.Limit+1)
+
+	rows, err := store.db.QueryContext(ctx, store.dialect.rebind(query), args...)
+	if err != nil {
+		return task.List{}, err
+	}
+	defer rows.Close()
+
+	currentTime := store.currentDateGetter.GetCurrentDate()
+	tasksList := task.List{Tasks: []task.Task{}}
+	for rows.Next() {
+		if len(tasksList.Tasks) == request.Limit {
+			tasksList.NextCursor = task.EncodeListCursor(tasksList.Tasks[len(tasksList.Tasks)-1].TaskID)
+			break
+		}
+		row, err := scanTaskRow(rows)
+		if err != nil {
+			return task.List{}, err
+		}
+		tasksList.Tasks = append(tasksList.Tasks, row.toTask(request.ProcessID, currentTime))
+	}
+	return tasksList, rows.Err()
+}
@@ -0,0 +1,76 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_List(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	expirationTime := storeAndMocks.currentDate.Add(time.Hour).Truncate(time.Second)
+	creationTime := storeAndMocks.currentDate.Truncate(time.Second)
+	for _, taskID := range []string{"3", "1", "2"} {
+		storeAndMocks.mustRegister(t, task.ID{ProcessID: "1", TaskID: taskID}, expirationTime)
+	}
+	_, err := storeAndMocks.store.Complete(context.Background(), task.CompleteRequest{
+		ID:      task.ID{ProcessID: "1", TaskID: "2"},
+		State:   task.StateAborted,
+		Message: aws.String("failure"),
+	})
+	assert.NoError(t, err)
+
+	firstPage, err := storeAndMocks.store.List(context.Background(), task.ListRequest{ProcessID: "1", Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []task.Task{
+		{ID: task.ID{ProcessID: "1", TaskID: "1"}, State: task.StateCreated, ExpirationTime: expirationTime,
+			CreationTime: creationTime},
+		{ID: task.ID{ProcessID: "1", TaskID: "2"}, State: task.StateAborted, StateMessage: aws.String("failure"),
+			ExpirationTime: expirationTime, CreationTime: creationTime},
+	}, firstPage.Tasks)
+	assert.NotEmpty(t, firstPage.NextCursor)
+
+	secondPage, err := storeAndMocks.store.List(context.Background(), task.ListRequest{ProcessID: "1", Cursor: firstPage.NextCursor, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, task.List{Tasks: []task.Task{
+		{ID: task.ID{ProcessID: "1", TaskID: "3"}, State: task.StateCreated, ExpirationTime: expirationTime,
+			CreationTime: creationTime},
+	}}, secondPage)
+}
+
+func TestStore_List_StateFilter(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	expirationTime := storeAndMocks.currentDate.Add(time.Hour).Truncate(time.Second)
+	creationTime := storeAndMocks.currentDate.Truncate(time.Second)
+	storeAndMocks.mustRegister(t, task.ID{ProcessID: "1", TaskID: "1"}, expirationTime)
+	storeAndMocks.mustRegister(t, task.ID{ProcessID: "1", TaskID: "2"}, expirationTime)
+	_, err := storeAndMocks.store.Complete(context.Background(), task.CompleteRequest{ID: task.ID{ProcessID: "1", TaskID: "1"}, State: task.StateFinished})
+	assert.NoError(t, err)
+
+	state := task.StateFinished
+	tasksList, err := storeAndMocks.store.List(context.Background(), task.ListRequest{ProcessID: "1", State: &state, Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, task.List{Tasks: []task.Task{
+		{ID: task.ID{ProcessID: "1", TaskID: "1"}, State: task.StateFinished, ExpirationTime: expirationTime,
+			CreationTime: creationTime},
+	}}, tasksList)
+}
+
+func TestStore_List_ProcessNotExists(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+
+	tasksList, err := storeAndMocks.store.List(context.Background(), task.ListRequest{ProcessID: "1", Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, task.List{Tasks: []task.Task{}}, tasksList)
+}
+
+func TestStore_List_InvalidCursor(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+
+	_, err := storeAndMocks.store.List(context.Background(), task.ListRequest{ProcessID: "1", Cursor: "!", Limit: 10})
+	assert.Equal(t, task.ErrInvalidCursor, err)
+}
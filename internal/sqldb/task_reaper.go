@@ -0,0 +1,71 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+const (
+	listTimedOutTasksQuery = `SELECT process_id, task_id FROM tasks WHERE state = ? AND expiration_time <= ?
+		ORDER BY expiration_time, process_id, task_id
+		LIMIT ?`
+	reapTimedOutTaskStatement = `UPDATE tasks SET state = ?, state_message = ?
+		WHERE process_id = ? AND task_id = ? AND state = ? AND expiration_time <= ?`
+)
+
+func (store *Store) ReapTimedOut(ctx context.Context, limit int) ([]task.ID, error) {
+	currentTime := toStoredTime(store.currentDateGetter.GetCurrentDate())
+	timedOutTaskIDs, err := store.listTimedOutTasks(ctx, currentTime, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	reapedTaskIDs := make([]task.ID, 0, len(timedOutTaskIDs))
+	for _, taskID := range timedOutTaskIDs {
+		isReaped, err := store.reapTimedOut(ctx, taskID, currentTime)
+		if err != nil {
+			return reapedTaskIDs, err
+		}
+		if isReaped {
+			reapedTaskIDs = append(reapedTaskIDs, taskID)
+		}
+	}
+	return reapedTaskIDs, nil
+}
+
+func (store *Store) reapTimedOut(ctx context.Context, taskID task.ID, currentTime int64) (bool, error) {
+	isReaped := false
+	err := store.inTransaction(ctx, func(tx *sql.Tx) (bool, error) {
+		var err error
+		isReaped, err = execAffectingRows(ctx, tx, store.dialect.rebind(reapTimedOutTaskStatement),
+			string(task.StateTimedOut), process.TimedOutErrorMessage, taskID.ProcessID, taskID.TaskID,
+			string(task.StateCreated), currentTime)
+		if err != nil || !isReaped {
+			return false, err
+		}
+		return true, store.timeOutInProcess(ctx, tx, taskID.ProcessID)
+	})
+	return isReaped, err
+}
+
+func (store *Store) listTimedOutTasks(ctx context.Context, currentTime int64, limit int) ([]task.ID, error) {
+	rows, err := store.db.QueryContext(ctx, store.dialect.rebind(listTimedOutTasksQuery), string(task.StateCreated),
+		currentTime, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var taskIDs []task.ID
+	for rows.Next() {
+		var taskID task.ID
+		if err := rows.Scan(&taskID.ProcessID, &taskID.TaskID); err != nil {
+			return nil, err
+		}
+		taskIDs = append(taskIDs, taskID)
+	}
+	return taskIDs, rows.Err()
+}
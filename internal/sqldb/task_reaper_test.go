@@ -0,0 +1,62 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_ReapTimedOut(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	firstTimedOutTaskID := task.ID{ProcessID: "2", TaskID: "1"}
+	secondTimedOutTaskID := task.ID{ProcessID: "1", TaskID: "2"}
+	thirdTimedOutTaskID := task.ID{ProcessID: "1", TaskID: "3"}
+	storeAndMocks.mustRegister(t, firstTimedOutTaskID, storeAndMocks.currentDate.Add(-time.Hour))
+	storeAndMocks.mustRegister(t, secondTimedOutTaskID, storeAndMocks.currentDate.Add(-time.Minute))
+	storeAndMocks.mustRegister(t, thirdTimedOutTaskID, storeAndMocks.currentDate)
+	storeAndMocks.mustRegister(t, task.ID{ProcessID: "1", TaskID: "1"}, storeAndMocks.currentDate.Add(time.Hour))
+
+	reapedTaskIDs, err := storeAndMocks.store.ReapTimedOut(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []task.ID{firstTimedOutTaskID, secondTimedOutTaskID}, reapedTaskIDs)
+
+	reapedTask, err := storeAndMocks.store.GetTask(context.Background(), firstTimedOutTaskID)
+	assert.NoError(t, err)
+	assert.Equal(t, task.StateTimedOut, reapedTask.State)
+	assert.Equal(t, process.TimedOutErrorMessage, *reapedTask.StateMessage)
+	assert.True(t, reapedTask.TimedOut)
+
+	reapedTaskIDs, err = storeAndMocks.store.ReapTimedOut(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []task.ID{thirdTimedOutTaskID}, reapedTaskIDs)
+
+	reapedTaskIDs, err = storeAndMocks.store.ReapTimedOut(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Empty(t, reapedTaskIDs)
+}
+
+func TestStore_ReapTimedOut_ProcessReportedAsTimedOut(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	taskID := task.ID{ProcessID: "1", TaskID: "1"}
+	storeAndMocks.mustRegister(t, taskID, storeAndMocks.currentDate.Add(-time.Minute))
+
+	_, err := storeAndMocks.store.ReapTimedOut(context.Background(), 1)
+	assert.NoError(t, err)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), taskID.ProcessID)
+	assert.NoError(t, err)
+	assert.Equal(t, process.StateError, proc.State)
+	assert.Equal(t, process.TimedOutErrorMessage, *proc.StateMessage)
+	assert.Equal(t, &process.Progress{TotalTasksCount: 1, TimedOutTasksCount: 1}, proc.Progress)
+
+	completingResult, err := storeAndMocks.store.Complete(context.Background(), task.CompleteRequest{
+		ID:    taskID,
+		State: task.StateFinished,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.CompletingResultExpired, completingResult)
+}
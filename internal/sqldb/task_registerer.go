@@ -0,0 +1,123 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+const registerTaskStatement = `INSERT INTO tasks
+	(process_id, task_id, state, expiration_time, bad_state_enter_time, creation_time)
+	VALUES (?, ?, ?, ?, ?, ?)
+	ON CONFLICT (process_id, task_id) DO NOTHING`
+
+func (store *Store) Register(ctx context.Context, registrationData task.RegistrationData) (task.RegistrationResult, error) {
+	var registrationResult task.RegistrationResult
+	err := store.inTransaction(ctx, func(tx *sql.Tx) (bool, error) {
+		isRegistered, err := store.register(ctx, tx, registrationData)
+		if err != nil || !isRegistered {
+			registrationResult = task.RegistrationResultAlreadyRegistered
+			return false, err
+		}
+		isRegisteredInProcess, err := store.registerInProcess(ctx, tx, registrationData.ID.ProcessID, 1,
+			registrationData.Creator)
+		if err != nil || !isRegisteredInProcess {
+			registrationResult = task.RegistrationResultProcessSealed
+			return false, err
+		}
+		if err := store.configureInitialCallback(ctx, tx, registrationData.ID.ProcessID, registrationData.CallbackURL); err != nil {
+			return false, err
+		}
+		if err := store.configureInitialDeadline(ctx, tx, registrationData.ID.ProcessID, registrationData.ProcessDeadline); err != nil {
+			return false, err
+		}
+		registrationResult = task.RegistrationResultCreated
+		return true, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if registrationResult == task.RegistrationResultProcessSealed {
+		return store.readProcessSealedRegistrationResult(ctx, registrationData.ID.ProcessID)
+	}
+	return registrationResult, nil
+}
+
+func (store *Store) BatchRegister(ctx context.Context,
+	request task.BatchRegistrationRequest) ([]task.BatchRegistrationResult, error) {
+	results := task.NewBatchRegistrationResults(request, task.RegistrationResultCreated)
+	isProcessSealed := false
+	err := store.inTransaction(ctx, func(tx *sql.Tx) (bool, error) {
+		registeredTasks, err := store.registerBatch(ctx, tx, request.Tasks, results)
+		if err != nil || len(registeredTasks) == 0 {
+			return false, err
+		}
+		isRegisteredInProcess, err := store.registerInProcess(ctx, tx, request.ProcessID, len(registeredTasks),
+			registeredTasks[0].Creator)
+		if err != nil || !isRegisteredInProcess {
+			isProcessSealed = err == nil
+			return false, err
+		}
+		if request.Transactional && len(registeredTasks) < len(request.Tasks) {
+			task.ReplaceRegistrationResults(results, task.RegistrationResultCreated, task.RegistrationResultCanceled)
+			return false, nil
+		}
+		for _, registrationData := range registeredTasks {
+			if err := store.configureInitialCallback(ctx, tx, request.ProcessID, registrationData.CallbackURL); err != nil {
+				return false, err
+			}
+			if err := store.configureInitialDeadline(ctx, tx, request.ProcessID, registrationData.ProcessDeadline); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if isProcessSealed {
+		sealedProcessResult, err := store.readProcessSealedRegistrationResult(ctx, request.ProcessID)
+		if err != nil {
+			return nil, err
+		}
+		task.ReplaceRegistrationResults(results, task.RegistrationResultCreated, sealedProcessResult)
+	}
+	return results, nil
+}
+
+func (store *Store) registerBatch(ctx context.Context, executor executor, tasksRegistrationData []task.RegistrationData,
+	results []task.BatchRegistrationResult) ([]task.RegistrationData, error) {
+	registeredTasks := make([]task.RegistrationData, 0, len(tasksRegistrationData))
+	for index, registrationData := range tasksRegistrationData {
+		isRegistered, err := store.register(ctx, executor, registrationData)
+		if err != nil {
+			return nil, err
+		}
+		if !isRegistered {
+			results[index].Result = task.RegistrationResultAlreadyRegistered
+			continue
+		}
+		registeredTasks = append(registeredTasks, registrationData)
+	}
+	return registeredTasks, nil
+}
+
+func (store *Store) readProcessSealedRegistrationResult(ctx context.Context,
+	processID string) (task.RegistrationResult, error) {
+	isDeadlineExceeded, err := store.isDeadlineExceeded(ctx, processID)
+	if err != nil {
+		return "", err
+	}
+	if isDeadlineExceeded {
+		return task.RegistrationResultProcessDeadlineExceeded, nil
+	}
+	return task.RegistrationResultProcessSealed, nil
+}
+
+func (store *Store) register(ctx context.Context, executor executor, registrationData task.RegistrationData) (bool, error) {
+	expirationTime := toStoredTime(registrationData.ExpirationTime)
+	return execAffectingRows(ctx, executor, store.dialect.rebind(registerTaskStatement), registrationData.ID.ProcessID,
+		registrationData.ID.TaskID, string(task.StateCreated), expirationTime, expirationTime,
+		toStoredTime(store.currentDateGetter.GetCurrentDate()))
+}
@@ -0,0 +1,263 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_Register(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	registrationData := task.RegistrationData{
+		ID: task.ID{
+			ProcessID: "2",
+			TaskID:    "1",
+		},
+		ExpirationTime: storeAndMocks.currentDate.Add(time.Hour),
+	}
+
+	registrationResult, err := storeAndMocks.store.Register(context.Background(), registrationData)
+	assert.NoError(t, err)
+	assert.Equal(t, task.RegistrationResultCreated, registrationResult)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), registrationData.ID.ProcessID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           registrationData.ID.ProcessID,
+		State:        process.StateCreated,
+		CreationTime: storeAndMocks.currentDate.Truncate(time.Second),
+		Progress: &process.Progress{
+			TotalTasksCount:               1,
+			CreatedTasksCount:             1,
+			EarliestPendingExpirationTime: storeAndMocks.currentDate.Add(time.Hour).Truncate(time.Second),
+		},
+	}, proc)
+}
+
+func TestStore_Register_TaskAlreadyExists(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	registrationData := task.RegistrationData{
+		ID: task.ID{
+			ProcessID: "2",
+			TaskID:    "1",
+		},
+		ExpirationTime: storeAndMocks.currentDate.Add(time.Hour),
+	}
+	storeAndMocks.mustRegister(t, registrationData.ID, registrationData.ExpirationTime)
+
+	registrationResult, err := storeAndMocks.store.Register(context.Background(), registrationData)
+	assert.NoError(t, err)
+	assert.Equal(t, task.RegistrationResultAlreadyRegistered, registrationResult)
+}
+
+func TestStore_Register_ProcessTerminated(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	finishedTaskID := task.ID{ProcessID: "2", TaskID: "1"}
+	storeAndMocks.mustRegister(t, finishedTaskID, storeAndMocks.currentDate.Add(time.Hour))
+	_, err := storeAndMocks.store.Complete(context.Background(), task.CompleteRequest{ID: finishedTaskID, State: task.StateFinished})
+	assert.NoError(t, err)
+	_, err = storeAndMocks.store.Get(context.Background(), finishedTaskID.ProcessID)
+	assert.NoError(t, err)
+
+	registrationResult, err := storeAndMocks.store.Register(context.Background(), task.RegistrationData{
+		ID:             task.ID{ProcessID: finishedTaskID.ProcessID, TaskID: "2"},
+		ExpirationTime: storeAndMocks.currentDate.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.RegistrationResultProcessSealed, registrationResult)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), finishedTaskID.ProcessID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           finishedTaskID.ProcessID,
+		State:        process.StateCompleted,
+		Sealed:       true,
+		CreationTime: storeAndMocks.currentDate.Truncate(time.Second),
+		Progress: &process.Progress{
+			TotalTasksCount:    1,
+			FinishedTasksCount: 1,
+		},
+	}, proc)
+}
+
+func TestStore_Register_ProcessAbortedBeforeRead(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	abortedTaskID := task.ID{ProcessID: "2", TaskID: "1"}
+	storeAndMocks.mustRegister(t, abortedTaskID, storeAndMocks.currentDate.Add(time.Hour))
+	storeAndMocks.mustRegister(t, task.ID{ProcessID: abortedTaskID.ProcessID, TaskID: "2"}, storeAndMocks.currentDate.Add(time.Hour))
+	_, err := storeAndMocks.store.Complete(context.Background(), task.CompleteRequest{ID: abortedTaskID, State: task.StateAborted})
+	assert.NoError(t, err)
+
+	registrationResult, err := storeAndMocks.store.Register(context.Background(), task.RegistrationData{
+		ID:             task.ID{ProcessID: abortedTaskID.ProcessID, TaskID: "3"},
+		ExpirationTime: storeAndMocks.currentDate.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.RegistrationResultProcessSealed, registrationResult)
+}
+
+func TestStore_Register_WithProcessDeadline(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	processDeadline := storeAndMocks.currentDate.Add(time.Hour).Truncate(time.Second)
+	registrationData := task.RegistrationData{
+		ID:              task.ID{ProcessID: "2", TaskID: "1"},
+		ExpirationTime:  storeAndMocks.currentDate.Add(time.Hour * 2),
+		ProcessDeadline: processDeadline,
+	}
+
+	registrationResult, err := storeAndMocks.store.Register(context.Background(), registrationData)
+	assert.NoError(t, err)
+	assert.Equal(t, task.RegistrationResultCreated, registrationResult)
+
+	registrationResult, err = storeAndMocks.store.Register(context.Background(), task.RegistrationData{
+		ID:              task.ID{ProcessID: registrationData.ID.ProcessID, TaskID: "2"},
+		ExpirationTime:  storeAndMocks.currentDate.Add(time.Hour),
+		ProcessDeadline: processDeadline.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.RegistrationResultCreated, registrationResult)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), registrationData.ID.ProcessID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           registrationData.ID.ProcessID,
+		State:        process.StateCreated,
+		Deadline:     processDeadline,
+		CreationTime: storeAndMocks.currentDate.Truncate(time.Second),
+		Progress: &process.Progress{
+			TotalTasksCount:               2,
+			CreatedTasksCount:             2,
+			EarliestPendingExpirationTime: storeAndMocks.currentDate.Add(time.Hour).Truncate(time.Second),
+		},
+	}, proc)
+}
+
+func TestStore_Register_ProcessDeadlineExceeded(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	registrationData := task.RegistrationData{
+		ID:              task.ID{ProcessID: "2", TaskID: "1"},
+		ExpirationTime:  storeAndMocks.currentDate.Add(time.Hour),
+		ProcessDeadline: storeAndMocks.currentDate.Add(-time.Minute),
+	}
+	_, err := storeAndMocks.store.Register(context.Background(), registrationData)
+	assert.NoError(t, err)
+
+	registrationResult, err := storeAndMocks.store.Register(context.Background(), task.RegistrationData{
+		ID:             task.ID{ProcessID: registrationData.ID.ProcessID, TaskID: "2"},
+		ExpirationTime: storeAndMocks.currentDate.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.RegistrationResultProcessDeadlineExceeded, registrationResult)
+}
+
+func TestStore_Register_WithCreator(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	registrationData := task.RegistrationData{
+		ID:             task.ID{ProcessID: "2", TaskID: "1"},
+		ExpirationTime: storeAndMocks.currentDate.Add(time.Hour),
+		Creator:        aws.String("arn:aws:iam::123456789012:user/first"),
+	}
+
+	registrationResult, err := storeAndMocks.store.Register(context.Background(), registrationData)
+	assert.NoError(t, err)
+	assert.Equal(t, task.RegistrationResultCreated, registrationResult)
+
+	registrationResult, err = storeAndMocks.store.Register(context.Background(), task.RegistrationData{
+		ID:             task.ID{ProcessID: registrationData.ID.ProcessID, TaskID: "2"},
+		ExpirationTime: storeAndMocks.currentDate.Add(time.Hour),
+		Creator:        aws.String("arn:aws:iam::123456789012:user/second"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.RegistrationResultCreated, registrationResult)
+
+	proc, err := storeAndMocks.store.Get(context.Background(), registrationData.ID.ProcessID)
+	assert.NoError(t, err)
+	assert.Equal(t, &process.Process{
+		ID:           registrationData.ID.ProcessID,
+		State:        process.StateCreated,
+		CreationTime: storeAndMocks.currentDate.Truncate(time.Second),
+		Creator:      registrationData.Creator,
+		Progress: &process.Progress{
+			TotalTasksCount:               2,
+			CreatedTasksCount:             2,
+			EarliestPendingExpirationTime: storeAndMocks.currentDate.Add(time.Hour).Truncate(time.Second),
+		},
+	}, proc)
+}
+
+func newBatchRegistrationRequest(processID string, expirationTime time.Time, transactional bool,
+	taskIDs ...string) task.BatchRegistrationRequest {
+	request := task.BatchRegistrationRequest{ProcessID: processID, Transactional: transactional}
+	for _, taskID := range taskIDs {
+		request.Tasks = append(request.Tasks, task.RegistrationData{
+			ID:             task.ID{ProcessID: processID, TaskID: taskID},
+			ExpirationTime: expirationTime,
+		})
+	}
+	return request
+}
+
+func TestStore_BatchRegister(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	existingTaskID := task.ID{ProcessID: "2", TaskID: "1"}
+	storeAndMocks.mustRegister(t, existingTaskID, storeAndMocks.currentDate.Add(time.Hour))
+	request := newBatchRegistrationRequest(existingTaskID.ProcessID, storeAndMocks.currentDate.Add(time.Hour),
+		false, "1", "2", "3")
+
+	results, err := storeAndMocks.store.BatchRegister(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, []task.BatchRegistrationResult{
+		{TaskID: "1", Result: task.RegistrationResultAlreadyRegistered},
+		{TaskID: "2", Result: task.RegistrationResultCreated},
+		{TaskID: "3", Result: task.RegistrationResultCreated},
+	}, results)
+
+	for _, taskID := range []string{"2", "3"} {
+		registeredTask, err := storeAndMocks.store.GetTask(context.Background(),
+			task.ID{ProcessID: existingTaskID.ProcessID, TaskID: taskID})
+		assert.NoError(t, err)
+		assert.NotNil(t, registeredTask)
+	}
+}
+
+func TestStore_BatchRegister_Transactional(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	existingTaskID := task.ID{ProcessID: "2", TaskID: "1"}
+	storeAndMocks.mustRegister(t, existingTaskID, storeAndMocks.currentDate.Add(time.Hour))
+	request := newBatchRegistrationRequest(existingTaskID.ProcessID, storeAndMocks.currentDate.Add(time.Hour),
+		true, "2", "1")
+
+	results, err := storeAndMocks.store.BatchRegister(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, []task.BatchRegistrationResult{
+		{TaskID: "2", Result: task.RegistrationResultCanceled},
+		{TaskID: "1", Result: task.RegistrationResultAlreadyRegistered},
+	}, results)
+
+	canceledTask, err := storeAndMocks.store.GetTask(context.Background(),
+		task.ID{ProcessID: existingTaskID.ProcessID, TaskID: "2"})
+	assert.NoError(t, err)
+	assert.Nil(t, canceledTask)
+}
+
+func TestStore_BatchRegister_ProcessSealed(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	existingTaskID := task.ID{ProcessID: "2", TaskID: "1"}
+	storeAndMocks.mustRegister(t, existingTaskID, storeAndMocks.currentDate.Add(time.Hour))
+	_, err := storeAndMocks.store.Seal(context.Background(), existingTaskID.ProcessID)
+	assert.NoError(t, err)
+	request := newBatchRegistrationRequest(existingTaskID.ProcessID, storeAndMocks.currentDate.Add(time.Hour),
+		false, "1", "2")
+
+	results, err := storeAndMocks.store.BatchRegister(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, []task.BatchRegistrationResult{
+		{TaskID: "1", Result: task.RegistrationResultAlreadyRegistered},
+		{TaskID: "2", Result: task.RegistrationResultProcessSealed},
+	}, results)
+}
@@ -0,0 +1,33 @@
+package sqldb
+
+import (
+	"context"
+
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+const (
+	claimTerminationEventStatement = `UPDATE processes SET termination_event_claim_time = ?
+		WHERE process_id = ? AND termination_event_time IS NULL
+		AND (termination_event_claim_time IS NULL OR termination_event_claim_time <= ?)`
+	confirmTerminationEventStatement = `UPDATE processes SET termination_event_time = ?, termination_event_claim_time = NULL
+		WHERE process_id = ?`
+	releaseTerminationEventStatement = `UPDATE processes SET termination_event_claim_time = NULL WHERE process_id = ?`
+)
+
+func (store *Store) ClaimTerminationEvent(ctx context.Context, processID string) (bool, error) {
+	currentDate := store.currentDateGetter.GetCurrentDate()
+	return execAffectingRows(ctx, store.db, store.dialect.rebind(claimTerminationEventStatement),
+		toStoredTime(currentDate), processID, toStoredTime(currentDate.Add(-process.TerminationEventClaimLease)))
+}
+
+func (store *Store) ConfirmTerminationEvent(ctx context.Context, processID string) error {
+	_, err := store.db.ExecContext(ctx, store.dialect.rebind(confirmTerminationEventStatement),
+		toStoredTime(store.currentDateGetter.GetCurrentDate()), processID)
+	return err
+}
+
+func (store *Store) ReleaseTerminationEvent(ctx context.Context, processID string) error {
+	_, err := store.db.ExecContext(ctx, store.dialect.rebind(releaseTerminationEventStatement), processID)
+	return err
+}
@@ -0,0 +1,81 @@
+package sqldb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_ClaimTerminationEvent(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	processID := "1"
+	storeAndMocks.mustRegister(t, task.ID{ProcessID: processID, TaskID: "1"}, storeAndMocks.currentDate.Add(time.Hour))
+
+	isClaimed, err := storeAndMocks.store.ClaimTerminationEvent(context.Background(), processID)
+	assert.NoError(t, err)
+	assert.True(t, isClaimed)
+
+	isClaimed, err = storeAndMocks.store.ClaimTerminationEvent(context.Background(), processID)
+	assert.NoError(t, err)
+	assert.False(t, isClaimed)
+}
+
+func TestStore_ReleaseTerminationEvent(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	processID := "1"
+	storeAndMocks.mustRegister(t, task.ID{ProcessID: processID, TaskID: "1"}, storeAndMocks.currentDate.Add(time.Hour))
+	_, err := storeAndMocks.store.ClaimTerminationEvent(context.Background(), processID)
+	assert.NoError(t, err)
+
+	assert.NoError(t, storeAndMocks.store.ReleaseTerminationEvent(context.Background(), processID))
+
+	isClaimed, err := storeAndMocks.store.ClaimTerminationEvent(context.Background(), processID)
+	assert.NoError(t, err)
+	assert.True(t, isClaimed)
+}
+
+func TestStore_ClaimTerminationEvent_ProcessNotExists(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+
+	isClaimed, err := storeAndMocks.store.ClaimTerminationEvent(context.Background(), "1")
+	assert.NoError(t, err)
+	assert.False(t, isClaimed)
+}
+
+func TestStore_ClaimTerminationEvent_ClaimedButNotPublished(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	processID := "1"
+	storeAndMocks.mustRegister(t, task.ID{ProcessID: processID, TaskID: "1"}, storeAndMocks.currentDate.Add(time.Hour))
+	claimTime := storeAndMocks.currentDate
+	_, err := storeAndMocks.store.ClaimTerminationEvent(context.Background(), processID)
+	assert.NoError(t, err)
+
+	storeAndMocks.moveCurrentDate(claimTime.Add(process.TerminationEventClaimLease - time.Second))
+	isClaimed, err := storeAndMocks.store.ClaimTerminationEvent(context.Background(), processID)
+	assert.NoError(t, err)
+	assert.False(t, isClaimed)
+
+	storeAndMocks.moveCurrentDate(claimTime.Add(process.TerminationEventClaimLease))
+	isClaimed, err = storeAndMocks.store.ClaimTerminationEvent(context.Background(), processID)
+	assert.NoError(t, err)
+	assert.True(t, isClaimed)
+}
+
+func TestStore_ConfirmTerminationEvent(t *testing.T) {
+	storeAndMocks := newStoreWithMocks(t)
+	processID := "1"
+	storeAndMocks.mustRegister(t, task.ID{ProcessID: processID, TaskID: "1"}, storeAndMocks.currentDate.Add(time.Hour))
+	_, err := storeAndMocks.store.ClaimTerminationEvent(context.Background(), processID)
+	assert.NoError(t, err)
+
+	assert.NoError(t, storeAndMocks.store.ConfirmTerminationEvent(context.Background(), processID))
+
+	storeAndMocks.moveCurrentDate(storeAndMocks.currentDate.Add(process.TerminationEventClaimLease))
+	isClaimed, err := storeAndMocks.store.ClaimTerminationEvent(context.Background(), processID)
+	assert.NoError(t, err)
+	assert.False(t, isClaimed)
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/artii15/termination-detector/internal/dynamo"
+	"github.com/artii15/termination-detector/internal/memory"
+	"github.com/artii15/termination-detector/internal/sqldb"
+	"github.com/artii15/termination-detector/pkg/env"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	_ "github.com/lib/pq"
+)
+
+const (
+	BackendDynamoDB Backend = "dynamodb"
+	BackendMemory   Backend = "memory"
+	BackendSQL      Backend = "sql"
+
+	BackendEnvVar  = "STORAGE_BACKEND"
+	DefaultBackend = BackendDynamoDB
+
+	tasksTableNameEnvVar       = "TASKS_TABLE_NAME"
+	tasksStoringDurationEnvVar = "TASKS_STORING_DURATION"
+	sqlDialectEnvVar           = "SQL_DIALECT"
+	sqlDataSourceNameEnvVar    = "SQL_DATA_SOURCE_NAME"
+)
+
+type currentDateGetter interface {
+	GetCurrentDate() time.Time
+}
+
+func NewDefaultRegistry(currentDateGetter currentDateGetter) *Registry {
+	registry := NewRegistry()
+	registry.Register(BackendDynamoDB, func() (Store, error) {
+		return newDynamoDBStore(currentDateGetter)
+	})
+	registry.Register(BackendMemory, func() (Store, error) {
+		return memory.NewStore(currentDateGetter), nil
+	})
+	registry.Register(BackendSQL, func() (Store, error) {
+		return newSQLStore(currentDateGetter)
+	})
+	return registry
+}
+
+func ReadBackend() Backend {
+	return Backend(env.ReadOrDefault(BackendEnvVar, string(DefaultBackend)))
+}
+
+func newDynamoDBStore(currentDateGetter currentDateGetter) (Store, error) {
+	tasksTableName, err := env.Read(tasksTableNameEnvVar)
+	if err != nil {
+		return nil, err
+	}
+	tasksStoringDurationString, err := env.Read(tasksStoringDurationEnvVar)
+	if err != nil {
+		return nil, err
+	}
+	tasksStoringDuration, err := time.ParseDuration(tasksStoringDurationString)
+	if err != nil {
+		return nil, err
+	}
+
+	awsSess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dynamo.NewStore(dynamodb.New(awsSess), tasksTableName, currentDateGetter, tasksStoringDuration), nil
+}
+
+func newSQLStore(currentDateGetter currentDateGetter) (Store, error) {
+	dialect, err := env.Read(sqlDialectEnvVar)
+	if err != nil {
+		return nil, err
+	}
+	dataSourceName, err := env.Read(sqlDataSourceNameEnvVar)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sqldb.Open(sqldb.Dialect(dialect), dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	if err := sqldb.Migrate(db, sqldb.Dialect(dialect)); err != nil {
+		return nil, err
+	}
+	return sqldb.NewStore(db, sqldb.Dialect(dialect), currentDateGetter), nil
+}
@@ -0,0 +1,8 @@
+//go:build sqlite3
+// +build sqlite3
+
+package storage
+
+import (
+	_ "github.com/mattn/go-sqlite3"
+)
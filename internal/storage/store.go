@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+type Store interface {
+	process.Getter
+	process.Lister
+	process.Sealer
+	process.Updater
+	process.CallbackRecorder
+	process.TerminationEventRecorder
+	task.Registerer
+	task.BatchRegisterer
+	task.Completer
+	task.Heartbeater
+	task.Lister
+	task.Getter
+	task.Reaper
+}
+
+type Backend string
+
+type Factory func() (Store, error)
+
+type Registry struct {
+	factories map[Backend]Factory
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		factories: make(map[Backend]Factory),
+	}
+}
+
+func (registry *Registry) Register(backend Backend, factory Factory) {
+	registry.factories[backend] = factory
+}
+
+func (registry *Registry) Build(backend Backend) (Store, error) {
+	factory, isFactoryRegistered := registry.factories[backend]
+	if !isFactoryRegistered {
+		return nil, fmt.Errorf("unknown storage backend: %s", backend)
+	}
+	return factory()
+}
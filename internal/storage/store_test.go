@@ -0,0 +1,50 @@
+package storage_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/artii15/termination-detector/internal/memory"
+	"github.com/artii15/termination-detector/internal/storage"
+	"github.com/artii15/termination-detector/pkg/dates"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Build(t *testing.T) {
+	registry := storage.NewRegistry()
+	store := memory.NewStore(dates.NewCurrentDateGetter())
+	backend := storage.Backend("test")
+	registry.Register(backend, func() (storage.Store, error) {
+		return store, nil
+	})
+
+	builtStore, err := registry.Build(backend)
+	assert.NoError(t, err)
+	assert.Equal(t, store, builtStore)
+}
+
+func TestRegistry_Build_UnknownBackend(t *testing.T) {
+	registry := storage.NewRegistry()
+
+	_, err := registry.Build("unknown")
+	assert.Error(t, err)
+}
+
+func TestRegistry_Build_FactoryError(t *testing.T) {
+	registry := storage.NewRegistry()
+	backend := storage.Backend("test")
+	registry.Register(backend, func() (storage.Store, error) {
+		return nil, errors.New("error")
+	})
+
+	_, err := registry.Build(backend)
+	assert.Error(t, err)
+}
+
+func TestNewDefaultRegistry(t *testing.T) {
+	registry := storage.NewDefaultRegistry(dates.NewCurrentDateGetter())
+
+	store, err := registry.Build(storage.BackendMemory)
+	assert.NoError(t, err)
+	assert.IsType(t, &memory.Store{}, store)
+}
@@ -0,0 +1,62 @@
+package streams
+
+import (
+	"context"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/dynamo"
+	"github.com/artii15/termination-detector/internal/events"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/task"
+	lambdaEvents "github.com/aws/aws-lambda-go/events"
+	"github.com/sirupsen/logrus"
+)
+
+type Processor struct {
+	terminationPublisher *events.TerminationPublisher
+}
+
+func NewProcessor(processGetter process.Getter, terminationEventRecorder process.TerminationEventRecorder,
+	sink events.Sink) *Processor {
+	return &Processor{
+		terminationPublisher: events.NewTerminationPublisher(processGetter, terminationEventRecorder, sink),
+	}
+}
+
+func (processor *Processor) Handle(ctx context.Context, event lambdaEvents.DynamoDBEvent) error {
+	processIDs, transitionTimes := readTransitionedProcesses(event.Records)
+	var publishingErr error
+	for _, processID := range processIDs {
+		err := processor.terminationPublisher.PublishIfTerminated(ctx, processID, transitionTimes[processID])
+		if err != nil {
+			logrus.WithError(err).WithField("process_id", processID).Error("failed to publish process terminated event")
+			publishingErr = err
+		}
+	}
+	return publishingErr
+}
+
+func readTransitionedProcesses(records []lambdaEvents.DynamoDBEventRecord) ([]string, map[string]time.Time) {
+	var processIDs []string
+	transitionTimes := make(map[string]time.Time)
+	for _, record := range records {
+		if lambdaEvents.DynamoDBOperationType(record.EventName) == lambdaEvents.DynamoDBOperationTypeRemove {
+			continue
+		}
+		processIDAttr, isProcessIDDefined := record.Change.Keys[dynamo.ProcessIDAttrName]
+		taskIDAttr, isTaskIDDefined := record.Change.Keys[dynamo.TaskIDAttrName]
+		if !isProcessIDDefined || !isTaskIDDefined || processIDAttr.DataType() != lambdaEvents.DataTypeString ||
+			taskIDAttr.DataType() != lambdaEvents.DataTypeString || task.IsReservedTaskID(taskIDAttr.String()) {
+			continue
+		}
+		processID := processIDAttr.String()
+		if _, isProcessTransitioned := transitionTimes[processID]; !isProcessTransitioned {
+			processIDs = append(processIDs, processID)
+		}
+		transitionTime := record.Change.ApproximateCreationDateTime.Time
+		if transitionTime.After(transitionTimes[processID]) {
+			transitionTimes[processID] = transitionTime
+		}
+	}
+	return processIDs, transitionTimes
+}
@@ -0,0 +1,165 @@
+package streams_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/dynamo"
+	"github.com/artii15/termination-detector/internal/events"
+	"github.com/artii15/termination-detector/internal/streams"
+	"github.com/artii15/termination-detector/pkg/process"
+	lambdaEvents "github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type processGetterMock struct {
+	mock.Mock
+}
+
+func (getter *processGetterMock) Get(ctx context.Context, processID string) (*process.Process, error) {
+	args := getter.Called(ctx, processID)
+	return args.Get(0).(*process.Process), args.Error(1)
+}
+
+type terminationEventRecorderMock struct {
+	mock.Mock
+}
+
+func (recorder *terminationEventRecorderMock) ClaimTerminationEvent(ctx context.Context, processID string) (bool, error) {
+	args := recorder.Called(ctx, processID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (recorder *terminationEventRecorderMock) ConfirmTerminationEvent(ctx context.Context, processID string) error {
+	return recorder.Called(ctx, processID).Error(0)
+}
+
+func (recorder *terminationEventRecorderMock) ReleaseTerminationEvent(ctx context.Context, processID string) error {
+	return recorder.Called(ctx, processID).Error(0)
+}
+
+type processorWithMocks struct {
+	processor                *streams.Processor
+	processGetter            *processGetterMock
+	terminationEventRecorder *terminationEventRecorderMock
+	sink                     *events.MemorySink
+}
+
+func (processorAndMocks *processorWithMocks) assertExpectations(t *testing.T) {
+	processorAndMocks.processGetter.AssertExpectations(t)
+	processorAndMocks.terminationEventRecorder.AssertExpectations(t)
+}
+
+func newProcessorWithMocks() *processorWithMocks {
+	processGetter := new(processGetterMock)
+	terminationEventRecorder := new(terminationEventRecorderMock)
+	sink := events.NewMemorySink()
+	return &processorWithMocks{
+		processor:                streams.NewProcessor(processGetter, terminationEventRecorder, sink),
+		processGetter:            processGetter,
+		terminationEventRecorder: terminationEventRecorder,
+		sink:                     sink,
+	}
+}
+
+func newTaskRecord(operationType lambdaEvents.DynamoDBOperationType, processID, taskID string,
+	transitionTime time.Time) lambdaEvents.DynamoDBEventRecord {
+	return lambdaEvents.DynamoDBEventRecord{
+		EventName: string(operationType),
+		Change: lambdaEvents.DynamoDBStreamRecord{
+			ApproximateCreationDateTime: lambdaEvents.SecondsEpochTime{Time: transitionTime},
+			Keys: map[string]lambdaEvents.DynamoDBAttributeValue{
+				dynamo.ProcessIDAttrName: lambdaEvents.NewStringAttribute(processID),
+				dynamo.TaskIDAttrName:    lambdaEvents.NewStringAttribute(taskID),
+			},
+		},
+	}
+}
+
+func TestProcessor_Handle(t *testing.T) {
+	processorAndMocks := newProcessorWithMocks()
+	firstTransitionTime := time.Unix(1000, 0)
+	lastTransitionTime := time.Unix(2000, 0)
+	terminatedProcess := &process.Process{ID: "1", State: process.StateCompleted}
+	runningProcess := &process.Process{ID: "2", State: process.StateCreated}
+	processorAndMocks.processGetter.On("Get", mock.Anything, terminatedProcess.ID).Return(terminatedProcess, nil).Once()
+	processorAndMocks.processGetter.On("Get", mock.Anything, runningProcess.ID).Return(runningProcess, nil).Once()
+	processorAndMocks.processGetter.On("Get", mock.Anything, "3").Return((*process.Process)(nil), nil).Once()
+	processorAndMocks.terminationEventRecorder.On("ClaimTerminationEvent", mock.Anything, terminatedProcess.ID).
+		Return(true, nil).Once()
+	processorAndMocks.terminationEventRecorder.On("ConfirmTerminationEvent", mock.Anything, terminatedProcess.ID).
+		Return(nil).Once()
+
+	err := processorAndMocks.processor.Handle(context.Background(), lambdaEvents.DynamoDBEvent{
+		Records: []lambdaEvents.DynamoDBEventRecord{
+			newTaskRecord(lambdaEvents.DynamoDBOperationTypeInsert, terminatedProcess.ID, "1", firstTransitionTime),
+			newTaskRecord(lambdaEvents.DynamoDBOperationTypeInsert, runningProcess.ID, "1", firstTransitionTime),
+			newTaskRecord(lambdaEvents.DynamoDBOperationTypeModify, terminatedProcess.ID, "1", lastTransitionTime),
+			newTaskRecord(lambdaEvents.DynamoDBOperationTypeModify, terminatedProcess.ID, dynamo.ProcessItemTaskID,
+				lastTransitionTime.Add(time.Second)),
+			newTaskRecord(lambdaEvents.DynamoDBOperationTypeModify, "3", "1", firstTransitionTime),
+			newTaskRecord(lambdaEvents.DynamoDBOperationTypeRemove, "4", "1", firstTransitionTime),
+		},
+	})
+	assert.NoError(t, err)
+	processorAndMocks.assertExpectations(t)
+	assert.Equal(t, []events.ProcessTerminated{{
+		Process: *terminatedProcess,
+		Time:    lastTransitionTime,
+	}}, processorAndMocks.sink.Events())
+}
+
+func TestProcessor_Handle_GetterError(t *testing.T) {
+	processorAndMocks := newProcessorWithMocks()
+	processorAndMocks.processGetter.On("Get", mock.Anything, "1").Return((*process.Process)(nil), errors.New("error"))
+
+	err := processorAndMocks.processor.Handle(context.Background(), lambdaEvents.DynamoDBEvent{
+		Records: []lambdaEvents.DynamoDBEventRecord{
+			newTaskRecord(lambdaEvents.DynamoDBOperationTypeModify, "1", "1", time.Unix(1000, 0)),
+		},
+	})
+	assert.Error(t, err)
+	processorAndMocks.assertExpectations(t)
+	assert.Empty(t, processorAndMocks.sink.Events())
+}
+
+func TestProcessor_Handle_AlreadyPublished(t *testing.T) {
+	processorAndMocks := newProcessorWithMocks()
+	terminatedProcess := &process.Process{ID: "1", State: process.StateCompleted}
+	processorAndMocks.processGetter.On("Get", mock.Anything, terminatedProcess.ID).Return(terminatedProcess, nil).Once()
+	processorAndMocks.terminationEventRecorder.On("ClaimTerminationEvent", mock.Anything, terminatedProcess.ID).
+		Return(false, nil).Once()
+
+	err := processorAndMocks.processor.Handle(context.Background(), lambdaEvents.DynamoDBEvent{
+		Records: []lambdaEvents.DynamoDBEventRecord{
+			newTaskRecord(lambdaEvents.DynamoDBOperationTypeModify, terminatedProcess.ID, "2", time.Unix(1000, 0)),
+		},
+	})
+	assert.NoError(t, err)
+	processorAndMocks.assertExpectations(t)
+	assert.Empty(t, processorAndMocks.sink.Events())
+}
+
+func TestProcessor_Handle_ContinuesAfterError(t *testing.T) {
+	processorAndMocks := newProcessorWithMocks()
+	terminatedProcess := &process.Process{ID: "2", State: process.StateError}
+	processorAndMocks.processGetter.On("Get", mock.Anything, "1").Return((*process.Process)(nil), errors.New("error"))
+	processorAndMocks.processGetter.On("Get", mock.Anything, terminatedProcess.ID).Return(terminatedProcess, nil).Once()
+	processorAndMocks.terminationEventRecorder.On("ClaimTerminationEvent", mock.Anything, terminatedProcess.ID).
+		Return(true, nil).Once()
+	processorAndMocks.terminationEventRecorder.On("ConfirmTerminationEvent", mock.Anything, terminatedProcess.ID).
+		Return(nil).Once()
+
+	err := processorAndMocks.processor.Handle(context.Background(), lambdaEvents.DynamoDBEvent{
+		Records: []lambdaEvents.DynamoDBEventRecord{
+			newTaskRecord(lambdaEvents.DynamoDBOperationTypeModify, "1", "1", time.Unix(1000, 0)),
+			newTaskRecord(lambdaEvents.DynamoDBOperationTypeModify, terminatedProcess.ID, "1", time.Unix(1000, 0)),
+		},
+	})
+	assert.Error(t, err)
+	processorAndMocks.assertExpectations(t)
+	assert.Len(t, processorAndMocks.sink.Events(), 1)
+}
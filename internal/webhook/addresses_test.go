@@ -0,0 +1,49 @@
+package webhook_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/artii15/termination-detector/internal/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	testCases := map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"10.0.0.1":        false,
+		"172.16.5.4":      false,
+		"192.168.1.1":     false,
+		"100.64.0.1":      false,
+		"169.254.169.254": false,
+		"0.0.0.0":         false,
+		"224.0.0.1":       false,
+		"::1":             false,
+		"fd00::1":         false,
+		"fe80::1":         false,
+	}
+	for address, isPublic := range testCases {
+		t.Run(address, func(t *testing.T) {
+			assert.Equal(t, isPublic, webhook.IsPublicIP(net.ParseIP(address)))
+		})
+	}
+}
+
+func TestIsPublicHost(t *testing.T) {
+	testCases := map[string]bool{
+		"example.com":     true,
+		"93.184.216.34":   true,
+		"localhost":       false,
+		"api.localhost":   false,
+		"10.0.0.1":        false,
+		"[::1]":           false,
+		"169.254.169.254": false,
+	}
+	for host, isPublic := range testCases {
+		t.Run(host, func(t *testing.T) {
+			assert.Equal(t, isPublic, webhook.IsPublicHost(host))
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/dates"
+	"github.com/artii15/termination-detector/pkg/env"
+)
+
+const (
+	SecretEnvVar                = "WEBHOOK_SECRET"
+	DispatchIntervalEnvVar      = "WEBHOOK_DISPATCH_INTERVAL"
+	DeliveryTimeoutEnvVar       = "WEBHOOK_DELIVERY_TIMEOUT"
+	DispatchMaxAttemptsEnvVar   = "WEBHOOK_MAX_ATTEMPTS"
+	DeliveryMaxAttemptsEnvVar   = "WEBHOOK_DELIVERY_MAX_ATTEMPTS"
+	AllowPrivateAddressesEnvVar = "WEBHOOK_ALLOW_PRIVATE_ADDRESSES"
+	defaultDeliveryTimeout      = "10s"
+	defaultDispatchMaxAttempts  = "30"
+	defaultDeliveryMaxAttempts  = "3"
+)
+
+func ReadDispatcherConfig() DispatcherConfig {
+	config := DefaultDispatcherConfig
+	config.MaxAttempts = mustParseInt(env.ReadOrDefault(DispatchMaxAttemptsEnvVar, defaultDispatchMaxAttempts))
+	return config
+}
+
+func ReadDeliveryConfig() DeliveryConfig {
+	config := DefaultDeliveryConfig
+	config.MaxAttempts = mustParseInt(env.ReadOrDefault(DeliveryMaxAttemptsEnvVar, defaultDeliveryMaxAttempts))
+	return config
+}
+
+func ReadAllowPrivateAddresses() bool {
+	allowPrivateAddresses, err := strconv.ParseBool(env.ReadOrDefault(AllowPrivateAddressesEnvVar, "false"))
+	if err != nil {
+		panic(err.Error())
+	}
+	return allowPrivateAddresses
+}
+
+func NewDefaultDeliverer(secret string, currentDateGetter currentDateGetter) *Deliverer {
+	httpClient := &http.Client{
+		Timeout: dates.MustParseDuration(env.ReadOrDefault(DeliveryTimeoutEnvVar, defaultDeliveryTimeout)),
+	}
+	if !ReadAllowPrivateAddresses() {
+		httpClient.Transport = newPublicAddressesTransport()
+	}
+	return NewDeliverer(httpClient, []byte(secret), currentDateGetter, ReadDeliveryConfig())
+}
+
+func newPublicAddressesTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   time.Second * 30,
+		KeepAlive: time.Second * 30,
+		Control:   rejectNonPublicAddress,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
+
+func mustParseInt(value string) int {
+	parsedValue, err := strconv.Atoi(value)
+	if err != nil {
+		panic(err.Error())
+	}
+	return parsedValue
+}
@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/dates"
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+const (
+	DefaultDeliveryMaxAttempts    = 3
+	DefaultDeliveryInitialBackoff = time.Millisecond * 500
+	DefaultDeliveryMaxBackoff     = time.Second * 5
+)
+
+type DeliveryConfig struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+var DefaultDeliveryConfig = DeliveryConfig{
+	MaxAttempts:    DefaultDeliveryMaxAttempts,
+	InitialBackoff: DefaultDeliveryInitialBackoff,
+	MaxBackoff:     DefaultDeliveryMaxBackoff,
+}
+
+type httpDoer interface {
+	Do(request *http.Request) (*http.Response, error)
+}
+
+type Deliverer struct {
+	doer              httpDoer
+	secret            []byte
+	currentDateGetter currentDateGetter
+	config            DeliveryConfig
+}
+
+func NewDeliverer(doer httpDoer, secret []byte, currentDateGetter currentDateGetter, config DeliveryConfig) *Deliverer {
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = 1
+	}
+	return &Deliverer{
+		doer:              doer,
+		secret:            secret,
+		currentDateGetter: currentDateGetter,
+		config:            config,
+	}
+}
+
+func (deliverer *Deliverer) Deliver(ctx context.Context, callbackURL string, proc process.Process) (int, error) {
+	proc.Callback = nil
+	body := []byte(internalHTTP.ConvertInternalToHTTPProcess(proc).JSON())
+	backoff := deliverer.config.InitialBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = deliverer.post(ctx, callbackURL, body); err == nil || attempt >= deliverer.config.MaxAttempts {
+			return attempt, err
+		}
+		if sleepErr := sleep(ctx, backoff); sleepErr != nil {
+			return attempt, err
+		}
+		backoff = dates.MinDuration(backoff*2, deliverer.config.MaxBackoff)
+	}
+}
+
+func (deliverer *Deliverer) post(ctx context.Context, callbackURL string, body []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set(internalHTTP.ContentTypeHeaderName, internalHTTP.ContentTypeApplicationJSON)
+	timestamp := FormatTimestamp(deliverer.currentDateGetter.GetCurrentDate())
+	request.Header.Set(TimestampHeaderName, timestamp)
+	request.Header.Set(SignatureHeaderName, Sign(deliverer.secret, timestamp, body))
+	response, err := deliverer.doer.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code: %d", response.StatusCode)
+	}
+	return nil
+}
+
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,152 @@
+package webhook_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/webhook"
+	"github.com/artii15/termination-detector/pkg/dates"
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/stretchr/testify/assert"
+)
+
+var testDeliveryConfig = webhook.DeliveryConfig{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     time.Millisecond * 5,
+}
+
+type receivedCallback struct {
+	body      []byte
+	timestamp string
+	signature string
+}
+
+type callbackReceiver struct {
+	server            *httptest.Server
+	mutex             sync.Mutex
+	received          []receivedCallback
+	statusCodes       []int
+	defaultStatusCode int
+}
+
+func newCallbackReceiver(statusCodes ...int) *callbackReceiver {
+	receiver := &callbackReceiver{
+		statusCodes:       statusCodes,
+		defaultStatusCode: http.StatusNoContent,
+	}
+	receiver.server = httptest.NewServer(http.HandlerFunc(receiver.handle))
+	return receiver
+}
+
+func (receiver *callbackReceiver) handle(writer http.ResponseWriter, request *http.Request) {
+	body, _ := ioutil.ReadAll(request.Body)
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+	receiver.received = append(receiver.received, receivedCallback{
+		body:      body,
+		timestamp: request.Header.Get(webhook.TimestampHeaderName),
+		signature: request.Header.Get(webhook.SignatureHeaderName),
+	})
+	statusCode := receiver.defaultStatusCode
+	if len(receiver.statusCodes) > 0 {
+		statusCode, receiver.statusCodes = receiver.statusCodes[0], receiver.statusCodes[1:]
+	}
+	writer.WriteHeader(statusCode)
+}
+
+func (receiver *callbackReceiver) receivedCallbacks() []receivedCallback {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+	return append([]receivedCallback(nil), receiver.received...)
+}
+
+func TestDeliverer_Deliver(t *testing.T) {
+	receiver := newCallbackReceiver()
+	defer receiver.server.Close()
+	secret := []byte("secret")
+	deliverer := webhook.NewDeliverer(receiver.server.Client(), secret, dates.NewCurrentDateGetter(),
+		testDeliveryConfig)
+	proc := process.Process{
+		ID:       "1",
+		State:    process.StateCompleted,
+		Callback: &process.Callback{URL: receiver.server.URL, State: process.CallbackStatePending},
+	}
+
+	attempts, err := deliverer.Deliver(context.Background(), receiver.server.URL, proc)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, attempts)
+
+	received := receiver.receivedCallbacks()
+	assert.Len(t, received, 1)
+	assert.True(t, webhook.VerifySignature(secret, received[0].timestamp, received[0].body, received[0].signature,
+		time.Now(), webhook.DefaultSignatureTolerance))
+	expectedBody := internalHTTP.ConvertInternalToHTTPProcess(process.Process{ID: "1", State: process.StateCompleted}).JSON()
+	assert.JSONEq(t, expectedBody, string(received[0].body))
+}
+
+func TestDeliverer_Deliver_RetriesFailedAttempts(t *testing.T) {
+	receiver := newCallbackReceiver(http.StatusInternalServerError, http.StatusBadGateway)
+	defer receiver.server.Close()
+	deliverer := webhook.NewDeliverer(receiver.server.Client(), []byte("secret"), dates.NewCurrentDateGetter(),
+		testDeliveryConfig)
+
+	attempts, err := deliverer.Deliver(context.Background(), receiver.server.URL,
+		process.Process{ID: "1", State: process.StateError})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Len(t, receiver.receivedCallbacks(), 3)
+}
+
+func TestDeliverer_Deliver_AttemptsExhausted(t *testing.T) {
+	receiver := newCallbackReceiver(http.StatusInternalServerError, http.StatusInternalServerError,
+		http.StatusInternalServerError)
+	defer receiver.server.Close()
+	deliverer := webhook.NewDeliverer(receiver.server.Client(), []byte("secret"), dates.NewCurrentDateGetter(),
+		testDeliveryConfig)
+
+	attempts, err := deliverer.Deliver(context.Background(), receiver.server.URL,
+		process.Process{ID: "1", State: process.StateError})
+	assert.Error(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Len(t, receiver.receivedCallbacks(), 3)
+}
+
+func TestDeliverer_Deliver_ContextCancelled(t *testing.T) {
+	receiver := newCallbackReceiver(http.StatusInternalServerError)
+	defer receiver.server.Close()
+	deliveryConfig := webhook.DeliveryConfig{
+		MaxAttempts:    3,
+		InitialBackoff: time.Hour,
+		MaxBackoff:     time.Hour,
+	}
+	deliverer := webhook.NewDeliverer(receiver.server.Client(), []byte("secret"), dates.NewCurrentDateGetter(),
+		deliveryConfig)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+
+	attempts, err := deliverer.Deliver(ctx, receiver.server.URL, process.Process{ID: "1", State: process.StateError})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestNewDefaultDeliverer_RejectsPrivateAddresses(t *testing.T) {
+	receiver := newCallbackReceiver(http.StatusNoContent)
+	defer receiver.server.Close()
+	assert.NoError(t, os.Setenv(webhook.DeliveryMaxAttemptsEnvVar, "1"))
+	defer func() { assert.NoError(t, os.Unsetenv(webhook.DeliveryMaxAttemptsEnvVar)) }()
+	deliverer := webhook.NewDefaultDeliverer("secret", dates.NewCurrentDateGetter())
+
+	attempts, err := deliverer.Deliver(context.Background(), receiver.server.URL,
+		process.Process{ID: "1", State: process.StateCompleted})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+	assert.Empty(t, receiver.receivedCallbacks())
+}
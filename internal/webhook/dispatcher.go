@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultDispatchBatchSize   = 100
+	DefaultDispatchMaxAttempts = 30
+)
+
+type DispatcherConfig struct {
+	BatchSize   int
+	MaxAttempts int
+}
+
+var DefaultDispatcherConfig = DispatcherConfig{
+	BatchSize:   DefaultDispatchBatchSize,
+	MaxAttempts: DefaultDispatchMaxAttempts,
+}
+
+type deliverer interface {
+	Deliver(ctx context.Context, callbackURL string, proc process.Process) (int, error)
+}
+
+type currentDateGetter interface {
+	GetCurrentDate() time.Time
+}
+
+type Dispatcher struct {
+	processGetter     process.Getter
+	callbackRecorder  process.CallbackRecorder
+	deliverer         deliverer
+	currentDateGetter currentDateGetter
+	config            DispatcherConfig
+	cursorMutex       sync.Mutex
+	cursor            string
+}
+
+func NewDispatcher(processGetter process.Getter, callbackRecorder process.CallbackRecorder, deliverer deliverer,
+	currentDateGetter currentDateGetter, config DispatcherConfig) *Dispatcher {
+	return &Dispatcher{
+		processGetter:     processGetter,
+		callbackRecorder:  callbackRecorder,
+		deliverer:         deliverer,
+		currentDateGetter: currentDateGetter,
+		config:            config,
+	}
+}
+
+func (dispatcher *Dispatcher) Dispatch(ctx context.Context) error {
+	dispatcher.cursorMutex.Lock()
+	defer dispatcher.cursorMutex.Unlock()
+	processIDs, err := dispatcher.callbackRecorder.ListPendingCallbacks(ctx, dispatcher.cursor,
+		dispatcher.config.BatchSize)
+	if err != nil {
+		return err
+	}
+	dispatcher.cursor = ""
+	if len(processIDs) >= dispatcher.config.BatchSize {
+		dispatcher.cursor = processIDs[len(processIDs)-1]
+	}
+	for _, processID := range processIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := dispatcher.dispatchProcess(ctx, processID); err != nil {
+			logrus.WithError(err).WithField("process_id", processID).Error("failed to dispatch process callback")
+		}
+	}
+	return nil
+}
+
+func (dispatcher *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := dispatcher.Dispatch(ctx); err != nil && ctx.Err() == nil {
+			logrus.WithError(err).Error("failed to dispatch process callbacks")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (dispatcher *Dispatcher) dispatchProcess(ctx context.Context, processID string) error {
+	proc, err := dispatcher.processGetter.Get(ctx, processID)
+	if err != nil || proc == nil || !proc.IsTerminated() || !proc.Callback.IsPending() {
+		return err
+	}
+
+	attempts, deliveryErr := dispatcher.deliverer.Deliver(ctx, proc.Callback.URL, *proc)
+	delivery := process.CallbackDelivery{
+		ProcessID: processID,
+		State:     process.CallbackStateDelivered,
+		Attempts:  proc.Callback.Attempts + attempts,
+	}
+	if deliveryErr == nil {
+		delivery.DeliveryTime = dispatcher.currentDateGetter.GetCurrentDate()
+	} else {
+		deliveryErrMessage := deliveryErr.Error()
+		delivery.LastError = &deliveryErrMessage
+		delivery.State = process.CallbackStatePending
+		if delivery.Attempts >= dispatcher.config.MaxAttempts {
+			delivery.State = process.CallbackStateFailed
+		}
+	}
+	return dispatcher.callbackRecorder.RecordCallbackDelivery(ctx, delivery)
+}
@@ -0,0 +1,204 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/webhook"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type processGetterMock struct {
+	mock.Mock
+}
+
+func (getter *processGetterMock) Get(ctx context.Context, processID string) (*process.Process, error) {
+	args := getter.Called(ctx, processID)
+	return args.Get(0).(*process.Process), args.Error(1)
+}
+
+type callbackRecorderMock struct {
+	mock.Mock
+}
+
+func (recorder *callbackRecorderMock) ListPendingCallbacks(ctx context.Context, afterProcessID string,
+	limit int) ([]string, error) {
+	args := recorder.Called(ctx, afterProcessID, limit)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (recorder *callbackRecorderMock) RecordCallbackDelivery(ctx context.Context, delivery process.CallbackDelivery) error {
+	return recorder.Called(ctx, delivery).Error(0)
+}
+
+type delivererMock struct {
+	mock.Mock
+}
+
+func (deliverer *delivererMock) Deliver(ctx context.Context, callbackURL string, proc process.Process) (int, error) {
+	args := deliverer.Called(ctx, callbackURL, proc)
+	return args.Int(0), args.Error(1)
+}
+
+type currentDateGetterMock struct {
+	mock.Mock
+}
+
+func (getter *currentDateGetterMock) GetCurrentDate() time.Time {
+	return getter.Called().Get(0).(time.Time)
+}
+
+type dispatcherWithMocks struct {
+	dispatcher        *webhook.Dispatcher
+	processGetter     *processGetterMock
+	callbackRecorder  *callbackRecorderMock
+	deliverer         *delivererMock
+	currentDateGetter *currentDateGetterMock
+	config            webhook.DispatcherConfig
+}
+
+func (dispatcherAndMocks *dispatcherWithMocks) assertExpectations(t *testing.T) {
+	dispatcherAndMocks.processGetter.AssertExpectations(t)
+	dispatcherAndMocks.callbackRecorder.AssertExpectations(t)
+	dispatcherAndMocks.deliverer.AssertExpectations(t)
+	dispatcherAndMocks.currentDateGetter.AssertExpectations(t)
+}
+
+func newDispatcherWithMocks() *dispatcherWithMocks {
+	processGetter := new(processGetterMock)
+	callbackRecorder := new(callbackRecorderMock)
+	deliverer := new(delivererMock)
+	currentDateGetter := new(currentDateGetterMock)
+	config := webhook.DispatcherConfig{
+		BatchSize:   10,
+		MaxAttempts: 5,
+	}
+	return &dispatcherWithMocks{
+		dispatcher:        webhook.NewDispatcher(processGetter, callbackRecorder, deliverer, currentDateGetter, config),
+		processGetter:     processGetter,
+		callbackRecorder:  callbackRecorder,
+		deliverer:         deliverer,
+		currentDateGetter: currentDateGetter,
+		config:            config,
+	}
+}
+
+func newTerminatedProcessWithCallback(attempts int) *process.Process {
+	return &process.Process{
+		ID:    "1",
+		State: process.StateCompleted,
+		Callback: &process.Callback{
+			URL:      "https://example.com/callbacks",
+			State:    process.CallbackStatePending,
+			Attempts: attempts,
+		},
+	}
+}
+
+func TestDispatcher_Dispatch_Delivered(t *testing.T) {
+	dispatcherAndMocks := newDispatcherWithMocks()
+	proc := newTerminatedProcessWithCallback(1)
+	deliveryTime := time.Now()
+	dispatcherAndMocks.callbackRecorder.On("ListPendingCallbacks", mock.Anything, "", dispatcherAndMocks.config.BatchSize).
+		Return([]string{proc.ID}, nil)
+	dispatcherAndMocks.processGetter.On("Get", mock.Anything, proc.ID).Return(proc, nil)
+	dispatcherAndMocks.deliverer.On("Deliver", mock.Anything, proc.Callback.URL, *proc).Return(2, nil)
+	dispatcherAndMocks.currentDateGetter.On("GetCurrentDate").Return(deliveryTime)
+	dispatcherAndMocks.callbackRecorder.On("RecordCallbackDelivery", mock.Anything, process.CallbackDelivery{
+		ProcessID:    proc.ID,
+		State:        process.CallbackStateDelivered,
+		Attempts:     3,
+		DeliveryTime: deliveryTime,
+	}).Return(nil)
+
+	assert.NoError(t, dispatcherAndMocks.dispatcher.Dispatch(context.Background()))
+	dispatcherAndMocks.assertExpectations(t)
+}
+
+func TestDispatcher_Dispatch_DeliveryFailed(t *testing.T) {
+	testCases := []struct {
+		name             string
+		previousAttempts int
+		expectedState    process.CallbackState
+	}{
+		{name: "attempts left", previousAttempts: 1, expectedState: process.CallbackStatePending},
+		{name: "attempts exhausted", previousAttempts: 3, expectedState: process.CallbackStateFailed},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dispatcherAndMocks := newDispatcherWithMocks()
+			proc := newTerminatedProcessWithCallback(testCase.previousAttempts)
+			deliveryErr := errors.New("unexpected status code: 500")
+			deliveryErrMessage := deliveryErr.Error()
+			dispatcherAndMocks.callbackRecorder.On("ListPendingCallbacks", mock.Anything, "",
+				dispatcherAndMocks.config.BatchSize).Return([]string{proc.ID}, nil)
+			dispatcherAndMocks.processGetter.On("Get", mock.Anything, proc.ID).Return(proc, nil)
+			dispatcherAndMocks.deliverer.On("Deliver", mock.Anything, proc.Callback.URL, *proc).Return(2, deliveryErr)
+			dispatcherAndMocks.callbackRecorder.On("RecordCallbackDelivery", mock.Anything, process.CallbackDelivery{
+				ProcessID: proc.ID,
+				State:     testCase.expectedState,
+				Attempts:  testCase.previousAttempts + 2,
+				LastError: &deliveryErrMessage,
+			}).Return(nil)
+
+			assert.NoError(t, dispatcherAndMocks.dispatcher.Dispatch(context.Background()))
+			dispatcherAndMocks.assertExpectations(t)
+		})
+	}
+}
+
+func TestDispatcher_Dispatch_ProcessNotTerminated(t *testing.T) {
+	dispatcherAndMocks := newDispatcherWithMocks()
+	proc := newTerminatedProcessWithCallback(0)
+	proc.State = process.StateCreated
+	dispatcherAndMocks.callbackRecorder.On("ListPendingCallbacks", mock.Anything, "", dispatcherAndMocks.config.BatchSize).
+		Return([]string{proc.ID}, nil)
+	dispatcherAndMocks.processGetter.On("Get", mock.Anything, proc.ID).Return(proc, nil)
+
+	assert.NoError(t, dispatcherAndMocks.dispatcher.Dispatch(context.Background()))
+	dispatcherAndMocks.assertExpectations(t)
+}
+
+func TestDispatcher_Dispatch_ProcessNotFound(t *testing.T) {
+	dispatcherAndMocks := newDispatcherWithMocks()
+	dispatcherAndMocks.callbackRecorder.On("ListPendingCallbacks", mock.Anything, "", dispatcherAndMocks.config.BatchSize).
+		Return([]string{"1", "2"}, nil)
+	dispatcherAndMocks.processGetter.On("Get", mock.Anything, "1").Return((*process.Process)(nil), nil)
+	dispatcherAndMocks.processGetter.On("Get", mock.Anything, "2").
+		Return((*process.Process)(nil), errors.New("error"))
+
+	assert.NoError(t, dispatcherAndMocks.dispatcher.Dispatch(context.Background()))
+	dispatcherAndMocks.assertExpectations(t)
+}
+
+func TestDispatcher_Dispatch_ListingError(t *testing.T) {
+	dispatcherAndMocks := newDispatcherWithMocks()
+	dispatcherAndMocks.callbackRecorder.On("ListPendingCallbacks", mock.Anything, "", dispatcherAndMocks.config.BatchSize).
+		Return([]string(nil), errors.New("error"))
+
+	assert.Error(t, dispatcherAndMocks.dispatcher.Dispatch(context.Background()))
+	dispatcherAndMocks.assertExpectations(t)
+}
+
+func TestDispatcher_Dispatch_PagesThroughPendingCallbacks(t *testing.T) {
+	dispatcherAndMocks := newDispatcherWithMocks()
+	dispatcherAndMocks.dispatcher = webhook.NewDispatcher(dispatcherAndMocks.processGetter,
+		dispatcherAndMocks.callbackRecorder, dispatcherAndMocks.deliverer, dispatcherAndMocks.currentDateGetter,
+		webhook.DispatcherConfig{BatchSize: 2, MaxAttempts: 5})
+	dispatcherAndMocks.callbackRecorder.On("ListPendingCallbacks", mock.Anything, "", 2).
+		Return([]string{"1", "2"}, nil).Twice()
+	dispatcherAndMocks.callbackRecorder.On("ListPendingCallbacks", mock.Anything, "2", 2).
+		Return([]string{"3"}, nil).Once()
+	for _, processID := range []string{"1", "2", "3"} {
+		dispatcherAndMocks.processGetter.On("Get", mock.Anything, processID).Return((*process.Process)(nil), nil)
+	}
+
+	for dispatchNumber := 0; dispatchNumber < 3; dispatchNumber++ {
+		assert.NoError(t, dispatcherAndMocks.dispatcher.Dispatch(context.Background()))
+	}
+	dispatcherAndMocks.assertExpectations(t)
+}
@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+const (
+	SignatureHeaderName       = "X-Termination-Detector-Signature"
+	TimestampHeaderName       = "X-Termination-Detector-Timestamp"
+	DefaultSignatureTolerance = time.Minute * 5
+	signaturePrefix           = "sha256="
+)
+
+func FormatTimestamp(date time.Time) string {
+	return strconv.FormatInt(date.Unix(), 10)
+}
+
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func VerifySignature(secret []byte, timestamp string, body []byte, signature string, now time.Time,
+	tolerance time.Duration) bool {
+	timestampSeconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	age := now.Sub(time.Unix(timestampSeconds, 0))
+	if age > tolerance || age < -tolerance {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,39 @@
+package webhook_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/internal/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	assert.Equal(t, "sha256=1354048c89565fb353820db0916156914e5c4cecd68eb47000802c94e4a9d8d2",
+		webhook.Sign([]byte("key"), "1585742400", []byte("The quick brown fox jumps over the lazy dog")))
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	assert.Equal(t, "1585742400", webhook.FormatTimestamp(time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	signingTime := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	timestamp := webhook.FormatTimestamp(signingTime)
+	signature := webhook.Sign([]byte("secret"), timestamp, body)
+	tolerance := webhook.DefaultSignatureTolerance
+
+	assert.True(t, webhook.VerifySignature([]byte("secret"), timestamp, body, signature, signingTime, tolerance))
+	assert.True(t, webhook.VerifySignature([]byte("secret"), timestamp, body, signature,
+		signingTime.Add(tolerance), tolerance))
+	assert.False(t, webhook.VerifySignature([]byte("other"), timestamp, body, signature, signingTime, tolerance))
+	assert.False(t, webhook.VerifySignature([]byte("secret"), timestamp, []byte(`{"id":"2"}`), signature,
+		signingTime, tolerance))
+	assert.False(t, webhook.VerifySignature([]byte("secret"), "1585742401", body, signature, signingTime, tolerance))
+	assert.False(t, webhook.VerifySignature([]byte("secret"), "invalid", body, signature, signingTime, tolerance))
+	assert.False(t, webhook.VerifySignature([]byte("secret"), timestamp, body, signature,
+		signingTime.Add(tolerance+time.Second), tolerance))
+	assert.False(t, webhook.VerifySignature([]byte("secret"), timestamp, body, signature,
+		signingTime.Add(-tolerance-time.Second), tolerance))
+}
@@ -0,0 +1,10 @@
+package dates
+
+import "time"
+
+func MinDuration(first, second time.Duration) time.Duration {
+	if first < second {
+		return first
+	}
+	return second
+}
@@ -0,0 +1,14 @@
+package dates_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/dates"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinDuration(t *testing.T) {
+	assert.Equal(t, time.Second, dates.MinDuration(time.Second, time.Minute))
+	assert.Equal(t, time.Second, dates.MinDuration(time.Minute, time.Second))
+}
@@ -6,9 +6,25 @@ import (
 )
 
 func MustRead(envVarName string) string {
+	envVarValue, err := Read(envVarName)
+	if err != nil {
+		panic(err.Error())
+	}
+	return envVarValue
+}
+
+func Read(envVarName string) (string, error) {
+	envVarValue, isSet := os.LookupEnv(envVarName)
+	if !isSet {
+		return "", fmt.Errorf("env variable %s is not set", envVarName)
+	}
+	return envVarValue, nil
+}
+
+func ReadOrDefault(envVarName, defaultValue string) string {
 	envVarValue, isSet := os.LookupEnv(envVarName)
 	if !isSet {
-		panic(fmt.Sprintf("env variable %s is not set", envVarName))
+		return defaultValue
 	}
 	return envVarValue
 }
@@ -23,3 +23,36 @@ func TestMustRead(t *testing.T) {
 		env.MustRead("NOT_EXISTING_ENV_VAR")
 	})
 }
+
+func TestRead(t *testing.T) {
+	testEnvVarName := "ENVS_READING_WITH_ERROR_TEST"
+	testEnvVarValue := "dummy"
+	err := os.Setenv(testEnvVarName, testEnvVarValue)
+	assert.NoError(t, err)
+	defer func() {
+		err := os.Unsetenv(testEnvVarName)
+		assert.NoError(t, err)
+	}()
+
+	envVarValue, err := env.Read(testEnvVarName)
+	assert.NoError(t, err)
+	assert.Equal(t, testEnvVarValue, envVarValue)
+
+	_, err = env.Read("NOT_EXISTING_ENV_VAR")
+	assert.Error(t, err)
+}
+
+func TestReadOrDefault(t *testing.T) {
+	testEnvVarName := "ENVS_READING_OR_DEFAULT_TEST"
+	testEnvVarValue := "dummy"
+	defaultValue := "default"
+	err := os.Setenv(testEnvVarName, testEnvVarValue)
+	assert.NoError(t, err)
+	defer func() {
+		err := os.Unsetenv(testEnvVarName)
+		assert.NoError(t, err)
+	}()
+
+	assert.Equal(t, testEnvVarValue, env.ReadOrDefault(testEnvVarName, defaultValue))
+	assert.Equal(t, defaultValue, env.ReadOrDefault("NOT_EXISTING_ENV_VAR", defaultValue))
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"io"
 	"net/http"
 
@@ -11,7 +12,7 @@ import (
 )
 
 type RequestModifier interface {
-	ModifyRequest(request *http.Request) error
+	ModifyRequest(ctx context.Context, request *http.Request) error
 }
 
 type RequestToNativeConverter interface {
@@ -25,34 +26,58 @@ type HTTPRequestDoer interface {
 type Client struct {
 	requestDoer      HTTPRequestDoer
 	baseURL          string
+	retryingConfig   RetryingConfig
 	requestModifiers []RequestModifier
 }
 
 func New(httpRequestDoer HTTPRequestDoer, baseURL string, requestModifiers ...RequestModifier) *Client {
+	return NewRetrying(httpRequestDoer, baseURL, RetryingConfig{MaxAttempts: 1}, requestModifiers...)
+}
+
+func NewRetrying(httpRequestDoer HTTPRequestDoer, baseURL string, retryingConfig RetryingConfig,
+	requestModifiers ...RequestModifier) *Client {
 	return &Client{
 		requestDoer:      httpRequestDoer,
 		baseURL:          baseURL,
+		retryingConfig:   retryingConfig.withDefaults(),
 		requestModifiers: requestModifiers,
 	}
 }
 
-func (executor *Client) ExecuteRequest(request internalHTTP.Request) (internalHTTP.Response, error) {
-	httpRequest, err := executor.buildHTTPRequest(request)
-	if err != nil {
-		return internalHTTP.Response{}, err
-	}
+func (executor *Client) ExecuteRequest(ctx context.Context, request internalHTTP.Request) (internalHTTP.Response, error) {
+	retrying := newRetrying(executor.retryingConfig)
+	for {
+		attempt := retrying.nextAttempt()
+		httpRequest, err := executor.buildHTTPRequest(ctx, request)
+		if err != nil {
+			return internalHTTP.Response{}, err
+		}
 
-	return executor.executeNativeRequest(httpRequest)
+		response, err := executor.executeNativeRequest(httpRequest)
+		response.Attempts = attempt
+		if !isRetryable(ctx, response, err) {
+			return response, err
+		}
+		delay, canRetry := retrying.nextDelay(readRetryAfter(response))
+		if !canRetry {
+			return response, err
+		}
+		logrus.WithError(err).WithField("statusCode", response.StatusCode).WithField("attempt", attempt).
+			Warn("retrying http request")
+		if sleepErr := sleep(ctx, delay); sleepErr != nil {
+			return response, err
+		}
+	}
 }
 
-func (executor *Client) buildHTTPRequest(request internalHTTP.Request) (*http.Request, error) {
-	httpRequest, err := InternalRequestToNative(executor.baseURL, request)
+func (executor *Client) buildHTTPRequest(ctx context.Context, request internalHTTP.Request) (*http.Request, error) {
+	httpRequest, err := InternalRequestToNative(ctx, executor.baseURL, request)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to convert request: %+v", request)
 	}
 
 	for _, requestModifier := range executor.requestModifiers {
-		if err := requestModifier.ModifyRequest(httpRequest); err != nil {
+		if err := requestModifier.ModifyRequest(ctx, httpRequest); err != nil {
 			return nil, errors.Wrapf(err, "failed to modify request: %+v", httpRequest)
 		}
 	}
@@ -2,6 +2,7 @@ package client_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io/ioutil"
 	"net/http"
@@ -32,8 +33,8 @@ type requestModifierMock struct {
 	mock.Mock
 }
 
-func (modifier *requestModifierMock) ModifyRequest(request *http.Request) error {
-	return modifier.Called(request).Error(0)
+func (modifier *requestModifierMock) ModifyRequest(ctx context.Context, request *http.Request) error {
+	return modifier.Called(ctx, request).Error(0)
 }
 
 type clientWithMocks struct {
@@ -70,10 +71,10 @@ func TestClient_ExecuteRequest(t *testing.T) {
 			internalHTTP.PathParameterProcessID: processID,
 		},
 	}
-	nativeRequest, err := client.InternalRequestToNative(clientAndMocks.apiURL, request)
+	nativeRequest, err := client.InternalRequestToNative(context.Background(), clientAndMocks.apiURL, request)
 	assert.NoError(t, err)
 
-	clientAndMocks.requestModifier.On("ModifyRequest", nativeRequest).Return(nil).Once()
+	clientAndMocks.requestModifier.On("ModifyRequest", mock.Anything, nativeRequest).Return(nil).Once()
 
 	returnedProcess := internalHTTP.Process{ID: processID, State: process.StateCreated}
 	returnedProcessJSON := returnedProcess.JSON()
@@ -85,12 +86,13 @@ func TestClient_ExecuteRequest(t *testing.T) {
 	}
 	clientAndMocks.requestDoer.On("Do", nativeRequest).Return(nativeResponse, nil)
 
-	response, err := clientAndMocks.client.ExecuteRequest(request)
+	response, err := clientAndMocks.client.ExecuteRequest(context.Background(), request)
 	assert.NoError(t, err)
 	assert.Equal(t, internalHTTP.Response{
 		StatusCode: nativeResponse.StatusCode,
 		Body:       returnedProcessJSON,
 		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+		Attempts:   1,
 	}, response)
 	clientAndMocks.assertExpectations(t)
 }
@@ -105,10 +107,10 @@ func TestClient_ExecuteRequest_NoResponseBody(t *testing.T) {
 			internalHTTP.PathParameterProcessID: processID,
 		},
 	}
-	nativeRequest, err := client.InternalRequestToNative(clientAndMocks.apiURL, request)
+	nativeRequest, err := client.InternalRequestToNative(context.Background(), clientAndMocks.apiURL, request)
 	assert.NoError(t, err)
 
-	clientAndMocks.requestModifier.On("ModifyRequest", nativeRequest).Return(nil).Once()
+	clientAndMocks.requestModifier.On("ModifyRequest", mock.Anything, nativeRequest).Return(nil).Once()
 
 	nativeResponse := &http.Response{
 		Status:     http.StatusText(http.StatusNotFound),
@@ -118,12 +120,13 @@ func TestClient_ExecuteRequest_NoResponseBody(t *testing.T) {
 	}
 	clientAndMocks.requestDoer.On("Do", nativeRequest).Return(nativeResponse, nil)
 
-	response, err := clientAndMocks.client.ExecuteRequest(request)
+	response, err := clientAndMocks.client.ExecuteRequest(context.Background(), request)
 	assert.NoError(t, err)
 	assert.Equal(t, internalHTTP.Response{
 		StatusCode: nativeResponse.StatusCode,
 		Body:       "",
 		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeTextPlain},
+		Attempts:   1,
 	}, response)
 	clientAndMocks.assertExpectations(t)
 }
@@ -145,14 +148,14 @@ func TestClient_ExecuteRequest_RequestWithPayload(t *testing.T) {
 		},
 		Body: taskRegistrationData.JSON(),
 	}
-	nativeRequest, err := client.InternalRequestToNative(clientAndMocks.apiURL, request)
+	nativeRequest, err := client.InternalRequestToNative(context.Background(), clientAndMocks.apiURL, request)
 	assert.NoError(t, err)
 	nativeRequestBodyBytes, err := ioutil.ReadAll(nativeRequest.Body)
 	assert.NoError(t, err)
 	nativeRequest.Body = ioutil.NopCloser(bytes.NewReader(nativeRequestBodyBytes))
 	assert.Equal(t, taskRegistrationData.JSON(), string(nativeRequestBodyBytes))
 
-	clientAndMocks.requestModifier.On("ModifyRequest", mock.MatchedBy(func(httpRequest *http.Request) bool {
+	clientAndMocks.requestModifier.On("ModifyRequest", mock.Anything, mock.MatchedBy(func(httpRequest *http.Request) bool {
 		return areRequestsEqual(t, nativeRequest, httpRequest)
 	})).Return(nil).Once()
 
@@ -167,12 +170,13 @@ func TestClient_ExecuteRequest_RequestWithPayload(t *testing.T) {
 		return areRequestsEqual(t, nativeRequest, httpRequest)
 	})).Return(nativeResponse, nil)
 
-	response, err := clientAndMocks.client.ExecuteRequest(request)
+	response, err := clientAndMocks.client.ExecuteRequest(context.Background(), request)
 	assert.NoError(t, err)
 	assert.Equal(t, internalHTTP.Response{
 		StatusCode: nativeResponse.StatusCode,
 		Body:       taskRegistrationData.JSON(),
 		Headers:    map[string]string{internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON},
+		Attempts:   1,
 	}, response)
 	clientAndMocks.assertExpectations(t)
 }
@@ -206,12 +210,12 @@ func TestClient_ExecuteRequest_ModifierError(t *testing.T) {
 			internalHTTP.PathParameterProcessID: processID,
 		},
 	}
-	nativeRequest, err := client.InternalRequestToNative(clientAndMocks.apiURL, request)
+	nativeRequest, err := client.InternalRequestToNative(context.Background(), clientAndMocks.apiURL, request)
 	assert.NoError(t, err)
 
-	clientAndMocks.requestModifier.On("ModifyRequest", nativeRequest).Return(errors.New("error"))
+	clientAndMocks.requestModifier.On("ModifyRequest", mock.Anything, nativeRequest).Return(errors.New("error"))
 
-	_, err = clientAndMocks.client.ExecuteRequest(request)
+	_, err = clientAndMocks.client.ExecuteRequest(context.Background(), request)
 	assert.Error(t, err)
 	clientAndMocks.assertExpectations(t)
 }
@@ -226,10 +230,10 @@ func TestClient_ExecuteRequest_DoerError(t *testing.T) {
 			internalHTTP.PathParameterProcessID: processID,
 		},
 	}
-	nativeRequest, err := client.InternalRequestToNative(clientAndMocks.apiURL, request)
+	nativeRequest, err := client.InternalRequestToNative(context.Background(), clientAndMocks.apiURL, request)
 	assert.NoError(t, err)
 
-	clientAndMocks.requestModifier.On("ModifyRequest", nativeRequest).Return(nil).Once()
+	clientAndMocks.requestModifier.On("ModifyRequest", mock.Anything, nativeRequest).Return(nil).Once()
 
 	returnedProcess := internalHTTP.Process{ID: processID, State: process.StateCreated}
 	returnedProcessJSON := returnedProcess.JSON()
@@ -241,7 +245,145 @@ func TestClient_ExecuteRequest_DoerError(t *testing.T) {
 	}
 	clientAndMocks.requestDoer.On("Do", nativeRequest).Return(nativeResponse, errors.New("error"))
 
-	_, err = clientAndMocks.client.ExecuteRequest(request)
+	_, err = clientAndMocks.client.ExecuteRequest(context.Background(), request)
+	assert.Error(t, err)
+	clientAndMocks.assertExpectations(t)
+}
+
+var retryingConfig = client.RetryingConfig{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     time.Millisecond,
+}
+
+func newRetryingClientWithMocks() *clientWithMocks {
+	clientAndMocks := newClientWithMocks()
+	clientAndMocks.client = client.NewRetrying(clientAndMocks.requestDoer, clientAndMocks.apiURL, retryingConfig,
+		clientAndMocks.requestModifier)
+	return clientAndMocks
+}
+
+func newRetryingTestRequest() internalHTTP.Request {
+	return internalHTTP.Request{
+		Method:       internalHTTP.MethodGet,
+		ResourcePath: internalHTTP.ResourcePathProcess,
+		PathParameters: map[internalHTTP.PathParameter]string{
+			internalHTTP.PathParameterProcessID: "1",
+		},
+	}
+}
+
+func newNativeResponse(statusCode int) *http.Response {
+	return &http.Response{
+		Status:     http.StatusText(statusCode),
+		StatusCode: statusCode,
+		Body:       ioutil.NopCloser(strings.NewReader(http.StatusText(statusCode))),
+	}
+}
+
+func TestClient_ExecuteRequest_RetriesRetryableFailures(t *testing.T) {
+	clientAndMocks := newRetryingClientWithMocks()
+	clientAndMocks.requestModifier.On("ModifyRequest", mock.Anything, mock.Anything).Return(nil).Times(3)
+	clientAndMocks.requestDoer.On("Do", mock.Anything).Return(nil, errors.New("error")).Once()
+	clientAndMocks.requestDoer.On("Do", mock.Anything).Return(newNativeResponse(http.StatusServiceUnavailable), nil).Once()
+	clientAndMocks.requestDoer.On("Do", mock.Anything).Return(newNativeResponse(http.StatusOK), nil).Once()
+
+	response, err := clientAndMocks.client.ExecuteRequest(context.Background(), newRetryingTestRequest())
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, 3, response.Attempts)
+	clientAndMocks.assertExpectations(t)
+}
+
+func TestClient_ExecuteRequest_StopsRetryingAfterMaxAttempts(t *testing.T) {
+	clientAndMocks := newRetryingClientWithMocks()
+	clientAndMocks.requestModifier.On("ModifyRequest", mock.Anything, mock.Anything).Return(nil).Times(3)
+	clientAndMocks.requestDoer.On("Do", mock.Anything).Return(newNativeResponse(http.StatusTooManyRequests), nil).Times(3)
+
+	response, err := clientAndMocks.client.ExecuteRequest(context.Background(), newRetryingTestRequest())
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+	assert.Equal(t, 3, response.Attempts)
+	clientAndMocks.assertExpectations(t)
+}
+
+func newNativeResponseWithRetryAfter(statusCode int, retryAfter string) *http.Response {
+	nativeResponse := newNativeResponse(statusCode)
+	nativeResponse.Header = map[string][]string{internalHTTP.RetryAfterHeaderName: {retryAfter}}
+	return nativeResponse
+}
+
+func TestClient_ExecuteRequest_HonoursRetryAfterLongerThanMaxBackoff(t *testing.T) {
+	clientAndMocks := newRetryingClientWithMocks()
+	clientAndMocks.requestModifier.On("ModifyRequest", mock.Anything, mock.Anything).Return(nil).Twice()
+	clientAndMocks.requestDoer.On("Do", mock.Anything).
+		Return(newNativeResponseWithRetryAfter(http.StatusTooManyRequests, "1"), nil).Once()
+	clientAndMocks.requestDoer.On("Do", mock.Anything).Return(newNativeResponse(http.StatusOK), nil).Once()
+
+	requestStart := time.Now()
+	response, err := clientAndMocks.client.ExecuteRequest(context.Background(), newRetryingTestRequest())
+	assert.NoError(t, err)
+	assert.True(t, time.Since(requestStart) >= time.Second)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, 2, response.Attempts)
+	clientAndMocks.assertExpectations(t)
+}
+
+func TestClient_ExecuteRequest_NotRetryingWhenRetryAfterExceedsMaxElapsedTime(t *testing.T) {
+	clientAndMocks := newClientWithMocks()
+	clientAndMocks.client = client.NewRetrying(clientAndMocks.requestDoer, clientAndMocks.apiURL, client.RetryingConfig{
+		MaxAttempts:    3,
+		MaxElapsedTime: time.Second,
+	}, clientAndMocks.requestModifier)
+	clientAndMocks.requestModifier.On("ModifyRequest", mock.Anything, mock.Anything).Return(nil).Once()
+	clientAndMocks.requestDoer.On("Do", mock.Anything).
+		Return(newNativeResponseWithRetryAfter(http.StatusServiceUnavailable, "2"), nil).Once()
+
+	response, err := clientAndMocks.client.ExecuteRequest(context.Background(), newRetryingTestRequest())
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+	assert.Equal(t, 1, response.Attempts)
+	clientAndMocks.assertExpectations(t)
+}
+
+func TestClient_ExecuteRequest_DefaultsZeroBackoff(t *testing.T) {
+	clientAndMocks := newClientWithMocks()
+	clientAndMocks.client = client.NewRetrying(clientAndMocks.requestDoer, clientAndMocks.apiURL,
+		client.RetryingConfig{MaxAttempts: 2}, clientAndMocks.requestModifier)
+	clientAndMocks.requestModifier.On("ModifyRequest", mock.Anything, mock.Anything).Return(nil).Twice()
+	clientAndMocks.requestDoer.On("Do", mock.Anything).Return(newNativeResponse(http.StatusBadGateway), nil).Once()
+	clientAndMocks.requestDoer.On("Do", mock.Anything).Return(newNativeResponse(http.StatusOK), nil).Once()
+
+	requestStart := time.Now()
+	response, err := clientAndMocks.client.ExecuteRequest(context.Background(), newRetryingTestRequest())
+	assert.NoError(t, err)
+	assert.True(t, time.Since(requestStart) >= client.DefaultRetryingInitialBackoff)
+	assert.Equal(t, 2, response.Attempts)
+	clientAndMocks.assertExpectations(t)
+}
+
+func TestClient_ExecuteRequest_NotRetryingOtherStatuses(t *testing.T) {
+	for _, statusCode := range []int{http.StatusConflict, http.StatusInternalServerError} {
+		clientAndMocks := newRetryingClientWithMocks()
+		clientAndMocks.requestModifier.On("ModifyRequest", mock.Anything, mock.Anything).Return(nil).Once()
+		clientAndMocks.requestDoer.On("Do", mock.Anything).Return(newNativeResponse(statusCode), nil).Once()
+
+		response, err := clientAndMocks.client.ExecuteRequest(context.Background(), newRetryingTestRequest())
+		assert.NoError(t, err)
+		assert.Equal(t, statusCode, response.StatusCode)
+		assert.Equal(t, 1, response.Attempts)
+		clientAndMocks.assertExpectations(t)
+	}
+}
+
+func TestClient_ExecuteRequest_NotRetryingCanceledRequest(t *testing.T) {
+	clientAndMocks := newRetryingClientWithMocks()
+	ctx, cancel := context.WithCancel(context.Background())
+	clientAndMocks.requestModifier.On("ModifyRequest", mock.Anything, mock.Anything).Return(nil).Once()
+	clientAndMocks.requestDoer.On("Do", mock.Anything).Return(nil, errors.New("error")).
+		Run(func(mock.Arguments) { cancel() }).Once()
+
+	_, err := clientAndMocks.client.ExecuteRequest(ctx, newRetryingTestRequest())
 	assert.Error(t, err)
 	clientAndMocks.assertExpectations(t)
 }
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -25,7 +26,7 @@ func NewIAMAuthorizingModifier(signer *v4.Signer, signatureRegion string) *IAMAu
 	}
 }
 
-func (modifier *IAMAuthorizingModifier) ModifyRequest(request *http.Request) error {
+func (modifier *IAMAuthorizingModifier) ModifyRequest(_ context.Context, request *http.Request) error {
 	if err := modifier.signRequest(request); err != nil {
 		return errors.Wrap(err, "failed to generate v4 signature for request")
 	}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -9,12 +10,12 @@ import (
 	internalHTTP "github.com/artii15/termination-detector/pkg/http"
 )
 
-func InternalRequestToNative(baseURL string, request internalHTTP.Request) (*http.Request, error) {
+func InternalRequestToNative(ctx context.Context, baseURL string, request internalHTTP.Request) (*http.Request, error) {
 	var payloadReader io.Reader
 	if len(request.Body) > 0 {
 		payloadReader = strings.NewReader(request.Body)
 	}
-	return http.NewRequest(string(request.Method), request.FullURL(baseURL), payloadReader)
+	return http.NewRequestWithContext(ctx, string(request.Method), request.FullURL(baseURL), payloadReader)
 }
 
 func readHeaders(header http.Header) (headers map[string]string) {
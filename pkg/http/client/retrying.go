@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/dates"
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+)
+
+const (
+	DefaultRetryingMaxAttempts       = 4
+	DefaultRetryingMaxElapsedTime    = time.Second * 20
+	DefaultRetryingInitialBackoff    = time.Millisecond * 100
+	DefaultRetryingMaxBackoff        = time.Second * 2
+	DefaultRetryingBackoffMultiplier = 2
+	DefaultRetryingJitter            = 0.2
+)
+
+var retryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+type RetryingConfig struct {
+	MaxAttempts       int
+	MaxElapsedTime    time.Duration
+	InitialBackoff    time.Duration
+	MaxBackoff        time.Duration
+	BackoffMultiplier float64
+	Jitter            float64
+}
+
+var DefaultRetryingConfig = RetryingConfig{
+	MaxAttempts:       DefaultRetryingMaxAttempts,
+	MaxElapsedTime:    DefaultRetryingMaxElapsedTime,
+	InitialBackoff:    DefaultRetryingInitialBackoff,
+	MaxBackoff:        DefaultRetryingMaxBackoff,
+	BackoffMultiplier: DefaultRetryingBackoffMultiplier,
+	Jitter:            DefaultRetryingJitter,
+}
+
+func (config RetryingConfig) withDefaults() RetryingConfig {
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = 1
+	}
+	if config.MaxElapsedTime <= 0 {
+		config.MaxElapsedTime = DefaultRetryingMaxElapsedTime
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = DefaultRetryingInitialBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = DefaultRetryingMaxBackoff
+	}
+	if config.MaxBackoff < config.InitialBackoff {
+		config.MaxBackoff = config.InitialBackoff
+	}
+	if config.BackoffMultiplier < 1 {
+		config.BackoffMultiplier = DefaultRetryingBackoffMultiplier
+	}
+	if config.Jitter < 0 {
+		config.Jitter = 0
+	}
+	if config.Jitter > 1 {
+		config.Jitter = 1
+	}
+	return config
+}
+
+type retrying struct {
+	config   RetryingConfig
+	start    time.Time
+	attempts int
+	backoff  time.Duration
+}
+
+func newRetrying(config RetryingConfig) *retrying {
+	return &retrying{
+		config:  config,
+		start:   time.Now(),
+		backoff: config.InitialBackoff,
+	}
+}
+
+func (retrying *retrying) nextAttempt() int {
+	retrying.attempts++
+	return retrying.attempts
+}
+
+func (retrying *retrying) nextDelay(retryAfter time.Duration) (time.Duration, bool) {
+	if retrying.attempts >= retrying.config.MaxAttempts {
+		return 0, false
+	}
+	delay := withJitter(retrying.backoff, retrying.config.Jitter)
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+	if time.Since(retrying.start)+delay > retrying.config.MaxElapsedTime {
+		return 0, false
+	}
+	retrying.backoff = dates.MinDuration(time.Duration(float64(retrying.backoff)*retrying.config.BackoffMultiplier),
+		retrying.config.MaxBackoff)
+	return delay, true
+}
+
+func isRetryable(ctx context.Context, response internalHTTP.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	return err != nil || retryableStatusCodes[response.StatusCode]
+}
+
+func readRetryAfter(response internalHTTP.Response) time.Duration {
+	retryAfterSeconds, err := strconv.Atoi(response.Headers[internalHTTP.RetryAfterHeaderName])
+	if err != nil || retryAfterSeconds < 0 {
+		return 0
+	}
+	return time.Duration(retryAfterSeconds) * time.Second
+}
+
+func withJitter(interval time.Duration, jitter float64) time.Duration {
+	jitterRange := float64(interval) * jitter
+	return interval + time.Duration(jitterRange*(2*rand.Float64()-1))
+}
+
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
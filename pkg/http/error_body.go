@@ -0,0 +1,33 @@
+package http
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+type ErrorBody struct {
+	Result  string `json:"result"`
+	Message string `json:"message"`
+}
+
+func (body ErrorBody) JSON() string {
+	marshalled, err := json.Marshal(body)
+	if err != nil {
+		panic(errors.Wrapf(err, "failed to marshal error body: %+v", body))
+	}
+	return string(marshalled)
+}
+
+func UnmarshalErrorBody(marshalledBody string) (body ErrorBody, err error) {
+	err = json.Unmarshal([]byte(marshalledBody), &body)
+	return
+}
+
+func readErrorResult(response Response) string {
+	body, err := UnmarshalErrorBody(response.Body)
+	if err != nil {
+		return ""
+	}
+	return body.Result
+}
@@ -4,4 +4,5 @@ const (
 	ContentTypeHeaderName      = "Content-Type"
 	ContentTypeTextPlain       = "text/plain"
 	ContentTypeApplicationJSON = "application/json"
+	RetryAfterHeaderName       = "Retry-After"
 )
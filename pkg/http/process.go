@@ -2,15 +2,159 @@ package http
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/artii15/termination-detector/pkg/process"
 	"github.com/pkg/errors"
 )
 
+const (
+	ProcessSealedMessage              = "process sealed"
+	ProcessDeadlineExceededMessage    = "process deadline exceeded"
+	InvalidProcessesListCursorMessage = "invalid processes list cursor"
+)
+
 type Process struct {
-	ID           string        `json:"id"`
-	State        process.State `json:"state"`
-	StateMessage *string       `json:"stateMessage,omitempty"`
+	ID           string            `json:"id"`
+	State        process.State     `json:"state"`
+	StateMessage *string           `json:"stateMessage,omitempty"`
+	Sealed       bool              `json:"sealed,omitempty"`
+	Callback     *Callback         `json:"callback,omitempty"`
+	Deadline     *time.Time        `json:"deadline,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	Description  *string           `json:"description,omitempty"`
+	CreatedAt    *time.Time        `json:"createdAt,omitempty"`
+	Creator      *string           `json:"creator,omitempty"`
+	Progress     *Progress         `json:"progress,omitempty"`
+}
+
+type Progress struct {
+	TotalTasksCount           int        `json:"totalTasksCount"`
+	CreatedTasksCount         int        `json:"createdTasksCount"`
+	FinishedTasksCount        int        `json:"finishedTasksCount"`
+	AbortedTasksCount         int        `json:"abortedTasksCount"`
+	TimedOutTasksCount        int        `json:"timedOutTasksCount"`
+	EarliestPendingExpiration *time.Time `json:"earliestPendingExpiration,omitempty"`
+}
+
+type ProcessesList struct {
+	Processes  []Process `json:"processes"`
+	NextCursor string    `json:"nextCursor,omitempty"`
+}
+
+func (processesList ProcessesList) JSON() string {
+	marshalled, err := json.Marshal(processesList)
+	if err != nil {
+		panic(errors.Wrapf(err, "failed to marshal processes list: %+v", processesList))
+	}
+	return string(marshalled)
+}
+
+func (processesList ProcessesList) internalProcessesList() process.List {
+	internalProcesses := make([]process.Process, 0, len(processesList.Processes))
+	for _, listedProcess := range processesList.Processes {
+		internalProcesses = append(internalProcesses, listedProcess.internalProcess())
+	}
+	return process.List{
+		Processes:  internalProcesses,
+		NextCursor: processesList.NextCursor,
+	}
+}
+
+func ConvertInternalToHTTPProcessesList(processesList process.List) ProcessesList {
+	httpProcesses := make([]Process, 0, len(processesList.Processes))
+	for _, listedProcess := range processesList.Processes {
+		httpProcesses = append(httpProcesses, ConvertInternalToHTTPProcess(listedProcess))
+	}
+	return ProcessesList{
+		Processes:  httpProcesses,
+		NextCursor: processesList.NextCursor,
+	}
+}
+
+type ProcessesBatchGet struct {
+	ProcessIDs []string `json:"processIds"`
+}
+
+func (batchGet ProcessesBatchGet) JSON() string {
+	marshalled, err := json.Marshal(batchGet)
+	if err != nil {
+		panic(errors.Wrapf(err, "failed to marshal processes batch get: %+v", batchGet))
+	}
+	return string(marshalled)
+}
+
+func UnmarshalProcessesBatchGet(marshalledBatchGet string) (batchGet ProcessesBatchGet, err error) {
+	err = json.Unmarshal([]byte(marshalledBatchGet), &batchGet)
+	return
+}
+
+type ProcessesBatchGetResult struct {
+	ProcessID string   `json:"processId"`
+	Process   *Process `json:"process,omitempty"`
+	NotFound  bool     `json:"notFound,omitempty"`
+}
+
+type ProcessesBatchGetResults struct {
+	Results []ProcessesBatchGetResult `json:"results"`
+}
+
+func (results ProcessesBatchGetResults) JSON() string {
+	marshalled, err := json.Marshal(results)
+	if err != nil {
+		panic(errors.Wrapf(err, "failed to marshal processes batch get results: %+v", results))
+	}
+	return string(marshalled)
+}
+
+func (results ProcessesBatchGetResults) internalProcesses() []*process.Process {
+	internalProcesses := make([]*process.Process, 0, len(results.Results))
+	for _, result := range results.Results {
+		internalProcesses = append(internalProcesses, result.Process.optionalInternalProcess())
+	}
+	return internalProcesses
+}
+
+func ConvertInternalToHTTPProcessesBatchGetResults(processIDs []string,
+	processes []*process.Process) ProcessesBatchGetResults {
+	results := ProcessesBatchGetResults{Results: make([]ProcessesBatchGetResult, 0, len(processIDs))}
+	for index, processID := range processIDs {
+		result := ProcessesBatchGetResult{ProcessID: processID, NotFound: processes[index] == nil}
+		if processes[index] != nil {
+			httpProcess := ConvertInternalToHTTPProcess(*processes[index])
+			result.Process = &httpProcess
+		}
+		results.Results = append(results.Results, result)
+	}
+	return results
+}
+
+type Callback struct {
+	URL          string                `json:"url"`
+	State        process.CallbackState `json:"state"`
+	Attempts     int                   `json:"attempts"`
+	LastError    *string               `json:"lastError,omitempty"`
+	DeliveryTime *time.Time            `json:"deliveryTime,omitempty"`
+}
+
+type ProcessUpdate struct {
+	CallbackURL *string           `json:"callbackUrl,omitempty"`
+	Deadline    *time.Time        `json:"deadline,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Description *string           `json:"description,omitempty"`
+}
+
+func (update ProcessUpdate) JSON() string {
+	marshalled, err := json.Marshal(update)
+	if err != nil {
+		panic(errors.Wrapf(err, "failed to marshal process update: %+v", update))
+	}
+	return string(marshalled)
+}
+
+func UnmarshalProcessUpdate(marshalledUpdate string) (update ProcessUpdate, err error) {
+	err = json.Unmarshal([]byte(marshalledUpdate), &update)
+	return
 }
 
 func (proc Process) JSON() string {
@@ -30,17 +174,113 @@ func (proc *Process) optionalInternalProcess() *process.Process {
 }
 
 func (proc Process) internalProcess() process.Process {
-	return process.Process{
+	internalProcess := process.Process{
 		ID:           proc.ID,
 		State:        proc.State,
 		StateMessage: proc.StateMessage,
+		Sealed:       proc.Sealed,
+		Callback:     proc.Callback.optionalInternalCallback(),
+		Labels:       proc.Labels,
+		Description:  proc.Description,
+		Creator:      proc.Creator,
+		Progress:     proc.Progress.optionalInternalProgress(),
+	}
+	if proc.Deadline != nil {
+		internalProcess.Deadline = *proc.Deadline
+	}
+	if proc.CreatedAt != nil {
+		internalProcess.CreationTime = *proc.CreatedAt
+	}
+	return internalProcess
+}
+
+func (callback *Callback) optionalInternalCallback() *process.Callback {
+	if callback == nil {
+		return nil
+	}
+	internalCallback := &process.Callback{
+		URL:       callback.URL,
+		State:     callback.State,
+		Attempts:  callback.Attempts,
+		LastError: callback.LastError,
 	}
+	if callback.DeliveryTime != nil {
+		internalCallback.DeliveryTime = *callback.DeliveryTime
+	}
+	return internalCallback
+}
+
+func (progress *Progress) optionalInternalProgress() *process.Progress {
+	if progress == nil {
+		return nil
+	}
+	internalProgress := &process.Progress{
+		TotalTasksCount:    progress.TotalTasksCount,
+		CreatedTasksCount:  progress.CreatedTasksCount,
+		FinishedTasksCount: progress.FinishedTasksCount,
+		AbortedTasksCount:  progress.AbortedTasksCount,
+		TimedOutTasksCount: progress.TimedOutTasksCount,
+	}
+	if progress.EarliestPendingExpiration != nil {
+		internalProgress.EarliestPendingExpirationTime = *progress.EarliestPendingExpiration
+	}
+	return internalProgress
 }
 
 func ConvertInternalToHTTPProcess(proc process.Process) Process {
-	return Process{
+	httpProcess := Process{
 		ID:           proc.ID,
 		State:        proc.State,
 		StateMessage: proc.StateMessage,
+		Sealed:       proc.Sealed,
+		Callback:     convertInternalToHTTPCallback(proc.Callback),
+		Labels:       proc.Labels,
+		Description:  proc.Description,
+		Creator:      proc.Creator,
+		Progress:     convertInternalToHTTPProgress(proc.Progress),
+	}
+	if !proc.Deadline.IsZero() {
+		deadline := proc.Deadline
+		httpProcess.Deadline = &deadline
+	}
+	if !proc.CreationTime.IsZero() {
+		creationTime := proc.CreationTime
+		httpProcess.CreatedAt = &creationTime
+	}
+	return httpProcess
+}
+
+func convertInternalToHTTPCallback(callback *process.Callback) *Callback {
+	if callback == nil {
+		return nil
+	}
+	httpCallback := &Callback{
+		URL:       callback.URL,
+		State:     callback.State,
+		Attempts:  callback.Attempts,
+		LastError: callback.LastError,
+	}
+	if !callback.DeliveryTime.IsZero() {
+		deliveryTime := callback.DeliveryTime
+		httpCallback.DeliveryTime = &deliveryTime
+	}
+	return httpCallback
+}
+
+func convertInternalToHTTPProgress(progress *process.Progress) *Progress {
+	if progress == nil {
+		return nil
+	}
+	httpProgress := &Progress{
+		TotalTasksCount:    progress.TotalTasksCount,
+		CreatedTasksCount:  progress.CreatedTasksCount,
+		FinishedTasksCount: progress.FinishedTasksCount,
+		AbortedTasksCount:  progress.AbortedTasksCount,
+		TimedOutTasksCount: progress.TimedOutTasksCount,
+	}
+	if !progress.EarliestPendingExpirationTime.IsZero() {
+		earliestPendingExpiration := progress.EarliestPendingExpirationTime
+		httpProgress.EarliestPendingExpiration = &earliestPendingExpiration
 	}
+	return httpProgress
 }
@@ -1,9 +1,12 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/artii15/termination-detector/pkg/process"
 )
@@ -18,13 +21,53 @@ func NewProcessGetter(requestExecutor requestExecutor) *ProcessGetter {
 	}
 }
 
-func (getter *ProcessGetter) Get(processID string) (*process.Process, error) {
-	response, err := getter.requestExecutor.ExecuteRequest(Request{
+func (getter *ProcessGetter) Get(ctx context.Context, processID string) (*process.Process, error) {
+	return getter.get(ctx, processID, nil)
+}
+
+func (getter *ProcessGetter) GetWhileState(ctx context.Context, processID string, whileState process.State,
+	wait time.Duration) (*process.Process, error) {
+	return getter.get(ctx, processID, map[QueryParameter]string{
+		QueryParameterWaitSeconds: strconv.Itoa(int(wait / time.Second)),
+		QueryParameterWhileState:  string(whileState),
+	})
+}
+
+func (getter *ProcessGetter) GetMany(ctx context.Context, processIDs []string) ([]*process.Process, error) {
+	response, err := getter.requestExecutor.ExecuteRequest(ctx, Request{
+		Method:       MethodPost,
+		ResourcePath: ResourcePathProcessesAction,
+		PathParameters: map[PathParameter]string{
+			PathParameterProcessesAction: ProcessesActionBatchGet,
+		},
+		Body: ProcessesBatchGet{ProcessIDs: processIDs}.JSON(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected error occurred: %d %s", response.StatusCode, response.Body)
+	}
+
+	var results ProcessesBatchGetResults
+	if err := json.Unmarshal([]byte(response.Body), &results); err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(processIDs) {
+		return nil, fmt.Errorf("expected %d processes, got %d", len(processIDs), len(results.Results))
+	}
+	return results.internalProcesses(), nil
+}
+
+func (getter *ProcessGetter) get(ctx context.Context,
+	processID string, queryParameters map[QueryParameter]string) (*process.Process, error) {
+	response, err := getter.requestExecutor.ExecuteRequest(ctx, Request{
 		Method:       MethodGet,
 		ResourcePath: ResourcePathProcess,
 		PathParameters: map[PathParameter]string{
 			PathParameterProcessID: processID,
 		},
+		QueryParameters: queryParameters,
 	})
 	if err != nil || response.StatusCode == http.StatusNotFound {
 		return nil, err
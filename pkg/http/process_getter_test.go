@@ -1,9 +1,11 @@
 package http_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	internalHTTP "github.com/artii15/termination-detector/pkg/http"
 	"github.com/artii15/termination-detector/pkg/process"
@@ -16,8 +18,8 @@ type requestExecutorMock struct {
 	mock.Mock
 }
 
-func (executor *requestExecutorMock) ExecuteRequest(request internalHTTP.Request) (internalHTTP.Response, error) {
-	args := executor.Called(request)
+func (executor *requestExecutorMock) ExecuteRequest(ctx context.Context, request internalHTTP.Request) (internalHTTP.Response, error) {
+	args := executor.Called(ctx, request)
 	return args.Get(0).(internalHTTP.Response), args.Error(1)
 }
 
@@ -40,10 +42,11 @@ func TestProcessGetter_Get(t *testing.T) {
 		ID:           "1",
 		State:        process.StateError,
 		StateMessage: aws.String("failed"),
+		Sealed:       true,
 	}
 	httpProcessToGet := internalHTTP.ConvertInternalToHTTPProcess(processToGet)
 
-	procGetterAndMocks.requestExecutor.On("ExecuteRequest", internalHTTP.Request{
+	procGetterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
 		Method:       internalHTTP.MethodGet,
 		ResourcePath: internalHTTP.ResourcePathProcess,
 		PathParameters: map[internalHTTP.PathParameter]string{
@@ -57,17 +60,116 @@ func TestProcessGetter_Get(t *testing.T) {
 		},
 	}, nil)
 
-	proc, err := procGetterAndMocks.procGetter.Get(processToGet.ID)
+	proc, err := procGetterAndMocks.procGetter.Get(context.Background(), processToGet.ID)
 	assert.NoError(t, err)
 	assert.NotNil(t, proc)
 	assert.Equal(t, processToGet, *proc)
 }
 
+func TestProcessGetter_Get_ProcessWithCallback(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	processToGet := process.Process{
+		ID:    "1",
+		State: process.StateCompleted,
+		Callback: &process.Callback{
+			URL:          "https://example.com/callbacks",
+			State:        process.CallbackStateDelivered,
+			Attempts:     2,
+			LastError:    aws.String("unexpected status code: 500"),
+			DeliveryTime: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		Deadline: time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+	httpProcessToGet := internalHTTP.ConvertInternalToHTTPProcess(processToGet)
+
+	procGetterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
+		Method:       internalHTTP.MethodGet,
+		ResourcePath: internalHTTP.ResourcePathProcess,
+		PathParameters: map[internalHTTP.PathParameter]string{
+			internalHTTP.PathParameterProcessID: processToGet.ID,
+		},
+	}).Return(internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body:       httpProcessToGet.JSON(),
+	}, nil)
+
+	proc, err := procGetterAndMocks.procGetter.Get(context.Background(), processToGet.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, proc)
+	assert.Equal(t, processToGet, *proc)
+}
+
+func TestProcessGetter_Get_ProcessWithMetadata(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	processToGet := process.Process{
+		ID:           "1",
+		State:        process.StateCreated,
+		Labels:       map[string]string{"team": "payments"},
+		Description:  aws.String("nightly settlement"),
+		CreationTime: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Creator:      aws.String("arn:aws:iam::123456789012:user/creator"),
+	}
+	httpProcessToGet := internalHTTP.ConvertInternalToHTTPProcess(processToGet)
+
+	procGetterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
+		Method:       internalHTTP.MethodGet,
+		ResourcePath: internalHTTP.ResourcePathProcess,
+		PathParameters: map[internalHTTP.PathParameter]string{
+			internalHTTP.PathParameterProcessID: processToGet.ID,
+		},
+	}).Return(internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body:       httpProcessToGet.JSON(),
+	}, nil)
+
+	proc, err := procGetterAndMocks.procGetter.Get(context.Background(), processToGet.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, proc)
+	assert.Equal(t, processToGet, *proc)
+	assert.Contains(t, httpProcessToGet.JSON(), `"createdAt":"2020-01-02T03:04:05Z"`)
+}
+
+func TestProcessGetter_Get_ProcessWithProgress(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	processToGet := process.Process{
+		ID:    "1",
+		State: process.StateCreated,
+		Progress: &process.Progress{
+			TotalTasksCount:               5,
+			CreatedTasksCount:             2,
+			FinishedTasksCount:            1,
+			AbortedTasksCount:             1,
+			TimedOutTasksCount:            1,
+			EarliestPendingExpirationTime: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	}
+	httpProcessToGet := internalHTTP.ConvertInternalToHTTPProcess(processToGet)
+
+	procGetterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
+		Method:       internalHTTP.MethodGet,
+		ResourcePath: internalHTTP.ResourcePathProcess,
+		PathParameters: map[internalHTTP.PathParameter]string{
+			internalHTTP.PathParameterProcessID: processToGet.ID,
+		},
+	}).Return(internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body:       httpProcessToGet.JSON(),
+	}, nil)
+
+	proc, err := procGetterAndMocks.procGetter.Get(context.Background(), processToGet.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, proc)
+	assert.Equal(t, processToGet, *proc)
+	assert.Contains(t, httpProcessToGet.JSON(), `"progress":{"totalTasksCount":5,"createdTasksCount":2,`+
+		`"finishedTasksCount":1,"abortedTasksCount":1,"timedOutTasksCount":1,`+
+		`"earliestPendingExpiration":"2020-01-02T03:04:05Z"}`)
+}
+
 func TestProcessGetter_Get_ProcessNotFound(t *testing.T) {
 	procGetterAndMocks := newProcessGetterWithMocks()
 	procID := "1"
 
-	procGetterAndMocks.requestExecutor.On("ExecuteRequest", internalHTTP.Request{
+	procGetterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
 		Method:       internalHTTP.MethodGet,
 		ResourcePath: internalHTTP.ResourcePathProcess,
 		PathParameters: map[internalHTTP.PathParameter]string{
@@ -80,7 +182,7 @@ func TestProcessGetter_Get_ProcessNotFound(t *testing.T) {
 		},
 	}, nil)
 
-	proc, err := procGetterAndMocks.procGetter.Get(procID)
+	proc, err := procGetterAndMocks.procGetter.Get(context.Background(), procID)
 	assert.NoError(t, err)
 	assert.Nil(t, proc)
 }
@@ -89,7 +191,7 @@ func TestProcessGetter_Get_RequestExecutorError(t *testing.T) {
 	procGetterAndMocks := newProcessGetterWithMocks()
 	procID := "1"
 
-	procGetterAndMocks.requestExecutor.On("ExecuteRequest", internalHTTP.Request{
+	procGetterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
 		Method:       internalHTTP.MethodGet,
 		ResourcePath: internalHTTP.ResourcePathProcess,
 		PathParameters: map[internalHTTP.PathParameter]string{
@@ -102,7 +204,7 @@ func TestProcessGetter_Get_RequestExecutorError(t *testing.T) {
 		},
 	}, errors.New("error"))
 
-	_, err := procGetterAndMocks.procGetter.Get(procID)
+	_, err := procGetterAndMocks.procGetter.Get(context.Background(), procID)
 	assert.Error(t, err)
 }
 
@@ -110,7 +212,7 @@ func TestProcessGetter_Get_UnknownResponseStatus(t *testing.T) {
 	procGetterAndMocks := newProcessGetterWithMocks()
 	procID := "1"
 
-	procGetterAndMocks.requestExecutor.On("ExecuteRequest", internalHTTP.Request{
+	procGetterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
 		Method:       internalHTTP.MethodGet,
 		ResourcePath: internalHTTP.ResourcePathProcess,
 		PathParameters: map[internalHTTP.PathParameter]string{
@@ -123,6 +225,75 @@ func TestProcessGetter_Get_UnknownResponseStatus(t *testing.T) {
 		},
 	}, nil)
 
-	_, err := procGetterAndMocks.procGetter.Get(procID)
+	_, err := procGetterAndMocks.procGetter.Get(context.Background(), procID)
+	assert.Error(t, err)
+}
+
+func TestProcessGetter_GetWhileState(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	processToGet := process.Process{ID: "1", State: process.StateCompleted}
+
+	procGetterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
+		Method:       internalHTTP.MethodGet,
+		ResourcePath: internalHTTP.ResourcePathProcess,
+		PathParameters: map[internalHTTP.PathParameter]string{
+			internalHTTP.PathParameterProcessID: processToGet.ID,
+		},
+		QueryParameters: map[internalHTTP.QueryParameter]string{
+			internalHTTP.QueryParameterWaitSeconds: "20",
+			internalHTTP.QueryParameterWhileState:  string(process.StateCreated),
+		},
+	}).Return(internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body:       internalHTTP.ConvertInternalToHTTPProcess(processToGet).JSON(),
+	}, nil)
+
+	proc, err := procGetterAndMocks.procGetter.GetWhileState(context.Background(), processToGet.ID, process.StateCreated, time.Second*20)
+	assert.NoError(t, err)
+	assert.Equal(t, &processToGet, proc)
+	procGetterAndMocks.requestExecutor.AssertExpectations(t)
+}
+
+func TestProcessGetter_GetMany(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	processIDs := []string{"1", "2"}
+	processes := []*process.Process{{ID: "1", State: process.StateCompleted}, nil}
+
+	procGetterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
+		Method:       internalHTTP.MethodPost,
+		ResourcePath: internalHTTP.ResourcePathProcessesAction,
+		PathParameters: map[internalHTTP.PathParameter]string{
+			internalHTTP.PathParameterProcessesAction: internalHTTP.ProcessesActionBatchGet,
+		},
+		Body: internalHTTP.ProcessesBatchGet{ProcessIDs: processIDs}.JSON(),
+	}).Return(internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body:       internalHTTP.ConvertInternalToHTTPProcessesBatchGetResults(processIDs, processes).JSON(),
+	}, nil)
+
+	foundProcesses, err := procGetterAndMocks.procGetter.GetMany(context.Background(), processIDs)
+	assert.NoError(t, err)
+	assert.Equal(t, processes, foundProcesses)
+	procGetterAndMocks.requestExecutor.AssertExpectations(t)
+}
+
+func TestProcessGetter_GetMany_UnknownResponseStatus(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	procGetterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, mock.Anything).Return(internalHTTP.Response{
+		StatusCode: http.StatusBadRequest,
+	}, nil)
+
+	_, err := procGetterAndMocks.procGetter.GetMany(context.Background(), []string{"1"})
+	assert.Error(t, err)
+}
+
+func TestProcessGetter_GetMany_MissingResults(t *testing.T) {
+	procGetterAndMocks := newProcessGetterWithMocks()
+	procGetterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, mock.Anything).Return(internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body:       internalHTTP.ProcessesBatchGetResults{Results: []internalHTTP.ProcessesBatchGetResult{}}.JSON(),
+	}, nil)
+
+	_, err := procGetterAndMocks.procGetter.GetMany(context.Background(), []string{"1"})
 	assert.Error(t, err)
 }
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+type ProcessLister struct {
+	requestExecutor requestExecutor
+}
+
+func NewProcessLister(requestExecutor requestExecutor) *ProcessLister {
+	return &ProcessLister{
+		requestExecutor: requestExecutor,
+	}
+}
+
+func (lister *ProcessLister) ListProcesses(ctx context.Context, request process.ListRequest) (process.List, error) {
+	response, err := lister.requestExecutor.ExecuteRequest(ctx, Request{
+		Method:          MethodGet,
+		ResourcePath:    ResourcePathProcesses,
+		QueryParameters: buildProcessesListQueryParameters(request),
+	})
+	if err != nil {
+		return process.List{}, err
+	}
+	if response.StatusCode == http.StatusBadRequest && response.Body == InvalidProcessesListCursorMessage {
+		return process.List{}, process.ErrInvalidCursor
+	}
+	if response.StatusCode != http.StatusOK {
+		return process.List{}, fmt.Errorf("unexpected error occurred: %d %s", response.StatusCode, response.Body)
+	}
+
+	var processesList ProcessesList
+	if err := json.Unmarshal([]byte(response.Body), &processesList); err != nil {
+		return process.List{}, err
+	}
+	return processesList.internalProcessesList(), nil
+}
+
+func buildProcessesListQueryParameters(request process.ListRequest) map[QueryParameter]string {
+	queryParameters := make(map[QueryParameter]string)
+	if request.State != nil {
+		queryParameters[QueryParameterState] = string(*request.State)
+	}
+	if request.Label != nil {
+		queryParameters[QueryParameterLabel] = request.Label.String()
+	}
+	if !request.CreatedAfter.IsZero() {
+		queryParameters[QueryParameterCreatedAfter] = request.CreatedAfter.Format(time.RFC3339)
+	}
+	if request.Cursor != "" {
+		queryParameters[QueryParameterCursor] = request.Cursor
+	}
+	if request.Limit > 0 {
+		queryParameters[QueryParameterLimit] = strconv.Itoa(request.Limit)
+	}
+	return queryParameters
+}
@@ -0,0 +1,108 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type processListerWithMocks struct {
+	requestExecutor *requestExecutorMock
+	processLister   *internalHTTP.ProcessLister
+	listRequest     process.ListRequest
+	request         internalHTTP.Request
+}
+
+func newProcessListerWithMocks() *processListerWithMocks {
+	requestExecutor := new(requestExecutorMock)
+	state := process.StateError
+	listRequest := process.ListRequest{
+		State:        &state,
+		Label:        &process.Label{Name: "team", Value: "ingest"},
+		CreatedAfter: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Cursor:       "cursor",
+		Limit:        10,
+	}
+	return &processListerWithMocks{
+		requestExecutor: requestExecutor,
+		processLister:   internalHTTP.NewProcessLister(requestExecutor),
+		listRequest:     listRequest,
+		request: internalHTTP.Request{
+			Method:       internalHTTP.MethodGet,
+			ResourcePath: internalHTTP.ResourcePathProcesses,
+			QueryParameters: map[internalHTTP.QueryParameter]string{
+				internalHTTP.QueryParameterState:        string(state),
+				internalHTTP.QueryParameterLabel:        "team:ingest",
+				internalHTTP.QueryParameterCreatedAfter: "2020-01-02T03:04:05Z",
+				internalHTTP.QueryParameterCursor:       listRequest.Cursor,
+				internalHTTP.QueryParameterLimit:        "10",
+			},
+		},
+	}
+}
+
+func TestProcessLister_ListProcesses(t *testing.T) {
+	listerAndMocks := newProcessListerWithMocks()
+	listedProcess := process.Process{
+		ID:           "1",
+		State:        process.StateError,
+		Sealed:       true,
+		Labels:       map[string]string{"team": "ingest"},
+		CreationTime: time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+	listerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, listerAndMocks.request).Return(internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body: internalHTTP.ConvertInternalToHTTPProcessesList(process.List{
+			Processes:  []process.Process{listedProcess},
+			NextCursor: "next",
+		}).JSON(),
+	}, nil)
+
+	processesList, err := listerAndMocks.processLister.ListProcesses(context.Background(), listerAndMocks.listRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, process.List{
+		Processes:  []process.Process{listedProcess},
+		NextCursor: "next",
+	}, processesList)
+	listerAndMocks.requestExecutor.AssertExpectations(t)
+}
+
+func TestProcessLister_ListProcesses_InvalidCursor(t *testing.T) {
+	listerAndMocks := newProcessListerWithMocks()
+	listerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, listerAndMocks.request).Return(internalHTTP.Response{
+		StatusCode: http.StatusBadRequest,
+		Body:       internalHTTP.InvalidProcessesListCursorMessage,
+	}, nil)
+
+	_, err := listerAndMocks.processLister.ListProcesses(context.Background(), listerAndMocks.listRequest)
+	assert.Equal(t, process.ErrInvalidCursor, err)
+	listerAndMocks.requestExecutor.AssertExpectations(t)
+}
+
+func TestProcessLister_ListProcesses_UnexpectedStatus(t *testing.T) {
+	listerAndMocks := newProcessListerWithMocks()
+	listerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, listerAndMocks.request).Return(internalHTTP.Response{
+		StatusCode: http.StatusInternalServerError,
+	}, nil)
+
+	_, err := listerAndMocks.processLister.ListProcesses(context.Background(), listerAndMocks.listRequest)
+	assert.Error(t, err)
+	listerAndMocks.requestExecutor.AssertExpectations(t)
+}
+
+func TestProcessLister_ListProcesses_RequestError(t *testing.T) {
+	listerAndMocks := newProcessListerWithMocks()
+	listerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, listerAndMocks.request).
+		Return(internalHTTP.Response{}, errors.New("error"))
+
+	_, err := listerAndMocks.processLister.ListProcesses(context.Background(), listerAndMocks.listRequest)
+	assert.Error(t, err)
+	listerAndMocks.requestExecutor.AssertExpectations(t)
+}
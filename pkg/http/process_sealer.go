@@ -0,0 +1,40 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+type ProcessSealer struct {
+	requestExecutor requestExecutor
+}
+
+func NewProcessSealer(requestExecutor requestExecutor) *ProcessSealer {
+	return &ProcessSealer{
+		requestExecutor: requestExecutor,
+	}
+}
+
+func (sealer *ProcessSealer) Seal(ctx context.Context, processID string) (process.SealingResult, error) {
+	response, err := sealer.requestExecutor.ExecuteRequest(ctx, Request{
+		Method:       MethodPut,
+		ResourcePath: ResourcePathProcessSeal,
+		PathParameters: map[PathParameter]string{
+			PathParameterProcessID: processID,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	switch response.StatusCode {
+	case http.StatusNoContent:
+		return process.SealingResultSealed, nil
+	case http.StatusNotFound:
+		return process.SealingResultNotFound, nil
+	default:
+		return "", fmt.Errorf("unexpected sealing result: %d %s", response.StatusCode, response.Body)
+	}
+}
@@ -0,0 +1,81 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type processSealerWithMocks struct {
+	requestExecutor *requestExecutorMock
+	procSealer      *internalHTTP.ProcessSealer
+}
+
+func newProcessSealerWithMocks() *processSealerWithMocks {
+	requestExecutor := new(requestExecutorMock)
+	return &processSealerWithMocks{
+		requestExecutor: requestExecutor,
+		procSealer:      internalHTTP.NewProcessSealer(requestExecutor),
+	}
+}
+
+func newSealRequest(procID string) internalHTTP.Request {
+	return internalHTTP.Request{
+		Method:       internalHTTP.MethodPut,
+		ResourcePath: internalHTTP.ResourcePathProcessSeal,
+		PathParameters: map[internalHTTP.PathParameter]string{
+			internalHTTP.PathParameterProcessID: procID,
+		},
+	}
+}
+
+func TestProcessSealer_Seal(t *testing.T) {
+	procSealerAndMocks := newProcessSealerWithMocks()
+	procID := "1"
+	procSealerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, newSealRequest(procID)).Return(internalHTTP.Response{
+		StatusCode: http.StatusNoContent,
+	}, nil)
+
+	sealingResult, err := procSealerAndMocks.procSealer.Seal(context.Background(), procID)
+	assert.NoError(t, err)
+	assert.Equal(t, process.SealingResultSealed, sealingResult)
+}
+
+func TestProcessSealer_Seal_ProcessNotFound(t *testing.T) {
+	procSealerAndMocks := newProcessSealerWithMocks()
+	procID := "1"
+	procSealerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, newSealRequest(procID)).Return(internalHTTP.Response{
+		StatusCode: http.StatusNotFound,
+	}, nil)
+
+	sealingResult, err := procSealerAndMocks.procSealer.Seal(context.Background(), procID)
+	assert.NoError(t, err)
+	assert.Equal(t, process.SealingResultNotFound, sealingResult)
+}
+
+func TestProcessSealer_Seal_UnknownResponseStatus(t *testing.T) {
+	procSealerAndMocks := newProcessSealerWithMocks()
+	procID := "1"
+	procSealerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, newSealRequest(procID)).Return(internalHTTP.Response{
+		StatusCode: http.StatusInternalServerError,
+	}, nil)
+
+	_, err := procSealerAndMocks.procSealer.Seal(context.Background(), procID)
+	assert.Error(t, err)
+}
+
+func TestProcessSealer_Seal_RequestExecutorError(t *testing.T) {
+	procSealerAndMocks := newProcessSealerWithMocks()
+	procID := "1"
+	procSealerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, newSealRequest(procID)).
+		Return(internalHTTP.Response{}, errors.New("error"))
+
+	_, err := procSealerAndMocks.procSealer.Seal(context.Background(), procID)
+	assert.Error(t, err)
+}
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+type ProcessUpdater struct {
+	requestExecutor requestExecutor
+}
+
+func NewProcessUpdater(requestExecutor requestExecutor) *ProcessUpdater {
+	return &ProcessUpdater{
+		requestExecutor: requestExecutor,
+	}
+}
+
+func (updater *ProcessUpdater) Update(ctx context.Context, request process.UpdateRequest) (process.UpdatingResult, error) {
+	update := ProcessUpdate{
+		CallbackURL: request.CallbackURL,
+		Deadline:    request.Deadline,
+		Labels:      request.Labels,
+		Description: request.Description,
+	}
+	response, err := updater.requestExecutor.ExecuteRequest(ctx, Request{
+		Method:       MethodPut,
+		ResourcePath: ResourcePathProcess,
+		Body:         update.JSON(),
+		PathParameters: map[PathParameter]string{
+			PathParameterProcessID: request.ProcessID,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	switch response.StatusCode {
+	case http.StatusNoContent:
+		return process.UpdatingResultUpdated, nil
+	case http.StatusNotFound:
+		return process.UpdatingResultNotFound, nil
+	default:
+		return "", fmt.Errorf("unexpected updating result: %d %s", response.StatusCode, response.Body)
+	}
+}
@@ -0,0 +1,123 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type processUpdaterWithMocks struct {
+	requestExecutor *requestExecutorMock
+	procUpdater     *internalHTTP.ProcessUpdater
+}
+
+func newProcessUpdaterWithMocks() *processUpdaterWithMocks {
+	requestExecutor := new(requestExecutorMock)
+	return &processUpdaterWithMocks{
+		requestExecutor: requestExecutor,
+		procUpdater:     internalHTTP.NewProcessUpdater(requestExecutor),
+	}
+}
+
+func newUpdateRequest(request process.UpdateRequest) internalHTTP.Request {
+	return internalHTTP.Request{
+		Method:       internalHTTP.MethodPut,
+		ResourcePath: internalHTTP.ResourcePathProcess,
+		Body: internalHTTP.ProcessUpdate{
+			CallbackURL: request.CallbackURL,
+			Deadline:    request.Deadline,
+			Labels:      request.Labels,
+			Description: request.Description,
+		}.JSON(),
+		PathParameters: map[internalHTTP.PathParameter]string{
+			internalHTTP.PathParameterProcessID: request.ProcessID,
+		},
+	}
+}
+
+func newProcessUpdateRequest() process.UpdateRequest {
+	callbackURL := "https://example.com/callbacks"
+	return process.UpdateRequest{
+		ProcessID:   "1",
+		CallbackURL: &callbackURL,
+	}
+}
+
+func TestProcessUpdater_Update(t *testing.T) {
+	procUpdaterAndMocks := newProcessUpdaterWithMocks()
+	updateRequest := newProcessUpdateRequest()
+	procUpdaterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, newUpdateRequest(updateRequest)).
+		Return(internalHTTP.Response{StatusCode: http.StatusNoContent}, nil)
+
+	updatingResult, err := procUpdaterAndMocks.procUpdater.Update(context.Background(), updateRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, process.UpdatingResultUpdated, updatingResult)
+}
+
+func TestProcessUpdater_Update_Deadline(t *testing.T) {
+	procUpdaterAndMocks := newProcessUpdaterWithMocks()
+	deadline := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	updateRequest := process.UpdateRequest{ProcessID: "1", Deadline: &deadline}
+	procUpdaterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, newUpdateRequest(updateRequest)).
+		Return(internalHTTP.Response{StatusCode: http.StatusNoContent}, nil)
+
+	updatingResult, err := procUpdaterAndMocks.procUpdater.Update(context.Background(), updateRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, process.UpdatingResultUpdated, updatingResult)
+	procUpdaterAndMocks.requestExecutor.AssertExpectations(t)
+}
+
+func TestProcessUpdater_Update_Metadata(t *testing.T) {
+	procUpdaterAndMocks := newProcessUpdaterWithMocks()
+	updateRequest := process.UpdateRequest{
+		ProcessID:   "1",
+		Labels:      map[string]string{"team": "payments"},
+		Description: aws.String("nightly settlement"),
+	}
+	procUpdaterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, newUpdateRequest(updateRequest)).
+		Return(internalHTTP.Response{StatusCode: http.StatusNoContent}, nil)
+
+	updatingResult, err := procUpdaterAndMocks.procUpdater.Update(context.Background(), updateRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, process.UpdatingResultUpdated, updatingResult)
+	procUpdaterAndMocks.requestExecutor.AssertExpectations(t)
+}
+
+func TestProcessUpdater_Update_ProcessNotFound(t *testing.T) {
+	procUpdaterAndMocks := newProcessUpdaterWithMocks()
+	updateRequest := newProcessUpdateRequest()
+	procUpdaterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, newUpdateRequest(updateRequest)).
+		Return(internalHTTP.Response{StatusCode: http.StatusNotFound}, nil)
+
+	updatingResult, err := procUpdaterAndMocks.procUpdater.Update(context.Background(), updateRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, process.UpdatingResultNotFound, updatingResult)
+}
+
+func TestProcessUpdater_Update_UnknownResponseStatus(t *testing.T) {
+	procUpdaterAndMocks := newProcessUpdaterWithMocks()
+	updateRequest := newProcessUpdateRequest()
+	procUpdaterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, newUpdateRequest(updateRequest)).
+		Return(internalHTTP.Response{StatusCode: http.StatusInternalServerError}, nil)
+
+	_, err := procUpdaterAndMocks.procUpdater.Update(context.Background(), updateRequest)
+	assert.Error(t, err)
+}
+
+func TestProcessUpdater_Update_RequestExecutorError(t *testing.T) {
+	procUpdaterAndMocks := newProcessUpdaterWithMocks()
+	updateRequest := newProcessUpdateRequest()
+	procUpdaterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, newUpdateRequest(updateRequest)).
+		Return(internalHTTP.Response{}, errors.New("error"))
+
+	_, err := procUpdaterAndMocks.procUpdater.Update(context.Background(), updateRequest)
+	assert.Error(t, err)
+}
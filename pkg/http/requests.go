@@ -1,40 +1,80 @@
 package http
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 )
 
 type ResourcePath string
 type Method string
 type PathParameter string
+type QueryParameter string
 
 const (
-	PathParameterProcessID PathParameter = "process_id"
-	PathParameterTaskID    PathParameter = "task_id"
+	PathParameterProcessID       PathParameter = "process_id"
+	PathParameterTaskID          PathParameter = "task_id"
+	PathParameterProcessesAction PathParameter = "processes_action"
 
-	ResourcePathTask           ResourcePath = "/processes/{process_id}/tasks/{task_id}"
-	ResourcePathTaskCompletion ResourcePath = "/processes/{process_id}/tasks/{task_id}/completion"
-	ResourcePathProcess        ResourcePath = "/processes/{process_id}"
+	ProcessesActionBatchGet = "processes:batchGet"
 
-	MethodGet Method = http.MethodGet
-	MethodPut Method = http.MethodPut
+	QueryParameterState  QueryParameter = "state"
+	QueryParameterCursor QueryParameter = "cursor"
+	QueryParameterLimit  QueryParameter = "limit"
+
+	QueryParameterLabel        QueryParameter = "label"
+	QueryParameterCreatedAfter QueryParameter = "createdAfter"
+
+	QueryParameterTransactional QueryParameter = "transactional"
+
+	QueryParameterWaitSeconds QueryParameter = "waitSeconds"
+	QueryParameterWhileState  QueryParameter = "whileState"
+
+	ResourcePathTasks                      ResourcePath = "/processes/{process_id}/tasks"
+	ResourcePathTask                       ResourcePath = "/processes/{process_id}/tasks/{task_id}"
+	ResourcePathTaskCompletion             ResourcePath = "/processes/{process_id}/tasks/{task_id}/completion"
+	ResourcePathTaskCompletionWithChildren ResourcePath = "/processes/{process_id}/tasks/{task_id}/completion-with-children"
+	ResourcePathTaskHeartbeat              ResourcePath = "/processes/{process_id}/tasks/{task_id}/heartbeat"
+	ResourcePathProcesses                  ResourcePath = "/processes"
+	ResourcePathProcessesAction            ResourcePath = "/{processes_action}"
+	ResourcePathProcess                    ResourcePath = "/processes/{process_id}"
+	ResourcePathCompletions                ResourcePath = "/processes/{process_id}/completions"
+	ResourcePathProcessSeal                ResourcePath = "/processes/{process_id}/seal"
+
+	MethodGet  Method = http.MethodGet
+	MethodPut  Method = http.MethodPut
+	MethodPost Method = http.MethodPost
 )
 
 type Request struct {
-	Method         Method
-	ResourcePath   ResourcePath
-	Body           string
-	PathParameters map[PathParameter]string
+	Method          Method
+	ResourcePath    ResourcePath
+	Body            string
+	PathParameters  map[PathParameter]string
+	QueryParameters map[QueryParameter]string
+	Principal       *string
 }
 
 func (request Request) FullURL(baseURL string) string {
 	resourceURL := request.resourceURL()
-	return strings.Join([]string{
+	fullURL := strings.Join([]string{
 		strings.TrimRight(baseURL, "/"),
 		strings.TrimLeft(resourceURL, "/"),
 	}, "/")
+	if len(request.QueryParameters) == 0 {
+		return fullURL
+	}
+	return fullURL + "?" + request.encodedQuery()
+}
+
+func (request Request) encodedQuery() string {
+	query := make(url.Values)
+	for paramName, paramValue := range request.QueryParameters {
+		query.Set(string(paramName), paramValue)
+	}
+	return query.Encode()
 }
 
 func (request Request) resourceURL() string {
@@ -50,8 +90,9 @@ type Response struct {
 	StatusCode int
 	Body       string
 	Headers    map[string]string
+	Attempts   int
 }
 
 type requestExecutor interface {
-	ExecuteRequest(request Request) (Response, error)
+	ExecuteRequest(ctx context.Context, request Request) (Response, error)
 }
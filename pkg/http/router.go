@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"net/http"
 
 	log "github.com/sirupsen/logrus"
@@ -9,7 +10,7 @@ import (
 type RequestsHandlersMap map[ResourcePath]map[Method]RequestHandler
 
 type RequestHandler interface {
-	HandleRequest(request Request) (Response, error)
+	HandleRequest(ctx context.Context, request Request) (Response, error)
 }
 
 type Router struct {
@@ -22,7 +23,7 @@ func NewRouter(requestsHandlers RequestsHandlersMap) *Router {
 	}
 }
 
-func (router *Router) Route(request Request) Response {
+func (router *Router) Route(ctx context.Context, request Request) Response {
 	methodsHandlers, handlersForResourceExist := router.requestsHandlers[request.ResourcePath]
 	if !handlersForResourceExist {
 		return CreateDefaultTextResponseWithStatus(http.StatusNotFound)
@@ -33,7 +34,7 @@ func (router *Router) Route(request Request) Response {
 		return CreateDefaultTextResponseWithStatus(http.StatusMethodNotAllowed)
 	}
 
-	response, err := requestHandler.HandleRequest(request)
+	response, err := requestHandler.HandleRequest(ctx, request)
 	if err != nil {
 		log.WithError(err).WithField("request", request).Error("failed to handle request")
 		return CreateDefaultTextResponseWithStatus(http.StatusInternalServerError)
@@ -50,3 +51,11 @@ func CreateDefaultTextResponseWithStatus(statusCode int) Response {
 		},
 	}
 }
+
+func (requestsHandlers RequestsHandlersMap) ResourcePaths() []ResourcePath {
+	resourcePaths := make([]ResourcePath, 0, len(requestsHandlers))
+	for resourcePath := range requestsHandlers {
+		resourcePaths = append(resourcePaths, resourcePath)
+	}
+	return resourcePaths
+}
@@ -1,6 +1,7 @@
 package http_test
 
 import (
+	"context"
 	"net/http"
 	"testing"
 
@@ -19,8 +20,8 @@ type routerWithMocks struct {
 	getTaskHandler *requestHandlerMock
 }
 
-func (handler *requestHandlerMock) HandleRequest(request internalHTTP.Request) (internalHTTP.Response, error) {
-	args := handler.Called(request)
+func (handler *requestHandlerMock) HandleRequest(ctx context.Context, request internalHTTP.Request) (internalHTTP.Response, error) {
+	args := handler.Called(ctx, request)
 	return args.Get(0).(internalHTTP.Response), args.Error(1)
 }
 
@@ -50,9 +51,9 @@ func TestRouter_Route(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       http.StatusText(http.StatusOK),
 	}
-	routerAndMocks.getTaskHandler.On("HandleRequest", request).Return(expectedResponse, nil)
+	routerAndMocks.getTaskHandler.On("HandleRequest", mock.Anything, request).Return(expectedResponse, nil)
 
-	response := routerAndMocks.router.Route(request)
+	response := routerAndMocks.router.Route(context.Background(), request)
 	assert.Equal(t, expectedResponse, response)
 }
 
@@ -71,7 +72,7 @@ func TestRouter_Route_UnknownResource(t *testing.T) {
 		},
 	}
 
-	response := routerAndMocks.router.Route(request)
+	response := routerAndMocks.router.Route(context.Background(), request)
 	assert.Equal(t, expectedResponse, response)
 }
 
@@ -90,7 +91,7 @@ func TestRouter_Route_UnknownMethod(t *testing.T) {
 		},
 	}
 
-	response := routerAndMocks.router.Route(request)
+	response := routerAndMocks.router.Route(context.Background(), request)
 	assert.Equal(t, expectedResponse, response)
 }
 
@@ -102,7 +103,7 @@ func TestRouter_Route_HandlerError(t *testing.T) {
 		Method:       internalHTTP.MethodGet,
 	}
 	expectedError := errors.New("error")
-	routerAndMocks.getTaskHandler.On("HandleRequest", request).Return(internalHTTP.Response{}, expectedError)
+	routerAndMocks.getTaskHandler.On("HandleRequest", mock.Anything, request).Return(internalHTTP.Response{}, expectedError)
 
 	expectedResponse := internalHTTP.Response{
 		StatusCode: http.StatusInternalServerError,
@@ -111,6 +112,22 @@ func TestRouter_Route_HandlerError(t *testing.T) {
 			internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeTextPlain,
 		},
 	}
-	response := routerAndMocks.router.Route(request)
+	response := routerAndMocks.router.Route(context.Background(), request)
 	assert.Equal(t, expectedResponse, response)
 }
+
+func TestRequestsHandlersMap_ResourcePaths(t *testing.T) {
+	requestsHandlers := internalHTTP.RequestsHandlersMap{
+		internalHTTP.ResourcePathTask: {
+			internalHTTP.MethodPut: new(requestHandlerMock),
+		},
+		internalHTTP.ResourcePathProcess: {
+			internalHTTP.MethodGet: new(requestHandlerMock),
+		},
+	}
+
+	assert.ElementsMatch(t, []internalHTTP.ResourcePath{
+		internalHTTP.ResourcePathTask,
+		internalHTTP.ResourcePathProcess,
+	}, requestsHandlers.ResourcePaths())
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/sirupsen/logrus"
+)
+
+type router interface {
+	Route(ctx context.Context, request internalHTTP.Request) internalHTTP.Response
+}
+
+type resourcePathMatcher interface {
+	Match(escapedURLPath string) (internalHTTP.ResourcePath, map[internalHTTP.PathParameter]string, bool)
+}
+
+const DefaultMaxRequestBodyBytes int64 = 1 << 20
+
+var errRequestBodyTooLarge = errors.New("http request body too large")
+
+type Handler struct {
+	router              router
+	resourcePathMatcher resourcePathMatcher
+	maxRequestBodyBytes int64
+}
+
+func NewHandler(router router, resourcePathMatcher resourcePathMatcher, maxRequestBodyBytes int64) *Handler {
+	return &Handler{
+		router:              router,
+		resourcePathMatcher: resourcePathMatcher,
+		maxRequestBodyBytes: maxRequestBodyBytes,
+	}
+}
+
+func (handler *Handler) ServeHTTP(responseWriter http.ResponseWriter, request *http.Request) {
+	routerRequest, err := handler.buildRouterRequest(responseWriter, request)
+	if err == errRequestBodyTooLarge {
+		writeResponse(responseWriter, internalHTTP.CreateDefaultTextResponseWithStatus(http.StatusRequestEntityTooLarge))
+		return
+	}
+	if err != nil {
+		logrus.WithError(err).Error("failed to read http request body")
+		writeResponse(responseWriter, internalHTTP.CreateDefaultTextResponseWithStatus(http.StatusBadRequest))
+		return
+	}
+	writeResponse(responseWriter, handler.router.Route(request.Context(), routerRequest))
+}
+
+func (handler *Handler) buildRouterRequest(responseWriter http.ResponseWriter,
+	request *http.Request) (internalHTTP.Request, error) {
+	body, err := handler.readRequestBody(responseWriter, request)
+	if err != nil {
+		return internalHTTP.Request{}, err
+	}
+	resourcePath, pathParameters, _ := handler.resourcePathMatcher.Match(request.URL.EscapedPath())
+	return internalHTTP.Request{
+		Method:          internalHTTP.Method(request.Method),
+		ResourcePath:    resourcePath,
+		Body:            body,
+		PathParameters:  pathParameters,
+		QueryParameters: readQueryParameters(request.URL.Query()),
+	}, nil
+}
+
+func readQueryParameters(query url.Values) map[internalHTTP.QueryParameter]string {
+	if len(query) == 0 {
+		return nil
+	}
+	queryParameters := make(map[internalHTTP.QueryParameter]string)
+	for parameterName := range query {
+		queryParameters[internalHTTP.QueryParameter(parameterName)] = query.Get(parameterName)
+	}
+	return queryParameters
+}
+
+func (handler *Handler) readRequestBody(responseWriter http.ResponseWriter, request *http.Request) (string, error) {
+	if request.Body == nil {
+		return "", nil
+	}
+	defer closeRequestBody(request)
+	if request.ContentLength > handler.maxRequestBodyBytes {
+		return "", errRequestBodyTooLarge
+	}
+	bodyBytes, err := ioutil.ReadAll(http.MaxBytesReader(responseWriter, request.Body, handler.maxRequestBodyBytes))
+	if err != nil && int64(len(bodyBytes)) == handler.maxRequestBodyBytes {
+		return "", errRequestBodyTooLarge
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(bodyBytes), nil
+}
+
+func closeRequestBody(request *http.Request) {
+	if err := request.Body.Close(); err != nil {
+		logrus.WithError(err).Error("failed to close http request body")
+	}
+}
+
+func writeResponse(responseWriter http.ResponseWriter, response internalHTTP.Response) {
+	for headerName, headerValue := range response.Headers {
+		responseWriter.Header().Set(headerName, headerValue)
+	}
+	responseWriter.WriteHeader(response.StatusCode)
+	if _, err := responseWriter.Write([]byte(response.Body)); err != nil {
+		logrus.WithError(err).Error("failed to write http response body")
+	}
+}
@@ -0,0 +1,131 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/http/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testMaxRequestBodyBytes = 1024
+
+type routerMock struct {
+	mock.Mock
+}
+
+func (router *routerMock) Route(ctx context.Context, request internalHTTP.Request) internalHTTP.Response {
+	return router.Called(ctx, request).Get(0).(internalHTTP.Response)
+}
+
+type handlerWithMocks struct {
+	handler *server.Handler
+	router  *routerMock
+}
+
+func newHandlerWithMocks() *handlerWithMocks {
+	router := new(routerMock)
+	return &handlerWithMocks{
+		handler: server.NewHandler(router, newResourcePathMatcher(), testMaxRequestBodyBytes),
+		router:  router,
+	}
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	handlerAndMocks := newHandlerWithMocks()
+	task := internalHTTP.Task{ExpirationTime: time.Now()}
+	requestBody := task.JSON()
+	responseFromRouter := internalHTTP.Response{
+		StatusCode: http.StatusCreated,
+		Body:       requestBody,
+		Headers: map[string]string{
+			internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON,
+		},
+	}
+	handlerAndMocks.router.On("Route", mock.Anything, internalHTTP.Request{
+		Method:       internalHTTP.MethodPut,
+		ResourcePath: internalHTTP.ResourcePathTask,
+		Body:         requestBody,
+		PathParameters: map[internalHTTP.PathParameter]string{
+			internalHTTP.PathParameterProcessID: "1",
+			internalHTTP.PathParameterTaskID:    "2",
+		},
+	}).Return(responseFromRouter)
+
+	responseRecorder := httptest.NewRecorder()
+	handlerAndMocks.handler.ServeHTTP(responseRecorder,
+		httptest.NewRequest(http.MethodPut, "/processes/1/tasks/2", strings.NewReader(requestBody)))
+
+	handlerAndMocks.router.AssertExpectations(t)
+	assert.Equal(t, responseFromRouter.StatusCode, responseRecorder.Code)
+	assert.Equal(t, responseFromRouter.Body, responseRecorder.Body.String())
+	assert.Equal(t, internalHTTP.ContentTypeApplicationJSON, responseRecorder.Header().Get(internalHTTP.ContentTypeHeaderName))
+}
+
+func TestHandler_ServeHTTP_QueryParameters(t *testing.T) {
+	handlerAndMocks := newHandlerWithMocks()
+	responseFromRouter := internalHTTP.CreateDefaultTextResponseWithStatus(http.StatusOK)
+	handlerAndMocks.router.On("Route", mock.Anything, internalHTTP.Request{
+		Method:       internalHTTP.MethodGet,
+		ResourcePath: internalHTTP.ResourcePathTasks,
+		PathParameters: map[internalHTTP.PathParameter]string{
+			internalHTTP.PathParameterProcessID: "1",
+		},
+		QueryParameters: map[internalHTTP.QueryParameter]string{
+			internalHTTP.QueryParameterState:  "CREATED",
+			internalHTTP.QueryParameterCursor: "a b",
+		},
+	}).Return(responseFromRouter)
+
+	responseRecorder := httptest.NewRecorder()
+	handlerAndMocks.handler.ServeHTTP(responseRecorder,
+		httptest.NewRequest(http.MethodGet, "/processes/1/tasks?state=CREATED&cursor=a+b", nil))
+
+	handlerAndMocks.router.AssertExpectations(t)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+}
+
+func TestHandler_ServeHTTP_UnknownPath(t *testing.T) {
+	handlerAndMocks := newHandlerWithMocks()
+	responseFromRouter := internalHTTP.CreateDefaultTextResponseWithStatus(http.StatusNotFound)
+	handlerAndMocks.router.On("Route", mock.Anything, internalHTTP.Request{
+		Method: internalHTTP.MethodGet,
+	}).Return(responseFromRouter)
+
+	responseRecorder := httptest.NewRecorder()
+	handlerAndMocks.handler.ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	handlerAndMocks.router.AssertExpectations(t)
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	assert.Equal(t, responseFromRouter.Body, responseRecorder.Body.String())
+}
+
+func TestHandler_ServeHTTP_RequestBodyTooLarge(t *testing.T) {
+	handlerAndMocks := newHandlerWithMocks()
+
+	responseRecorder := httptest.NewRecorder()
+	handlerAndMocks.handler.ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodPut, "/processes/1/tasks/2",
+		strings.NewReader(strings.Repeat("a", testMaxRequestBodyBytes+1))))
+
+	handlerAndMocks.router.AssertExpectations(t)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, responseRecorder.Code)
+}
+
+func TestHandler_ServeHTTP_RequestBodyOfUnknownLengthTooLarge(t *testing.T) {
+	handlerAndMocks := newHandlerWithMocks()
+	request := httptest.NewRequest(http.MethodPut, "/processes/1/tasks/2",
+		strings.NewReader(strings.Repeat("a", testMaxRequestBodyBytes+1)))
+	request.ContentLength = -1
+
+	responseRecorder := httptest.NewRecorder()
+	handlerAndMocks.handler.ServeHTTP(responseRecorder, request)
+
+	handlerAndMocks.router.AssertExpectations(t)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, responseRecorder.Code)
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+)
+
+const (
+	pathSeparator       = "/"
+	pathParameterPrefix = "{"
+	pathParameterSuffix = "}"
+)
+
+type resourcePathTemplate struct {
+	resourcePath internalHTTP.ResourcePath
+	segments     []string
+}
+
+type ResourcePathMatcher struct {
+	templates []resourcePathTemplate
+}
+
+func NewResourcePathMatcher(resourcePaths []internalHTTP.ResourcePath) *ResourcePathMatcher {
+	templates := make([]resourcePathTemplate, 0, len(resourcePaths))
+	for _, resourcePath := range resourcePaths {
+		templates = append(templates, resourcePathTemplate{
+			resourcePath: resourcePath,
+			segments:     splitPath(string(resourcePath)),
+		})
+	}
+	sort.SliceStable(templates, func(i, j int) bool {
+		return isMoreSpecific(templates[i].segments, templates[j].segments)
+	})
+	return &ResourcePathMatcher{templates: templates}
+}
+
+func (matcher *ResourcePathMatcher) Match(escapedURLPath string) (internalHTTP.ResourcePath, map[internalHTTP.PathParameter]string, bool) {
+	urlSegments := splitPath(escapedURLPath)
+	for _, template := range matcher.templates {
+		if pathParameters, matches := template.match(urlSegments); matches {
+			return template.resourcePath, pathParameters, true
+		}
+	}
+	return "", nil, false
+}
+
+func (template resourcePathTemplate) match(urlSegments []string) (map[internalHTTP.PathParameter]string, bool) {
+	if len(template.segments) != len(urlSegments) {
+		return nil, false
+	}
+	pathParameters := make(map[internalHTTP.PathParameter]string)
+	for segmentIndex, templateSegment := range template.segments {
+		urlSegment, err := url.PathUnescape(urlSegments[segmentIndex])
+		if err != nil {
+			return nil, false
+		}
+		if isPathParameter(templateSegment) {
+			if urlSegment == "" {
+				return nil, false
+			}
+			pathParameters[readPathParameterName(templateSegment)] = urlSegment
+		} else if templateSegment != urlSegment {
+			return nil, false
+		}
+	}
+	return pathParameters, true
+}
+
+func isMoreSpecific(segments, otherSegments []string) bool {
+	for segmentIndex := 0; segmentIndex < len(segments) && segmentIndex < len(otherSegments); segmentIndex++ {
+		isParameter := isPathParameter(segments[segmentIndex])
+		isOtherParameter := isPathParameter(otherSegments[segmentIndex])
+		if isParameter != isOtherParameter {
+			return !isParameter
+		}
+	}
+	return false
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, pathSeparator), pathSeparator)
+}
+
+func isPathParameter(segment string) bool {
+	return strings.HasPrefix(segment, pathParameterPrefix) && strings.HasSuffix(segment, pathParameterSuffix)
+}
+
+func readPathParameterName(segment string) internalHTTP.PathParameter {
+	return internalHTTP.PathParameter(strings.TrimSuffix(strings.TrimPrefix(segment, pathParameterPrefix), pathParameterSuffix))
+}
@@ -0,0 +1,103 @@
+package server_test
+
+import (
+	"testing"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/http/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func newResourcePathMatcher() *server.ResourcePathMatcher {
+	return server.NewResourcePathMatcher([]internalHTTP.ResourcePath{
+		internalHTTP.ResourcePathProcess,
+		internalHTTP.ResourcePathTask,
+		internalHTTP.ResourcePathTaskCompletion,
+		internalHTTP.ResourcePathTasks,
+	})
+}
+
+func TestResourcePathMatcher_Match(t *testing.T) {
+	matcher := newResourcePathMatcher()
+
+	resourcePath, pathParameters, matches := matcher.Match("/processes/1/tasks/2")
+	assert.True(t, matches)
+	assert.Equal(t, internalHTTP.ResourcePathTask, resourcePath)
+	assert.Equal(t, map[internalHTTP.PathParameter]string{
+		internalHTTP.PathParameterProcessID: "1",
+		internalHTTP.PathParameterTaskID:    "2",
+	}, pathParameters)
+}
+
+func TestResourcePathMatcher_Match_TrailingSlash(t *testing.T) {
+	matcher := newResourcePathMatcher()
+
+	resourcePath, pathParameters, matches := matcher.Match("/processes/1/")
+	assert.True(t, matches)
+	assert.Equal(t, internalHTTP.ResourcePathProcess, resourcePath)
+	assert.Equal(t, map[internalHTTP.PathParameter]string{
+		internalHTTP.PathParameterProcessID: "1",
+	}, pathParameters)
+}
+
+func TestResourcePathMatcher_Match_EscapedPathParameter(t *testing.T) {
+	matcher := newResourcePathMatcher()
+
+	resourcePath, pathParameters, matches := matcher.Match("/processes/a%2Fb/tasks/c%20d/completion")
+	assert.True(t, matches)
+	assert.Equal(t, internalHTTP.ResourcePathTaskCompletion, resourcePath)
+	assert.Equal(t, map[internalHTTP.PathParameter]string{
+		internalHTTP.PathParameterProcessID: "a/b",
+		internalHTTP.PathParameterTaskID:    "c d",
+	}, pathParameters)
+}
+
+func TestResourcePathMatcher_Match_StaticSegmentPreferred(t *testing.T) {
+	staticResourcePath := internalHTTP.ResourcePath("/processes/{process_id}/tasks/summary")
+	matcher := server.NewResourcePathMatcher([]internalHTTP.ResourcePath{
+		internalHTTP.ResourcePathTask,
+		staticResourcePath,
+	})
+
+	resourcePath, pathParameters, matches := matcher.Match("/processes/1/tasks/summary")
+	assert.True(t, matches)
+	assert.Equal(t, staticResourcePath, resourcePath)
+	assert.Equal(t, map[internalHTTP.PathParameter]string{
+		internalHTTP.PathParameterProcessID: "1",
+	}, pathParameters)
+}
+
+func TestResourcePathMatcher_Match_ProcessesAction(t *testing.T) {
+	matcher := server.NewResourcePathMatcher([]internalHTTP.ResourcePath{
+		internalHTTP.ResourcePathProcessesAction,
+		internalHTTP.ResourcePathProcesses,
+		internalHTTP.ResourcePathProcess,
+	})
+
+	resourcePath, pathParameters, matches := matcher.Match("/processes:batchGet")
+	assert.True(t, matches)
+	assert.Equal(t, internalHTTP.ResourcePathProcessesAction, resourcePath)
+	assert.Equal(t, map[internalHTTP.PathParameter]string{
+		internalHTTP.PathParameterProcessesAction: internalHTTP.ProcessesActionBatchGet,
+	}, pathParameters)
+
+	resourcePath, pathParameters, matches = matcher.Match("/processes/batch-get")
+	assert.True(t, matches)
+	assert.Equal(t, internalHTTP.ResourcePathProcess, resourcePath)
+	assert.Equal(t, map[internalHTTP.PathParameter]string{
+		internalHTTP.PathParameterProcessID: "batch-get",
+	}, pathParameters)
+
+	resourcePath, _, matches = matcher.Match("/processes")
+	assert.True(t, matches)
+	assert.Equal(t, internalHTTP.ResourcePathProcesses, resourcePath)
+}
+
+func TestResourcePathMatcher_Match_UnknownPath(t *testing.T) {
+	matcher := newResourcePathMatcher()
+
+	for _, path := range []string{"/", "/processes", "/processes//tasks/1", "/processes/1/tasks/2/unknown"} {
+		_, _, matches := matcher.Match(path)
+		assert.False(t, matches, path)
+	}
+}
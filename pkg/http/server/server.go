@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Config struct {
+	ListenAddress     string
+	TLSCertFile       string
+	TLSKeyFile        string
+	ShutdownTimeout   time.Duration
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+}
+
+func (config Config) isTLSEnabled() bool {
+	return config.TLSCertFile != "" || config.TLSKeyFile != ""
+}
+
+type Server struct {
+	httpServer *http.Server
+	config     Config
+}
+
+func New(handler http.Handler, config Config) *Server {
+	return &Server{
+		httpServer: &http.Server{
+			Addr:              config.ListenAddress,
+			Handler:           handler,
+			ReadHeaderTimeout: config.ReadHeaderTimeout,
+			ReadTimeout:       config.ReadTimeout,
+			WriteTimeout:      config.WriteTimeout,
+			IdleTimeout:       config.IdleTimeout,
+		},
+		config: config,
+	}
+}
+
+func (server *Server) Run(stopSignals <-chan os.Signal) error {
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- server.listenAndServe()
+	}()
+
+	select {
+	case err := <-serveErrors:
+		return err
+	case stopSignal := <-stopSignals:
+		logrus.WithField("signal", stopSignal).Info("shutting down http server")
+	}
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), server.config.ShutdownTimeout)
+	defer cancelShutdown()
+	return server.httpServer.Shutdown(shutdownCtx)
+}
+
+func (server *Server) listenAndServe() error {
+	var err error
+	if server.config.isTLSEnabled() {
+		err = server.httpServer.ListenAndServeTLS(server.config.TLSCertFile, server.config.TLSKeyFile)
+	} else {
+		err = server.httpServer.ListenAndServe()
+	}
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
@@ -8,8 +8,16 @@ import (
 	"github.com/pkg/errors"
 )
 
+const (
+	ChildTaskAlreadyRegisteredMessage      = "child task already registered"
+	TaskAlreadyCompletedDifferentlyMessage = "task already completed with different state or message"
+	TaskExpiredMessage                     = "task expired before completion"
+)
+
 type Task struct {
-	ExpirationTime time.Time `json:"expirationTime"`
+	ExpirationTime  time.Time  `json:"expirationTime"`
+	CallbackURL     *string    `json:"callbackUrl,omitempty"`
+	ProcessDeadline *time.Time `json:"processDeadline,omitempty"`
 }
 
 func (task Task) JSON() string {
@@ -25,6 +33,71 @@ func UnmarshalTask(marshalledTask string) (task Task, err error) {
 	return
 }
 
+type BatchTask struct {
+	TaskID         string    `json:"taskId"`
+	ExpirationTime time.Time `json:"expirationTime"`
+}
+
+type BatchTasks []BatchTask
+
+func (tasks BatchTasks) JSON() string {
+	marshalled, err := json.Marshal(tasks)
+	if err != nil {
+		panic(errors.Wrapf(err, "failed to marshal batch tasks: %+v", tasks))
+	}
+	return string(marshalled)
+}
+
+func UnmarshalBatchTasks(marshalledTasks string) (tasks BatchTasks, err error) {
+	err = json.Unmarshal([]byte(marshalledTasks), &tasks)
+	return
+}
+
+type BatchRegistrationResult struct {
+	TaskID string                  `json:"taskId"`
+	Result task.RegistrationResult `json:"result"`
+}
+
+type BatchRegistrationResults struct {
+	Results []BatchRegistrationResult `json:"results"`
+}
+
+func (results BatchRegistrationResults) JSON() string {
+	marshalled, err := json.Marshal(results)
+	if err != nil {
+		panic(errors.Wrapf(err, "failed to marshal batch registration results: %+v", results))
+	}
+	return string(marshalled)
+}
+
+func UnmarshalBatchRegistrationResults(marshalledResults string) (results BatchRegistrationResults, err error) {
+	err = json.Unmarshal([]byte(marshalledResults), &results)
+	return
+}
+
+func ConvertInternalToHTTPBatchRegistrationResults(
+	internalResults []task.BatchRegistrationResult) BatchRegistrationResults {
+	results := BatchRegistrationResults{Results: make([]BatchRegistrationResult, 0, len(internalResults))}
+	for _, internalResult := range internalResults {
+		results.Results = append(results.Results, BatchRegistrationResult{
+			TaskID: internalResult.TaskID,
+			Result: internalResult.Result,
+		})
+	}
+	return results
+}
+
+func (results BatchRegistrationResults) internalBatchRegistrationResults() []task.BatchRegistrationResult {
+	internalResults := make([]task.BatchRegistrationResult, 0, len(results.Results))
+	for _, result := range results.Results {
+		internalResults = append(internalResults, task.BatchRegistrationResult{
+			TaskID: result.TaskID,
+			Result: result.Result,
+		})
+	}
+	return internalResults
+}
+
 type CompletionState string
 
 const (
@@ -54,3 +127,90 @@ func UnmarshalCompletion(marshalledCompletion string) (completion Completion, er
 	err = json.Unmarshal([]byte(marshalledCompletion), &completion)
 	return
 }
+
+type BatchCompletion struct {
+	TaskID string `json:"taskId"`
+	Completion
+}
+
+type BatchCompletions []BatchCompletion
+
+func (completions BatchCompletions) JSON() string {
+	marshalled, err := json.Marshal(completions)
+	if err != nil {
+		panic(errors.Wrapf(err, "failed to marshal batch completions: %+v", completions))
+	}
+	return string(marshalled)
+}
+
+func UnmarshalBatchCompletions(marshalledCompletions string) (completions BatchCompletions, err error) {
+	err = json.Unmarshal([]byte(marshalledCompletions), &completions)
+	return
+}
+
+type BatchCompletingResult struct {
+	TaskID string                `json:"taskId"`
+	Result task.CompletingResult `json:"result"`
+}
+
+type BatchCompletingResults struct {
+	Results []BatchCompletingResult `json:"results"`
+}
+
+func (results BatchCompletingResults) JSON() string {
+	marshalled, err := json.Marshal(results)
+	if err != nil {
+		panic(errors.Wrapf(err, "failed to marshal batch completing results: %+v", results))
+	}
+	return string(marshalled)
+}
+
+func UnmarshalBatchCompletingResults(marshalledResults string) (results BatchCompletingResults, err error) {
+	err = json.Unmarshal([]byte(marshalledResults), &results)
+	return
+}
+
+func ConvertInternalToHTTPBatchCompletingResults(internalResults []task.BatchCompletingResult) BatchCompletingResults {
+	results := BatchCompletingResults{Results: make([]BatchCompletingResult, 0, len(internalResults))}
+	for _, internalResult := range internalResults {
+		results.Results = append(results.Results, BatchCompletingResult{
+			TaskID: internalResult.TaskID,
+			Result: internalResult.Result,
+		})
+	}
+	return results
+}
+
+func (results BatchCompletingResults) internalBatchCompletingResults() []task.BatchCompletingResult {
+	internalResults := make([]task.BatchCompletingResult, 0, len(results.Results))
+	for _, result := range results.Results {
+		internalResults = append(internalResults, task.BatchCompletingResult{
+			TaskID: result.TaskID,
+			Result: result.Result,
+		})
+	}
+	return internalResults
+}
+
+type ChildTask struct {
+	TaskID         string    `json:"taskId"`
+	ExpirationTime time.Time `json:"expirationTime"`
+}
+
+type CompletionWithChildren struct {
+	Completion
+	Children []ChildTask `json:"children"`
+}
+
+func (completion CompletionWithChildren) JSON() string {
+	marshalled, err := json.Marshal(completion)
+	if err != nil {
+		panic(errors.Wrapf(err, "failed to marshal task completion with children: %+v", completion))
+	}
+	return string(marshalled)
+}
+
+func UnmarshalCompletionWithChildren(marshalledCompletion string) (completion CompletionWithChildren, err error) {
+	err = json.Unmarshal([]byte(marshalledCompletion), &completion)
+	return
+}
@@ -1,8 +1,10 @@
 package http
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/artii15/termination-detector/pkg/task"
 )
@@ -17,33 +19,170 @@ func NewTaskCompleter(requestExecutor requestExecutor) *TaskCompleter {
 	}
 }
 
-func (completer *TaskCompleter) Complete(request task.CompleteRequest) (task.CompletingResult, error) {
-	completionState, isCompletionStateDefined := taskStateToCompletionStateMapping[request.State]
-	if !isCompletionStateDefined {
-		return "", fmt.Errorf("not allowed terminal task state requested: %s", request.State)
+func (completer *TaskCompleter) Complete(ctx context.Context, request task.CompleteRequest) (task.CompletingResult, error) {
+	taskCompletion, err := buildCompletion(request)
+	if err != nil {
+		return "", err
 	}
-	taskCompletion := Completion{
-		State:        completionState,
-		ErrorMessage: request.Message,
+	response, err := completer.requestExecutor.ExecuteRequest(ctx, Request{
+		Method:         MethodPut,
+		ResourcePath:   ResourcePathTaskCompletion,
+		Body:           taskCompletion.JSON(),
+		PathParameters: buildTaskPathParameters(request.ID),
+	})
+	if err != nil {
+		return "", err
+	}
+	return completer.resolveCompletingResult(ctx, request, response)
+}
+
+func (completer *TaskCompleter) CompleteWithChildren(ctx context.Context,
+	request task.CompleteWithChildrenRequest) (task.CompletingResult, error) {
+	taskCompletion, err := buildCompletion(request.CompleteRequest)
+	if err != nil {
+		return "", err
 	}
-	response, err := completer.requestExecutor.ExecuteRequest(Request{
+	children := make([]ChildTask, 0, len(request.Children))
+	for _, child := range request.Children {
+		if child.ID.ProcessID != request.ProcessID {
+			return "", fmt.Errorf("child task %s does not belong to process %s", child.ID.TaskID, request.ProcessID)
+		}
+		children = append(children, ChildTask{
+			TaskID:         child.ID.TaskID,
+			ExpirationTime: child.ExpirationTime,
+		})
+	}
+	completionWithChildren := CompletionWithChildren{
+		Completion: taskCompletion,
+		Children:   children,
+	}
+	response, err := completer.requestExecutor.ExecuteRequest(ctx, Request{
+		Method:         MethodPut,
+		ResourcePath:   ResourcePathTaskCompletionWithChildren,
+		Body:           completionWithChildren.JSON(),
+		PathParameters: buildTaskPathParameters(request.ID),
+	})
+	if err != nil {
+		return "", err
+	}
+	return completer.resolveCompletingResult(ctx, request.CompleteRequest, response)
+}
+
+func (completer *TaskCompleter) BatchComplete(ctx context.Context,
+	request task.BatchCompleteRequest) ([]task.BatchCompletingResult, error) {
+	batchCompletions := make(BatchCompletions, 0, len(request.Tasks))
+	for _, completeRequest := range request.Tasks {
+		if completeRequest.ProcessID != request.ProcessID {
+			return nil, fmt.Errorf("task %s does not belong to process %s", completeRequest.TaskID, request.ProcessID)
+		}
+		taskCompletion, err := buildCompletion(completeRequest)
+		if err != nil {
+			return nil, err
+		}
+		batchCompletions = append(batchCompletions, BatchCompletion{
+			TaskID:     completeRequest.TaskID,
+			Completion: taskCompletion,
+		})
+	}
+	response, err := completer.requestExecutor.ExecuteRequest(ctx, Request{
 		Method:       MethodPut,
-		ResourcePath: ResourcePathTaskCompletion,
-		Body:         taskCompletion.JSON(),
+		ResourcePath: ResourcePathCompletions,
+		Body:         batchCompletions.JSON(),
 		PathParameters: map[PathParameter]string{
 			PathParameterProcessID: request.ProcessID,
-			PathParameterTaskID:    request.TaskID,
+		},
+		QueryParameters: map[QueryParameter]string{
+			QueryParameterTransactional: strconv.FormatBool(request.Transactional),
 		},
 	})
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected batch completion result: %d %s", response.StatusCode, response.Body)
+	}
+	batchCompletingResults, err := UnmarshalBatchCompletingResults(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	results := batchCompletingResults.internalBatchCompletingResults()
+	if response.Attempts > 1 {
+		task.ReplaceCompletingResults(results, task.CompletingResultAlreadyCompletedSame, task.CompletingResultCompleted)
+	}
+	return results, nil
+}
+
+func buildCompletion(request task.CompleteRequest) (Completion, error) {
+	completionState, isCompletionStateDefined := taskStateToCompletionStateMapping[request.State]
+	if !isCompletionStateDefined {
+		return Completion{}, fmt.Errorf("not allowed terminal task state requested: %s", request.State)
 	}
+	return Completion{
+		State:        completionState,
+		ErrorMessage: request.Message,
+	}, nil
+}
+
+func buildTaskPathParameters(taskID task.ID) map[PathParameter]string {
+	return map[PathParameter]string{
+		PathParameterProcessID: taskID.ProcessID,
+		PathParameterTaskID:    taskID.TaskID,
+	}
+}
+
+func (completer *TaskCompleter) resolveCompletingResult(ctx context.Context, request task.CompleteRequest,
+	response Response) (task.CompletingResult, error) {
+	completingResult, err := readCompletingResult(response)
+	if err != nil || response.Attempts <= 1 {
+		return completingResult, err
+	}
+	if completingResult == task.CompletingResultConflict {
+		completedTask, err := NewTaskGetter(completer.requestExecutor).GetTask(ctx, request.ID)
+		if err != nil {
+			return "", err
+		}
+		completingResult = task.ReadCompletingConflictResult(completedTask, request)
+	}
+	if completingResult == task.CompletingResultAlreadyCompletedSame {
+		return task.CompletingResultCompleted, nil
+	}
+	return completingResult, nil
+}
+
+func readCompletingResult(response Response) (task.CompletingResult, error) {
 	switch response.StatusCode {
 	case http.StatusCreated:
 		return task.CompletingResultCompleted, nil
+	case http.StatusOK:
+		return task.CompletingResultAlreadyCompletedSame, nil
+	case http.StatusNotFound:
+		return task.CompletingResultNotFound, nil
 	case http.StatusConflict:
-		return task.CompletingResultConflict, nil
+		return readCompletingErrorResult(response, conflictCompletingResults, task.CompletingResultConflict), nil
+	case http.StatusGone:
+		return readCompletingErrorResult(response, goneCompletingResults, task.CompletingResultProcessSealed), nil
 	default:
 		return "", fmt.Errorf("unexpected completion result: %d %s", response.StatusCode, response.Body)
 	}
 }
+
+var conflictCompletingResults = map[task.CompletingResult]bool{
+	task.CompletingResultConflict:                  true,
+	task.CompletingResultChildConflict:             true,
+	task.CompletingResultAlreadyCompletedDifferent: true,
+	task.CompletingResultExpired:                   true,
+}
+
+var goneCompletingResults = map[task.CompletingResult]bool{
+	task.CompletingResultProcessSealed:           true,
+	task.CompletingResultProcessDeadlineExceeded: true,
+}
+
+func readCompletingErrorResult(response Response, allowedResults map[task.CompletingResult]bool,
+	defaultResult task.CompletingResult) task.CompletingResult {
+	result := task.CompletingResult(readErrorResult(response))
+	if !allowedResults[result] {
+		return defaultResult
+	}
+	return result
+}
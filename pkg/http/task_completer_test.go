@@ -1,14 +1,17 @@
 package http_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	internalHTTP "github.com/artii15/termination-detector/pkg/http"
 	"github.com/artii15/termination-detector/pkg/task"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 type taskCompleterWithMocks struct {
@@ -24,6 +27,10 @@ func newTaskCompleterWithMocks() *taskCompleterWithMocks {
 	}
 }
 
+func newErrorBody(result task.CompletingResult) string {
+	return internalHTTP.ErrorBody{Result: string(result), Message: "message"}.JSON()
+}
+
 func TestTaskCompleter_Complete(t *testing.T) {
 	completerAndMocks := newTaskCompleterWithMocks()
 	taskCompletion := internalHTTP.Completion{
@@ -32,7 +39,7 @@ func TestTaskCompleter_Complete(t *testing.T) {
 	}
 	procID := "1"
 	taskID := "2"
-	completerAndMocks.requestExecutor.On("ExecuteRequest", internalHTTP.Request{
+	completerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
 		Method:       internalHTTP.MethodPut,
 		ResourcePath: internalHTTP.ResourcePathTaskCompletion,
 		Body:         taskCompletion.JSON(),
@@ -47,7 +54,7 @@ func TestTaskCompleter_Complete(t *testing.T) {
 		},
 	}, nil)
 
-	completion, err := completerAndMocks.taskCompleter.Complete(task.CompleteRequest{
+	completion, err := completerAndMocks.taskCompleter.Complete(context.Background(), task.CompleteRequest{
 		ID: task.ID{
 			ProcessID: procID,
 			TaskID:    taskID,
@@ -67,7 +74,7 @@ func TestTaskCompleter_Complete_ConflictingState(t *testing.T) {
 	}
 	procID := "1"
 	taskID := "2"
-	completerAndMocks.requestExecutor.On("ExecuteRequest", internalHTTP.Request{
+	completerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
 		Method:       internalHTTP.MethodPut,
 		ResourcePath: internalHTTP.ResourcePathTaskCompletion,
 		Body:         taskCompletion.JSON(),
@@ -82,7 +89,7 @@ func TestTaskCompleter_Complete_ConflictingState(t *testing.T) {
 		},
 	}, nil)
 
-	completion, err := completerAndMocks.taskCompleter.Complete(task.CompleteRequest{
+	completion, err := completerAndMocks.taskCompleter.Complete(context.Background(), task.CompleteRequest{
 		ID: task.ID{
 			ProcessID: procID,
 			TaskID:    taskID,
@@ -94,6 +101,92 @@ func TestTaskCompleter_Complete_ConflictingState(t *testing.T) {
 	assert.Equal(t, task.CompletingResultConflict, completion)
 }
 
+func TestTaskCompleter_Complete_CompletionConflicts(t *testing.T) {
+	completeRequest := task.CompleteRequest{
+		ID:    task.ID{ProcessID: "1", TaskID: "2"},
+		State: task.StateFinished,
+	}
+	testCases := []struct {
+		response       internalHTTP.Response
+		expectedResult task.CompletingResult
+	}{
+		{
+			response:       internalHTTP.Response{StatusCode: http.StatusOK},
+			expectedResult: task.CompletingResultAlreadyCompletedSame,
+		},
+		{
+			response:       internalHTTP.Response{StatusCode: http.StatusNotFound},
+			expectedResult: task.CompletingResultNotFound,
+		},
+		{
+			response:       internalHTTP.Response{StatusCode: http.StatusConflict, Body: newErrorBody(task.CompletingResultAlreadyCompletedDifferent)},
+			expectedResult: task.CompletingResultAlreadyCompletedDifferent,
+		},
+		{
+			response:       internalHTTP.Response{StatusCode: http.StatusConflict, Body: newErrorBody(task.CompletingResultExpired)},
+			expectedResult: task.CompletingResultExpired,
+		},
+		{
+			response:       internalHTTP.Response{StatusCode: http.StatusConflict, Attempts: 2, Body: newErrorBody(task.CompletingResultExpired)},
+			expectedResult: task.CompletingResultExpired,
+		},
+		{
+			response:       internalHTTP.Response{StatusCode: http.StatusConflict, Body: internalHTTP.TaskExpiredMessage},
+			expectedResult: task.CompletingResultConflict,
+		},
+		{
+			response:       internalHTTP.Response{StatusCode: http.StatusConflict, Body: newErrorBody(task.CompletingResultCompleted)},
+			expectedResult: task.CompletingResultConflict,
+		},
+		{
+			response:       internalHTTP.Response{StatusCode: http.StatusOK, Attempts: 2},
+			expectedResult: task.CompletingResultCompleted,
+		},
+		{
+			response:       internalHTTP.Response{StatusCode: http.StatusGone, Body: newErrorBody(task.CompletingResultProcessDeadlineExceeded)},
+			expectedResult: task.CompletingResultProcessDeadlineExceeded,
+		},
+	}
+	for _, testCase := range testCases {
+		completerAndMocks := newTaskCompleterWithMocks()
+		completerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, mock.Anything).Return(testCase.response, nil).Once()
+
+		completion, err := completerAndMocks.taskCompleter.Complete(context.Background(), completeRequest)
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expectedResult, completion)
+		completerAndMocks.requestExecutor.AssertExpectations(t)
+	}
+}
+
+func TestTaskCompleter_Complete_ProcessSealed(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	taskCompletion := internalHTTP.Completion{State: internalHTTP.CompletionStateCompleted}
+	procID := "1"
+	taskID := "2"
+	completerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
+		Method:       internalHTTP.MethodPut,
+		ResourcePath: internalHTTP.ResourcePathTaskCompletion,
+		Body:         taskCompletion.JSON(),
+		PathParameters: map[internalHTTP.PathParameter]string{
+			internalHTTP.PathParameterProcessID: procID,
+			internalHTTP.PathParameterTaskID:    taskID,
+		},
+	}).Return(internalHTTP.Response{
+		StatusCode: http.StatusGone,
+		Body:       newErrorBody(task.CompletingResultProcessSealed),
+	}, nil)
+
+	completion, err := completerAndMocks.taskCompleter.Complete(context.Background(), task.CompleteRequest{
+		ID: task.ID{
+			ProcessID: procID,
+			TaskID:    taskID,
+		},
+		State: task.StateFinished,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.CompletingResultProcessSealed, completion)
+}
+
 func TestTaskCompleter_Complete_UnexpectedTaskState(t *testing.T) {
 	completerAndMocks := newTaskCompleterWithMocks()
 	taskCompletion := internalHTTP.Completion{
@@ -102,7 +195,7 @@ func TestTaskCompleter_Complete_UnexpectedTaskState(t *testing.T) {
 	}
 	procID := "1"
 	taskID := "2"
-	completerAndMocks.requestExecutor.On("ExecuteRequest", internalHTTP.Request{
+	completerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
 		Method:       internalHTTP.MethodPut,
 		ResourcePath: internalHTTP.ResourcePathTaskCompletion,
 		Body:         taskCompletion.JSON(),
@@ -117,7 +210,7 @@ func TestTaskCompleter_Complete_UnexpectedTaskState(t *testing.T) {
 		},
 	}, nil)
 
-	_, err := completerAndMocks.taskCompleter.Complete(task.CompleteRequest{
+	_, err := completerAndMocks.taskCompleter.Complete(context.Background(), task.CompleteRequest{
 		ID: task.ID{
 			ProcessID: procID,
 			TaskID:    taskID,
@@ -136,7 +229,7 @@ func TestTaskCompleter_Complete_ExecutorError(t *testing.T) {
 	}
 	procID := "1"
 	taskID := "2"
-	completerAndMocks.requestExecutor.On("ExecuteRequest", internalHTTP.Request{
+	completerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
 		Method:       internalHTTP.MethodPut,
 		ResourcePath: internalHTTP.ResourcePathTaskCompletion,
 		Body:         taskCompletion.JSON(),
@@ -151,7 +244,7 @@ func TestTaskCompleter_Complete_ExecutorError(t *testing.T) {
 		},
 	}, errors.New("error"))
 
-	_, err := completerAndMocks.taskCompleter.Complete(task.CompleteRequest{
+	_, err := completerAndMocks.taskCompleter.Complete(context.Background(), task.CompleteRequest{
 		ID: task.ID{
 			ProcessID: procID,
 			TaskID:    taskID,
@@ -167,7 +260,7 @@ func TestTaskCompleter_Complete_InvalidCompletionStateRequested(t *testing.T) {
 	procID := "1"
 	taskID := "2"
 
-	_, err := completerAndMocks.taskCompleter.Complete(task.CompleteRequest{
+	_, err := completerAndMocks.taskCompleter.Complete(context.Background(), task.CompleteRequest{
 		ID: task.ID{
 			ProcessID: procID,
 			TaskID:    taskID,
@@ -177,3 +270,187 @@ func TestTaskCompleter_Complete_InvalidCompletionStateRequested(t *testing.T) {
 	})
 	assert.Error(t, err)
 }
+
+func TestTaskCompleter_CompleteWithChildren(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	procID := "1"
+	taskID := "2"
+	childExpirationTime := time.Now().Add(time.Hour)
+	completion := internalHTTP.CompletionWithChildren{
+		Completion: internalHTTP.Completion{State: internalHTTP.CompletionStateCompleted},
+		Children:   []internalHTTP.ChildTask{{TaskID: "3", ExpirationTime: childExpirationTime}},
+	}
+	completerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
+		Method:       internalHTTP.MethodPut,
+		ResourcePath: internalHTTP.ResourcePathTaskCompletionWithChildren,
+		Body:         completion.JSON(),
+		PathParameters: map[internalHTTP.PathParameter]string{
+			internalHTTP.PathParameterProcessID: procID,
+			internalHTTP.PathParameterTaskID:    taskID,
+		},
+	}).Return(internalHTTP.Response{
+		StatusCode: http.StatusConflict,
+		Body:       newErrorBody(task.CompletingResultChildConflict),
+	}, nil)
+
+	completingResult, err := completerAndMocks.taskCompleter.CompleteWithChildren(context.Background(), task.CompleteWithChildrenRequest{
+		CompleteRequest: task.CompleteRequest{
+			ID:    task.ID{ProcessID: procID, TaskID: taskID},
+			State: task.StateFinished,
+		},
+		Children: []task.RegistrationData{
+			{ID: task.ID{ProcessID: procID, TaskID: "3"}, ExpirationTime: childExpirationTime},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, task.CompletingResultChildConflict, completingResult)
+}
+
+func TestTaskCompleter_CompleteWithChildren_ChildFromOtherProcess(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+
+	_, err := completerAndMocks.taskCompleter.CompleteWithChildren(context.Background(), task.CompleteWithChildrenRequest{
+		CompleteRequest: task.CompleteRequest{
+			ID:    task.ID{ProcessID: "1", TaskID: "2"},
+			State: task.StateFinished,
+		},
+		Children: []task.RegistrationData{
+			{ID: task.ID{ProcessID: "other", TaskID: "3"}, ExpirationTime: time.Now().Add(time.Hour)},
+		},
+	})
+	assert.Error(t, err)
+}
+
+func TestTaskCompleter_Complete_ConflictOnRetriedAttempt(t *testing.T) {
+	completeRequest := task.CompleteRequest{
+		ID:      task.ID{ProcessID: "1", TaskID: "2"},
+		State:   task.StateAborted,
+		Message: aws.String("error"),
+	}
+	for completedTask, expectedResult := range map[*task.Task]task.CompletingResult{
+		{ID: completeRequest.ID, State: task.StateAborted, StateMessage: aws.String("error")}: task.CompletingResultCompleted,
+		{ID: completeRequest.ID, State: task.StateAborted, StateMessage: aws.String("other")}: task.CompletingResultAlreadyCompletedDifferent,
+		{ID: completeRequest.ID, State: task.StateFinished}:                                   task.CompletingResultAlreadyCompletedDifferent,
+	} {
+		completerAndMocks := newTaskCompleterWithMocks()
+		completerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything,
+			mock.MatchedBy(func(request internalHTTP.Request) bool {
+				return request.Method == internalHTTP.MethodPut
+			})).Return(internalHTTP.Response{StatusCode: http.StatusConflict, Attempts: 3}, nil).Once()
+		completerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
+			Method:       internalHTTP.MethodGet,
+			ResourcePath: internalHTTP.ResourcePathTask,
+			PathParameters: map[internalHTTP.PathParameter]string{
+				internalHTTP.PathParameterProcessID: completeRequest.ProcessID,
+				internalHTTP.PathParameterTaskID:    completeRequest.TaskID,
+			},
+		}).Return(internalHTTP.Response{
+			StatusCode: http.StatusOK,
+			Body:       internalHTTP.ConvertInternalToHTTPTaskDetails(*completedTask).JSON(),
+		}, nil).Once()
+
+		completion, err := completerAndMocks.taskCompleter.Complete(context.Background(), completeRequest)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, completion)
+		completerAndMocks.requestExecutor.AssertExpectations(t)
+	}
+}
+
+func TestTaskCompleter_CompleteWithChildren_ConflictOnRetriedAttempt(t *testing.T) {
+	completeRequest := task.CompleteRequest{
+		ID:    task.ID{ProcessID: "1", TaskID: "2"},
+		State: task.StateFinished,
+	}
+	completerAndMocks := newTaskCompleterWithMocks()
+	completerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything,
+		mock.MatchedBy(func(request internalHTTP.Request) bool {
+			return request.Method == internalHTTP.MethodPut
+		})).Return(internalHTTP.Response{StatusCode: http.StatusConflict, Attempts: 2}, nil).Once()
+	completerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything,
+		mock.MatchedBy(func(request internalHTTP.Request) bool {
+			return request.Method == internalHTTP.MethodGet
+		})).Return(internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body: internalHTTP.ConvertInternalToHTTPTaskDetails(task.Task{
+			ID:    completeRequest.ID,
+			State: task.StateFinished,
+		}).JSON(),
+	}, nil).Once()
+
+	completion, err := completerAndMocks.taskCompleter.CompleteWithChildren(context.Background(),
+		task.CompleteWithChildrenRequest{CompleteRequest: completeRequest})
+	assert.NoError(t, err)
+	assert.Equal(t, task.CompletingResultCompleted, completion)
+	completerAndMocks.requestExecutor.AssertExpectations(t)
+}
+
+func TestTaskCompleter_BatchComplete(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	request := task.BatchCompleteRequest{
+		ProcessID: "1",
+		Tasks: []task.CompleteRequest{
+			{ID: task.ID{ProcessID: "1", TaskID: "2"}, State: task.StateFinished},
+			{ID: task.ID{ProcessID: "1", TaskID: "3"}, State: task.StateAborted, Message: aws.String("error")},
+		},
+		Transactional: true,
+	}
+	results := []task.BatchCompletingResult{
+		{TaskID: "2", Result: task.CompletingResultCompleted},
+		{TaskID: "3", Result: task.CompletingResultAlreadyCompletedSame},
+	}
+	completerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
+		Method:       internalHTTP.MethodPut,
+		ResourcePath: internalHTTP.ResourcePathCompletions,
+		Body: internalHTTP.BatchCompletions{
+			{TaskID: "2", Completion: internalHTTP.Completion{State: internalHTTP.CompletionStateCompleted}},
+			{TaskID: "3", Completion: internalHTTP.Completion{
+				State:        internalHTTP.CompletionStateError,
+				ErrorMessage: aws.String("error"),
+			}},
+		}.JSON(),
+		PathParameters: map[internalHTTP.PathParameter]string{
+			internalHTTP.PathParameterProcessID: request.ProcessID,
+		},
+		QueryParameters: map[internalHTTP.QueryParameter]string{
+			internalHTTP.QueryParameterTransactional: "true",
+		},
+	}).Return(internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body:       internalHTTP.ConvertInternalToHTTPBatchCompletingResults(results).JSON(),
+	}, nil)
+
+	completingResults, err := completerAndMocks.taskCompleter.BatchComplete(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, results, completingResults)
+	completerAndMocks.requestExecutor.AssertExpectations(t)
+}
+
+func TestTaskCompleter_BatchComplete_RetriedAttempt(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+	completerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, mock.Anything).Return(internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body: internalHTTP.ConvertInternalToHTTPBatchCompletingResults([]task.BatchCompletingResult{
+			{TaskID: "2", Result: task.CompletingResultAlreadyCompletedSame},
+		}).JSON(),
+		Attempts: 2,
+	}, nil)
+
+	completingResults, err := completerAndMocks.taskCompleter.BatchComplete(context.Background(), task.BatchCompleteRequest{
+		ProcessID: "1",
+		Tasks:     []task.CompleteRequest{{ID: task.ID{ProcessID: "1", TaskID: "2"}, State: task.StateFinished}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []task.BatchCompletingResult{{TaskID: "2", Result: task.CompletingResultCompleted}},
+		completingResults)
+}
+
+func TestTaskCompleter_BatchComplete_TaskFromOtherProcess(t *testing.T) {
+	completerAndMocks := newTaskCompleterWithMocks()
+
+	_, err := completerAndMocks.taskCompleter.BatchComplete(context.Background(), task.BatchCompleteRequest{
+		ProcessID: "1",
+		Tasks:     []task.CompleteRequest{{ID: task.ID{ProcessID: "2", TaskID: "2"}, State: task.StateFinished}},
+	})
+	assert.Error(t, err)
+	completerAndMocks.requestExecutor.AssertNotCalled(t, "ExecuteRequest", mock.Anything, mock.Anything)
+}
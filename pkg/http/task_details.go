@@ -0,0 +1,92 @@
+package http
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/pkg/errors"
+)
+
+const InvalidTasksListCursorMessage = "invalid tasks list cursor"
+
+type TaskDetails struct {
+	TaskID         string     `json:"taskId"`
+	State          task.State `json:"state"`
+	StateMessage   *string    `json:"stateMessage,omitempty"`
+	ExpirationTime time.Time  `json:"expirationTime"`
+	CreationTime   *time.Time `json:"creationTime,omitempty"`
+	TimedOut       bool       `json:"timedOut"`
+}
+
+func (taskDetails TaskDetails) JSON() string {
+	marshalled, err := json.Marshal(taskDetails)
+	if err != nil {
+		panic(errors.Wrapf(err, "failed to marshal task details: %+v", taskDetails))
+	}
+	return string(marshalled)
+}
+
+func (taskDetails TaskDetails) internalTask(processID string) task.Task {
+	internalTask := task.Task{
+		ID:             task.ID{ProcessID: processID, TaskID: taskDetails.TaskID},
+		State:          taskDetails.State,
+		StateMessage:   taskDetails.StateMessage,
+		ExpirationTime: taskDetails.ExpirationTime,
+		TimedOut:       taskDetails.TimedOut,
+	}
+	if taskDetails.CreationTime != nil {
+		internalTask.CreationTime = *taskDetails.CreationTime
+	}
+	return internalTask
+}
+
+func ConvertInternalToHTTPTaskDetails(internalTask task.Task) TaskDetails {
+	taskDetails := TaskDetails{
+		TaskID:         internalTask.TaskID,
+		State:          internalTask.State,
+		StateMessage:   internalTask.StateMessage,
+		ExpirationTime: internalTask.ExpirationTime,
+		TimedOut:       internalTask.TimedOut,
+	}
+	if !internalTask.CreationTime.IsZero() {
+		creationTime := internalTask.CreationTime
+		taskDetails.CreationTime = &creationTime
+	}
+	return taskDetails
+}
+
+type TasksList struct {
+	Tasks      []TaskDetails `json:"tasks"`
+	NextCursor string        `json:"nextCursor,omitempty"`
+}
+
+func (tasksList TasksList) JSON() string {
+	marshalled, err := json.Marshal(tasksList)
+	if err != nil {
+		panic(errors.Wrapf(err, "failed to marshal tasks list: %+v", tasksList))
+	}
+	return string(marshalled)
+}
+
+func (tasksList TasksList) internalTasksList(processID string) task.List {
+	internalTasks := make([]task.Task, 0, len(tasksList.Tasks))
+	for _, taskDetails := range tasksList.Tasks {
+		internalTasks = append(internalTasks, taskDetails.internalTask(processID))
+	}
+	return task.List{
+		Tasks:      internalTasks,
+		NextCursor: tasksList.NextCursor,
+	}
+}
+
+func ConvertInternalToHTTPTasksList(tasksList task.List) TasksList {
+	httpTasks := make([]TaskDetails, 0, len(tasksList.Tasks))
+	for _, listedTask := range tasksList.Tasks {
+		httpTasks = append(httpTasks, ConvertInternalToHTTPTaskDetails(listedTask))
+	}
+	return TasksList{
+		Tasks:      httpTasks,
+		NextCursor: tasksList.NextCursor,
+	}
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+type TaskGetter struct {
+	requestExecutor requestExecutor
+}
+
+func NewTaskGetter(requestExecutor requestExecutor) *TaskGetter {
+	return &TaskGetter{
+		requestExecutor: requestExecutor,
+	}
+}
+
+func (getter *TaskGetter) GetTask(ctx context.Context, id task.ID) (*task.Task, error) {
+	response, err := getter.requestExecutor.ExecuteRequest(ctx, Request{
+		Method:         MethodGet,
+		ResourcePath:   ResourcePathTask,
+		PathParameters: buildTaskPathParameters(id),
+	})
+	if err != nil || response.StatusCode == http.StatusNotFound {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected error occurred: %d %s", response.StatusCode, response.Body)
+	}
+
+	var taskDetails TaskDetails
+	if err := json.Unmarshal([]byte(response.Body), &taskDetails); err != nil {
+		return nil, err
+	}
+	foundTask := taskDetails.internalTask(id.ProcessID)
+	return &foundTask, nil
+}
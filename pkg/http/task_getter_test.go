@@ -0,0 +1,94 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type taskGetterWithMocks struct {
+	requestExecutor *requestExecutorMock
+	taskGetter      *internalHTTP.TaskGetter
+	taskID          task.ID
+	request         internalHTTP.Request
+}
+
+func newTaskGetterWithMocks() *taskGetterWithMocks {
+	requestExecutor := new(requestExecutorMock)
+	taskID := task.ID{ProcessID: "1", TaskID: "2"}
+	return &taskGetterWithMocks{
+		requestExecutor: requestExecutor,
+		taskGetter:      internalHTTP.NewTaskGetter(requestExecutor),
+		taskID:          taskID,
+		request: internalHTTP.Request{
+			Method:       internalHTTP.MethodGet,
+			ResourcePath: internalHTTP.ResourcePathTask,
+			PathParameters: map[internalHTTP.PathParameter]string{
+				internalHTTP.PathParameterProcessID: taskID.ProcessID,
+				internalHTTP.PathParameterTaskID:    taskID.TaskID,
+			},
+		},
+	}
+}
+
+func TestTaskGetter_GetTask(t *testing.T) {
+	getterAndMocks := newTaskGetterWithMocks()
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	taskToGet := task.Task{
+		ID:             getterAndMocks.taskID,
+		State:          task.StateAborted,
+		StateMessage:   aws.String("failure"),
+		ExpirationTime: currentTime.Add(time.Hour),
+		CreationTime:   currentTime,
+	}
+	getterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, getterAndMocks.request).Return(internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body:       internalHTTP.ConvertInternalToHTTPTaskDetails(taskToGet).JSON(),
+	}, nil)
+
+	foundTask, err := getterAndMocks.taskGetter.GetTask(context.Background(), getterAndMocks.taskID)
+	assert.NoError(t, err)
+	assert.Equal(t, &taskToGet, foundTask)
+	getterAndMocks.requestExecutor.AssertExpectations(t)
+}
+
+func TestTaskGetter_GetTask_NotFound(t *testing.T) {
+	getterAndMocks := newTaskGetterWithMocks()
+	getterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, getterAndMocks.request).Return(internalHTTP.Response{
+		StatusCode: http.StatusNotFound,
+	}, nil)
+
+	foundTask, err := getterAndMocks.taskGetter.GetTask(context.Background(), getterAndMocks.taskID)
+	assert.NoError(t, err)
+	assert.Nil(t, foundTask)
+	getterAndMocks.requestExecutor.AssertExpectations(t)
+}
+
+func TestTaskGetter_GetTask_UnexpectedStatus(t *testing.T) {
+	getterAndMocks := newTaskGetterWithMocks()
+	getterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, getterAndMocks.request).Return(internalHTTP.Response{
+		StatusCode: http.StatusInternalServerError,
+	}, nil)
+
+	_, err := getterAndMocks.taskGetter.GetTask(context.Background(), getterAndMocks.taskID)
+	assert.Error(t, err)
+	getterAndMocks.requestExecutor.AssertExpectations(t)
+}
+
+func TestTaskGetter_GetTask_RequestError(t *testing.T) {
+	getterAndMocks := newTaskGetterWithMocks()
+	getterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, getterAndMocks.request).
+		Return(internalHTTP.Response{}, errors.New("error"))
+
+	_, err := getterAndMocks.taskGetter.GetTask(context.Background(), getterAndMocks.taskID)
+	assert.Error(t, err)
+	getterAndMocks.requestExecutor.AssertExpectations(t)
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+type TaskHeartbeater struct {
+	requestExecutor requestExecutor
+}
+
+func NewTaskHeartbeater(requestExecutor requestExecutor) *TaskHeartbeater {
+	return &TaskHeartbeater{
+		requestExecutor: requestExecutor,
+	}
+}
+
+func (heartbeater *TaskHeartbeater) Heartbeat(ctx context.Context,
+	request task.HeartbeatRequest) (task.HeartbeatResult, error) {
+	extendedTask := Task{
+		ExpirationTime: request.ExpirationTime,
+	}
+	response, err := heartbeater.requestExecutor.ExecuteRequest(ctx, Request{
+		Method:         MethodPut,
+		ResourcePath:   ResourcePathTaskHeartbeat,
+		Body:           extendedTask.JSON(),
+		PathParameters: buildTaskPathParameters(request.ID),
+	})
+	if err != nil {
+		return "", err
+	}
+	switch response.StatusCode {
+	case http.StatusOK:
+		return task.HeartbeatResultExtended, nil
+	case http.StatusConflict:
+		return task.HeartbeatResultConflict, nil
+	default:
+		return "", fmt.Errorf("unexpected heartbeat result: %d %s", response.StatusCode, response.Body)
+	}
+}
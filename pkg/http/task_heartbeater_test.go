@@ -0,0 +1,81 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type taskHeartbeaterWithMocks struct {
+	requestExecutor  *requestExecutorMock
+	taskHeartbeater  *internalHTTP.TaskHeartbeater
+	heartbeatRequest task.HeartbeatRequest
+	request          internalHTTP.Request
+}
+
+func newTaskHeartbeaterWithMocks() *taskHeartbeaterWithMocks {
+	requestExecutor := new(requestExecutorMock)
+	heartbeatRequest := task.HeartbeatRequest{
+		ID:             task.ID{ProcessID: "1", TaskID: "2"},
+		ExpirationTime: time.Now().Add(time.Hour),
+	}
+	return &taskHeartbeaterWithMocks{
+		requestExecutor:  requestExecutor,
+		taskHeartbeater:  internalHTTP.NewTaskHeartbeater(requestExecutor),
+		heartbeatRequest: heartbeatRequest,
+		request: internalHTTP.Request{
+			Method:       internalHTTP.MethodPut,
+			ResourcePath: internalHTTP.ResourcePathTaskHeartbeat,
+			Body:         internalHTTP.Task{ExpirationTime: heartbeatRequest.ExpirationTime}.JSON(),
+			PathParameters: map[internalHTTP.PathParameter]string{
+				internalHTTP.PathParameterProcessID: heartbeatRequest.ProcessID,
+				internalHTTP.PathParameterTaskID:    heartbeatRequest.TaskID,
+			},
+		},
+	}
+}
+
+func TestTaskHeartbeater_Heartbeat(t *testing.T) {
+	heartbeaterAndMocks := newTaskHeartbeaterWithMocks()
+	heartbeaterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, heartbeaterAndMocks.request).
+		Return(internalHTTP.Response{StatusCode: http.StatusOK}, nil)
+
+	heartbeatResult, err := heartbeaterAndMocks.taskHeartbeater.Heartbeat(context.Background(), heartbeaterAndMocks.heartbeatRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, task.HeartbeatResultExtended, heartbeatResult)
+}
+
+func TestTaskHeartbeater_Heartbeat_Conflict(t *testing.T) {
+	heartbeaterAndMocks := newTaskHeartbeaterWithMocks()
+	heartbeaterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, heartbeaterAndMocks.request).
+		Return(internalHTTP.Response{StatusCode: http.StatusConflict}, nil)
+
+	heartbeatResult, err := heartbeaterAndMocks.taskHeartbeater.Heartbeat(context.Background(), heartbeaterAndMocks.heartbeatRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, task.HeartbeatResultConflict, heartbeatResult)
+}
+
+func TestTaskHeartbeater_Heartbeat_UnexpectedResponseStatus(t *testing.T) {
+	heartbeaterAndMocks := newTaskHeartbeaterWithMocks()
+	heartbeaterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, heartbeaterAndMocks.request).
+		Return(internalHTTP.Response{StatusCode: http.StatusInternalServerError}, nil)
+
+	_, err := heartbeaterAndMocks.taskHeartbeater.Heartbeat(context.Background(), heartbeaterAndMocks.heartbeatRequest)
+	assert.Error(t, err)
+}
+
+func TestTaskHeartbeater_Heartbeat_ExecutorError(t *testing.T) {
+	heartbeaterAndMocks := newTaskHeartbeaterWithMocks()
+	heartbeaterAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, heartbeaterAndMocks.request).
+		Return(internalHTTP.Response{}, errors.New("error"))
+
+	_, err := heartbeaterAndMocks.taskHeartbeater.Heartbeat(context.Background(), heartbeaterAndMocks.heartbeatRequest)
+	assert.Error(t, err)
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+type TaskLister struct {
+	requestExecutor requestExecutor
+}
+
+func NewTaskLister(requestExecutor requestExecutor) *TaskLister {
+	return &TaskLister{
+		requestExecutor: requestExecutor,
+	}
+}
+
+func (lister *TaskLister) List(ctx context.Context, request task.ListRequest) (task.List, error) {
+	response, err := lister.requestExecutor.ExecuteRequest(ctx, Request{
+		Method:       MethodGet,
+		ResourcePath: ResourcePathTasks,
+		PathParameters: map[PathParameter]string{
+			PathParameterProcessID: request.ProcessID,
+		},
+		QueryParameters: buildTasksListQueryParameters(request),
+	})
+	if err != nil {
+		return task.List{}, err
+	}
+	if response.StatusCode == http.StatusBadRequest && response.Body == InvalidTasksListCursorMessage {
+		return task.List{}, task.ErrInvalidCursor
+	}
+	if response.StatusCode != http.StatusOK {
+		return task.List{}, fmt.Errorf("unexpected error occurred: %d %s", response.StatusCode, response.Body)
+	}
+
+	var tasksList TasksList
+	if err := json.Unmarshal([]byte(response.Body), &tasksList); err != nil {
+		return task.List{}, err
+	}
+	return tasksList.internalTasksList(request.ProcessID), nil
+}
+
+func buildTasksListQueryParameters(request task.ListRequest) map[QueryParameter]string {
+	queryParameters := make(map[QueryParameter]string)
+	if request.State != nil {
+		queryParameters[QueryParameterState] = string(*request.State)
+	}
+	if request.Cursor != "" {
+		queryParameters[QueryParameterCursor] = request.Cursor
+	}
+	if request.Limit > 0 {
+		queryParameters[QueryParameterLimit] = strconv.Itoa(request.Limit)
+	}
+	return queryParameters
+}
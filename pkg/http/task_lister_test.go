@@ -0,0 +1,106 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	internalHTTP "github.com/artii15/termination-detector/pkg/http"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type taskListerWithMocks struct {
+	requestExecutor *requestExecutorMock
+	taskLister      *internalHTTP.TaskLister
+	listRequest     task.ListRequest
+	request         internalHTTP.Request
+}
+
+func newTaskListerWithMocks() *taskListerWithMocks {
+	requestExecutor := new(requestExecutorMock)
+	state := task.StateCreated
+	listRequest := task.ListRequest{
+		ProcessID: "1",
+		State:     &state,
+		Cursor:    "cursor",
+		Limit:     10,
+	}
+	return &taskListerWithMocks{
+		requestExecutor: requestExecutor,
+		taskLister:      internalHTTP.NewTaskLister(requestExecutor),
+		listRequest:     listRequest,
+		request: internalHTTP.Request{
+			Method:       internalHTTP.MethodGet,
+			ResourcePath: internalHTTP.ResourcePathTasks,
+			PathParameters: map[internalHTTP.PathParameter]string{
+				internalHTTP.PathParameterProcessID: listRequest.ProcessID,
+			},
+			QueryParameters: map[internalHTTP.QueryParameter]string{
+				internalHTTP.QueryParameterState:  string(state),
+				internalHTTP.QueryParameterCursor: listRequest.Cursor,
+				internalHTTP.QueryParameterLimit:  "10",
+			},
+		},
+	}
+}
+
+func TestTaskLister_List(t *testing.T) {
+	listerAndMocks := newTaskListerWithMocks()
+	expirationTime := time.Now().UTC().Truncate(time.Second)
+	listerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, listerAndMocks.request).Return(internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body: internalHTTP.TasksList{
+			Tasks: []internalHTTP.TaskDetails{
+				{TaskID: "2", State: task.StateCreated, ExpirationTime: expirationTime},
+			},
+			NextCursor: "next",
+		}.JSON(),
+	}, nil)
+
+	tasksList, err := listerAndMocks.taskLister.List(context.Background(), listerAndMocks.listRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, task.List{
+		Tasks: []task.Task{
+			{ID: task.ID{ProcessID: "1", TaskID: "2"}, State: task.StateCreated, ExpirationTime: expirationTime},
+		},
+		NextCursor: "next",
+	}, tasksList)
+	listerAndMocks.requestExecutor.AssertExpectations(t)
+}
+
+func TestTaskLister_List_InvalidCursor(t *testing.T) {
+	listerAndMocks := newTaskListerWithMocks()
+	listerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, listerAndMocks.request).Return(internalHTTP.Response{
+		StatusCode: http.StatusBadRequest,
+		Body:       internalHTTP.InvalidTasksListCursorMessage,
+	}, nil)
+
+	_, err := listerAndMocks.taskLister.List(context.Background(), listerAndMocks.listRequest)
+	assert.Equal(t, task.ErrInvalidCursor, err)
+	listerAndMocks.requestExecutor.AssertExpectations(t)
+}
+
+func TestTaskLister_List_UnexpectedStatus(t *testing.T) {
+	listerAndMocks := newTaskListerWithMocks()
+	listerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, listerAndMocks.request).Return(internalHTTP.Response{
+		StatusCode: http.StatusInternalServerError,
+	}, nil)
+
+	_, err := listerAndMocks.taskLister.List(context.Background(), listerAndMocks.listRequest)
+	assert.Error(t, err)
+	listerAndMocks.requestExecutor.AssertExpectations(t)
+}
+
+func TestTaskLister_List_RequestError(t *testing.T) {
+	listerAndMocks := newTaskListerWithMocks()
+	listerAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, listerAndMocks.request).
+		Return(internalHTTP.Response{}, errors.New("error"))
+
+	_, err := listerAndMocks.taskLister.List(context.Background(), listerAndMocks.listRequest)
+	assert.Error(t, err)
+	listerAndMocks.requestExecutor.AssertExpectations(t)
+}
@@ -1,8 +1,11 @@
 package http
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/artii15/termination-detector/pkg/task"
 )
@@ -17,11 +20,16 @@ func NewTaskRegisterer(requestExecutor requestExecutor) *TaskRegisterer {
 	}
 }
 
-func (registerer *TaskRegisterer) Register(registrationData task.RegistrationData) (task.RegistrationResult, error) {
+func (registerer *TaskRegisterer) Register(ctx context.Context,
+	registrationData task.RegistrationData) (task.RegistrationResult, error) {
 	taskToRegister := Task{
 		ExpirationTime: registrationData.ExpirationTime,
+		CallbackURL:    registrationData.CallbackURL,
 	}
-	response, err := registerer.requestExecutor.ExecuteRequest(Request{
+	if !registrationData.ProcessDeadline.IsZero() {
+		taskToRegister.ProcessDeadline = &registrationData.ProcessDeadline
+	}
+	response, err := registerer.requestExecutor.ExecuteRequest(ctx, Request{
 		Method:       MethodPut,
 		ResourcePath: ResourcePathTask,
 		Body:         taskToRegister.JSON(),
@@ -37,8 +45,81 @@ func (registerer *TaskRegisterer) Register(registrationData task.RegistrationDat
 	case http.StatusCreated:
 		return task.RegistrationResultCreated, nil
 	case http.StatusConflict:
+		if response.Attempts > 1 {
+			return registerer.readRetriedConflictResult(ctx, registrationData)
+		}
 		return task.RegistrationResultAlreadyRegistered, nil
+	case http.StatusGone:
+		if task.RegistrationResult(readErrorResult(response)) == task.RegistrationResultProcessDeadlineExceeded {
+			return task.RegistrationResultProcessDeadlineExceeded, nil
+		}
+		return task.RegistrationResultProcessSealed, nil
 	default:
 		return "", fmt.Errorf("unknown task registration result: %d %s", response.StatusCode, response.Body)
 	}
 }
+
+func (registerer *TaskRegisterer) readRetriedConflictResult(ctx context.Context,
+	registrationData task.RegistrationData) (task.RegistrationResult, error) {
+	registeredTask, err := NewTaskGetter(registerer.requestExecutor).GetTask(ctx, registrationData.ID)
+	if err != nil {
+		return "", err
+	}
+	if registeredTask != nil && registeredTask.State == task.StateCreated &&
+		registeredTask.ExpirationTime.Truncate(time.Second).Equal(registrationData.ExpirationTime.Truncate(time.Second)) {
+		return task.RegistrationResultCreated, nil
+	}
+	return task.RegistrationResultAlreadyRegistered, nil
+}
+
+func (registerer *TaskRegisterer) BatchRegister(ctx context.Context,
+	request task.BatchRegistrationRequest) ([]task.BatchRegistrationResult, error) {
+	batchTasks := make(BatchTasks, 0, len(request.Tasks))
+	for _, registrationData := range request.Tasks {
+		batchTasks = append(batchTasks, BatchTask{
+			TaskID:         registrationData.ID.TaskID,
+			ExpirationTime: registrationData.ExpirationTime,
+		})
+	}
+	response, err := registerer.requestExecutor.ExecuteRequest(ctx, Request{
+		Method:       MethodPut,
+		ResourcePath: ResourcePathTasks,
+		Body:         batchTasks.JSON(),
+		PathParameters: map[PathParameter]string{
+			PathParameterProcessID: request.ProcessID,
+		},
+		QueryParameters: map[QueryParameter]string{
+			QueryParameterTransactional: strconv.FormatBool(request.Transactional),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unknown batch task registration result: %d %s", response.StatusCode, response.Body)
+	}
+	batchRegistrationResults, err := UnmarshalBatchRegistrationResults(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	results := batchRegistrationResults.internalBatchRegistrationResults()
+	if response.Attempts > 1 {
+		return registerer.readRetriedBatchResults(ctx, request, results)
+	}
+	return results, nil
+}
+
+func (registerer *TaskRegisterer) readRetriedBatchResults(ctx context.Context, request task.BatchRegistrationRequest,
+	results []task.BatchRegistrationResult) ([]task.BatchRegistrationResult, error) {
+	for index, registrationData := range request.Tasks {
+		if index >= len(results) || results[index].Result != task.RegistrationResultAlreadyRegistered {
+			continue
+		}
+		retriedResult, err := registerer.readRetriedConflictResult(ctx, registrationData)
+		if err != nil {
+			return nil, err
+		}
+		results[index].Result = retriedResult
+	}
+	return results, nil
+}
@@ -1,6 +1,7 @@
 package http_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	internalHTTP "github.com/artii15/termination-detector/pkg/http"
 	"github.com/artii15/termination-detector/pkg/task"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 type taskRegistererWithMocks struct {
@@ -36,7 +38,7 @@ func TestTaskRegisterer_Register(t *testing.T) {
 		},
 		ExpirationTime: taskExpirationTime,
 	}
-	taskRegistererAndMocks.requestExecutor.On("ExecuteRequest", internalHTTP.Request{
+	taskRegistererAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
 		Method:       internalHTTP.MethodPut,
 		ResourcePath: internalHTTP.ResourcePathTask,
 		Body:         taskToRegister.JSON(),
@@ -48,7 +50,7 @@ func TestTaskRegisterer_Register(t *testing.T) {
 		StatusCode: http.StatusCreated,
 	}, nil)
 
-	registrationStatus, err := taskRegistererAndMocks.taskRegisterer.Register(taskRegistrationData)
+	registrationStatus, err := taskRegistererAndMocks.taskRegisterer.Register(context.Background(), taskRegistrationData)
 	assert.NoError(t, err)
 	assert.Equal(t, task.RegistrationResultCreated, registrationStatus)
 }
@@ -64,7 +66,7 @@ func TestTaskRegisterer_Register_TaskAlreadyRegistered(t *testing.T) {
 		},
 		ExpirationTime: taskExpirationTime,
 	}
-	taskRegistererAndMocks.requestExecutor.On("ExecuteRequest", internalHTTP.Request{
+	taskRegistererAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
 		Method:       internalHTTP.MethodPut,
 		ResourcePath: internalHTTP.ResourcePathTask,
 		Body:         taskToRegister.JSON(),
@@ -76,11 +78,77 @@ func TestTaskRegisterer_Register_TaskAlreadyRegistered(t *testing.T) {
 		StatusCode: http.StatusConflict,
 	}, nil)
 
-	registrationStatus, err := taskRegistererAndMocks.taskRegisterer.Register(taskRegistrationData)
+	registrationStatus, err := taskRegistererAndMocks.taskRegisterer.Register(context.Background(), taskRegistrationData)
 	assert.NoError(t, err)
 	assert.Equal(t, task.RegistrationResultAlreadyRegistered, registrationStatus)
 }
 
+func TestTaskRegisterer_Register_ProcessSealed(t *testing.T) {
+	taskRegistererAndMocks := newTaskRegistererWithMocks()
+	taskExpirationTime := time.Now().Add(time.Hour)
+	taskToRegister := internalHTTP.Task{ExpirationTime: taskExpirationTime}
+	taskRegistrationData := task.RegistrationData{
+		ID: task.ID{
+			ProcessID: "1",
+			TaskID:    "2",
+		},
+		ExpirationTime: taskExpirationTime,
+	}
+	taskRegistererAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
+		Method:       internalHTTP.MethodPut,
+		ResourcePath: internalHTTP.ResourcePathTask,
+		Body:         taskToRegister.JSON(),
+		PathParameters: map[internalHTTP.PathParameter]string{
+			internalHTTP.PathParameterProcessID: taskRegistrationData.ID.ProcessID,
+			internalHTTP.PathParameterTaskID:    taskRegistrationData.ID.TaskID,
+		},
+	}).Return(internalHTTP.Response{
+		StatusCode: http.StatusGone,
+		Body: internalHTTP.ErrorBody{
+			Result:  string(task.RegistrationResultProcessSealed),
+			Message: internalHTTP.ProcessSealedMessage,
+		}.JSON(),
+	}, nil)
+
+	registrationStatus, err := taskRegistererAndMocks.taskRegisterer.Register(context.Background(), taskRegistrationData)
+	assert.NoError(t, err)
+	assert.Equal(t, task.RegistrationResultProcessSealed, registrationStatus)
+}
+
+func TestTaskRegisterer_Register_ProcessDeadlineExceeded(t *testing.T) {
+	taskRegistererAndMocks := newTaskRegistererWithMocks()
+	taskExpirationTime := time.Now().Add(time.Hour)
+	processDeadline := taskExpirationTime.Add(time.Hour)
+	taskToRegister := internalHTTP.Task{ExpirationTime: taskExpirationTime, ProcessDeadline: &processDeadline}
+	taskRegistrationData := task.RegistrationData{
+		ID: task.ID{
+			ProcessID: "1",
+			TaskID:    "2",
+		},
+		ExpirationTime:  taskExpirationTime,
+		ProcessDeadline: processDeadline,
+	}
+	taskRegistererAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
+		Method:       internalHTTP.MethodPut,
+		ResourcePath: internalHTTP.ResourcePathTask,
+		Body:         taskToRegister.JSON(),
+		PathParameters: map[internalHTTP.PathParameter]string{
+			internalHTTP.PathParameterProcessID: taskRegistrationData.ID.ProcessID,
+			internalHTTP.PathParameterTaskID:    taskRegistrationData.ID.TaskID,
+		},
+	}).Return(internalHTTP.Response{
+		StatusCode: http.StatusGone,
+		Body: internalHTTP.ErrorBody{
+			Result:  string(task.RegistrationResultProcessDeadlineExceeded),
+			Message: internalHTTP.ProcessDeadlineExceededMessage,
+		}.JSON(),
+	}, nil)
+
+	registrationStatus, err := taskRegistererAndMocks.taskRegisterer.Register(context.Background(), taskRegistrationData)
+	assert.NoError(t, err)
+	assert.Equal(t, task.RegistrationResultProcessDeadlineExceeded, registrationStatus)
+}
+
 func TestTaskRegisterer_Register_UnexpectedResponseStatus(t *testing.T) {
 	taskRegistererAndMocks := newTaskRegistererWithMocks()
 	taskExpirationTime := time.Now().Add(time.Hour)
@@ -92,7 +160,7 @@ func TestTaskRegisterer_Register_UnexpectedResponseStatus(t *testing.T) {
 		},
 		ExpirationTime: taskExpirationTime,
 	}
-	taskRegistererAndMocks.requestExecutor.On("ExecuteRequest", internalHTTP.Request{
+	taskRegistererAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
 		Method:       internalHTTP.MethodPut,
 		ResourcePath: internalHTTP.ResourcePathTask,
 		Body:         taskToRegister.JSON(),
@@ -104,7 +172,7 @@ func TestTaskRegisterer_Register_UnexpectedResponseStatus(t *testing.T) {
 		StatusCode: http.StatusInternalServerError,
 	}, nil)
 
-	_, err := taskRegistererAndMocks.taskRegisterer.Register(taskRegistrationData)
+	_, err := taskRegistererAndMocks.taskRegisterer.Register(context.Background(), taskRegistrationData)
 	assert.Error(t, err)
 }
 
@@ -119,7 +187,7 @@ func TestTaskRegisterer_Register_ExecutorError(t *testing.T) {
 		},
 		ExpirationTime: taskExpirationTime,
 	}
-	taskRegistererAndMocks.requestExecutor.On("ExecuteRequest", internalHTTP.Request{
+	taskRegistererAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
 		Method:       internalHTTP.MethodPut,
 		ResourcePath: internalHTTP.ResourcePathTask,
 		Body:         taskToRegister.JSON(),
@@ -129,6 +197,133 @@ func TestTaskRegisterer_Register_ExecutorError(t *testing.T) {
 		},
 	}).Return(internalHTTP.Response{}, errors.New("error"))
 
-	_, err := taskRegistererAndMocks.taskRegisterer.Register(taskRegistrationData)
+	_, err := taskRegistererAndMocks.taskRegisterer.Register(context.Background(), taskRegistrationData)
+	assert.Error(t, err)
+}
+
+func TestTaskRegisterer_Register_ConflictOnRetriedAttempt(t *testing.T) {
+	taskExpirationTime := time.Now().UTC().Add(time.Hour)
+	taskRegistrationData := task.RegistrationData{
+		ID:             task.ID{ProcessID: "1", TaskID: "2"},
+		ExpirationTime: taskExpirationTime,
+	}
+	registeredTask := task.Task{ID: taskRegistrationData.ID, State: task.StateCreated,
+		ExpirationTime: taskExpirationTime.Truncate(time.Second)}
+	extendedTask := registeredTask
+	extendedTask.ExpirationTime = taskExpirationTime.Add(time.Minute)
+	finishedTask := registeredTask
+	finishedTask.State = task.StateFinished
+	for registeredTask, expectedResult := range map[task.Task]task.RegistrationResult{
+		registeredTask: task.RegistrationResultCreated,
+		extendedTask:   task.RegistrationResultAlreadyRegistered,
+		finishedTask:   task.RegistrationResultAlreadyRegistered,
+	} {
+		taskRegistererAndMocks := newTaskRegistererWithMocks()
+		taskRegistererAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything,
+			mock.MatchedBy(func(request internalHTTP.Request) bool {
+				return request.Method == internalHTTP.MethodPut
+			})).Return(internalHTTP.Response{StatusCode: http.StatusConflict, Attempts: 2}, nil).Once()
+		taskRegistererAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
+			Method:       internalHTTP.MethodGet,
+			ResourcePath: internalHTTP.ResourcePathTask,
+			PathParameters: map[internalHTTP.PathParameter]string{
+				internalHTTP.PathParameterProcessID: taskRegistrationData.ID.ProcessID,
+				internalHTTP.PathParameterTaskID:    taskRegistrationData.ID.TaskID,
+			},
+		}).Return(internalHTTP.Response{
+			StatusCode: http.StatusOK,
+			Body:       internalHTTP.ConvertInternalToHTTPTaskDetails(registeredTask).JSON(),
+		}, nil).Once()
+
+		registrationStatus, err := taskRegistererAndMocks.taskRegisterer.Register(context.Background(), taskRegistrationData)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, registrationStatus)
+		taskRegistererAndMocks.requestExecutor.AssertExpectations(t)
+	}
+}
+
+func TestTaskRegisterer_BatchRegister(t *testing.T) {
+	taskRegistererAndMocks := newTaskRegistererWithMocks()
+	taskExpirationTime := time.Now().UTC().Add(time.Hour)
+	request := task.BatchRegistrationRequest{
+		ProcessID: "1",
+		Tasks: []task.RegistrationData{
+			{ID: task.ID{ProcessID: "1", TaskID: "2"}, ExpirationTime: taskExpirationTime},
+			{ID: task.ID{ProcessID: "1", TaskID: "3"}, ExpirationTime: taskExpirationTime},
+		},
+		Transactional: true,
+	}
+	results := []task.BatchRegistrationResult{
+		{TaskID: "2", Result: task.RegistrationResultCanceled},
+		{TaskID: "3", Result: task.RegistrationResultAlreadyRegistered},
+	}
+	taskRegistererAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
+		Method:       internalHTTP.MethodPut,
+		ResourcePath: internalHTTP.ResourcePathTasks,
+		Body: internalHTTP.BatchTasks{
+			{TaskID: "2", ExpirationTime: taskExpirationTime},
+			{TaskID: "3", ExpirationTime: taskExpirationTime},
+		}.JSON(),
+		PathParameters: map[internalHTTP.PathParameter]string{
+			internalHTTP.PathParameterProcessID: request.ProcessID,
+		},
+		QueryParameters: map[internalHTTP.QueryParameter]string{
+			internalHTTP.QueryParameterTransactional: "true",
+		},
+	}).Return(internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body:       internalHTTP.ConvertInternalToHTTPBatchRegistrationResults(results).JSON(),
+	}, nil)
+
+	registrationResults, err := taskRegistererAndMocks.taskRegisterer.BatchRegister(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, results, registrationResults)
+	taskRegistererAndMocks.requestExecutor.AssertExpectations(t)
+}
+
+func TestTaskRegisterer_BatchRegister_RetriedAttempt(t *testing.T) {
+	taskRegistererAndMocks := newTaskRegistererWithMocks()
+	taskExpirationTime := time.Now().UTC().Add(time.Hour)
+	registrationData := task.RegistrationData{ID: task.ID{ProcessID: "1", TaskID: "2"}, ExpirationTime: taskExpirationTime}
+	taskRegistererAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything,
+		mock.MatchedBy(func(request internalHTTP.Request) bool {
+			return request.Method == internalHTTP.MethodPut
+		})).Return(internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body: internalHTTP.ConvertInternalToHTTPBatchRegistrationResults([]task.BatchRegistrationResult{
+			{TaskID: "2", Result: task.RegistrationResultAlreadyRegistered},
+		}).JSON(),
+		Attempts: 2,
+	}, nil).Once()
+	taskRegistererAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, internalHTTP.Request{
+		Method:       internalHTTP.MethodGet,
+		ResourcePath: internalHTTP.ResourcePathTask,
+		PathParameters: map[internalHTTP.PathParameter]string{
+			internalHTTP.PathParameterProcessID: registrationData.ID.ProcessID,
+			internalHTTP.PathParameterTaskID:    registrationData.ID.TaskID,
+		},
+	}).Return(internalHTTP.Response{
+		StatusCode: http.StatusOK,
+		Body: internalHTTP.ConvertInternalToHTTPTaskDetails(task.Task{ID: registrationData.ID, State: task.StateCreated,
+			ExpirationTime: taskExpirationTime.Truncate(time.Second)}).JSON(),
+	}, nil).Once()
+
+	registrationResults, err := taskRegistererAndMocks.taskRegisterer.BatchRegister(context.Background(),
+		task.BatchRegistrationRequest{ProcessID: "1", Tasks: []task.RegistrationData{registrationData}})
+	assert.NoError(t, err)
+	assert.Equal(t, []task.BatchRegistrationResult{{TaskID: "2", Result: task.RegistrationResultCreated}},
+		registrationResults)
+	taskRegistererAndMocks.requestExecutor.AssertExpectations(t)
+}
+
+func TestTaskRegisterer_BatchRegister_UnexpectedResponseStatus(t *testing.T) {
+	taskRegistererAndMocks := newTaskRegistererWithMocks()
+	taskRegistererAndMocks.requestExecutor.On("ExecuteRequest", mock.Anything, mock.Anything).
+		Return(internalHTTP.Response{StatusCode: http.StatusBadRequest}, nil)
+
+	_, err := taskRegistererAndMocks.taskRegisterer.BatchRegister(context.Background(), task.BatchRegistrationRequest{
+		ProcessID: "1",
+		Tasks:     []task.RegistrationData{{ID: task.ID{ProcessID: "1", TaskID: "2"}}},
+	})
 	assert.Error(t, err)
 }
@@ -1,12 +1,16 @@
 package lambda
 
 import (
+	"context"
+
 	"github.com/artii15/termination-detector/pkg/http"
 	"github.com/aws/aws-lambda-go/events"
 )
 
+const authorizerPrincipalIDKey = "principalId"
+
 type router interface {
-	Route(request http.Request) http.Response
+	Route(ctx context.Context, request http.Request) http.Response
 }
 
 type APIGatewayEventHandler struct {
@@ -17,14 +21,17 @@ func NewAPIGatewayEventHandler(router router) *APIGatewayEventHandler {
 	return &APIGatewayEventHandler{router: router}
 }
 
-func (handler *APIGatewayEventHandler) Handle(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+func (handler *APIGatewayEventHandler) Handle(ctx context.Context,
+	request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	routerRequest := http.Request{
-		Method:         http.Method(request.HTTPMethod),
-		ResourcePath:   http.ResourcePath(request.Resource),
-		Body:           request.Body,
-		PathParameters: readPathParameters(request.PathParameters),
+		Method:          http.Method(request.HTTPMethod),
+		ResourcePath:    http.ResourcePath(request.Resource),
+		Body:            request.Body,
+		PathParameters:  readPathParameters(request.PathParameters),
+		QueryParameters: readQueryParameters(request.QueryStringParameters),
+		Principal:       readPrincipal(request.RequestContext),
 	}
-	response := handler.router.Route(routerRequest)
+	response := handler.router.Route(ctx, routerRequest)
 	return events.APIGatewayProxyResponse{
 		StatusCode: response.StatusCode,
 		Headers:    response.Headers,
@@ -32,6 +39,17 @@ func (handler *APIGatewayEventHandler) Handle(request events.APIGatewayProxyRequ
 	}, nil
 }
 
+func readPrincipal(requestContext events.APIGatewayProxyRequestContext) *string {
+	if requestContext.Identity.UserArn != "" {
+		userARN := requestContext.Identity.UserArn
+		return &userARN
+	}
+	if principalID, isDefined := requestContext.Authorizer[authorizerPrincipalIDKey].(string); isDefined && principalID != "" {
+		return &principalID
+	}
+	return nil
+}
+
 func readPathParameters(parameters map[string]string) map[http.PathParameter]string {
 	pathParameters := make(map[http.PathParameter]string)
 	for parameterName, parameterValue := range parameters {
@@ -39,3 +57,14 @@ func readPathParameters(parameters map[string]string) map[http.PathParameter]str
 	}
 	return pathParameters
 }
+
+func readQueryParameters(parameters map[string]string) map[http.QueryParameter]string {
+	if len(parameters) == 0 {
+		return nil
+	}
+	queryParameters := make(map[http.QueryParameter]string)
+	for parameterName, parameterValue := range parameters {
+		queryParameters[http.QueryParameter(parameterName)] = parameterValue
+	}
+	return queryParameters
+}
@@ -1,6 +1,7 @@
 package lambda_test
 
 import (
+	"context"
 	"net/http"
 	"testing"
 	"time"
@@ -16,8 +17,8 @@ type routerMock struct {
 	mock.Mock
 }
 
-func (router *routerMock) Route(request internalHTTP.Request) internalHTTP.Response {
-	return router.Called(request).Get(0).(internalHTTP.Response)
+func (router *routerMock) Route(ctx context.Context, request internalHTTP.Request) internalHTTP.Response {
+	return router.Called(ctx, request).Get(0).(internalHTTP.Response)
 }
 
 type apiGatewayEventHandlerWithMocks struct {
@@ -50,7 +51,7 @@ func TestAPIGatewayEventHandler_Handle(t *testing.T) {
 			internalHTTP.ContentTypeHeaderName: internalHTTP.ContentTypeApplicationJSON,
 		},
 	}
-	handlerAndMocks.router.On("Route", internalHTTP.Request{
+	handlerAndMocks.router.On("Route", mock.Anything, internalHTTP.Request{
 		Method:       method,
 		ResourcePath: resource,
 		Body:         requestBody,
@@ -58,15 +59,21 @@ func TestAPIGatewayEventHandler_Handle(t *testing.T) {
 			internalHTTP.PathParameterProcessID: procID,
 			internalHTTP.PathParameterTaskID:    taskID,
 		},
+		QueryParameters: map[internalHTTP.QueryParameter]string{
+			internalHTTP.QueryParameterLimit: "10",
+		},
 	}).Return(responseFromRouter)
 
-	response, err := handlerAndMocks.handler.Handle(events.APIGatewayProxyRequest{
+	response, err := handlerAndMocks.handler.Handle(context.Background(), events.APIGatewayProxyRequest{
 		Resource:   string(resource),
 		HTTPMethod: string(method),
 		PathParameters: map[string]string{
 			string(internalHTTP.PathParameterProcessID): procID,
 			string(internalHTTP.PathParameterTaskID):    taskID,
 		},
+		QueryStringParameters: map[string]string{
+			string(internalHTTP.QueryParameterLimit): "10",
+		},
 		Body: requestBody,
 	})
 	assert.NoError(t, err)
@@ -78,3 +85,48 @@ func TestAPIGatewayEventHandler_Handle(t *testing.T) {
 		Body: responseFromRouter.Body,
 	}, response)
 }
+
+func TestAPIGatewayEventHandler_Handle_Principal(t *testing.T) {
+	handlerAndMocks := newAPIGatewayEventHandlerWithMocks()
+	userARN := "arn:aws:iam::123456789012:user/creator"
+	handlerAndMocks.router.On("Route", mock.Anything, internalHTTP.Request{
+		Method:         internalHTTP.MethodGet,
+		ResourcePath:   internalHTTP.ResourcePathProcess,
+		PathParameters: map[internalHTTP.PathParameter]string{},
+		Principal:      &userARN,
+	}).Return(internalHTTP.Response{StatusCode: http.StatusOK})
+
+	response, err := handlerAndMocks.handler.Handle(context.Background(), events.APIGatewayProxyRequest{
+		Resource:   string(internalHTTP.ResourcePathProcess),
+		HTTPMethod: string(internalHTTP.MethodGet),
+		RequestContext: events.APIGatewayProxyRequestContext{
+			Identity:   events.APIGatewayRequestIdentity{UserArn: userARN},
+			Authorizer: map[string]interface{}{"principalId": "user"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	handlerAndMocks.router.AssertExpectations(t)
+}
+
+func TestAPIGatewayEventHandler_Handle_AuthorizerPrincipal(t *testing.T) {
+	handlerAndMocks := newAPIGatewayEventHandlerWithMocks()
+	principalID := "user"
+	handlerAndMocks.router.On("Route", mock.Anything, internalHTTP.Request{
+		Method:         internalHTTP.MethodGet,
+		ResourcePath:   internalHTTP.ResourcePathProcess,
+		PathParameters: map[internalHTTP.PathParameter]string{},
+		Principal:      &principalID,
+	}).Return(internalHTTP.Response{StatusCode: http.StatusOK})
+
+	response, err := handlerAndMocks.handler.Handle(context.Background(), events.APIGatewayProxyRequest{
+		Resource:   string(internalHTTP.ResourcePathProcess),
+		HTTPMethod: string(internalHTTP.MethodGet),
+		RequestContext: events.APIGatewayProxyRequestContext{
+			Authorizer: map[string]interface{}{"principalId": principalID},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	handlerAndMocks.router.AssertExpectations(t)
+}
@@ -0,0 +1,39 @@
+package process
+
+import (
+	"context"
+	"time"
+)
+
+type CallbackState string
+
+const (
+	CallbackStatePending   CallbackState = "PENDING"
+	CallbackStateDelivered CallbackState = "DELIVERED"
+	CallbackStateFailed    CallbackState = "FAILED"
+)
+
+type Callback struct {
+	URL          string
+	State        CallbackState
+	Attempts     int
+	LastError    *string
+	DeliveryTime time.Time
+}
+
+func (callback *Callback) IsPending() bool {
+	return callback != nil && callback.State == CallbackStatePending
+}
+
+type CallbackDelivery struct {
+	ProcessID    string
+	State        CallbackState
+	Attempts     int
+	LastError    *string
+	DeliveryTime time.Time
+}
+
+type CallbackRecorder interface {
+	ListPendingCallbacks(ctx context.Context, afterProcessID string, limit int) ([]string, error)
+	RecordCallbackDelivery(ctx context.Context, delivery CallbackDelivery) error
+}
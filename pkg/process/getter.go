@@ -1,5 +1,18 @@
 package process
 
+import (
+	"context"
+	"time"
+)
+
 type Getter interface {
-	Get(processID string) (*Process, error)
+	Get(ctx context.Context, processID string) (*Process, error)
+}
+
+type ManyGetter interface {
+	GetMany(ctx context.Context, processIDs []string) ([]*Process, error)
+}
+
+type LongPollingGetter interface {
+	GetWhileState(ctx context.Context, processID string, whileState State, wait time.Duration) (*Process, error)
 }
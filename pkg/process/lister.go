@@ -0,0 +1,100 @@
+package process
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	listCursorSeparator = ":"
+	labelSeparator      = ":"
+
+	MaxScannedProcessesPerPage = 250
+)
+
+var ErrInvalidCursor = errors.New("invalid processes list cursor")
+
+type Label struct {
+	Name  string
+	Value string
+}
+
+type ListRequest struct {
+	State        *State
+	Label        *Label
+	CreatedAfter time.Time
+	Cursor       string
+	Limit        int
+}
+
+type List struct {
+	Processes  []Process
+	NextCursor string
+}
+
+type Lister interface {
+	ListProcesses(ctx context.Context, request ListRequest) (List, error)
+}
+
+type ListPosition struct {
+	CreationTime time.Time
+	ProcessID    string
+}
+
+func (position ListPosition) IsAfter(other ListPosition) bool {
+	if position.CreationTime.Equal(other.CreationTime) {
+		return position.ProcessID > other.ProcessID
+	}
+	return position.CreationTime.After(other.CreationTime)
+}
+
+func (request ListRequest) Matches(proc Process) bool {
+	if request.State != nil && proc.State != *request.State {
+		return false
+	}
+	if request.Label != nil {
+		labelValue, isLabelDefined := proc.Labels[request.Label.Name]
+		return isLabelDefined && labelValue == request.Label.Value
+	}
+	return true
+}
+
+func EncodeListCursor(lastPosition ListPosition) string {
+	creationTime := strconv.FormatInt(lastPosition.CreationTime.Unix(), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(creationTime + listCursorSeparator + lastPosition.ProcessID))
+}
+
+func DecodeListCursor(cursor string) (ListPosition, error) {
+	decodedCursor, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ListPosition{}, ErrInvalidCursor
+	}
+	cursorParts := strings.SplitN(string(decodedCursor), listCursorSeparator, 2)
+	if len(cursorParts) != 2 || cursorParts[1] == "" {
+		return ListPosition{}, ErrInvalidCursor
+	}
+	creationTime, err := strconv.ParseInt(cursorParts[0], 10, 64)
+	if err != nil {
+		return ListPosition{}, ErrInvalidCursor
+	}
+	return ListPosition{
+		CreationTime: time.Unix(creationTime, 0).UTC(),
+		ProcessID:    cursorParts[1],
+	}, nil
+}
+
+func ParseLabel(label string) (Label, bool) {
+	labelParts := strings.SplitN(label, labelSeparator, 2)
+	if len(labelParts) != 2 || labelParts[0] == "" {
+		return Label{}, false
+	}
+	return Label{Name: labelParts[0], Value: labelParts[1]}, true
+}
+
+func (label Label) String() string {
+	return label.Name + labelSeparator + label.Value
+}
@@ -1,5 +1,7 @@
 package process
 
+import "time"
+
 type State string
 
 const (
@@ -7,11 +9,41 @@ const (
 	StateCreated   State = "CREATED"
 	StateError     State = "ERROR"
 
-	TimedOutErrorMessage = "process timed out"
+	TimedOutErrorMessage         = "process timed out"
+	DeadlineExceededErrorMessage = "process deadline exceeded"
 )
 
 type Process struct {
 	ID           string
 	State        State
 	StateMessage *string
+	Sealed       bool
+	Callback     *Callback
+	Deadline     time.Time
+	Labels       map[string]string
+	Description  *string
+	CreationTime time.Time
+	Creator      *string
+	Progress     *Progress
+}
+
+type Progress struct {
+	TotalTasksCount               int
+	CreatedTasksCount             int
+	FinishedTasksCount            int
+	AbortedTasksCount             int
+	TimedOutTasksCount            int
+	EarliestPendingExpirationTime time.Time
+}
+
+func (proc Process) IsTerminated() bool {
+	return proc.State != StateCreated
+}
+
+func IsDeadlineExceeded(deadline, currentTime time.Time) bool {
+	return !deadline.IsZero() && !currentTime.Before(deadline)
+}
+
+func IsDeadlineExceededBefore(deadline, badStateEnterTime, currentTime time.Time) bool {
+	return IsDeadlineExceeded(deadline, currentTime) && !badStateEnterTime.Before(deadline)
 }
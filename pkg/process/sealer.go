@@ -0,0 +1,14 @@
+package process
+
+import "context"
+
+type SealingResult string
+
+const (
+	SealingResultSealed   SealingResult = "SEALED"
+	SealingResultNotFound SealingResult = "NOT_FOUND"
+)
+
+type Sealer interface {
+	Seal(ctx context.Context, processID string) (SealingResult, error)
+}
@@ -0,0 +1,14 @@
+package process
+
+import (
+	"context"
+	"time"
+)
+
+const TerminationEventClaimLease = time.Minute
+
+type TerminationEventRecorder interface {
+	ClaimTerminationEvent(ctx context.Context, processID string) (bool, error)
+	ConfirmTerminationEvent(ctx context.Context, processID string) error
+	ReleaseTerminationEvent(ctx context.Context, processID string) error
+}
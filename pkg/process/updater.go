@@ -0,0 +1,47 @@
+package process
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type UpdatingResult string
+
+const (
+	UpdatingResultUpdated  UpdatingResult = "UPDATED"
+	UpdatingResultNotFound UpdatingResult = "NOT_FOUND"
+)
+
+const (
+	MaxLabelsCount      = 50
+	MaxLabelNameLength  = 128
+	MaxLabelValueLength = 256
+)
+
+type UpdateRequest struct {
+	ProcessID   string
+	CallbackURL *string
+	Deadline    *time.Time
+	Labels      map[string]string
+	Description *string
+}
+
+type Updater interface {
+	Update(ctx context.Context, request UpdateRequest) (UpdatingResult, error)
+}
+
+func AreValidLabels(labels map[string]string) bool {
+	if len(labels) > MaxLabelsCount {
+		return false
+	}
+	for labelName, labelValue := range labels {
+		if labelName == "" || strings.Contains(labelName, labelSeparator) ||
+			utf8.RuneCountInString(labelName) > MaxLabelNameLength ||
+			utf8.RuneCountInString(labelValue) > MaxLabelValueLength {
+			return false
+		}
+	}
+	return true
+}
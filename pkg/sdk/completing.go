@@ -0,0 +1,26 @@
+package sdk
+
+import (
+	"fmt"
+
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+type CompletingError struct {
+	TaskID task.ID
+	Result task.CompletingResult
+}
+
+func (err *CompletingError) Error() string {
+	return fmt.Sprintf("completing task %s of process %s failed: %s", err.TaskID.TaskID, err.TaskID.ProcessID,
+		err.Result)
+}
+
+func CompletingResultError(taskID task.ID, result task.CompletingResult) error {
+	switch result {
+	case task.CompletingResultCompleted, task.CompletingResultAlreadyCompletedSame:
+		return nil
+	default:
+		return &CompletingError{TaskID: taskID, Result: result}
+	}
+}
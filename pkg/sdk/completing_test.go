@@ -0,0 +1,33 @@
+package sdk_test
+
+import (
+	"testing"
+
+	"github.com/artii15/termination-detector/pkg/sdk"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletingResultError(t *testing.T) {
+	taskID := task.ID{ProcessID: "1", TaskID: "2"}
+	for _, result := range []task.CompletingResult{
+		task.CompletingResultCompleted,
+		task.CompletingResultAlreadyCompletedSame,
+	} {
+		assert.NoError(t, sdk.CompletingResultError(taskID, result))
+	}
+	for _, result := range []task.CompletingResult{
+		task.CompletingResultAlreadyCompletedDifferent,
+		task.CompletingResultNotFound,
+		task.CompletingResultExpired,
+		task.CompletingResultConflict,
+		task.CompletingResultChildConflict,
+		task.CompletingResultProcessSealed,
+		task.CompletingResultProcessDeadlineExceeded,
+	} {
+		err := sdk.CompletingResultError(taskID, result)
+		completingErr, isCompletingErr := err.(*sdk.CompletingError)
+		assert.True(t, isCompletingErr)
+		assert.Equal(t, &sdk.CompletingError{TaskID: taskID, Result: result}, completingErr)
+	}
+}
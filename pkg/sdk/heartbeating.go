@@ -0,0 +1,91 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/task"
+)
+
+const (
+	DefaultHeartbeatingLeaseDuration = time.Second * 30
+	heartbeatsPerLease               = 3
+)
+
+type HeartbeatingConfig struct {
+	Interval      time.Duration
+	LeaseDuration time.Duration
+}
+
+func (config HeartbeatingConfig) withDefaults() HeartbeatingConfig {
+	if config.LeaseDuration <= 0 {
+		config.LeaseDuration = DefaultHeartbeatingLeaseDuration
+	}
+	if config.Interval <= 0 {
+		config.Interval = config.LeaseDuration / heartbeatsPerLease
+	}
+	return config
+}
+
+type Heartbeating struct {
+	heartbeater task.Heartbeater
+	taskID      task.ID
+	config      HeartbeatingConfig
+	stopSignal  chan struct{}
+	stopOnce    sync.Once
+	done        chan struct{}
+	lastErr     error
+}
+
+func StartHeartbeating(ctx context.Context, heartbeater task.Heartbeater, taskID task.ID,
+	config HeartbeatingConfig) *Heartbeating {
+	heartbeating := &Heartbeating{
+		heartbeater: heartbeater,
+		taskID:      taskID,
+		config:      config.withDefaults(),
+		stopSignal:  make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	go heartbeating.run(ctx)
+	return heartbeating
+}
+
+func (heartbeating *Heartbeating) Done() <-chan struct{} {
+	return heartbeating.done
+}
+
+func (heartbeating *Heartbeating) Stop() error {
+	heartbeating.stopOnce.Do(func() {
+		close(heartbeating.stopSignal)
+	})
+	<-heartbeating.done
+	return heartbeating.lastErr
+}
+
+func (heartbeating *Heartbeating) run(ctx context.Context) {
+	defer close(heartbeating.done)
+	ticker := time.NewTicker(heartbeating.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-heartbeating.stopSignal:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !heartbeating.beat(ctx) {
+				return
+			}
+		}
+	}
+}
+
+func (heartbeating *Heartbeating) beat(ctx context.Context) bool {
+	heartbeatResult, err := heartbeating.heartbeater.Heartbeat(ctx, task.HeartbeatRequest{
+		ID:             heartbeating.taskID,
+		ExpirationTime: time.Now().UTC().Add(heartbeating.config.LeaseDuration),
+	})
+	heartbeating.lastErr = err
+	return err != nil || heartbeatResult != task.HeartbeatResultConflict
+}
@@ -0,0 +1,126 @@
+package sdk_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/sdk"
+	"github.com/artii15/termination-detector/pkg/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const heartbeatingTestTimeout = time.Second * 5
+
+type taskHeartbeaterMock struct {
+	mock.Mock
+}
+
+func (heartbeater *taskHeartbeaterMock) Heartbeat(ctx context.Context, request task.HeartbeatRequest) (
+	task.HeartbeatResult, error) {
+	args := heartbeater.Called(ctx, request)
+	return args.Get(0).(task.HeartbeatResult), args.Error(1)
+}
+
+var heartbeatingConfig = sdk.HeartbeatingConfig{
+	Interval:      time.Millisecond,
+	LeaseDuration: time.Minute,
+}
+
+func TestStartHeartbeating_EndsWhenTaskIsNoLongerActive(t *testing.T) {
+	heartbeater := new(taskHeartbeaterMock)
+	taskID := task.ID{ProcessID: "1", TaskID: "2"}
+	heartbeater.On("Heartbeat", mock.Anything, mock.AnythingOfType("task.HeartbeatRequest")).
+		Return(task.HeartbeatResultExtended, nil).Twice()
+	heartbeater.On("Heartbeat", mock.Anything, mock.AnythingOfType("task.HeartbeatRequest")).
+		Return(task.HeartbeatResultConflict, nil).Once()
+
+	heartbeating := sdk.StartHeartbeating(context.Background(), heartbeater, taskID, heartbeatingConfig)
+	select {
+	case <-heartbeating.Done():
+	case <-time.After(heartbeatingTestTimeout):
+		t.Fatal("heartbeating did not end after conflict")
+	}
+	assert.NoError(t, heartbeating.Stop())
+	heartbeater.AssertExpectations(t)
+	for _, call := range heartbeater.Calls {
+		request := call.Arguments.Get(1).(task.HeartbeatRequest)
+		assert.Equal(t, taskID, request.ID)
+		assert.True(t, request.ExpirationTime.After(time.Now()))
+	}
+}
+
+func TestStartHeartbeating_KeepsBeatingAfterError(t *testing.T) {
+	heartbeater := new(taskHeartbeaterMock)
+	heartbeater.On("Heartbeat", mock.Anything, mock.AnythingOfType("task.HeartbeatRequest")).
+		Return(task.HeartbeatResult(""), errors.New("error")).Once()
+	heartbeater.On("Heartbeat", mock.Anything, mock.AnythingOfType("task.HeartbeatRequest")).
+		Return(task.HeartbeatResultConflict, nil).Once()
+
+	heartbeating := sdk.StartHeartbeating(context.Background(), heartbeater, task.ID{ProcessID: "1", TaskID: "2"}, heartbeatingConfig)
+	select {
+	case <-heartbeating.Done():
+	case <-time.After(heartbeatingTestTimeout):
+		t.Fatal("heartbeating did not end after conflict")
+	}
+	assert.NoError(t, heartbeating.Stop())
+	heartbeater.AssertExpectations(t)
+}
+
+func TestHeartbeating_Stop(t *testing.T) {
+	heartbeater := new(taskHeartbeaterMock)
+
+	heartbeating := sdk.StartHeartbeating(context.Background(), heartbeater, task.ID{ProcessID: "1", TaskID: "2"}, sdk.HeartbeatingConfig{
+		Interval:      time.Hour,
+		LeaseDuration: time.Hour,
+	})
+	assert.NoError(t, heartbeating.Stop())
+	assert.NoError(t, heartbeating.Stop())
+	heartbeater.AssertNotCalled(t, "Heartbeat", mock.Anything, mock.Anything)
+}
+
+func TestStartHeartbeating_EndsWhenContextIsCanceled(t *testing.T) {
+	heartbeater := new(taskHeartbeaterMock)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	heartbeating := sdk.StartHeartbeating(ctx, heartbeater, task.ID{ProcessID: "1", TaskID: "2"}, sdk.HeartbeatingConfig{
+		Interval:      time.Hour,
+		LeaseDuration: time.Hour,
+	})
+	cancel()
+	select {
+	case <-heartbeating.Done():
+	case <-time.After(heartbeatingTestTimeout):
+		t.Fatal("heartbeating did not end after context cancellation")
+	}
+	assert.NoError(t, heartbeating.Stop())
+	heartbeater.AssertNotCalled(t, "Heartbeat", mock.Anything, mock.Anything)
+}
+
+func TestStartHeartbeating_DefaultsZeroInterval(t *testing.T) {
+	heartbeater := new(taskHeartbeaterMock)
+	heartbeater.On("Heartbeat", mock.Anything, mock.AnythingOfType("task.HeartbeatRequest")).
+		Return(task.HeartbeatResultConflict, nil).Once()
+
+	heartbeating := sdk.StartHeartbeating(context.Background(), heartbeater, task.ID{ProcessID: "1", TaskID: "2"}, sdk.HeartbeatingConfig{
+		LeaseDuration: time.Millisecond * 3,
+	})
+	select {
+	case <-heartbeating.Done():
+	case <-time.After(heartbeatingTestTimeout):
+		t.Fatal("heartbeating did not end after conflict")
+	}
+	assert.NoError(t, heartbeating.Stop())
+	heartbeater.AssertExpectations(t)
+}
+
+func TestHeartbeating_Stop_ZeroConfig(t *testing.T) {
+	heartbeater := new(taskHeartbeaterMock)
+
+	heartbeating := sdk.StartHeartbeating(context.Background(), heartbeater, task.ID{ProcessID: "1", TaskID: "2"},
+		sdk.HeartbeatingConfig{})
+	assert.NoError(t, heartbeating.Stop())
+	heartbeater.AssertNotCalled(t, "Heartbeat", mock.Anything, mock.Anything)
+}
@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -13,21 +14,92 @@ import (
 )
 
 type SDK struct {
-	processGetter  process.Getter
-	taskRegisterer task.Registerer
-	taskCompleter  task.Completer
+	processGetter       process.Getter
+	processManyGetter   process.ManyGetter
+	processSealer       process.Sealer
+	processUpdater      process.Updater
+	processLister       process.Lister
+	taskRegisterer      task.Registerer
+	taskBatchRegisterer task.BatchRegisterer
+	taskCompleter       task.Completer
+	taskHeartbeater     task.Heartbeater
+	taskLister          task.Lister
+	taskGetter          task.Getter
+	longPollWait        time.Duration
 }
 
-func (sdk *SDK) Get(processID string) (*process.Process, error) {
-	return sdk.processGetter.Get(processID)
+func (sdk *SDK) Get(ctx context.Context, processID string) (*process.Process, error) {
+	return sdk.processGetter.Get(ctx, processID)
 }
 
-func (sdk *SDK) Register(registrationData task.RegistrationData) (task.RegistrationResult, error) {
-	return sdk.taskRegisterer.Register(registrationData)
+func (sdk *SDK) GetMany(ctx context.Context, processIDs []string) ([]*process.Process, error) {
+	return sdk.processManyGetter.GetMany(ctx, processIDs)
 }
 
-func (sdk *SDK) Complete(request task.CompleteRequest) (task.CompletingResult, error) {
-	return sdk.taskCompleter.Complete(request)
+func (sdk *SDK) Seal(ctx context.Context, processID string) (process.SealingResult, error) {
+	return sdk.processSealer.Seal(ctx, processID)
+}
+
+func (sdk *SDK) Update(ctx context.Context, request process.UpdateRequest) (process.UpdatingResult, error) {
+	return sdk.processUpdater.Update(ctx, request)
+}
+
+func (sdk *SDK) ListProcesses(ctx context.Context, request process.ListRequest) (process.List, error) {
+	return sdk.processLister.ListProcesses(ctx, request)
+}
+
+func (sdk *SDK) Register(ctx context.Context, registrationData task.RegistrationData) (task.RegistrationResult, error) {
+	return sdk.taskRegisterer.Register(ctx, registrationData)
+}
+
+func (sdk *SDK) BatchRegister(ctx context.Context,
+	request task.BatchRegistrationRequest) ([]task.BatchRegistrationResult, error) {
+	return sdk.taskBatchRegisterer.BatchRegister(ctx, request)
+}
+
+func (sdk *SDK) Complete(ctx context.Context, request task.CompleteRequest) (task.CompletingResult, error) {
+	completingResult, err := sdk.taskCompleter.Complete(ctx, request)
+	if err != nil {
+		return "", err
+	}
+	return completingResult, CompletingResultError(request.ID, completingResult)
+}
+
+func (sdk *SDK) CompleteWithChildren(ctx context.Context,
+	request task.CompleteWithChildrenRequest) (task.CompletingResult, error) {
+	completingResult, err := sdk.taskCompleter.CompleteWithChildren(ctx, request)
+	if err != nil {
+		return "", err
+	}
+	return completingResult, CompletingResultError(request.ID, completingResult)
+}
+
+func (sdk *SDK) BatchComplete(ctx context.Context,
+	request task.BatchCompleteRequest) ([]task.BatchCompletingResult, error) {
+	return sdk.taskCompleter.BatchComplete(ctx, request)
+}
+
+func (sdk *SDK) Heartbeat(ctx context.Context, request task.HeartbeatRequest) (task.HeartbeatResult, error) {
+	return sdk.taskHeartbeater.Heartbeat(ctx, request)
+}
+
+func (sdk *SDK) StartHeartbeating(ctx context.Context, taskID task.ID, config HeartbeatingConfig) *Heartbeating {
+	return StartHeartbeating(ctx, sdk.taskHeartbeater, taskID, config)
+}
+
+func (sdk *SDK) List(ctx context.Context, request task.ListRequest) (task.List, error) {
+	return sdk.taskLister.List(ctx, request)
+}
+
+func (sdk *SDK) GetTask(ctx context.Context, id task.ID) (*task.Task, error) {
+	return sdk.taskGetter.GetTask(ctx, id)
+}
+
+func (sdk *SDK) WaitForTermination(ctx context.Context, processID string, options WaitingOptions) (process.Process, error) {
+	if options.LongPollWait == 0 {
+		options.LongPollWait = sdk.longPollWait
+	}
+	return WaitForTermination(ctx, sdk.processGetter, processID, options)
 }
 
 func NewAWSIAMAuthorized(requestsTimeout time.Duration, apiURL, region string, awsCredentials *credentials.Credentials) *SDK {
@@ -38,13 +110,36 @@ func NewAWSIAMAuthorized(requestsTimeout time.Duration, apiURL, region string, a
 }
 
 func New(requestsTimeout time.Duration, apiURL string, requestModifiers ...client.RequestModifier) *SDK {
+	return NewRetrying(requestsTimeout, apiURL, client.DefaultRetryingConfig, requestModifiers...)
+}
+
+func NewRetrying(requestsTimeout time.Duration, apiURL string, retryingConfig client.RetryingConfig,
+	requestModifiers ...client.RequestModifier) *SDK {
 	httpClient := &http.Client{
 		Timeout: requestsTimeout,
 	}
-	requestExecutor := client.New(httpClient, apiURL, requestModifiers...)
+	requestExecutor := client.NewRetrying(httpClient, apiURL, retryingConfig, requestModifiers...)
+	processGetter := internalHTTP.NewProcessGetter(requestExecutor)
+	taskRegisterer := internalHTTP.NewTaskRegisterer(requestExecutor)
 	return &SDK{
-		processGetter:  internalHTTP.NewProcessGetter(requestExecutor),
-		taskRegisterer: internalHTTP.NewTaskRegisterer(requestExecutor),
-		taskCompleter:  internalHTTP.NewTaskCompleter(requestExecutor),
+		processGetter:       processGetter,
+		processManyGetter:   processGetter,
+		processSealer:       internalHTTP.NewProcessSealer(requestExecutor),
+		processUpdater:      internalHTTP.NewProcessUpdater(requestExecutor),
+		processLister:       internalHTTP.NewProcessLister(requestExecutor),
+		taskRegisterer:      taskRegisterer,
+		taskBatchRegisterer: taskRegisterer,
+		taskCompleter:       internalHTTP.NewTaskCompleter(requestExecutor),
+		taskHeartbeater:     internalHTTP.NewTaskHeartbeater(requestExecutor),
+		taskLister:          internalHTTP.NewTaskLister(requestExecutor),
+		taskGetter:          internalHTTP.NewTaskGetter(requestExecutor),
+		longPollWait:        readLongPollWait(requestsTimeout),
+	}
+}
+
+func readLongPollWait(requestsTimeout time.Duration) time.Duration {
+	if requestsTimeout <= 0 || requestsTimeout > DefaultLongPollWait*2 {
+		return DefaultLongPollWait
 	}
+	return requestsTimeout / 2
 }
@@ -0,0 +1,174 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/dates"
+	"github.com/artii15/termination-detector/pkg/process"
+)
+
+const (
+	DefaultPollInterval      = time.Second
+	DefaultMaxPollInterval   = time.Second * 30
+	DefaultBackoffMultiplier = 2
+	DefaultJitter            = 0.2
+	DefaultLongPollWait      = time.Second * 20
+)
+
+type WaitingOptions struct {
+	PollInterval        time.Duration
+	MaxPollInterval     time.Duration
+	BackoffMultiplier   float64
+	Jitter              float64
+	Deadline            time.Duration
+	NotFoundGracePeriod time.Duration
+	LongPollWait        time.Duration
+	OnState             func(proc process.Process)
+}
+
+func (options WaitingOptions) withDefaults() WaitingOptions {
+	if options.PollInterval <= 0 {
+		options.PollInterval = DefaultPollInterval
+	}
+	if options.MaxPollInterval <= 0 {
+		options.MaxPollInterval = DefaultMaxPollInterval
+	}
+	if options.MaxPollInterval < options.PollInterval {
+		options.MaxPollInterval = options.PollInterval
+	}
+	if options.BackoffMultiplier < 1 {
+		options.BackoffMultiplier = DefaultBackoffMultiplier
+	}
+	if options.Jitter == 0 {
+		options.Jitter = DefaultJitter
+	}
+	if options.Jitter < 0 {
+		options.Jitter = 0
+	}
+	if options.Jitter > 1 {
+		options.Jitter = 1
+	}
+	return options
+}
+
+type WaitingErrorReason string
+
+const (
+	WaitingErrorReasonDeadlineExceeded WaitingErrorReason = "DEADLINE_EXCEEDED"
+	WaitingErrorReasonCanceled         WaitingErrorReason = "CANCELED"
+	WaitingErrorReasonNotFound         WaitingErrorReason = "NOT_FOUND"
+	WaitingErrorReasonGetFailed        WaitingErrorReason = "GET_FAILED"
+)
+
+type WaitingError struct {
+	ProcessID   string
+	Reason      WaitingErrorReason
+	LastProcess *process.Process
+	Cause       error
+}
+
+func (err *WaitingError) Error() string {
+	if err.Cause != nil {
+		return fmt.Sprintf("waiting for termination of process %s failed: %s: %s", err.ProcessID, err.Reason, err.Cause)
+	}
+	return fmt.Sprintf("waiting for termination of process %s failed: %s", err.ProcessID, err.Reason)
+}
+
+func (err *WaitingError) Unwrap() error {
+	return err.Cause
+}
+
+func WaitForTermination(ctx context.Context, processGetter process.Getter, processID string,
+	options WaitingOptions) (process.Process, error) {
+	options = options.withDefaults()
+	if options.Deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Deadline)
+		defer cancel()
+	}
+
+	waitingStart := time.Now()
+	pollInterval := options.PollInterval
+	var lastProcess *process.Process
+	for {
+		observationStart := time.Now()
+		foundProcess, isLongPolled, err := observeProcess(ctx, processGetter, processID, options.LongPollWait)
+		if err != nil {
+			return process.Process{}, &WaitingError{ProcessID: processID, Reason: WaitingErrorReasonGetFailed,
+				LastProcess: lastProcess, Cause: err}
+		}
+		if foundProcess != nil {
+			if foundProcess.IsTerminated() {
+				return *foundProcess, nil
+			}
+			lastProcess = foundProcess
+			if options.OnState != nil {
+				options.OnState(*foundProcess)
+			}
+			if isLongPolled && time.Since(observationStart) >= options.PollInterval {
+				pollInterval = options.PollInterval
+				if err := ctx.Err(); err != nil {
+					return process.Process{}, newInterruptedWaitingError(processID, lastProcess, err)
+				}
+				continue
+			}
+		} else if time.Since(waitingStart) >= options.NotFoundGracePeriod {
+			return process.Process{}, &WaitingError{ProcessID: processID, Reason: WaitingErrorReasonNotFound,
+				LastProcess: lastProcess}
+		}
+
+		if err := sleep(ctx, withJitter(pollInterval, options.Jitter)); err != nil {
+			return process.Process{}, newInterruptedWaitingError(processID, lastProcess, err)
+		}
+		pollInterval = nextPollInterval(pollInterval, options)
+	}
+}
+
+func observeProcess(ctx context.Context, processGetter process.Getter, processID string,
+	longPollWait time.Duration) (*process.Process, bool, error) {
+	longPollingGetter, canLongPoll := processGetter.(process.LongPollingGetter)
+	if deadline, hasDeadline := ctx.Deadline(); hasDeadline {
+		longPollWait = dates.MinDuration(longPollWait, time.Until(deadline))
+	}
+	if !canLongPoll || longPollWait < time.Second {
+		foundProcess, err := processGetter.Get(ctx, processID)
+		return foundProcess, false, err
+	}
+	foundProcess, err := longPollingGetter.GetWhileState(ctx, processID, process.StateCreated, longPollWait)
+	return foundProcess, true, err
+}
+
+func nextPollInterval(pollInterval time.Duration, options WaitingOptions) time.Duration {
+	nextInterval := time.Duration(float64(pollInterval) * options.BackoffMultiplier)
+	if nextInterval > options.MaxPollInterval {
+		return options.MaxPollInterval
+	}
+	return nextInterval
+}
+
+func withJitter(interval time.Duration, jitter float64) time.Duration {
+	jitterRange := float64(interval) * jitter
+	return interval + time.Duration(jitterRange*(2*rand.Float64()-1))
+}
+
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func newInterruptedWaitingError(processID string, lastProcess *process.Process, ctxErr error) *WaitingError {
+	reason := WaitingErrorReasonCanceled
+	if ctxErr == context.DeadlineExceeded {
+		reason = WaitingErrorReasonDeadlineExceeded
+	}
+	return &WaitingError{ProcessID: processID, Reason: reason, LastProcess: lastProcess, Cause: ctxErr}
+}
@@ -0,0 +1,143 @@
+package sdk_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/artii15/termination-detector/pkg/process"
+	"github.com/artii15/termination-detector/pkg/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type processGetterMock struct {
+	mock.Mock
+}
+
+func (getter *processGetterMock) Get(ctx context.Context, processID string) (*process.Process, error) {
+	args := getter.Called(ctx, processID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*process.Process), args.Error(1)
+}
+
+var waitingOptions = sdk.WaitingOptions{
+	PollInterval:    time.Millisecond,
+	MaxPollInterval: time.Millisecond * 4,
+}
+
+func TestWaitForTermination(t *testing.T) {
+	processGetter := new(processGetterMock)
+	runningProcess := &process.Process{ID: "1", State: process.StateCreated}
+	completedProcess := &process.Process{ID: "1", State: process.StateCompleted, Sealed: true}
+	processGetter.On("Get", mock.Anything, "1").Return(runningProcess, nil).Twice()
+	processGetter.On("Get", mock.Anything, "1").Return(completedProcess, nil).Once()
+	var observedStates []process.Process
+	options := waitingOptions
+	options.OnState = func(proc process.Process) {
+		observedStates = append(observedStates, proc)
+	}
+
+	terminatedProcess, err := sdk.WaitForTermination(context.Background(), processGetter, "1", options)
+	assert.NoError(t, err)
+	assert.Equal(t, *completedProcess, terminatedProcess)
+	assert.Equal(t, []process.Process{*runningProcess, *runningProcess}, observedStates)
+	processGetter.AssertExpectations(t)
+}
+
+func TestWaitForTermination_NotFoundWithinGracePeriod(t *testing.T) {
+	processGetter := new(processGetterMock)
+	erroneousProcess := &process.Process{ID: "1", State: process.StateError}
+	processGetter.On("Get", mock.Anything, "1").Return(nil, nil).Once()
+	processGetter.On("Get", mock.Anything, "1").Return(erroneousProcess, nil).Once()
+	options := waitingOptions
+	options.NotFoundGracePeriod = time.Minute
+
+	terminatedProcess, err := sdk.WaitForTermination(context.Background(), processGetter, "1", options)
+	assert.NoError(t, err)
+	assert.Equal(t, *erroneousProcess, terminatedProcess)
+	processGetter.AssertExpectations(t)
+}
+
+func TestWaitForTermination_NotFound(t *testing.T) {
+	processGetter := new(processGetterMock)
+	processGetter.On("Get", mock.Anything, "1").Return(nil, nil).Once()
+
+	_, err := sdk.WaitForTermination(context.Background(), processGetter, "1", waitingOptions)
+	waitingErr, isWaitingErr := err.(*sdk.WaitingError)
+	assert.True(t, isWaitingErr)
+	assert.Equal(t, sdk.WaitingErrorReasonNotFound, waitingErr.Reason)
+	processGetter.AssertExpectations(t)
+}
+
+func TestWaitForTermination_DeadlineExceeded(t *testing.T) {
+	processGetter := new(processGetterMock)
+	runningProcess := &process.Process{ID: "1", State: process.StateCreated}
+	processGetter.On("Get", mock.Anything, "1").Return(runningProcess, nil)
+	options := waitingOptions
+	options.Deadline = time.Millisecond * 20
+
+	_, err := sdk.WaitForTermination(context.Background(), processGetter, "1", options)
+	waitingErr, isWaitingErr := err.(*sdk.WaitingError)
+	assert.True(t, isWaitingErr)
+	assert.Equal(t, sdk.WaitingErrorReasonDeadlineExceeded, waitingErr.Reason)
+	assert.Equal(t, runningProcess, waitingErr.LastProcess)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestWaitForTermination_Canceled(t *testing.T) {
+	processGetter := new(processGetterMock)
+	processGetter.On("Get", mock.Anything, "1").Return(&process.Process{ID: "1", State: process.StateCreated}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := sdk.WaitForTermination(ctx, processGetter, "1", waitingOptions)
+	waitingErr, isWaitingErr := err.(*sdk.WaitingError)
+	assert.True(t, isWaitingErr)
+	assert.Equal(t, sdk.WaitingErrorReasonCanceled, waitingErr.Reason)
+}
+
+func TestWaitForTermination_GetError(t *testing.T) {
+	processGetter := new(processGetterMock)
+	getErr := errors.New("error")
+	processGetter.On("Get", mock.Anything, "1").Return(nil, getErr).Once()
+
+	_, err := sdk.WaitForTermination(context.Background(), processGetter, "1", waitingOptions)
+	waitingErr, isWaitingErr := err.(*sdk.WaitingError)
+	assert.True(t, isWaitingErr)
+	assert.Equal(t, sdk.WaitingErrorReasonGetFailed, waitingErr.Reason)
+	assert.True(t, errors.Is(err, getErr))
+	processGetter.AssertExpectations(t)
+}
+
+type longPollingProcessGetterMock struct {
+	processGetterMock
+}
+
+func (getter *longPollingProcessGetterMock) GetWhileState(ctx context.Context, processID string,
+	whileState process.State, wait time.Duration) (*process.Process, error) {
+	args := getter.Called(ctx, processID, whileState, wait)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*process.Process), args.Error(1)
+}
+
+func TestWaitForTermination_LongPolling(t *testing.T) {
+	processGetter := new(longPollingProcessGetterMock)
+	runningProcess := &process.Process{ID: "1", State: process.StateCreated}
+	completedProcess := &process.Process{ID: "1", State: process.StateCompleted}
+	processGetter.On("GetWhileState", mock.Anything, "1", process.StateCreated, time.Second*5).Return(runningProcess, nil).Once()
+	processGetter.On("GetWhileState", mock.Anything, "1", process.StateCreated, time.Second*5).Return(completedProcess, nil).Once()
+	options := waitingOptions
+	options.LongPollWait = time.Second * 5
+
+	terminatedProcess, err := sdk.WaitForTermination(context.Background(), processGetter, "1", options)
+	assert.NoError(t, err)
+	assert.Equal(t, *completedProcess, terminatedProcess)
+	processGetter.AssertExpectations(t)
+	processGetter.AssertNotCalled(t, "Get", mock.Anything, "1")
+}
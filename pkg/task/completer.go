@@ -1,18 +1,96 @@
 package task
 
+import "context"
+
 type CompleteRequest struct {
 	ID
 	State   State
 	Message *string
 }
 
+type CompleteWithChildrenRequest struct {
+	CompleteRequest
+	Children []RegistrationData
+}
+
 type CompletingResult string
 
 const (
-	CompletingResultConflict  CompletingResult = "CONFLICT"
-	CompletingResultCompleted CompletingResult = "COMPLETED"
+	CompletingResultConflict                  CompletingResult = "CONFLICT"
+	CompletingResultCompleted                 CompletingResult = "COMPLETED"
+	CompletingResultChildConflict             CompletingResult = "CHILD_CONFLICT"
+	CompletingResultProcessSealed             CompletingResult = "PROCESS_SEALED"
+	CompletingResultAlreadyCompletedSame      CompletingResult = "ALREADY_COMPLETED_SAME"
+	CompletingResultAlreadyCompletedDifferent CompletingResult = "ALREADY_COMPLETED_DIFFERENT"
+	CompletingResultNotFound                  CompletingResult = "NOT_FOUND"
+	CompletingResultExpired                   CompletingResult = "EXPIRED"
+	CompletingResultProcessDeadlineExceeded   CompletingResult = "PROCESS_DEADLINE_EXCEEDED"
+	CompletingResultCanceled                  CompletingResult = "CANCELED"
+	CompletingResultFailed                    CompletingResult = "FAILED"
 )
 
+type BatchCompleteRequest struct {
+	ProcessID     string
+	Tasks         []CompleteRequest
+	Transactional bool
+}
+
+type BatchCompletingResult struct {
+	TaskID string
+	Result CompletingResult
+}
+
 type Completer interface {
-	Complete(request CompleteRequest) (CompletingResult, error)
+	Complete(ctx context.Context, request CompleteRequest) (CompletingResult, error)
+	CompleteWithChildren(ctx context.Context, request CompleteWithChildrenRequest) (CompletingResult, error)
+	BatchComplete(ctx context.Context, request BatchCompleteRequest) ([]BatchCompletingResult, error)
+}
+
+func NewBatchCompletingResults(request BatchCompleteRequest, result CompletingResult) []BatchCompletingResult {
+	results := make([]BatchCompletingResult, 0, len(request.Tasks))
+	for _, completeRequest := range request.Tasks {
+		results = append(results, BatchCompletingResult{TaskID: completeRequest.TaskID, Result: result})
+	}
+	return results
+}
+
+func ReplaceCompletingResults(results []BatchCompletingResult, replaced, replacement CompletingResult) {
+	for index := range results {
+		if results[index].Result == replaced {
+			results[index].Result = replacement
+		}
+	}
+}
+
+func IsCompletingSucceeded(result CompletingResult) bool {
+	return result == CompletingResultCompleted || result == CompletingResultAlreadyCompletedSame
+}
+
+func AreCompletingSucceeded(results []BatchCompletingResult) bool {
+	for _, result := range results {
+		if !IsCompletingSucceeded(result.Result) {
+			return false
+		}
+	}
+	return true
+}
+
+func ReadCompletingConflictResult(existingTask *Task, request CompleteRequest) CompletingResult {
+	switch {
+	case existingTask == nil:
+		return CompletingResultNotFound
+	case existingTask.State == StateCreated || existingTask.State == StateTimedOut:
+		return CompletingResultExpired
+	case existingTask.State == request.State && areStateMessagesEqual(existingTask.StateMessage, request.Message):
+		return CompletingResultAlreadyCompletedSame
+	default:
+		return CompletingResultAlreadyCompletedDifferent
+	}
+}
+
+func areStateMessagesEqual(first, second *string) bool {
+	if first == nil || second == nil {
+		return first == second
+	}
+	return *first == *second
 }
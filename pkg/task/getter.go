@@ -0,0 +1,7 @@
+package task
+
+import "context"
+
+type Getter interface {
+	GetTask(ctx context.Context, id ID) (*Task, error)
+}
@@ -0,0 +1,22 @@
+package task
+
+import (
+	"context"
+	"time"
+)
+
+type HeartbeatRequest struct {
+	ID
+	ExpirationTime time.Time
+}
+
+type HeartbeatResult string
+
+const (
+	HeartbeatResultExtended HeartbeatResult = "EXTENDED"
+	HeartbeatResultConflict HeartbeatResult = "CONFLICT"
+)
+
+type Heartbeater interface {
+	Heartbeat(ctx context.Context, request HeartbeatRequest) (HeartbeatResult, error)
+}
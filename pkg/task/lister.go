@@ -0,0 +1,37 @@
+package task
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+)
+
+var ErrInvalidCursor = errors.New("invalid tasks list cursor")
+
+type ListRequest struct {
+	ProcessID string
+	State     *State
+	Cursor    string
+	Limit     int
+}
+
+type List struct {
+	Tasks      []Task
+	NextCursor string
+}
+
+type Lister interface {
+	List(ctx context.Context, request ListRequest) (List, error)
+}
+
+func EncodeListCursor(lastTaskID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(lastTaskID))
+}
+
+func DecodeListCursor(cursor string) (string, error) {
+	lastTaskID, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(lastTaskID) == 0 {
+		return "", ErrInvalidCursor
+	}
+	return string(lastTaskID), nil
+}
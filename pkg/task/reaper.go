@@ -0,0 +1,7 @@
+package task
+
+import "context"
+
+type Reaper interface {
+	ReapTimedOut(ctx context.Context, limit int) ([]ID, error)
+}
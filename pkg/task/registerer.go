@@ -1,21 +1,61 @@
 package task
 
 import (
+	"context"
 	"time"
 )
 
 type RegistrationResult string
 
 const (
-	RegistrationResultCreated           RegistrationResult = "CREATED"
-	RegistrationResultAlreadyRegistered RegistrationResult = "ALREADY_REGISTERED"
+	RegistrationResultCreated                 RegistrationResult = "CREATED"
+	RegistrationResultAlreadyRegistered       RegistrationResult = "ALREADY_REGISTERED"
+	RegistrationResultProcessSealed           RegistrationResult = "PROCESS_SEALED"
+	RegistrationResultProcessDeadlineExceeded RegistrationResult = "PROCESS_DEADLINE_EXCEEDED"
+	RegistrationResultCanceled                RegistrationResult = "CANCELED"
+	RegistrationResultFailed                  RegistrationResult = "FAILED"
 )
 
 type RegistrationData struct {
-	ID             ID
-	ExpirationTime time.Time
+	ID              ID
+	ExpirationTime  time.Time
+	CallbackURL     *string
+	ProcessDeadline time.Time
+	Creator         *string
 }
 
 type Registerer interface {
-	Register(registrationData RegistrationData) (RegistrationResult, error)
+	Register(ctx context.Context, registrationData RegistrationData) (RegistrationResult, error)
+}
+
+type BatchRegistrationRequest struct {
+	ProcessID     string
+	Tasks         []RegistrationData
+	Transactional bool
+}
+
+type BatchRegistrationResult struct {
+	TaskID string
+	Result RegistrationResult
+}
+
+type BatchRegisterer interface {
+	BatchRegister(ctx context.Context, request BatchRegistrationRequest) ([]BatchRegistrationResult, error)
+}
+
+func NewBatchRegistrationResults(request BatchRegistrationRequest,
+	result RegistrationResult) []BatchRegistrationResult {
+	results := make([]BatchRegistrationResult, 0, len(request.Tasks))
+	for _, registrationData := range request.Tasks {
+		results = append(results, BatchRegistrationResult{TaskID: registrationData.ID.TaskID, Result: result})
+	}
+	return results
+}
+
+func ReplaceRegistrationResults(results []BatchRegistrationResult, replaced, replacement RegistrationResult) {
+	for index := range results {
+		if results[index].Result == replaced {
+			results[index].Result = replacement
+		}
+	}
 }
@@ -1,14 +1,38 @@
 package task
 
+import (
+	"time"
+)
+
 type State string
 
 const (
 	StateAborted  State = "ABORTED"
 	StateCreated  State = "CREATED"
 	StateFinished State = "FINISHED"
+	StateTimedOut State = "TIMED_OUT"
+
+	ReservedID = "#process"
 )
 
 type ID struct {
 	ProcessID string
 	TaskID    string
 }
+
+func IsReservedTaskID(taskID string) bool {
+	return taskID == ReservedID
+}
+
+type Task struct {
+	ID
+	State          State
+	StateMessage   *string
+	ExpirationTime time.Time
+	CreationTime   time.Time
+	TimedOut       bool
+}
+
+func IsTimedOut(state State, expirationTime, currentTime time.Time) bool {
+	return state == StateTimedOut || (state == StateCreated && !currentTime.Before(expirationTime))
+}